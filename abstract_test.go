@@ -2,6 +2,7 @@ package graphql_test
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/graphql-go/graphql"
@@ -654,3 +655,122 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestTypedValuesResolveRuntimeTypeWithoutResolveType(t *testing.T) {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"woofs": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"meows": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	petUnion := graphql.NewUnion(graphql.UnionConfig{
+		Name:        "PetUnion",
+		Types:       []*graphql.Object{dogType, catType},
+		TypedValues: true,
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							graphql.Typed{TypeName: "Dog", Value: map[string]interface{}{"name": "Odie", "woofs": true}},
+							&graphql.Typed{TypeName: "Cat", Value: &testCat{Name: "Garfield", Meows: false}},
+							graphql.Typed{TypeName: "Cat"},
+						}, nil
+					},
+				},
+				"favorite": &graphql.Field{
+					Type: petUnion,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return graphql.Typed{TypeName: "Dog", Value: &testDog{Name: "Odie", Woofs: true}}, nil
+					},
+				},
+				"unknown": &graphql.Field{
+					Type: petType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return graphql.Typed{TypeName: "Bird", Value: map[string]interface{}{}}, nil
+					},
+				},
+				"impossible": &graphql.Field{
+					Type: petUnion,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return graphql.Typed{TypeName: "Query", Value: map[string]interface{}{}}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType, catType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+		pets {
+			__typename
+			name
+			... on Dog { woofs }
+			... on Cat { meows }
+		}
+		favorite {
+			... on Dog { name woofs }
+		}
+		unknown { name }
+		impossible { __typename }
+	}`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{"__typename": "Dog", "name": "Odie", "woofs": true},
+				map[string]interface{}{"__typename": "Cat", "name": "Garfield", "meows": false},
+				nil,
+			},
+			"favorite":   map[string]interface{}{"name": "Odie", "woofs": true},
+			"unknown":    nil,
+			"impossible": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Abstract type Pet must resolve to an Object type at runtime for field Query.unknown with value "map[]", received "Bird".`,
+				Locations: []location.SourceLocation{{Line: 11, Column: 3}},
+				Path:      []interface{}{"unknown"},
+			},
+			{
+				Message:   `Runtime Object type "Query" is not a possible type for "PetUnion".`,
+				Locations: []location.SourceLocation{{Line: 12, Column: 3}},
+				Path:      []interface{}{"impossible"},
+			},
+		},
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	// the errors of the fields are reported in no particular order
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Message < result.Errors[j].Message
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,168 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+var appliedDirectivesCostDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name: "cost",
+	Args: graphql.FieldConfigArgument{
+		"weight": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"unit":   &graphql.ArgumentConfig{Type: graphql.String},
+	},
+	Locations: []string{
+		graphql.DirectiveLocationObject,
+		graphql.DirectiveLocationFieldDefinition,
+		graphql.DirectiveLocationArgumentDefinition,
+		graphql.DirectiveLocationEnumValue,
+	},
+})
+
+func appliedDirectivesTestSchema(t *testing.T, introspect bool) graphql.Schema {
+	sort := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Sort",
+		Values: graphql.EnumValueConfigMap{
+			"RANDOM": &graphql.EnumValueConfig{
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "cost", Args: map[string]interface{}{"weight": 5}},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(sort),
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{
+							Type: graphql.Int,
+							AppliedDirectives: []*graphql.AppliedDirective{
+								{Name: "constraint", Args: map[string]interface{}{"max": 100}},
+							},
+						},
+					},
+					AppliedDirectives: []*graphql.AppliedDirective{
+						{Name: "cost", Args: map[string]interface{}{"weight": 2, "unit": "ms"}},
+					},
+				},
+			},
+			AppliedDirectives: []*graphql.AppliedDirective{
+				{Name: "cost", Args: map[string]interface{}{"weight": 1}},
+			},
+		}),
+		Directives:                  append(graphql.SpecifiedDirectives, appliedDirectivesCostDirective),
+		IntrospectAppliedDirectives: introspect,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+const appliedDirectivesQuery = `{
+	__type(name: "Query") {
+		appliedDirectives { name args { name value } }
+		fields {
+			appliedDirectives { name args { name value } }
+			args { name appliedDirectives { name args { name value } } }
+		}
+	}
+	sort: __type(name: "Sort") {
+		appliedDirectives { name }
+		enumValues { name appliedDirectives { name args { name value } } }
+	}
+}`
+
+func TestAppliedDirectives_Introspection(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        appliedDirectivesTestSchema(t, true),
+		RequestString: appliedDirectivesQuery,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"__type": map[string]interface{}{
+			"appliedDirectives": []interface{}{
+				map[string]interface{}{
+					"name": "cost",
+					"args": []interface{}{
+						map[string]interface{}{"name": "weight", "value": "1"},
+					},
+				},
+			},
+			"fields": []interface{}{
+				map[string]interface{}{
+					"appliedDirectives": []interface{}{
+						map[string]interface{}{
+							"name": "cost",
+							"args": []interface{}{
+								map[string]interface{}{"name": "unit", "value": `"ms"`},
+								map[string]interface{}{"name": "weight", "value": "2"},
+							},
+						},
+					},
+					"args": []interface{}{
+						map[string]interface{}{
+							"name": "first",
+							"appliedDirectives": []interface{}{
+								map[string]interface{}{
+									"name": "constraint",
+									"args": []interface{}{
+										map[string]interface{}{"name": "max", "value": "100"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"sort": map[string]interface{}{
+			"appliedDirectives": []interface{}{},
+			"enumValues": []interface{}{
+				map[string]interface{}{
+					"name": "RANDOM",
+					"appliedDirectives": []interface{}{
+						map[string]interface{}{
+							"name": "cost",
+							"args": []interface{}{
+								map[string]interface{}{"name": "weight", "value": "5"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !testutil.EqualResults(&graphql.Result{Data: expected}, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestAppliedDirectives_NullUnlessIntrospected(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        appliedDirectivesTestSchema(t, false),
+		RequestString: `{ __type(name: "Query") { appliedDirectives { name } fields { appliedDirectives { name } } } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"__type": map[string]interface{}{
+			"appliedDirectives": nil,
+			"fields": []interface{}{
+				map[string]interface{}{"appliedDirectives": nil},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
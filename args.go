@@ -0,0 +1,229 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// ArgumentValues returns the values of the arguments of the field, coerced
+// as given to its resolve function in ResolveParams.Args, e.g. for the
+// extensions notified of the field, see Extension.ResolveFieldDidStart. It is
+// nil outside of the execution of an operation.
+func (info ResolveInfo) ArgumentValues() map[string]interface{} {
+	return info.args
+}
+
+// Arg returns the argument of the resolved field with the given name
+// converted to T, and whether it was provided and not null, e.g.
+//
+//	first, ok, err := graphql.Arg[int32](p, "first")
+//
+// Numbers are converted to any integer or float type, failing if they overflow
+// it or lose their fractional part, and values are converted to the Go types
+// having the same underlying kind, such as a custom `type UserID string`, or to
+// pointers to T. Input objects are converted to structs, their fields
+// matched by the `graphql` or `json` tags or the names of the struct fields,
+// and lists to slices. An error is returned if the argument cannot be
+// converted.
+func Arg[T any](p ResolveParams, name string) (T, bool, error) {
+	var zero T
+	value, ok := p.Args[name]
+	if !ok || value == nil {
+		return zero, false, nil
+	}
+	converted, err := convertArg(value, reflect.TypeOf(&zero).Elem())
+	if err != nil {
+		return zero, true, fmt.Errorf(`argument "%v": %v`, name, err)
+	}
+	return converted.(T), true, nil
+}
+
+// ArgSlice returns the list argument of the resolved field with the given
+// name, with its items converted to T as by Arg, and whether it was provided
+// and not null. Null items are the zero value of T.
+func ArgSlice[T any](p ResolveParams, name string) ([]T, bool, error) {
+	value, ok := p.Args[name]
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return nil, true, fmt.Errorf(`argument "%v": expected a list, got %T`, name, value)
+	}
+	var zero T
+	itemType := reflect.TypeOf(&zero).Elem()
+	items := make([]T, list.Len())
+	for i := range items {
+		item := list.Index(i).Interface()
+		if item == nil {
+			continue
+		}
+		converted, err := convertArg(item, itemType)
+		if err != nil {
+			return nil, true, fmt.Errorf(`argument "%v" at index %d: %v`, name, i, err)
+		}
+		items[i] = converted.(T)
+	}
+	return items, true, nil
+}
+
+// convertArg converts the non-null argument value to the given type.
+func convertArg(value interface{}, to reflect.Type) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(to) {
+		return value, nil
+	}
+	if to.Kind() == reflect.Ptr {
+		converted, err := convertArg(value, to.Elem())
+		if err != nil {
+			return nil, err
+		}
+		ptr := reflect.New(to.Elem())
+		ptr.Elem().Set(reflect.ValueOf(converted))
+		return ptr.Interface(), nil
+	}
+
+	switch {
+	case isIntKind(to.Kind()):
+		var n int64
+		switch {
+		case isIntKind(v.Kind()):
+			n = v.Int()
+		case isUintKind(v.Kind()):
+			if v.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("%v overflows %v", value, to)
+			}
+			n = int64(v.Uint())
+		case isFloatKind(v.Kind()):
+			f := v.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf("%v is not an integer of %v", value, to)
+			}
+			n = int64(f)
+		default:
+			return nil, fmt.Errorf("cannot convert %T to %v", value, to)
+		}
+		if reflect.Zero(to).OverflowInt(n) {
+			return nil, fmt.Errorf("%v overflows %v", value, to)
+		}
+		return reflect.ValueOf(n).Convert(to).Interface(), nil
+	case isUintKind(to.Kind()):
+		var n uint64
+		switch {
+		case isIntKind(v.Kind()):
+			if v.Int() < 0 {
+				return nil, fmt.Errorf("%v overflows %v", value, to)
+			}
+			n = uint64(v.Int())
+		case isUintKind(v.Kind()):
+			n = v.Uint()
+		case isFloatKind(v.Kind()):
+			f := v.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return nil, fmt.Errorf("%v is not an integer of %v", value, to)
+			}
+			n = uint64(f)
+		default:
+			return nil, fmt.Errorf("cannot convert %T to %v", value, to)
+		}
+		if reflect.Zero(to).OverflowUint(n) {
+			return nil, fmt.Errorf("%v overflows %v", value, to)
+		}
+		return reflect.ValueOf(n).Convert(to).Interface(), nil
+	case isFloatKind(to.Kind()):
+		var f float64
+		switch {
+		case isIntKind(v.Kind()):
+			f = float64(v.Int())
+		case isUintKind(v.Kind()):
+			f = float64(v.Uint())
+		case isFloatKind(v.Kind()):
+			f = v.Float()
+		default:
+			return nil, fmt.Errorf("cannot convert %T to %v", value, to)
+		}
+		if reflect.Zero(to).OverflowFloat(f) {
+			return nil, fmt.Errorf("%v overflows %v", value, to)
+		}
+		return reflect.ValueOf(f).Convert(to).Interface(), nil
+	}
+
+	if v.Kind() == to.Kind() && v.Type().ConvertibleTo(to) {
+		return v.Convert(to).Interface(), nil
+	}
+	switch {
+	case to.Kind() == reflect.Struct && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		return convertStructArg(v, to)
+	case to.Kind() == reflect.Slice && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+		converted := reflect.MakeSlice(to, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i).Interface()
+			if item == nil {
+				continue
+			}
+			convertedItem, err := convertArg(item, to.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %v", i, err)
+			}
+			converted.Index(i).Set(reflect.ValueOf(convertedItem))
+		}
+		return converted.Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to %v", value, to)
+}
+
+// convertStructArg converts the input object value to the given struct type,
+// leaving the fields not provided or null to their zero value.
+func convertStructArg(v reflect.Value, to reflect.Type) (interface{}, error) {
+	converted := reflect.New(to).Elem()
+	for i := 0; i < to.NumField(); i++ {
+		field := to.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := structFieldName(field)
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !value.IsValid() || value.Interface() == nil {
+			continue
+		}
+		convertedField, err := convertArg(value.Interface(), field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", name, err)
+		}
+		converted.Field(i).Set(reflect.ValueOf(convertedField))
+	}
+	return converted.Interface(), nil
+}
+
+// structFieldName returns the name of the input field of a struct field, from
+// its `graphql` or `json` tag, or its name with a lowercase first letter.
+func structFieldName(field reflect.StructField) string {
+	for _, key := range []string{"graphql", "json"} {
+		if name := strings.Split(field.Tag.Get(key), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name[:1]) + field.Name[1:]
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
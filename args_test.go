@@ -0,0 +1,124 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type argUserID string
+
+func TestArg(t *testing.T) {
+	p := graphql.ResolveParams{Args: map[string]interface{}{
+		"first":  42,
+		"big":    1 << 40,
+		"ratio":  1.5,
+		"whole":  float64(3),
+		"id":     "u1",
+		"active": true,
+	}}
+
+	if first, ok, err := graphql.Arg[int32](p, "first"); err != nil || !ok || first != 42 {
+		t.Fatalf("unexpected int32 argument: %v %v %v", first, ok, err)
+	}
+	if first, ok, err := graphql.Arg[uint8](p, "first"); err != nil || !ok || first != 42 {
+		t.Fatalf("unexpected uint8 argument: %v %v %v", first, ok, err)
+	}
+	if first, _, err := graphql.Arg[float64](p, "first"); err != nil || first != 42 {
+		t.Fatalf("unexpected float64 argument: %v %v", first, err)
+	}
+	if whole, _, err := graphql.Arg[int](p, "whole"); err != nil || whole != 3 {
+		t.Fatalf("unexpected int argument: %v %v", whole, err)
+	}
+	if id, _, err := graphql.Arg[argUserID](p, "id"); err != nil || id != "u1" {
+		t.Fatalf("unexpected custom argument: %v %v", id, err)
+	}
+	if active, _, err := graphql.Arg[*bool](p, "active"); err != nil || active == nil || !*active {
+		t.Fatalf("unexpected pointer argument: %v %v", active, err)
+	}
+	if missing, ok, err := graphql.Arg[string](p, "missing"); err != nil || ok || missing != "" {
+		t.Fatalf("unexpected missing argument: %v %v %v", missing, ok, err)
+	}
+
+	errorCases := []struct {
+		name     string
+		convert  func() error
+		expected string
+	}{
+		{"overflow", func() error { _, _, err := graphql.Arg[int32](p, "big"); return err }, `argument "big": 1099511627776 overflows int32`},
+		{"fraction", func() error { _, _, err := graphql.Arg[int](p, "ratio"); return err }, `argument "ratio": 1.5 is not an integer of int`},
+		{"kind", func() error { _, _, err := graphql.Arg[int](p, "id"); return err }, `argument "id": cannot convert string to int`},
+	}
+	for _, test := range errorCases {
+		err := test.convert()
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%v: expected error %q, got: %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestArgSlice(t *testing.T) {
+	p := graphql.ResolveParams{Args: map[string]interface{}{
+		"ids":   []interface{}{"a", nil, "c"},
+		"sizes": []interface{}{1, 300},
+		"name":  "a",
+	}}
+	ids, ok, err := graphql.ArgSlice[argUserID](p, "ids")
+	if err != nil || !ok || !reflect.DeepEqual(ids, []argUserID{"a", "", "c"}) {
+		t.Fatalf("unexpected list argument: %v %v %v", ids, ok, err)
+	}
+	if _, _, err := graphql.ArgSlice[uint8](p, "sizes"); err == nil || err.Error() != `argument "sizes" at index 1: 300 overflows uint8` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := graphql.ArgSlice[string](p, "name"); err == nil {
+		t.Fatalf("expected an error for a non list argument")
+	}
+	if sizes, ok, err := graphql.ArgSlice[int](p, "missing"); err != nil || ok || sizes != nil {
+		t.Fatalf("unexpected missing argument: %v %v %v", sizes, ok, err)
+	}
+}
+
+type argAddress struct {
+	City    string  `graphql:"city"`
+	ZipCode *string `json:"zip_code,omitempty"`
+}
+
+type argUserInput struct {
+	Name      string
+	ID        argUserID    `graphql:"id"`
+	Age       *int32       `graphql:"age"`
+	Addresses []argAddress `graphql:"addresses"`
+}
+
+func TestArg_InputObjects(t *testing.T) {
+	p := graphql.ResolveParams{Args: map[string]interface{}{
+		"input": map[string]interface{}{
+			"name": "Jane",
+			"id":   "u1",
+			"age":  nil,
+			"addresses": []interface{}{
+				map[string]interface{}{"city": "Paris", "zip_code": "75001"},
+				nil,
+			},
+		},
+		"invalid": map[string]interface{}{"addresses": []interface{}{map[string]interface{}{"city": 1}}},
+	}}
+	zipCode := "75001"
+	expected := argUserInput{
+		Name:      "Jane",
+		ID:        "u1",
+		Addresses: []argAddress{{City: "Paris", ZipCode: &zipCode}, {}},
+	}
+	input, ok, err := graphql.Arg[argUserInput](p, "input")
+	if err != nil || !ok || !reflect.DeepEqual(input, expected) {
+		t.Fatalf("unexpected input object argument: %+v %v %v", input, ok, err)
+	}
+	if pointer, _, err := graphql.Arg[*argUserInput](p, "input"); err != nil || !reflect.DeepEqual(*pointer, expected) {
+		t.Fatalf("unexpected pointer argument: %+v %v", pointer, err)
+	}
+	expectedErr := `argument "invalid": field addresses: at index 0: field city: cannot convert int to string`
+	if _, _, err := graphql.Arg[argUserInput](p, "invalid"); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got: %v", expectedErr, err)
+	}
+}
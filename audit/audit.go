@@ -0,0 +1,249 @@
+// Package audit provides a graphql.Extension emitting structured events of
+// the execution of operations to a sink, as an audit trail of the
+// state-changing calls: the start and end of the mutations, the mutation
+// fields invoked with their arguments redacted by a policy, and their errors.
+//
+//	schema.AddExtensions(audit.New(audit.Config{
+//	    Redact: slowquery.RedactNames("password"),
+//	    Sink: audit.SinkFunc(func(ctx context.Context, e *audit.Event) {
+//	        log.Printf("%v %v %v %v", e.Kind, e.ExecutionID, e.Field, e.Arguments)
+//	    }),
+//	}))
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/slowquery"
+)
+
+// EventKind is the kind of an event.
+type EventKind string
+
+const (
+	// OperationStarted is emitted when the operation resolves its first field.
+	OperationStarted EventKind = "OperationStarted"
+
+	// MutationFieldInvoked is emitted when a top level field of a mutation is
+	// resolved, with its arguments.
+	MutationFieldInvoked EventKind = "MutationFieldInvoked"
+
+	// OperationFailed is emitted for each error of the result of the operation.
+	OperationFailed EventKind = "OperationFailed"
+
+	// OperationFinished is emitted when the execution of the operation is done.
+	OperationFinished EventKind = "OperationFinished"
+)
+
+// Event describes a step of the execution of an operation.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+
+	// ExecutionID identifies the operation, see graphql.ExecuteParams.ExecutionID.
+	ExecutionID   string
+	OperationName string
+
+	// OperationType is the type of the operation, see ast.OperationTypeMutation.
+	OperationType string
+
+	// Field and Path are the name and path of the field of
+	// MutationFieldInvoked events, and Arguments the values of its arguments,
+	// redacted by Config.Redact.
+	Field     string
+	Path      []interface{}
+	Arguments map[string]interface{}
+
+	// Error is the error of OperationFailed events.
+	Error *gqlerrors.FormattedError
+
+	// Duration is the time elapsed since the execution of the operation
+	// started, for OperationFinished events.
+	Duration time.Duration
+}
+
+// Sink receives the events. It is called synchronously during the execution,
+// implementations should hand the events off quickly.
+type Sink interface {
+	Emit(ctx context.Context, event *Event)
+}
+
+// SinkFunc is an adapter to allow the use of ordinary functions as sinks.
+type SinkFunc func(ctx context.Context, event *Event)
+
+// Emit calls f(ctx, event).
+func (f SinkFunc) Emit(ctx context.Context, event *Event) {
+	f(ctx, event)
+}
+
+// Config configures the audit of operations.
+type Config struct {
+	// Sink receives the events.
+	Sink Sink
+
+	// Redact is applied to the arguments of the mutation fields, defaults to
+	// slowquery.RedactAll.
+	Redact slowquery.RedactFunc
+
+	// AllOperations audits the queries and subscriptions as well, only the
+	// mutations are audited otherwise. Their fields are not reported.
+	AllOperations bool
+}
+
+// Extension emits the events of the execution of operations, see New.
+//
+// The operations are audited once they resolve their first field: those
+// failing before, such as those whose variables are invalid, are not.
+type Extension struct {
+	config Config
+}
+
+// New returns an extension auditing operations according to the given config.
+func New(config Config) *Extension {
+	if config.Redact == nil {
+		config.Redact = slowquery.RedactAll
+	}
+	return &Extension{config: config}
+}
+
+type operationKeyType int
+
+const operationKey operationKeyType = 0
+
+// operation holds the state of an operation being audited.
+type operation struct {
+	executionID string
+	start       time.Time
+
+	mu            sync.Mutex
+	started       bool
+	operationName string
+	operationType string
+}
+
+func operationFromContext(ctx context.Context) *operation {
+	if ctx == nil {
+		return nil
+	}
+	o, _ := ctx.Value(operationKey).(*operation)
+	return o
+}
+
+// event returns a new event of the operation.
+func (o *operation) event(kind EventKind, now time.Time) *Event {
+	return &Event{
+		Kind:          kind,
+		Time:          now,
+		ExecutionID:   o.executionID,
+		OperationName: o.operationName,
+		OperationType: o.operationType,
+	}
+}
+
+// Init implements graphql.Extension.
+func (e *Extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+// Name implements graphql.Extension.
+func (e *Extension) Name() string {
+	return "Audit"
+}
+
+// ParseDidStart implements graphql.Extension.
+func (e *Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (e *Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart implements graphql.Extension.
+func (e *Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	if e.config.Sink == nil {
+		return ctx, func(*graphql.Result) {}
+	}
+	o := &operation{
+		executionID: graphql.ExecutionIDFromContext(ctx),
+		start:       time.Now(),
+	}
+	return context.WithValue(ctx, operationKey, o), func(result *graphql.Result) {
+		o.mu.Lock()
+		started := o.started
+		o.mu.Unlock()
+		if !started {
+			return
+		}
+		now := time.Now()
+		if result != nil {
+			for i := range result.Errors {
+				event := o.event(OperationFailed, now)
+				event.Error = &result.Errors[i]
+				e.config.Sink.Emit(ctx, event)
+			}
+		}
+		event := o.event(OperationFinished, now)
+		event.Duration = now.Sub(o.start)
+		e.config.Sink.Emit(ctx, event)
+	}
+}
+
+// ResolveFieldDidStart implements graphql.Extension.
+func (e *Extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	o := operationFromContext(ctx)
+	if o == nil {
+		return ctx, func(interface{}, error) {}
+	}
+	operationDefinition, ok := info.Operation.(*ast.OperationDefinition)
+	if !ok {
+		return ctx, func(interface{}, error) {}
+	}
+	mutation := operationDefinition.Operation == ast.OperationTypeMutation
+	if !mutation && !e.config.AllOperations {
+		return ctx, func(interface{}, error) {}
+	}
+
+	o.mu.Lock()
+	started := o.started
+	if !started {
+		o.started = true
+		o.operationType = operationDefinition.Operation
+		if operationDefinition.Name != nil {
+			o.operationName = operationDefinition.Name.Value
+		}
+	}
+	o.mu.Unlock()
+	if !started {
+		event := o.event(OperationStarted, o.start)
+		e.config.Sink.Emit(ctx, event)
+	}
+
+	// the top level fields of a mutation change the state
+	if mutation && info.Path != nil && info.Path.Prev == nil {
+		event := o.event(MutationFieldInvoked, time.Now())
+		event.Field = info.FieldName
+		event.Path = info.Path.AsArray()
+		if args := info.ArgumentValues(); args != nil {
+			event.Arguments = e.config.Redact(args)
+		}
+		e.config.Sink.Emit(ctx, event)
+	}
+	return ctx, func(interface{}, error) {}
+}
+
+// HasResult implements graphql.Extension.
+func (e *Extension) HasResult() bool {
+	return false
+}
+
+// GetResult implements graphql.Extension.
+func (e *Extension) GetResult(context.Context) interface{} {
+	return nil
+}
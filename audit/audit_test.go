@@ -0,0 +1,192 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/audit"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/slowquery"
+)
+
+// recorder records the emitted events.
+type recorder struct {
+	mu     sync.Mutex
+	events []*audit.Event
+}
+
+func (r *recorder) Emit(ctx context.Context, event *audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func newSchema(t *testing.T, ext graphql.Extension) graphql.Schema {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "ada"}, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createUser": &graphql.Field{
+					Type: user,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.NewInputObject(graphql.InputObjectConfig{
+							Name: "CreateUserInput",
+							Fields: graphql.InputObjectConfigFieldMap{
+								"name":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+								"password": &graphql.InputObjectFieldConfig{Type: graphql.String},
+							},
+						})},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["input"], nil
+					},
+				},
+				"deleteUser": &graphql.Field{
+					Type: graphql.Boolean,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("permission denied")
+					},
+				},
+			},
+		}),
+		Extensions: []graphql.Extension{ext},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+// summary keeps the fields of the events set by the extension, for
+// comparison.
+func summary(events []*audit.Event) []audit.Event {
+	summarized := []audit.Event{}
+	for _, event := range events {
+		e := audit.Event{
+			Kind:          event.Kind,
+			OperationName: event.OperationName,
+			OperationType: event.OperationType,
+			Field:         event.Field,
+			Path:          event.Path,
+			Arguments:     event.Arguments,
+		}
+		if event.Error != nil {
+			e.Error = &gqlerrors.FormattedError{Message: event.Error.Message}
+		}
+		summarized = append(summarized, e)
+	}
+	return summarized
+}
+
+func TestExtension_AuditsMutations(t *testing.T) {
+	r := &recorder{}
+	schema := newSchema(t, audit.New(audit.Config{Sink: r, Redact: slowquery.RedactNames("password")}))
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `mutation Signup($password: String) {
+			createUser(input: {name: "ada", password: $password}) { name }
+			deleteUser(name: "bob")
+		}`,
+		VariableValues: map[string]interface{}{"password": "secret"},
+		ExecutionID:    "execution",
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected an error, got %v", result.Errors)
+	}
+
+	for _, event := range r.events {
+		if event.ExecutionID != "execution" || event.Time.IsZero() {
+			t.Fatalf("expected the execution ID and time of the events, got %+v", event)
+		}
+	}
+	finished := r.events[len(r.events)-1]
+	if finished.Duration != finished.Time.Sub(r.events[0].Time) {
+		t.Fatalf("expected the duration of the operation, got %v", finished.Duration)
+	}
+
+	expected := []audit.Event{
+		{Kind: audit.OperationStarted, OperationName: "Signup", OperationType: "mutation"},
+		{
+			Kind:          audit.MutationFieldInvoked,
+			OperationName: "Signup",
+			OperationType: "mutation",
+			Field:         "createUser",
+			Path:          []interface{}{"createUser"},
+			Arguments: map[string]interface{}{
+				"input": map[string]interface{}{"name": "ada", "password": slowquery.Redacted},
+			},
+		},
+		{
+			Kind:          audit.MutationFieldInvoked,
+			OperationName: "Signup",
+			OperationType: "mutation",
+			Field:         "deleteUser",
+			Path:          []interface{}{"deleteUser"},
+			Arguments:     map[string]interface{}{"name": "bob"},
+		},
+		{
+			Kind:          audit.OperationFailed,
+			OperationName: "Signup",
+			OperationType: "mutation",
+			Error:         &gqlerrors.FormattedError{Message: "permission denied"},
+		},
+		{Kind: audit.OperationFinished, OperationName: "Signup", OperationType: "mutation"},
+	}
+	if events := summary(r.events); !reflect.DeepEqual(expected, events) {
+		t.Fatalf("unexpected events\nexpected: %+v\n     got: %+v", expected, events)
+	}
+}
+
+func TestExtension_RedactsAllArgumentsByDefault(t *testing.T) {
+	r := &recorder{}
+	schema := newSchema(t, audit.New(audit.Config{Sink: r}))
+	graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation { createUser(input: {name: "ada"}) { name } }`})
+	if len(r.events) != 3 || !reflect.DeepEqual(r.events[1].Arguments, map[string]interface{}{"input": slowquery.Redacted}) {
+		t.Fatalf("expected the arguments to be redacted, got %+v", r.events)
+	}
+}
+
+func TestExtension_AuditsQueriesWhenConfigured(t *testing.T) {
+	r := &recorder{}
+	schema := newSchema(t, audit.New(audit.Config{Sink: r}))
+	graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { name } }`})
+	if len(r.events) != 0 {
+		t.Fatalf("expected the queries not to be audited, got %+v", r.events)
+	}
+
+	r = &recorder{}
+	schema = newSchema(t, audit.New(audit.Config{Sink: r, AllOperations: true}))
+	graphql.Do(graphql.Params{Schema: schema, RequestString: `query Me { user { name } }`})
+	expected := []audit.Event{
+		{Kind: audit.OperationStarted, OperationName: "Me", OperationType: "query"},
+		{Kind: audit.OperationFinished, OperationName: "Me", OperationType: "query"},
+	}
+	if events := summary(r.events); !reflect.DeepEqual(expected, events) {
+		t.Fatalf("unexpected events\nexpected: %+v\n     got: %+v", expected, events)
+	}
+}
@@ -0,0 +1,121 @@
+package benchutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+type node struct {
+	Depth int
+}
+
+// DeepSchemaWithXLevelsAndYChildren returns a schema of nodes nested up to x levels, each
+// node having y children.
+func DeepSchemaWithXLevelsAndYChildren(x int, y int) graphql.Schema {
+	nodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Node",
+		Description: "A node of a tree",
+		Fields: graphql.Fields{
+			"depth": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if n, ok := p.Source.(node); ok {
+						return n.Depth, nil
+					}
+					return nil, nil
+				},
+			},
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if n, ok := p.Source.(node); ok {
+						return fmt.Sprintf("node-%d", n.Depth), nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	nodeType.AddFieldConfig("children", &graphql.Field{
+		Type: graphql.NewList(nodeType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			n, ok := p.Source.(node)
+			if !ok || n.Depth >= x {
+				return nil, nil
+			}
+			children := make([]node, 0, y)
+			for i := 0; i < y; i++ {
+				children = append(children, node{Depth: n.Depth + 1})
+			}
+			return children, nil
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"root": {
+				Type: nodeType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return node{}, nil
+				},
+			},
+		},
+	})
+
+	deepSchema, _ := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+
+	return deepSchema
+}
+
+// DeepSchemaQuery returns a query selecting the nodes of a deep schema down to x levels.
+func DeepSchemaQuery(x int) string {
+	var b strings.Builder
+	b.WriteString("query { root { ")
+	for i := 0; i < x; i++ {
+		b.WriteString("depth name children { ")
+	}
+	b.WriteString("depth name")
+	for i := 0; i < x; i++ {
+		b.WriteString(" }")
+	}
+	b.WriteString(" } }")
+	return b.String()
+}
+
+// DeepSchemaFragmentsQuery returns the same query as DeepSchemaQuery, selecting
+// the fields of each level through nested fragments.
+func DeepSchemaFragmentsQuery(x int) string {
+	var b strings.Builder
+	b.WriteString("query { root { ...Level0 } }\n")
+	for i := 0; i <= x; i++ {
+		fmt.Fprintf(&b, "fragment Level%d on Node { ...Fields", i)
+		if i < x {
+			fmt.Fprintf(&b, " children { ...Level%d }", i+1)
+		}
+		b.WriteString(" }\n")
+	}
+	b.WriteString("fragment Fields on Node { depth name }\n")
+	return b.String()
+}
+
+// DeepSchemaConditionalFragmentsQuery returns the same query as
+// DeepSchemaFragmentsQuery with the fragments and fields of each level
+// conditioned by @include and @skip, with literals and the $withName variable.
+func DeepSchemaConditionalFragmentsQuery(x int) string {
+	var b strings.Builder
+	b.WriteString("query ($withName: Boolean!) { root { ...Level0 } }\n")
+	for i := 0; i <= x; i++ {
+		fmt.Fprintf(&b, "fragment Level%d on Node { ...Fields @include(if: true)", i)
+		if i < x {
+			fmt.Fprintf(&b, " children @skip(if: false) { ...Level%d }", i+1)
+		}
+		b.WriteString(" }\n")
+	}
+	b.WriteString("fragment Fields on Node { depth @skip(if: false) name @include(if: $withName) }\n")
+	return b.String()
+}
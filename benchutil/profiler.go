@@ -0,0 +1,150 @@
+package benchutil
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Phases of a request, as reported by PhaseProfiler.
+const (
+	PhaseParse      = "parse"
+	PhaseValidation = "validation"
+	PhaseExecution  = "execution"
+)
+
+// PhaseProfile describes the resources used by a phase of the profiled requests.
+type PhaseProfile struct {
+	Count          int
+	Duration       time.Duration
+	Mallocs        uint64
+	AllocatedBytes uint64
+}
+
+// PhaseProfiler is a graphql.Extension capturing the time and allocations
+// spent in each phase of the requests, and optionally a CPU profile of one of
+// the phases. It is meant for benchmarks, as reading the allocation counters of
+// the runtime stops the world and the counters are shared by all goroutines.
+//
+//     profiler := benchutil.NewPhaseProfiler()
+//     schema.AddExtensions(profiler)
+//     ...
+//     profile := profiler.Profile(benchutil.PhaseExecution)
+type PhaseProfiler struct {
+	// CPUProfilePhase is the phase to capture a CPU profile of into CPUProfile.
+	CPUProfilePhase string
+	CPUProfile      io.Writer
+
+	mu       sync.Mutex
+	profiles map[string]*PhaseProfile
+}
+
+// NewPhaseProfiler returns a new PhaseProfiler.
+func NewPhaseProfiler() *PhaseProfiler {
+	return &PhaseProfiler{
+		profiles: map[string]*PhaseProfile{},
+	}
+}
+
+// Profile returns the profile accumulated for the given phase.
+func (p *PhaseProfiler) Profile(phase string) PhaseProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if profile, ok := p.profiles[phase]; ok {
+		return *profile
+	}
+	return PhaseProfile{}
+}
+
+// Reset discards the accumulated profiles.
+func (p *PhaseProfiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.profiles = map[string]*PhaseProfile{}
+}
+
+// start starts profiling the given phase and returns the function to call when it is done.
+func (p *PhaseProfiler) start(phase string) func() {
+	cpuProfile := p.CPUProfile != nil && p.CPUProfilePhase == phase
+	if cpuProfile && pprof.StartCPUProfile(p.CPUProfile) != nil {
+		cpuProfile = false
+	}
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	return func() {
+		duration := time.Since(start)
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		if cpuProfile {
+			pprof.StopCPUProfile()
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		profile, ok := p.profiles[phase]
+		if !ok {
+			profile = &PhaseProfile{}
+			p.profiles[phase] = profile
+		}
+		profile.Count++
+		profile.Duration += duration
+		profile.Mallocs += after.Mallocs - before.Mallocs
+		profile.AllocatedBytes += after.TotalAlloc - before.TotalAlloc
+	}
+}
+
+// Init implements graphql.Extension.
+func (p *PhaseProfiler) Init(ctx context.Context, _ *graphql.Params) context.Context {
+	return ctx
+}
+
+// Name implements graphql.Extension.
+func (p *PhaseProfiler) Name() string {
+	return "PhaseProfiler"
+}
+
+// ParseDidStart implements graphql.Extension.
+func (p *PhaseProfiler) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	done := p.start(PhaseParse)
+	return ctx, func(error) {
+		done()
+	}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (p *PhaseProfiler) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	done := p.start(PhaseValidation)
+	return ctx, func([]gqlerrors.FormattedError) {
+		done()
+	}
+}
+
+// ExecutionDidStart implements graphql.Extension.
+func (p *PhaseProfiler) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	done := p.start(PhaseExecution)
+	return ctx, func(*graphql.Result) {
+		done()
+	}
+}
+
+// ResolveFieldDidStart implements graphql.Extension.
+func (p *PhaseProfiler) ResolveFieldDidStart(ctx context.Context, _ *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+// HasResult implements graphql.Extension.
+func (p *PhaseProfiler) HasResult() bool {
+	return false
+}
+
+// GetResult implements graphql.Extension.
+func (p *PhaseProfiler) GetResult(context.Context) interface{} {
+	return nil
+}
@@ -0,0 +1,453 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// BuildSchema builds an executable schema from the type definitions of an SDL
+// document, binding the resolvers to its fields by type and field name, e.g.
+//
+//	schema, err := graphql.BuildSchema(`
+//	  type Query {
+//	    user(id: ID!): User
+//	  }
+//	  type User {
+//	    id: ID!
+//	    name: String
+//	  }
+//	`, graphql.ResolverMap{
+//		"Query": {"user": resolveUser},
+//	})
+//
+// See BuildASTSchema.
+func BuildSchema(sdl string, resolvers ResolverMap) (Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(sdl),
+			Name: "GraphQL SDL",
+		}),
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+	return BuildASTSchema(doc, resolvers)
+}
+
+// BuildASTSchema builds an executable schema from the type definitions of a
+// parsed SDL document, binding the resolvers to its fields, see
+// Schema.BindResolvers.
+//
+// The root types are those of the schema definition, or the types named
+// Query, Mutation and Subscription. The types extended with `extend type` get
+// the fields and interfaces of their extensions, and the fields, arguments and
+// enum values annotated with @deprecated are deprecated. Custom scalars
+// serialize and parse their values as is, enum values have their name as
+// internal value, and abstract types resolve the type of Typed values, see
+// Typed.
+//
+// Fields without resolvers are not reported, they resolve the properties of
+// their parent, see DefaultResolveFn. It fails on executable definitions,
+// duplicate or unknown types and types of the wrong kind.
+func BuildASTSchema(doc *ast.Document, resolvers ResolverMap) (Schema, error) {
+	b := &schemaBuilder{
+		definitions: map[string]ast.Node{},
+		extensions:  map[string][]*ast.ObjectDefinition{},
+		types: map[string]Type{
+			Int.Name():     Int,
+			Float.Name():   Float,
+			String.Name():  String,
+			Boolean.Name(): Boolean,
+			ID.Name():      ID,
+		},
+	}
+	var schemaDefinition *ast.SchemaDefinition
+	directiveDefinitions := []*ast.DirectiveDefinition{}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.SchemaDefinition:
+			if schemaDefinition != nil {
+				return Schema{}, NewLocatedError("Must provide only one schema definition.", []ast.Node{definition})
+			}
+			schemaDefinition = definition
+		case *ast.DirectiveDefinition:
+			directiveDefinitions = append(directiveDefinitions, definition)
+		case *ast.TypeExtensionDefinition:
+			if object := definition.Definition; object != nil && object.Name != nil {
+				b.extensions[object.Name.Value] = append(b.extensions[object.Name.Value], object)
+			}
+		case *ast.ScalarDefinition, *ast.ObjectDefinition, *ast.InterfaceDefinition,
+			*ast.UnionDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition:
+			name := ""
+			if named, ok := definition.(interface{ GetName() *ast.Name }); ok && named.GetName() != nil {
+				name = named.GetName().Value
+			}
+			if _, ok := b.definitions[name]; ok || b.types[name] != nil {
+				return Schema{}, NewLocatedError(fmt.Sprintf(`There can be only one type named "%v".`, name), []ast.Node{definition})
+			}
+			b.definitions[name] = definition
+		default:
+			return Schema{}, NewLocatedError(
+				fmt.Sprintf("Schema definition documents cannot contain a %v.", definition.GetKind()),
+				[]ast.Node{definition},
+			)
+		}
+	}
+	for name, extensions := range b.extensions {
+		if _, ok := b.definitions[name].(*ast.ObjectDefinition); !ok {
+			return Schema{}, NewLocatedError(
+				fmt.Sprintf(`Cannot extend type "%v" because it is not an object type of the document.`, name),
+				[]ast.Node{extensions[0]},
+			)
+		}
+	}
+
+	config := SchemaConfig{}
+	roots := map[string]**Object{
+		ast.OperationTypeQuery:        &config.Query,
+		ast.OperationTypeMutation:     &config.Mutation,
+		ast.OperationTypeSubscription: &config.Subscription,
+	}
+	if schemaDefinition != nil {
+		for _, operationType := range schemaDefinition.OperationTypes {
+			root, ok := roots[operationType.Operation]
+			if !ok || operationType.Type == nil {
+				continue
+			}
+			*root = b.rootType(operationType.Type)
+		}
+	} else {
+		for operation, name := range map[string]string{
+			ast.OperationTypeQuery:        "Query",
+			ast.OperationTypeMutation:     "Mutation",
+			ast.OperationTypeSubscription: "Subscription",
+		} {
+			if _, ok := b.definitions[name]; ok {
+				*roots[operation] = b.rootType(ast.NewNamed(&ast.Named{Name: ast.NewName(&ast.Name{Value: name})}))
+			}
+		}
+	}
+
+	for name := range b.definitions {
+		config.Types = append(config.Types, b.namedType(name, nil))
+	}
+	defined := map[string]bool{}
+	for _, definition := range directiveDefinitions {
+		directive := b.directive(definition)
+		defined[directive.Name] = true
+		config.Directives = append(config.Directives, directive)
+	}
+	for _, directive := range SpecifiedDirectives {
+		if !defined[directive.Name] {
+			config.Directives = append(config.Directives, directive)
+		}
+	}
+	if b.err != nil {
+		return Schema{}, b.err
+	}
+
+	schema, err := NewSchema(config)
+	if b.err != nil {
+		// the errors of the thunks of the types take precedence
+		return Schema{}, b.err
+	}
+	if err != nil {
+		return Schema{}, err
+	}
+	if err := schema.BindResolversWithConfig(BindResolversConfig{Resolvers: resolvers, AllowMissing: true}); err != nil {
+		return Schema{}, err
+	}
+	return schema, nil
+}
+
+// schemaBuilder builds the types of an SDL document on demand, the fields of
+// the types being thunks so the types may reference each other.
+type schemaBuilder struct {
+	definitions map[string]ast.Node
+	extensions  map[string][]*ast.ObjectDefinition
+	types       map[string]Type
+
+	// err is the first error of the build, the thunks of the types cannot
+	// return theirs.
+	err error
+}
+
+func (b *schemaBuilder) fail(message string, node ast.Node) {
+	if b.err == nil {
+		b.err = NewLocatedError(message, []ast.Node{node})
+	}
+}
+
+func (b *schemaBuilder) rootType(named *ast.Named) *Object {
+	object, ok := b.namedType(named.Name.Value, named).(*Object)
+	if !ok {
+		b.fail(fmt.Sprintf(`The root type "%v" must be an object type.`, named.Name.Value), named)
+		return nil
+	}
+	return object
+}
+
+// namedType returns the named type, building it on first use, or nil after
+// reporting an unknown type at the given reference.
+func (b *schemaBuilder) namedType(name string, ref ast.Node) Type {
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	var ttype Type
+	switch definition := b.definitions[name].(type) {
+	case *ast.ScalarDefinition:
+		ttype = NewScalar(ScalarConfig{
+			Name:         name,
+			Description:  descriptionValue(definition.Description),
+			Serialize:    func(value interface{}) interface{} { return value },
+			ParseValue:   func(value interface{}) interface{} { return value },
+			ParseLiteral: untypedLiteralValue,
+		})
+	case *ast.ObjectDefinition:
+		ttype = b.object(definition)
+	case *ast.InterfaceDefinition:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Fields: (FieldsThunk)(func() Fields {
+				return b.fields(name, definition.Fields)
+			}),
+		})
+	case *ast.UnionDefinition:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			TypedValues: true,
+			Types: (UnionTypesThunk)(func() []*Object {
+				types := []*Object{}
+				for _, named := range definition.Types {
+					object, ok := b.namedType(named.Name.Value, named).(*Object)
+					if !ok {
+						b.fail(fmt.Sprintf(`Union "%v" may only contain object types, it cannot contain: %v.`, name, named.Name.Value), named)
+						continue
+					}
+					types = append(types, object)
+				}
+				return types
+			}),
+		})
+	case *ast.EnumDefinition:
+		values := EnumValueConfigMap{}
+		for _, value := range definition.Values {
+			values[value.Name.Value] = &EnumValueConfig{
+				Value:             value.Name.Value,
+				Description:       descriptionValue(value.Description),
+				DeprecationReason: deprecationReason(value.Directives),
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Values:      values,
+		})
+	case *ast.InputObjectDefinition:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Fields: (InputObjectConfigFieldMapThunk)(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for _, field := range definition.Fields {
+					fields[field.Name.Value] = &InputObjectFieldConfig{
+						Type:         b.inputType(fmt.Sprintf("%v.%v", name, field.Name.Value), field.Type),
+						DefaultValue: field.DefaultValue,
+						Description:  descriptionValue(field.Description),
+					}
+				}
+				return fields
+			}),
+		})
+	default:
+		if ref == nil {
+			ref = b.definitions[name]
+		}
+		b.fail(fmt.Sprintf(`Unknown type "%v".`, name), ref)
+		return nil
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+func (b *schemaBuilder) object(definition *ast.ObjectDefinition) *Object {
+	name := definition.Name.Value
+	definitions := append([]*ast.ObjectDefinition{definition}, b.extensions[name]...)
+	return NewObject(ObjectConfig{
+		Name:        name,
+		Description: descriptionValue(definition.Description),
+		Interfaces: (InterfacesThunk)(func() []*Interface {
+			interfaces := []*Interface{}
+			for _, definition := range definitions {
+				for _, named := range definition.Interfaces {
+					iface, ok := b.namedType(named.Name.Value, named).(*Interface)
+					if !ok {
+						b.fail(fmt.Sprintf(`%v may only implement interface types, it cannot implement: %v.`, name, named.Name.Value), named)
+						continue
+					}
+					interfaces = append(interfaces, iface)
+				}
+			}
+			return interfaces
+		}),
+		Fields: (FieldsThunk)(func() Fields {
+			fields := Fields{}
+			for _, definition := range definitions {
+				for fieldName, field := range b.fields(name, definition.Fields) {
+					if _, ok := fields[fieldName]; ok {
+						b.fail(fmt.Sprintf(`Field "%v.%v" can only be defined once.`, name, fieldName), definition)
+					}
+					fields[fieldName] = field
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func (b *schemaBuilder) fields(typeName string, definitions []*ast.FieldDefinition) Fields {
+	fields := Fields{}
+	for _, definition := range definitions {
+		fieldName := definition.Name.Value
+		ttype, _ := b.typeRef(definition.Type).(Output)
+		if ttype == nil || !IsOutputType(ttype) {
+			b.fail(fmt.Sprintf(`The type of "%v.%v" must be an output type.`, typeName, fieldName), definition.Type)
+		}
+		fields[fieldName] = &Field{
+			Name:              fieldName,
+			Type:              ttype,
+			Args:              b.arguments(typeName+"."+fieldName, definition.Arguments),
+			Description:       descriptionValue(definition.Description),
+			DeprecationReason: deprecationReason(definition.Directives),
+		}
+	}
+	return fields
+}
+
+func (b *schemaBuilder) arguments(owner string, definitions []*ast.InputValueDefinition) FieldConfigArgument {
+	args := FieldConfigArgument{}
+	for _, definition := range definitions {
+		args[definition.Name.Value] = &ArgumentConfig{
+			Type:              b.inputType(fmt.Sprintf("%v(%v:)", owner, definition.Name.Value), definition.Type),
+			DefaultValue:      definition.DefaultValue,
+			Description:       descriptionValue(definition.Description),
+			DeprecationReason: deprecationReason(definition.Directives),
+		}
+	}
+	return args
+}
+
+func (b *schemaBuilder) inputType(owner string, typeAST ast.Type) Input {
+	ttype, _ := b.typeRef(typeAST).(Input)
+	if ttype == nil || !IsInputType(ttype) {
+		b.fail(fmt.Sprintf(`The type of "%v" must be an input type.`, owner), typeAST)
+	}
+	return ttype
+}
+
+// typeRef returns the type of a type reference, nil after reporting an
+// unknown type.
+func (b *schemaBuilder) typeRef(typeAST ast.Type) Type {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		if ofType := b.typeRef(typeAST.Type); ofType != nil {
+			return NewList(ofType)
+		}
+	case *ast.NonNull:
+		if ofType := b.typeRef(typeAST.Type); ofType != nil {
+			return NewNonNull(ofType)
+		}
+	case *ast.Named:
+		return b.namedType(typeAST.Name.Value, typeAST)
+	}
+	return nil
+}
+
+func (b *schemaBuilder) directive(definition *ast.DirectiveDefinition) *Directive {
+	locations := []string{}
+	for _, location := range definition.Locations {
+		locations = append(locations, location.Value)
+	}
+	directive := NewDirective(DirectiveConfig{
+		Name:        definition.Name.Value,
+		Description: descriptionValue(definition.Description),
+		Locations:   locations,
+		Args:        b.arguments("@"+definition.Name.Value, definition.Arguments),
+		Repeatable:  definition.Repeatable,
+	})
+	if err := directive.err; err != nil {
+		b.fail(err.Error(), definition)
+	}
+	return directive
+}
+
+func descriptionValue(value *ast.StringValue) string {
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+// deprecationReason returns the reason of the @deprecated directive, empty
+// when not deprecated.
+func deprecationReason(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name != nil && arg.Name.Value == "reason" {
+				if reason, ok := arg.Value.(*ast.StringValue); ok {
+					return reason.Value
+				}
+			}
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// untypedLiteralValue returns the Go value of a literal of a custom scalar:
+// numbers, strings, booleans, enum values as strings, lists and objects.
+func untypedLiteralValue(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		if value, err := strconv.Atoi(valueAST.Value); err == nil {
+			return value
+		}
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.FloatValue:
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, value := range valueAST.Values {
+			values = append(values, untypedLiteralValue(value))
+		}
+		return values
+	case *ast.ObjectValue:
+		object := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			if field.Name != nil {
+				object[field.Name.Value] = untypedLiteralValue(field.Value)
+			}
+		}
+		return object
+	}
+	return nil
+}
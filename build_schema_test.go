@@ -0,0 +1,165 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const buildSchemaSDL = `
+"A user of the service."
+type User implements Node {
+  id: ID!
+  name: String
+  nickname: String @deprecated(reason: "Use name.")
+  role: Role
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Role {
+  ADMIN
+  MEMBER @deprecated
+}
+
+input UserFilter {
+  role: Role = MEMBER
+  first: Int = 10
+}
+
+scalar JSON
+
+type Query {
+  user(id: ID!): User
+  users(filter: UserFilter): [User]
+  node(id: ID!): Node
+  echo(value: JSON): JSON
+}
+
+extend type Query {
+  version: String
+}
+`
+
+func TestBuildSchema(t *testing.T) {
+	users := map[string]map[string]interface{}{
+		"1": {"id": "1", "name": "Ada", "role": "ADMIN"},
+		"2": {"id": "2", "name": "Alan", "role": "MEMBER"},
+	}
+	schema, err := graphql.BuildSchema(buildSchemaSDL, graphql.ResolverMap{
+		"Query": {
+			"user": func(p graphql.ResolveParams) (interface{}, error) {
+				return users[p.Args["id"].(string)], nil
+			},
+			"users": func(p graphql.ResolveParams) (interface{}, error) {
+				filter := p.Args["filter"].(map[string]interface{})
+				result := []interface{}{}
+				for _, id := range []string{"1", "2"} {
+					if users[id]["role"] == filter["role"] && len(result) < filter["first"].(int) {
+						result = append(result, users[id])
+					}
+				}
+				return result, nil
+			},
+			"node": func(p graphql.ResolveParams) (interface{}, error) {
+				return graphql.Typed{TypeName: "User", Value: users[p.Args["id"].(string)]}, nil
+			},
+			"echo": func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Args["value"], nil
+			},
+			"version": func(p graphql.ResolveParams) (interface{}, error) {
+				return "1.0", nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			user(id: "1") { name role }
+			users(filter: {}) { id }
+			node(id: "2") { ... on User { name } }
+			echo(value: {a: [1, "b"]})
+			version
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user":    map[string]interface{}{"name": "Ada", "role": "ADMIN"},
+			"users":   []interface{}{map[string]interface{}{"id": "2"}},
+			"node":    map[string]interface{}{"name": "Alan"},
+			"echo":    map[string]interface{}{"a": []interface{}{1, "b"}},
+			"version": "1.0",
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	userType := schema.Type("User").(*graphql.Object)
+	if userType.Description() != "A user of the service." || userType.Fields()["nickname"].DeprecationReason != "Use name." {
+		t.Fatalf("unexpected User type: %v", userType)
+	}
+	if reason := schema.Type("Role").(*graphql.Enum).ValueByName("MEMBER").DeprecationReason; reason != graphql.DefaultDeprecationReason {
+		t.Fatalf("expected the enum value to be deprecated, got %q", reason)
+	}
+}
+
+func TestBuildSchema_SchemaDefinitionAndDirectives(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+		schema {
+		  query: Root
+		  mutation: Mutations
+		}
+		directive @cost(weight: Int!) repeatable on FIELD_DEFINITION
+		type Root { ok: Boolean @cost(weight: 1) }
+		type Mutations { reset: Boolean }
+		type Query { unused: Boolean }
+	`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.QueryType().Name() != "Root" || schema.MutationType().Name() != "Mutations" || schema.SubscriptionType() != nil {
+		t.Fatalf("unexpected root types: %v, %v", schema.QueryType(), schema.MutationType())
+	}
+	cost := schema.Directive("cost")
+	if cost == nil || !cost.Repeatable || !reflect.DeepEqual(cost.Locations, []string{graphql.DirectiveLocationFieldDefinition}) {
+		t.Fatalf("unexpected directive: %v", cost)
+	}
+	if schema.Directive("deprecated") == nil {
+		t.Fatalf("expected the specified directives")
+	}
+}
+
+func TestBuildSchema_Errors(t *testing.T) {
+	for sdl, expected := range map[string]string{
+		`type Query { user: User }`:                                         `Unknown type "User".`,
+		`type Query { a: String } type Query { b: String }`:                 `There can be only one type named "Query".`,
+		`type Query { a(filter: Query): String }`:                           `The type of "Query.a(filter:)" must be an input type.`,
+		`input Filter { a: Int } type Query { a: Filter }`:                  `The type of "Query.a" must be an output type.`,
+		`type Query { a: String } query { a }`:                              `Schema definition documents cannot contain a OperationDefinition.`,
+		`type Query { a: String } extend type User { a: ID }`:               `Cannot extend type "User" because it is not an object type of the document.`,
+		`type Query { a: String } type User implements Query { a: String }`: `User may only implement interface types, it cannot implement: Query.`,
+		`type Query { a: String`:                                            `Syntax Error GraphQL SDL (1:23) Expected Name, found EOF`,
+	} {
+		_, err := graphql.BuildSchema(sdl, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("expected error %q for %v, got %v", expected, sdl, err)
+		}
+	}
+
+	_, err := graphql.BuildSchema(`type Query { a: String }`, graphql.ResolverMap{
+		"Query": {"b": func(p graphql.ResolveParams) (interface{}, error) { return nil, nil }},
+	})
+	if expected := `Cannot bind a resolver to "Query.b", the field does not exist.`; err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
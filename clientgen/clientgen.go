@@ -0,0 +1,706 @@
+// Package clientgen generates typed Go client code for the operations of a
+// GraphQL API, from the result of its introspection query: the structs of the
+// responses and variables of the operations, the types of the enums and input
+// objects they use, and constants for their documents. For the servers
+// built in Go, GenerateTypes generates the structs of the types of a schema.
+//
+// It is a library so it can be wired into any build tooling, e.g. a go:generate
+// command:
+//
+//	code, err := clientgen.Generate(introspectionJSON, []string{operations}, clientgen.Config{
+//		Package: "api",
+//		Scalars: map[string]string{"Time": "time.Time"},
+//	})
+package clientgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// Config configures the generated code.
+type Config struct {
+	// Package is the name of the package of the generated code.
+	Package string
+
+	// Scalars maps the names of custom scalars to the qualified Go types
+	// decoding them, such as "time.Time", whose package is imported. The
+	// custom scalars which are not mapped are decoded as json.RawMessage.
+	Scalars map[string]string
+}
+
+// builtinScalars are the Go types of the specified scalars.
+var builtinScalars = map[string]string{
+	"String":  "string",
+	"ID":      "string",
+	"Int":     "int",
+	"Float":   "float64",
+	"Boolean": "bool",
+}
+
+// Generate returns the formatted Go code of the operations of the documents,
+// typed by the schema described by the introspection JSON, as
+// returned by the introspection query with or without its "data" envelope.
+//
+// For each operation named Name, it generates:
+//
+//   - NameDocument, the constant document of the operation and its fragments;
+//   - NameVariables, the struct of its variables;
+//   - NameResponse, the struct of its data, along with one struct per
+//     selection set named after its path, such as NameHeroFriends.
+//
+// Nullable fields are pointers, the fields selected only under type
+// conditions or conditional directives are omitted when absent.
+func Generate(introspectionJSON []byte, documents []string, config Config) ([]byte, error) {
+	schema, err := decodeSchema(introspectionJSON)
+	if err != nil {
+		return nil, err
+	}
+	if config.Package == "" {
+		return nil, fmt.Errorf("clientgen: the package name is required")
+	}
+	g := &generator{
+		config:    config,
+		schema:    schema,
+		types:     map[string]*schemaType{},
+		fragments: map[string]*ast.FragmentDefinition{},
+		enums:     map[string]bool{},
+		inputs:    map[string]bool{},
+		imports:   map[string]bool{},
+	}
+	for _, ttype := range schema.Types {
+		g.types[ttype.Name] = ttype
+	}
+
+	operations := []*ast.OperationDefinition{}
+	for _, document := range documents {
+		doc, err := parser.Parse(parser.ParseParams{Source: document})
+		if err != nil {
+			return nil, err
+		}
+		for _, definition := range doc.Definitions {
+			switch definition := definition.(type) {
+			case *ast.OperationDefinition:
+				operations = append(operations, definition)
+			case *ast.FragmentDefinition:
+				name := definition.Name.Value
+				if _, ok := g.fragments[name]; ok {
+					return nil, fmt.Errorf("clientgen: the fragment %v is defined more than once", name)
+				}
+				g.fragments[name] = definition
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for _, operation := range operations {
+		if operation.Name == nil {
+			return nil, fmt.Errorf("clientgen: the operations must be named")
+		}
+		name := exportName(operation.Name.Value)
+		if names[name] {
+			return nil, fmt.Errorf("clientgen: the operation %v is defined more than once", operation.Name.Value)
+		}
+		names[name] = true
+		if err := g.operation(name, operation); err != nil {
+			return nil, fmt.Errorf("clientgen: operation %v: %v", operation.Name.Value, err)
+		}
+	}
+	if err := g.inputTypes(); err != nil {
+		return nil, fmt.Errorf("clientgen: %v", err)
+	}
+	g.enumTypes()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by clientgen. DO NOT EDIT.\n\npackage %v\n\n", config.Package)
+	if len(g.imports) > 0 {
+		imports := []string{}
+		for path := range g.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		out.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.body.Bytes())
+	out.Write(g.declarations.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// schema is the part of the introspection result used by the generator.
+type schema struct {
+	QueryType        *typeName     `json:"queryType"`
+	MutationType     *typeName     `json:"mutationType"`
+	SubscriptionType *typeName     `json:"subscriptionType"`
+	Types            []*schemaType `json:"types"`
+}
+
+type typeName struct {
+	Name string `json:"name"`
+}
+
+type schemaType struct {
+	Kind        string         `json:"kind"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Fields      []*schemaField `json:"fields"`
+	InputFields []*schemaField `json:"inputFields"`
+	EnumValues  []*enumValue   `json:"enumValues"`
+}
+
+type schemaField struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        *typeRef `json:"type"`
+}
+
+type enumValue struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+func decodeSchema(introspectionJSON []byte) (*schema, error) {
+	var result struct {
+		Data struct {
+			Schema *schema `json:"__schema"`
+		} `json:"data"`
+		Schema *schema `json:"__schema"`
+	}
+	if err := json.Unmarshal(introspectionJSON, &result); err != nil {
+		return nil, fmt.Errorf("clientgen: invalid introspection JSON: %v", err)
+	}
+	if result.Schema == nil {
+		result.Schema = result.Data.Schema
+	}
+	if result.Schema == nil {
+		return nil, fmt.Errorf("clientgen: the introspection JSON has no __schema")
+	}
+	return result.Schema, nil
+}
+
+type generator struct {
+	config    Config
+	schema    *schema
+	types     map[string]*schemaType
+	fragments map[string]*ast.FragmentDefinition
+	enums     map[string]bool
+	inputs    map[string]bool
+	imports   map[string]bool
+	// server generates the types of a schema, see GenerateTypes: the custom
+	// scalars which are not mapped are interface{} values and the input
+	// fields are tagged with their GraphQL names
+	server bool
+
+	// body holds the code of the operations, declarations the code of the
+	// enums and input objects
+	body         bytes.Buffer
+	declarations bytes.Buffer
+}
+
+func (g *generator) operation(name string, operation *ast.OperationDefinition) error {
+	var root *typeName
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = g.schema.QueryType
+	case ast.OperationTypeMutation:
+		root = g.schema.MutationType
+	case ast.OperationTypeSubscription:
+		root = g.schema.SubscriptionType
+	}
+	if root == nil || g.types[root.Name] == nil {
+		return fmt.Errorf("the schema has no %v type", operation.Operation)
+	}
+
+	document, err := g.document(operation)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.body, "// %vDocument is the document of the %v %v.\n", name, operation.Name.Value, operation.Operation)
+	fmt.Fprintf(&g.body, "const %vDocument = %v\n\n", name, goString(document))
+
+	fmt.Fprintf(&g.body, "// %vVariables are the variables of the %v %v.\n", name, operation.Name.Value, operation.Operation)
+	fmt.Fprintf(&g.body, "type %vVariables struct {\n", name)
+	for _, definition := range operation.VariableDefinitions {
+		ref, err := g.astTypeRef(definition.Type)
+		if err != nil {
+			return err
+		}
+		goType, err := g.inputGoType(ref)
+		if err != nil {
+			return err
+		}
+		key := definition.Variable.Name.Value
+		tag := key
+		if ref.Kind != "NON_NULL" {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&g.body, "\t%v %v `json:%q`\n", exportName(key), goType, tag)
+	}
+	g.body.WriteString("}\n\n")
+
+	structs := []string{}
+	err = g.selectionStruct(&structs, name+"Response", name, g.types[root.Name], operation.SelectionSet.Selections,
+		fmt.Sprintf("%vResponse is the data of the %v %v.", name, operation.Name.Value, operation.Operation))
+	if err != nil {
+		return err
+	}
+	for _, code := range structs {
+		g.body.WriteString(code)
+	}
+	return nil
+}
+
+// document returns the printed operation followed by the fragments it uses.
+func (g *generator) document(operation *ast.OperationDefinition) (string, error) {
+	used := map[string]bool{}
+	var visit func(selectionSet *ast.SelectionSet) error
+	visit = func(selectionSet *ast.SelectionSet) error {
+		if selectionSet == nil {
+			return nil
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if err := visit(selection.SelectionSet); err != nil {
+					return err
+				}
+			case *ast.InlineFragment:
+				if err := visit(selection.SelectionSet); err != nil {
+					return err
+				}
+			case *ast.FragmentSpread:
+				name := selection.Name.Value
+				fragment, ok := g.fragments[name]
+				if !ok {
+					return fmt.Errorf("unknown fragment %v", name)
+				}
+				if !used[name] {
+					used[name] = true
+					if err := visit(fragment.SelectionSet); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	if err := visit(operation.SelectionSet); err != nil {
+		return "", err
+	}
+	names := []string{}
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := []string{fmt.Sprint(printer.Print(operation))}
+	for _, name := range names {
+		parts = append(parts, fmt.Sprint(printer.Print(g.fragments[name])))
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// selectedField is a field of a selection set, by response key, merging the
+// selection sets of the fields of the same key.
+type selectedField struct {
+	key        string
+	ref        *typeRef
+	selections []ast.Selection
+	// optional is true when the field is only selected under a type
+	// condition or a conditional directive
+	optional bool
+}
+
+// selectionStruct appends to structs the code of the struct of a selection set
+// on parentType, and of the structs of its fields.
+func (g *generator) selectionStruct(structs *[]string, structName, prefix string, parentType *schemaType, selections []ast.Selection, comment string) error {
+	fields := []*selectedField{}
+	byKey := map[string]*selectedField{}
+	var collect func(parentType *schemaType, selections []ast.Selection, optional bool) error
+	collect = func(ttype *schemaType, selections []ast.Selection, optional bool) error {
+		for _, selection := range selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				key := selection.Name.Value
+				if selection.Alias != nil {
+					key = selection.Alias.Value
+				}
+				ref, err := g.fieldType(ttype, selection.Name.Value)
+				if err != nil {
+					return err
+				}
+				fieldOptional := optional || isConditional(selection.Directives)
+				var subselections []ast.Selection
+				if selection.SelectionSet != nil {
+					subselections = selection.SelectionSet.Selections
+				}
+				if field, ok := byKey[key]; ok {
+					field.selections = append(field.selections, subselections...)
+					field.optional = field.optional && fieldOptional
+					continue
+				}
+				field := &selectedField{key: key, ref: ref, selections: subselections, optional: fieldOptional}
+				byKey[key] = field
+				fields = append(fields, field)
+			case *ast.InlineFragment:
+				target, err := g.typeCondition(ttype, selection.TypeCondition)
+				if err != nil {
+					return err
+				}
+				conditional := target != ttype || isConditional(selection.Directives)
+				if err := collect(target, selection.SelectionSet.Selections, optional || conditional); err != nil {
+					return err
+				}
+			case *ast.FragmentSpread:
+				fragment, ok := g.fragments[selection.Name.Value]
+				if !ok {
+					return fmt.Errorf("unknown fragment %v", selection.Name.Value)
+				}
+				target, err := g.typeCondition(ttype, fragment.TypeCondition)
+				if err != nil {
+					return err
+				}
+				conditional := target != ttype || isConditional(selection.Directives)
+				if err := collect(target, fragment.SelectionSet.Selections, optional || conditional); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := collect(parentType, selections, false); err != nil {
+		return err
+	}
+
+	var code bytes.Buffer
+	fmt.Fprintf(&code, "// %v\ntype %v struct {\n", comment, structName)
+	index := len(*structs)
+	*structs = append(*structs, "")
+	for _, field := range fields {
+		goType, err := g.outputGoType(structs, prefix+exportName(field.key), field.ref, field.selections)
+		if err != nil {
+			return err
+		}
+		tag := field.key
+		if field.optional {
+			tag += ",omitempty"
+			if !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+				goType = "*" + goType
+			}
+		}
+		fmt.Fprintf(&code, "\t%v %v `json:%q`\n", exportName(field.key), goType, tag)
+	}
+	code.WriteString("}\n\n")
+	(*structs)[index] = code.String()
+	return nil
+}
+
+// outputGoType returns the Go type of a selected field, appending the structs
+// of its selection set.
+func (g *generator) outputGoType(structs *[]string, structName string, ref *typeRef, selections []ast.Selection) (string, error) {
+	nonNull := false
+	if ref.Kind == "NON_NULL" {
+		nonNull, ref = true, ref.OfType
+	}
+	var goType string
+	if ref.Kind == "LIST" {
+		itemType, err := g.outputGoType(structs, structName, ref.OfType, selections)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	}
+	ttype, ok := g.types[ref.Name]
+	if !ok {
+		return "", fmt.Errorf("unknown type %v", ref.Name)
+	}
+	switch ttype.Kind {
+	case "OBJECT", "INTERFACE", "UNION":
+		if len(selections) == 0 {
+			return "", fmt.Errorf("the fields of type %v must have a selection set", ttype.Name)
+		}
+		comment := fmt.Sprintf("%v is a selection of %v.", structName, ttype.Name)
+		if err := g.selectionStruct(structs, structName, structName, ttype, selections, comment); err != nil {
+			return "", err
+		}
+		goType = structName
+	default:
+		var err error
+		if goType, err = g.leafGoType(ttype); err != nil {
+			return "", err
+		}
+	}
+	if !nonNull {
+		goType = "*" + goType
+	}
+	return goType, nil
+}
+
+// inputGoType returns the Go type of a variable or input field.
+func (g *generator) inputGoType(ref *typeRef) (string, error) {
+	nonNull := false
+	if ref.Kind == "NON_NULL" {
+		nonNull, ref = true, ref.OfType
+	}
+	if ref.Kind == "LIST" {
+		itemType, err := g.inputGoType(ref.OfType)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	}
+	ttype, ok := g.types[ref.Name]
+	if !ok {
+		return "", fmt.Errorf("unknown type %v", ref.Name)
+	}
+	var goType string
+	switch ttype.Kind {
+	case "INPUT_OBJECT":
+		g.inputs[ttype.Name] = true
+		goType = exportName(ttype.Name)
+	case "SCALAR", "ENUM":
+		var err error
+		if goType, err = g.leafGoType(ttype); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("%v is not an input type", ttype.Name)
+	}
+	if !nonNull && goType != "interface{}" {
+		goType = "*" + goType
+	}
+	return goType, nil
+}
+
+// leafGoType returns the Go type of a scalar or enum.
+func (g *generator) leafGoType(ttype *schemaType) (string, error) {
+	switch ttype.Kind {
+	case "ENUM":
+		g.enums[ttype.Name] = true
+		return exportName(ttype.Name), nil
+	case "SCALAR":
+		if goType, ok := g.config.Scalars[ttype.Name]; ok {
+			return g.qualifiedGoType(goType), nil
+		}
+		if goType, ok := builtinScalars[ttype.Name]; ok {
+			return goType, nil
+		}
+		if g.server {
+			return "interface{}", nil
+		}
+		g.imports["encoding/json"] = true
+		return "json.RawMessage", nil
+	}
+	return "", fmt.Errorf("%v is not a leaf type", ttype.Name)
+}
+
+// qualifiedGoType imports the package of a mapped scalar type, such as
+// "*github.com/shopspring/decimal.Decimal", and returns the type qualified by
+// the package name, "*decimal.Decimal".
+func (g *generator) qualifiedGoType(goType string) string {
+	prefix := goType[:len(goType)-len(strings.TrimLeft(goType, "*[]"))]
+	qualified := goType[len(prefix):]
+	i := strings.LastIndex(qualified, ".")
+	if i < 0 {
+		return goType
+	}
+	path := qualified[:i]
+	g.imports[path] = true
+	return prefix + path[strings.LastIndex(path, "/")+1:] + qualified[i:]
+}
+
+// inputTypes appends the structs of the input objects used by the
+// operations, and of the input objects they use.
+func (g *generator) inputTypes() error {
+	done := map[string]bool{}
+	for {
+		names := []string{}
+		for name := range g.inputs {
+			if !done[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			done[name] = true
+			ttype := g.types[name]
+			writeComment(&g.declarations, exportName(name), "is the input object", name, ttype.Description)
+			fmt.Fprintf(&g.declarations, "type %v struct {\n", exportName(name))
+			for _, field := range sortedFields(ttype.InputFields) {
+				goType, err := g.inputGoType(field.Type)
+				if err != nil {
+					return err
+				}
+				tag := field.Name
+				if field.Type.Kind != "NON_NULL" {
+					tag += ",omitempty"
+				}
+				if g.server {
+					fmt.Fprintf(&g.declarations, "\t%v %v `graphql:%q json:%q`\n", exportName(field.Name), goType, field.Name, tag)
+					continue
+				}
+				fmt.Fprintf(&g.declarations, "\t%v %v `json:%q`\n", exportName(field.Name), goType, tag)
+			}
+			g.declarations.WriteString("}\n\n")
+		}
+	}
+}
+
+// enumTypes appends the types and constants of the enums used by the
+// operations.
+func (g *generator) enumTypes() {
+	names := []string{}
+	for name := range g.enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ttype := g.types[name]
+		goName := exportName(name)
+		writeComment(&g.declarations, goName, "is the enum", name, ttype.Description)
+		fmt.Fprintf(&g.declarations, "type %v string\n\n", goName)
+		fmt.Fprintf(&g.declarations, "// The values of %v.\nconst (\n", goName)
+		values := append([]*enumValue{}, ttype.EnumValues...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+		for _, value := range values {
+			fmt.Fprintf(&g.declarations, "\t%v%v %v = %q\n", goName, exportName(strings.ToLower(value.Name)), goName, value.Name)
+		}
+		g.declarations.WriteString(")\n\n")
+	}
+}
+
+// sortedFields returns the fields sorted by name, the order of the
+// introspection not being stable for the types defined by maps.
+func sortedFields(fields []*schemaField) []*schemaField {
+	sorted := append([]*schemaField{}, fields...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// fieldType returns the type of a field of an object or interface, or of
+// the __typename meta field.
+func (g *generator) fieldType(ttype *schemaType, name string) (*typeRef, error) {
+	if name == "__typename" {
+		return &typeRef{Kind: "NON_NULL", OfType: &typeRef{Kind: "SCALAR", Name: "String"}}, nil
+	}
+	for _, field := range ttype.Fields {
+		if field.Name == name {
+			return field.Type, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot query field %q on type %q", name, ttype.Name)
+}
+
+// typeCondition returns the type of a type condition, ttype when there is
+// none.
+func (g *generator) typeCondition(ttype *schemaType, condition *ast.Named) (*schemaType, error) {
+	if condition == nil || condition.Name.Value == ttype.Name {
+		return ttype, nil
+	}
+	target, ok := g.types[condition.Name.Value]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %v", condition.Name.Value)
+	}
+	return target, nil
+}
+
+// astTypeRef returns the type reference of the type of a variable.
+func (g *generator) astTypeRef(ttype ast.Type) (*typeRef, error) {
+	switch ttype := ttype.(type) {
+	case *ast.NonNull:
+		ofType, err := g.astTypeRef(ttype.Type)
+		if err != nil {
+			return nil, err
+		}
+		return &typeRef{Kind: "NON_NULL", OfType: ofType}, nil
+	case *ast.List:
+		ofType, err := g.astTypeRef(ttype.Type)
+		if err != nil {
+			return nil, err
+		}
+		return &typeRef{Kind: "LIST", OfType: ofType}, nil
+	case *ast.Named:
+		named, ok := g.types[ttype.Name.Value]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %v", ttype.Name.Value)
+		}
+		return &typeRef{Kind: named.Kind, Name: named.Name}, nil
+	}
+	return nil, fmt.Errorf("invalid type %v", ttype)
+}
+
+// isConditional reports whether the directives include the selection
+// conditionally.
+func isConditional(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if name := directive.Name.Value; name == "include" || name == "skip" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeComment writes the doc comment of a generated type, with the
+// description of the schema type when it has one.
+func writeComment(w *bytes.Buffer, goName, what, name, description string) {
+	fmt.Fprintf(w, "// %v %v %v", goName, what, name)
+	if description == "" {
+		w.WriteString(".\n")
+		return
+	}
+	w.WriteString(":\n")
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(w, "// %v\n", line)
+	}
+}
+
+// exportName returns the exported Go name of a GraphQL name, e.g. Typename
+// for __typename and FirstName for first_name.
+func exportName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// goString returns a Go string literal of the text, a raw one when possible.
+func goString(text string) string {
+	if strings.Contains(text, "`") {
+		return strconv.Quote(text)
+	}
+	return "`" + text + "`"
+}
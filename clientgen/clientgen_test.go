@@ -0,0 +1,191 @@
+package clientgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/clientgen"
+	"github.com/graphql-go/graphql/graphqltest"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func reviewSchemaJSON(t *testing.T) []byte {
+	timeType := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "Time",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+	reviewType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Review",
+		Fields: graphql.Fields{
+			"stars":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"commentary": &graphql.Field{Type: graphql.String},
+			"created_at": &graphql.Field{Type: timeType},
+		},
+	})
+	reviewInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "ReviewInput",
+		Description: "The input of a review.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"stars":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"commentary": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tags":       &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testutil.StarWarsSchema.QueryType(),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createReview": &graphql.Field{
+					Type: reviewType,
+					Args: graphql.FieldConfigArgument{
+						"episode": &graphql.ArgumentConfig{Type: graphql.NewNonNull(testutil.StarWarsSchema.Type("Episode").(graphql.Input))},
+						"review":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(reviewInputType)},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	introspectionJSON, err := schema.IntrospectionJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return introspectionJSON
+}
+
+const operations = `
+query HeroForEpisode($episode: Episode) {
+  hero(episode: $episode) {
+    __typename
+    name
+    friends {
+      name
+    }
+    ... on Droid {
+      primaryFunction
+    }
+    ...HumanDetails
+  }
+}
+
+fragment HumanDetails on Human {
+  homePlanet
+}
+
+mutation CreateReview($episode: Episode!, $review: ReviewInput!, $withDate: Boolean = false) {
+  createReview(episode: $episode, review: $review) {
+    stars
+    commentary
+    createdAt: created_at @include(if: $withDate)
+  }
+}
+`
+
+func TestGenerate(t *testing.T) {
+	code, err := clientgen.Generate(reviewSchemaJSON(t), []string{operations}, clientgen.Config{
+		Package: "starwars",
+		Scalars: map[string]string{"Time": "time.Time"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphqltest.AssertGolden(t, string(code), "testdata/starwars.go.golden")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	introspectionJSON := reviewSchemaJSON(t)
+	tests := []struct {
+		document string
+		expected string
+	}{
+		{`{ hero { name } }`, "clientgen: the operations must be named"},
+		{`query Hero { hero { unknown } }`, `clientgen: operation Hero: cannot query field "unknown" on type "Character"`},
+		{`query Hero { hero }`, "clientgen: operation Hero: the fields of type Character must have a selection set"},
+		{`query Hero { hero { ...Unknown } }`, "clientgen: operation Hero: unknown fragment Unknown"},
+		{`subscription Hero { hero { name } }`, "clientgen: operation Hero: the schema has no subscription type"},
+	}
+	for _, test := range tests {
+		_, err := clientgen.Generate(introspectionJSON, []string{test.document}, clientgen.Config{Package: "starwars"})
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%v: expected error %q, got %v", test.document, test.expected, err)
+		}
+	}
+
+	_, err := clientgen.Generate([]byte(`{"data": {}}`), nil, clientgen.Config{Package: "starwars"})
+	if err == nil || !strings.Contains(err.Error(), "no __schema") {
+		t.Fatalf("expected an error for the missing schema, got %v", err)
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	timeType := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "Time",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+	jsonType := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "JSON",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+	addressInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AddressInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"city":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"zip_code": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	userInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"addresses": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(addressInputType))},
+			"episode":   &graphql.InputObjectFieldConfig{Type: testutil.StarWarsSchema.Type("Episode").(graphql.Input)},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "A registered user.",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"first_name": &graphql.Field{Type: graphql.String},
+			"created_at": &graphql.Field{Type: graphql.NewNonNull(timeType)},
+			"settings":   &graphql.Field{Type: jsonType},
+			"favorite":   &graphql.Field{Type: testutil.StarWarsSchema.Type("Character").(graphql.Output)},
+		},
+	})
+	userType.AddFieldConfig("friends", &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(userType))})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"me": &graphql.Field{Type: userType},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createUser": &graphql.Field{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(userInputType)},
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{testutil.StarWarsSchema.Type("Human"), testutil.StarWarsSchema.Type("Droid")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := clientgen.GenerateTypes(&schema, clientgen.Config{
+		Package: "users",
+		Scalars: map[string]string{"Time": "time.Time"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphqltest.AssertGolden(t, string(code), "testdata/users.go.golden")
+}
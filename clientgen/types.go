@@ -0,0 +1,143 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// GenerateTypes returns the formatted Go code of the structs of the object and
+// input object types of a schema built in Go, for code-first servers to keep
+// their resolvers typed: the structs of the objects are returned by the
+// resolvers and resolved by graphql.DefaultResolveFn, the structs of the input
+// objects are read from the arguments by graphql.Arg. The fields are tagged
+// with their GraphQL names, e.g. `graphql:"first_name"`.
+//
+// The root types and the introspection types have no struct. The nullable
+// fields are pointers, the fields of interface and union types and of the
+// custom scalars which are not mapped by config.Scalars are interface{}
+// values, and the enums are typed by the names of their values, as are the
+// enums whose values have no internal value.
+func GenerateTypes(schema *graphql.Schema, config Config) ([]byte, error) {
+	if config.Package == "" {
+		return nil, fmt.Errorf("clientgen: the package name is required")
+	}
+	introspectionJSON, err := schema.IntrospectionJSON()
+	if err != nil {
+		return nil, fmt.Errorf("clientgen: %v", err)
+	}
+	decoded, err := decodeSchema(introspectionJSON)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		config:  config,
+		schema:  decoded,
+		types:   map[string]*schemaType{},
+		enums:   map[string]bool{},
+		inputs:  map[string]bool{},
+		imports: map[string]bool{},
+		server:  true,
+	}
+	roots := map[string]bool{}
+	for _, root := range []*typeName{decoded.QueryType, decoded.MutationType, decoded.SubscriptionType} {
+		if root != nil {
+			roots[root.Name] = true
+		}
+	}
+	names := []string{}
+	for _, ttype := range decoded.Types {
+		g.types[ttype.Name] = ttype
+		if strings.HasPrefix(ttype.Name, "__") || roots[ttype.Name] {
+			continue
+		}
+		switch ttype.Kind {
+		case "OBJECT":
+			names = append(names, ttype.Name)
+		case "INPUT_OBJECT":
+			g.inputs[ttype.Name] = true
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.objectType(g.types[name]); err != nil {
+			return nil, fmt.Errorf("clientgen: %v", err)
+		}
+	}
+	if err := g.inputTypes(); err != nil {
+		return nil, fmt.Errorf("clientgen: %v", err)
+	}
+	g.enumTypes()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by clientgen. DO NOT EDIT.\n\npackage %v\n\n", config.Package)
+	if len(g.imports) > 0 {
+		imports := []string{}
+		for path := range g.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		out.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.body.Bytes())
+	out.Write(g.declarations.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// objectType appends the struct of an object type.
+func (g *generator) objectType(ttype *schemaType) error {
+	writeComment(&g.body, exportName(ttype.Name), "is the object", ttype.Name, ttype.Description)
+	fmt.Fprintf(&g.body, "type %v struct {\n", exportName(ttype.Name))
+	for _, field := range sortedFields(ttype.Fields) {
+		goType, err := g.objectGoType(field.Type)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.body, "\t%v %v `graphql:%q`\n", exportName(field.Name), goType, field.Name)
+	}
+	g.body.WriteString("}\n\n")
+	return nil
+}
+
+// objectGoType returns the Go type of a field of an object type.
+func (g *generator) objectGoType(ref *typeRef) (string, error) {
+	nonNull := false
+	if ref.Kind == "NON_NULL" {
+		nonNull, ref = true, ref.OfType
+	}
+	if ref.Kind == "LIST" {
+		itemType, err := g.objectGoType(ref.OfType)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	}
+	ttype, ok := g.types[ref.Name]
+	if !ok {
+		return "", fmt.Errorf("unknown type %v", ref.Name)
+	}
+	var goType string
+	switch ttype.Kind {
+	case "OBJECT":
+		goType = exportName(ttype.Name)
+	case "INTERFACE", "UNION":
+		return "interface{}", nil
+	default:
+		var err error
+		if goType, err = g.leafGoType(ttype); err != nil {
+			return "", err
+		}
+	}
+	if !nonNull && goType != "interface{}" {
+		goType = "*" + goType
+	}
+	return goType, nil
+}
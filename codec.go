@@ -0,0 +1,84 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Codec encodes and decodes the JSON payloads exchanged with clients: the
+// variables decoded by DecodeVariables and the results encoded by EncodeResult,
+// as the handler package does. It allows high-throughput users to plug a faster
+// JSON library (e.g. jsoniter) in place of encoding/json.
+//
+// Codecs used to decode variables should decode numbers as json.Number, as
+// DecodeVariables does with StdCodec, so integers do not lose precision before
+// being coerced.
+type Codec interface {
+	// Marshal returns the JSON encoding of v.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal parses the JSON-encoded data and stores the result in the value pointed to by v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StdCodec is the default Codec, backed by encoding/json.
+var StdCodec Codec = stdCodec{}
+
+type stdCodec struct{}
+
+func (stdCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// unmarshalNumbers is like json.Unmarshal, but decodes numbers as json.Number.
+func unmarshalNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("graphql: unexpected data after top-level JSON value")
+	}
+	return nil
+}
+
+// codecOrDefault returns the given codec, or StdCodec if none is provided.
+func codecOrDefault(c Codec) Codec {
+	if c == nil {
+		return StdCodec
+	}
+	return c
+}
+
+// DecodeVariables decodes a JSON object of variables using the given codec,
+// or StdCodec if none is provided, in which case numbers are decoded as
+// json.Number so large integers keep their precision until they are coerced
+// by the scalar they are provided for.
+func DecodeVariables(c Codec, data []byte) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return variables, nil
+	}
+	unmarshal := codecOrDefault(c).Unmarshal
+	if _, ok := codecOrDefault(c).(stdCodec); ok {
+		unmarshal = unmarshalNumbers
+	}
+	if err := unmarshal(data, &variables); err != nil {
+		return nil, err
+	}
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+	return variables, nil
+}
+
+// EncodeResult encodes a result using the given codec, or StdCodec if none is provided.
+func EncodeResult(c Codec, r *Result) ([]byte, error) {
+	return codecOrDefault(c).Marshal(r)
+}
@@ -0,0 +1,116 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type countingCodec struct {
+	marshals   int
+	unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestDecodeVariables(t *testing.T) {
+	codec := &countingCodec{}
+	variables, err := graphql.DecodeVariables(codec, []byte(`{"a": "b", "c": [1, 2]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"a": "b", "c": []interface{}{float64(1), float64(2)}}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Fatalf("unexpected variables, expected: %v, got: %v", expected, variables)
+	}
+	if codec.unmarshals != 1 {
+		t.Fatalf("expected the codec to be used once, got: %v", codec.unmarshals)
+	}
+
+	variables, err = graphql.DecodeVariables(nil, []byte(`{"big": 9007199254740993}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variables["big"] != json.Number("9007199254740993") {
+		t.Fatalf("expected numbers to be decoded as json.Number, got: %#v", variables["big"])
+	}
+
+	for _, empty := range []string{"", "  ", "null"} {
+		variables, err = graphql.DecodeVariables(nil, []byte(empty))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", empty, err)
+		}
+		if variables == nil || len(variables) != 0 {
+			t.Fatalf("expected empty variables for %q, got: %v", empty, variables)
+		}
+	}
+
+	if _, err := graphql.DecodeVariables(nil, []byte(`[1]`)); err == nil {
+		t.Fatalf("expected an error decoding a non-object")
+	}
+
+	var decoded map[string]interface{}
+	if err := graphql.StdCodec.Unmarshal([]byte(`{"n": 1}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded["n"] != float64(1) {
+		t.Fatalf("expected StdCodec to decode numbers like encoding/json, got: %#v", decoded["n"])
+	}
+}
+
+func TestEncodeResult(t *testing.T) {
+	codec := &countingCodec{}
+	b, err := graphql.EncodeResult(codec, &graphql.Result{Data: map[string]interface{}{"a": 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != `{"data":{"a":1}}` {
+		t.Fatalf("unexpected encoding: %s", b)
+	}
+	if codec.marshals != 1 {
+		t.Fatalf("expected the codec to be used once, got: %v", codec.marshals)
+	}
+}
+
+func TestDoUsesCodecForVariableErrors(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	codec := &countingCodec{}
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($value: Int) { echo(value: $value) }`,
+		VariableValues: map[string]interface{}{"value": "nope"},
+		Codec:          codec,
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, `got invalid value "nope"`) {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if codec.marshals != 1 {
+		t.Fatalf("expected the codec to be used once, got: %v", codec.marshals)
+	}
+}
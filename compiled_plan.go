@@ -0,0 +1,346 @@
+package graphql
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// maxCompiledSelections bounds the number of selection sets collected ahead of
+// time per operation, as the selection sets of abstract types are collected
+// for each of their possible types.
+const maxCompiledSelections = 4096
+
+// compiledOperation is the plan of an operation compiled ahead of time, see
+// Schema.Warmup. It holds the work of the executor that does not depend on
+// the variables, so executing the operation is reduced to coercing its
+// variables and calling its resolvers. The selection sets whose fields depend
+// on the variables, through @skip and @include or fragment variables, are
+// still collected on execution.
+type compiledOperation struct {
+	// fields are the fields collected from the selection sets of the entries
+	// of the collected fields, by runtime type, see collectKey.
+	fields map[collectKey]map[string][]*ast.Field
+
+	// fragments are the fragments through which the entries of the collected
+	// fields are selected, see ResolveInfo.FragmentDirectives.
+	fragments map[**ast.Field][]ast.Node
+
+	// args are the argument values of the fields without variables, only
+	// kept when they hold no maps or slices, see isFlatArgumentValues.
+	args map[argsKey]map[string]interface{}
+}
+
+// collectKey identifies the selection set of an entry of collected fields,
+// by the address of its first field AST which is unique to the entry, nil
+// for the selection set of the operation.
+type collectKey struct {
+	entry       **ast.Field
+	runtimeType *Object
+}
+
+type argsKey struct {
+	fieldAST *ast.Field
+	fieldDef *FieldDefinition
+}
+
+// collectedFields returns the fields collected ahead of time from the
+// selection sets of the field ASTs, the selection set of the operation when
+// nil, for the runtime type.
+func (plan *compiledOperation) collectedFields(fieldASTs []*ast.Field, runtimeType *Object) (map[string][]*ast.Field, bool) {
+	if plan == nil {
+		return nil, false
+	}
+	fields, ok := plan.fields[collectKey{entryOf(fieldASTs), runtimeType}]
+	return fields, ok
+}
+
+// collectedFragments returns the fragments through which the field ASTs
+// were collected ahead of time.
+func (plan *compiledOperation) collectedFragments(fieldASTs []*ast.Field) ([]ast.Node, bool) {
+	if plan == nil || len(fieldASTs) == 0 {
+		return nil, false
+	}
+	fragments, ok := plan.fragments[entryOf(fieldASTs)]
+	return fragments, ok
+}
+
+// argumentValues returns a copy of the argument values of the field coerced
+// ahead of time, resolvers being free to modify their arguments.
+func (plan *compiledOperation) argumentValues(fieldAST *ast.Field, fieldDef *FieldDefinition) (map[string]interface{}, bool) {
+	if plan == nil {
+		return nil, false
+	}
+	args, ok := plan.args[argsKey{fieldAST, fieldDef}]
+	if !ok {
+		return nil, false
+	}
+	values := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		values[name] = value
+	}
+	return values, true
+}
+
+func entryOf(fieldASTs []*ast.Field) **ast.Field {
+	if len(fieldASTs) == 0 {
+		return nil
+	}
+	return &fieldASTs[0]
+}
+
+// compileOperation compiles the operation of the document with the given
+// name, nil if the document has no such operation.
+func compileOperation(schema Schema, document *ast.Document, operationName string) *compiledOperation {
+	eCtx := &executionContext{
+		Schema:    schema,
+		Fragments: map[string]ast.Definition{},
+	}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || definition.Name != nil && definition.Name.Value == operationName {
+				eCtx.Operation = definition
+			}
+		case *ast.FragmentDefinition:
+			if definition.Name != nil {
+				eCtx.Fragments[definition.Name.Value] = definition
+			}
+		}
+	}
+	if eCtx.Operation == nil {
+		return nil
+	}
+	operationType, err := getOperationRootType(schema, eCtx.Operation)
+	if err != nil {
+		return nil
+	}
+	plan := &compiledOperation{
+		fields:    map[collectKey]map[string][]*ast.Field{},
+		fragments: map[**ast.Field][]ast.Node{},
+		args:      map[argsKey]map[string]interface{}{},
+	}
+	selectionSet := eCtx.Operation.GetSelectionSet()
+	if !isStaticSelectionSet(eCtx, selectionSet, map[string]bool{}) {
+		return plan
+	}
+	fields := collectFields(collectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  operationType,
+		SelectionSet: selectionSet,
+	})
+	plan.compileFields(eCtx, collectKey{nil, operationType}, fields)
+	return plan
+}
+
+// compileFields keeps the fields collected for the key, and compiles the
+// arguments and selection sets of their entries.
+func (plan *compiledOperation) compileFields(eCtx *executionContext, key collectKey, fields map[string][]*ast.Field) {
+	plan.fields[key] = fields
+	// the fragments of the fields are overwritten by the next collections
+	for _, fieldASTs := range fields {
+		plan.fragments[entryOf(fieldASTs)] = fieldFragments(eCtx, fieldASTs)
+	}
+	for _, fieldASTs := range fields {
+		entry := entryOf(fieldASTs)
+		fieldAST := fieldASTs[0]
+		if fieldAST.Name == nil {
+			continue
+		}
+		fieldDef := getFieldDef(eCtx.Schema, key.runtimeType, fieldAST.Name.Value)
+		if fieldDef == nil {
+			continue
+		}
+		if !hasVariables(fieldAST.Arguments) {
+			if args, err := transformedArgumentValues(eCtx.Schema.fieldArgs(key.runtimeType, fieldDef), fieldAST.Arguments); err == nil && isFlatArgumentValues(args) {
+				plan.args[argsKey{fieldAST, fieldDef}] = args
+			}
+		}
+
+		var runtimeTypes []*Object
+		switch ttype := GetNamed(fieldDef.Type).(type) {
+		case *Object:
+			runtimeTypes = []*Object{ttype}
+		case *Interface:
+			runtimeTypes = eCtx.Schema.PossibleTypes(ttype)
+		case *Union:
+			runtimeTypes = eCtx.Schema.PossibleTypes(ttype)
+		}
+		for _, runtimeType := range runtimeTypes {
+			if len(plan.fields) >= maxCompiledSelections {
+				return
+			}
+			static := true
+			for _, fieldAST := range fieldASTs {
+				if fieldAST != nil && !isStaticSelectionSet(eCtx, fieldAST.SelectionSet, map[string]bool{}) {
+					static = false
+				}
+			}
+			if !static {
+				continue
+			}
+			// collected as by completeObjectValue
+			subFieldASTs := map[string][]*ast.Field{}
+			visitedFragmentNames := map[string]bool{}
+			for _, fieldAST := range fieldASTs {
+				if fieldAST == nil || fieldAST.SelectionSet == nil {
+					continue
+				}
+				subFieldASTs = collectFields(collectFieldsParams{
+					ExeContext:           eCtx,
+					RuntimeType:          runtimeType,
+					SelectionSet:         fieldAST.SelectionSet,
+					Fields:               subFieldASTs,
+					VisitedFragmentNames: visitedFragmentNames,
+				})
+			}
+			plan.compileFields(eCtx, collectKey{entry, runtimeType}, subFieldASTs)
+		}
+	}
+}
+
+// isStaticSelectionSet reports whether the fields collected from the
+// selection set do not depend on the variables, through the @skip and
+// @include directives of its selections or the variables of its fragments.
+func isStaticSelectionSet(eCtx *executionContext, selectionSet *ast.SelectionSet, visited map[string]bool) bool {
+	if selectionSet == nil {
+		return true
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if hasConditionVariables(selection.Directives) {
+				return false
+			}
+		case *ast.InlineFragment:
+			if hasConditionVariables(selection.Directives) ||
+				!isStaticSelectionSet(eCtx, selection.SelectionSet, visited) {
+				return false
+			}
+		case *ast.FragmentSpread:
+			if hasConditionVariables(selection.Directives) || len(selection.Arguments) > 0 {
+				return false
+			}
+			if selection.Name == nil || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			fragment, ok := eCtx.Fragments[selection.Name.Value].(*ast.FragmentDefinition)
+			if !ok {
+				continue
+			}
+			if len(fragment.VariableDefinitions) > 0 ||
+				!isStaticSelectionSet(eCtx, fragment.SelectionSet, visited) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasConditionVariables reports whether the @skip or @include directives
+// depend on variables.
+func hasConditionVariables(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive == nil || directive.Name == nil {
+			continue
+		}
+		if directive.Name.Value == SkipDirective.Name || directive.Name.Value == IncludeDirective.Name {
+			if hasVariables(directive.Arguments) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isFlatArgumentValues reports whether the argument values hold no maps or
+// slices, such as input objects and lists. Those are coerced again on each
+// execution instead, as resolvers may modify them in place and the Transform
+// of their input object fields must run on each request.
+func isFlatArgumentValues(args map[string]interface{}) bool {
+	for _, value := range args {
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Map, reflect.Slice:
+			return false
+		}
+	}
+	return true
+}
+
+func hasVariables(args []*ast.Argument) bool {
+	for _, arg := range args {
+		if arg != nil && valueHasVariables(arg.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueHasVariables(value ast.Value) bool {
+	switch value := value.(type) {
+	case *ast.Variable:
+		return true
+	case *ast.ListValue:
+		for _, item := range value.Values {
+			if valueHasVariables(item) {
+				return true
+			}
+		}
+	case *ast.ObjectValue:
+		for _, field := range value.Fields {
+			if field != nil && valueHasVariables(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// planCache holds the plans of the operations compiled against a schema, by
+// document and operation name. It is shared by copies of a schema and reset
+// whenever the schema is modified.
+type planCache struct {
+	mu    sync.RWMutex
+	plans map[planKey]*compiledOperation
+}
+
+type planKey struct {
+	document      *ast.Document
+	operationName string
+}
+
+func newPlanCache() *planCache {
+	return &planCache{}
+}
+
+func (c *planCache) get(document *ast.Document, operationName string) *compiledOperation {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.plans[planKey{document, operationName}]
+}
+
+func (c *planCache) set(document *ast.Document, operationName string, plan *compiledOperation) {
+	if c == nil || plan == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plans == nil {
+		c.plans = map[planKey]*compiledOperation{}
+	}
+	c.plans[planKey{document, operationName}] = plan
+}
+
+func (c *planCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plans = nil
+}
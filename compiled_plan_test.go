@@ -0,0 +1,229 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// planRecorder records the plans of the default executor.
+type planRecorder struct {
+	plans []graphql.ExecutionPlan
+}
+
+func (r *planRecorder) Plan(p graphql.ExecuteParams) (graphql.ExecutionPlan, error) {
+	plan, err := graphql.DefaultExecutor.Plan(p)
+	r.plans = append(r.plans, plan)
+	return plan, err
+}
+
+func (r *planRecorder) Execute(ctx context.Context, plan graphql.ExecutionPlan, p graphql.ExecuteParams) *graphql.Result {
+	return graphql.DefaultExecutor.Execute(ctx, plan, p)
+}
+
+var compiledOperations = map[string]string{
+	"hero": `query Hero($episode: Episode, $withFriends: Boolean!) {
+		hero(episode: $episode) {
+			...CharacterFields
+			... on Droid { primaryFunction }
+			friends @include(if: $withFriends) {
+				name
+				... on Human { homePlanet }
+			}
+		}
+	}
+	fragment CharacterFields on Character {
+		id
+		name @skip(if: false)
+		appearsIn @include(if: false)
+	}`,
+	"human": `query Human {
+		luke: human(id: "1000") { name friends { name } }
+		leia: human(id: "1003") { ...on Human { name homePlanet } }
+	}`,
+}
+
+// starWarsSchema returns a new Star Wars schema, with its own caches.
+func starWarsSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: testutil.StarWarsSchema.QueryType()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestCompiledPlans_MatchTreeExecution(t *testing.T) {
+	schema := starWarsSchema(t)
+	registry := graphql.NewOperationRegistry()
+	for id, query := range compiledOperations {
+		if err := registry.Register(&graphql.PersistedOperation{ID: id, Query: query}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := schema.Warmup(graphql.WarmupConfig{Operations: registry}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id        string
+		variables map[string]interface{}
+	}{
+		{"hero", map[string]interface{}{"withFriends": true}},
+		{"hero", map[string]interface{}{"withFriends": false, "episode": "EMPIRE"}},
+		{"human", nil},
+	}
+	for _, test := range tests {
+		recorder := &planRecorder{}
+		params, err := registry.Params(test.id, graphql.Params{
+			Schema:         schema,
+			VariableValues: test.variables,
+			Executor:       recorder,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		compiled := graphql.Do(params)
+		if len(recorder.plans) != 1 || recorder.plans[0] == nil {
+			t.Fatalf("%v: expected a compiled plan, got %v", test.id, recorder.plans)
+		}
+
+		expected := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  compiledOperations[test.id],
+			VariableValues: test.variables,
+		})
+		if len(expected.Errors) > 0 {
+			t.Fatalf("%v: unexpected errors: %v", test.id, expected.Errors)
+		}
+		if !testutil.EqualResults(expected, compiled) {
+			t.Fatalf("%v: unexpected result, Diff: %v", test.id, testutil.Diff(expected, compiled))
+		}
+	}
+}
+
+func TestCompiledPlans_InvalidatedWithTheSchema(t *testing.T) {
+	schema := starWarsSchema(t)
+	registry := graphql.NewOperationRegistry()
+	if err := registry.Register(&graphql.PersistedOperation{ID: "human", Query: compiledOperations["human"]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Warmup(graphql.WarmupConfig{Operations: registry}); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.AddImplementation(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &planRecorder{}
+	params, err := registry.Params("human", graphql.Params{Schema: schema, Executor: recorder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := graphql.Do(params); len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(recorder.plans) != 1 || recorder.plans[0] != nil {
+		t.Fatalf("expected the plans to be invalidated, got %v", recorder.plans)
+	}
+}
+
+func TestCompiledPlans_KeepFragmentDirectives(t *testing.T) {
+	schema := resolveDirectivesSchema(t)
+	registry := graphql.NewOperationRegistry()
+	err := registry.Register(&graphql.PersistedOperation{
+		ID: "product",
+		Query: `{
+			product { ...Prices ... @uppercase { directives } }
+			other: product { ...Prices @uppercase }
+		}
+		fragment Prices on Product @currency(code: "JPY") { price name }`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Warmup(graphql.WarmupConfig{Operations: registry}); err != nil {
+		t.Fatal(err)
+	}
+	params, err := registry.Params("product", graphql.Params{Schema: schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"product": map[string]interface{}{
+				"price":      "42 JPY",
+				"name":       "widget",
+				"directives": " | INLINE_FRAGMENT@uppercase map[] | ",
+			},
+			"other": map[string]interface{}{"price": "42 JPY", "name": "WIDGET"},
+		},
+	}
+	if result := graphql.Do(params); !testutil.EqualResults(expected, result) {
+		t.Fatalf("unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestCompiledPlans_CoerceNestedArgumentsOnEachExecution(t *testing.T) {
+	transforms := 0
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+				Transform: func(value interface{}) (interface{}, error) {
+					transforms++
+					return value, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filter},
+						"tags":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						filter := p.Args["filter"].(map[string]interface{})
+						tags := p.Args["tags"].([]interface{})
+						echo := filter["name"].(string) + tags[0].(string)
+						// resolvers are free to modify their arguments
+						filter["name"] = "modified"
+						tags[0] = "modified"
+						return echo, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := graphql.NewOperationRegistry()
+	if err := registry.Register(&graphql.PersistedOperation{ID: "echo", Query: `{ echo(filter: {name: "a"}, tags: ["b"]) }`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Warmup(graphql.WarmupConfig{Operations: registry}); err != nil {
+		t.Fatal(err)
+	}
+	transforms = 0
+	expected := &graphql.Result{Data: map[string]interface{}{"echo": "ab"}}
+	for i := 0; i < 2; i++ {
+		params, err := registry.Params("echo", graphql.Params{Schema: schema})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result := graphql.Do(params); !testutil.EqualResults(expected, result) {
+			t.Fatalf("unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+	if transforms != 2 {
+		t.Fatalf("expected the transform to run on each execution, got %v runs", transforms)
+	}
+}
@@ -0,0 +1,290 @@
+// Package dataloader provides loaders batching and caching the loads of the
+// resolvers during the execution of an operation, avoiding the N+1 queries of
+// loading the value of a field for each item of a list one by one.
+//
+// The batch functions of the loaders are registered by name, e.g. the name of
+// the type they load, in a Registry, which is the graphql.Extension giving
+// each execution its own loaders:
+//
+//	loaders := dataloader.NewRegistry()
+//	dataloader.Register(loaders, "User", func(ctx context.Context, ids []string) ([]*User, []error) {
+//		return db.UsersByID(ctx, ids)
+//	})
+//	schema.AddExtensions(loaders)
+//
+// The resolvers return the thunks of the values they load, see Load:
+//
+//	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+//		return dataloader.Load[string, *User](p.Context, "User", p.Source.(*Post).AuthorID), nil
+//	},
+//
+// The executor resolves the fields of a level of the response, collecting the
+// keys they load, before completing their thunks: the first thunk completed
+// dispatches the batch of the keys collected so far, and the fields of the
+// next level load theirs in the next batch.
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// BatchFunc loads the values of the keys, in the order of the keys. The
+// errors are nil when all the values are loaded, a single error failing all
+// the keys, or an error for each key, nil for the keys whose value is loaded.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+// Registry holds the batch functions of the loaders, by name. It is the
+// extension of the schema giving each execution its own loaders, caching the
+// values they load until the end of the execution.
+type Registry struct {
+	mu      sync.RWMutex
+	loaders map[string]func(ctx context.Context) interface{}
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{loaders: map[string]func(ctx context.Context) interface{}{}}
+}
+
+// Register registers the batch function of the loader with the given name,
+// replacing the loader of that name, if any.
+func Register[K comparable, V any](r *Registry, name string, batch BatchFunc[K, V]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loaders[name] = func(ctx context.Context) interface{} {
+		return &loader[K, V]{
+			name:  name,
+			batch: batch,
+			ctx:   ctx,
+			cache: map[K]*loaderBatch[K, V]{},
+		}
+	}
+}
+
+// Load returns the thunk of the value of the key, loaded by the loader with
+// the given name along with the other keys loaded until the value of one of
+// them is needed. Resolvers return the thunk in place of the value, see
+// graphql.FieldResolveFn.
+//
+// The context is the context of the resolver, see graphql.ResolveParams. The
+// thunk fails when the schema is not extended with the registry of the
+// loader, or when K and V are not the types of its keys and values.
+func Load[K comparable, V any](ctx context.Context, name string, key K) func() (interface{}, error) {
+	l, err := loaderFromContext[K, V](ctx, name)
+	if err != nil {
+		return func() (interface{}, error) {
+			return nil, err
+		}
+	}
+	value := l.load(key)
+	return func() (interface{}, error) {
+		return value()
+	}
+}
+
+// LoadMany returns the thunk of the values of the keys, in the order of the
+// keys, failing with the first error of the keys, see Load.
+func LoadMany[K comparable, V any](ctx context.Context, name string, keys []K) func() (interface{}, error) {
+	l, err := loaderFromContext[K, V](ctx, name)
+	if err != nil {
+		return func() (interface{}, error) {
+			return nil, err
+		}
+	}
+	values := make([]func() (V, error), len(keys))
+	for i, key := range keys {
+		values[i] = l.load(key)
+	}
+	return func() (interface{}, error) {
+		loaded := make([]V, len(values))
+		for i, value := range values {
+			v, err := value()
+			if err != nil {
+				return nil, err
+			}
+			loaded[i] = v
+		}
+		return loaded, nil
+	}
+}
+
+type loadersKeyType int
+
+const loadersKey loadersKeyType = 0
+
+// executionLoaders are the loaders of an execution, created when first used.
+type executionLoaders struct {
+	registry *Registry
+	ctx      context.Context
+
+	mu      sync.Mutex
+	loaders map[string]interface{}
+}
+
+// loaderFromContext returns the loader with the given name of the execution
+// of the context.
+func loaderFromContext[K comparable, V any](ctx context.Context, name string) (*loader[K, V], error) {
+	var loaders *executionLoaders
+	if ctx != nil {
+		loaders, _ = ctx.Value(loadersKey).(*executionLoaders)
+	}
+	if loaders == nil {
+		return nil, fmt.Errorf(`Cannot load from the loader "%v" outside of the execution of an operation of a schema extended with its registry.`, name)
+	}
+
+	loaders.mu.Lock()
+	defer loaders.mu.Unlock()
+	l, ok := loaders.loaders[name]
+	if !ok {
+		loaders.registry.mu.RLock()
+		newLoader, ok := loaders.registry.loaders[name]
+		loaders.registry.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf(`Unknown loader "%v".`, name)
+		}
+		l = newLoader(loaders.ctx)
+		loaders.loaders[name] = l
+	}
+	typed, ok := l.(*loader[K, V])
+	if !ok {
+		return nil, fmt.Errorf(`The loader "%v" does not load %v values by %v keys.`,
+			name, reflect.TypeOf((*V)(nil)).Elem(), reflect.TypeOf((*K)(nil)).Elem())
+	}
+	return typed, nil
+}
+
+// loader batches and caches the loads of an execution.
+type loader[K comparable, V any] struct {
+	name  string
+	batch BatchFunc[K, V]
+	ctx   context.Context
+
+	mu      sync.Mutex
+	pending *loaderBatch[K, V]
+	cache   map[K]*loaderBatch[K, V]
+}
+
+// loaderBatch is a batch of keys, loaded once.
+type loaderBatch[K comparable, V any] struct {
+	keys  []K
+	index map[K]int
+
+	once   sync.Once
+	values []V
+	errs   []error
+}
+
+// load adds the key to the pending batch, unless it is already loaded or
+// pending, and returns the function returning its value.
+func (l *loader[K, V]) load(key K) func() (V, error) {
+	l.mu.Lock()
+	b, ok := l.cache[key]
+	if !ok {
+		if l.pending == nil {
+			l.pending = &loaderBatch[K, V]{index: map[K]int{}}
+		}
+		b = l.pending
+		b.index[key] = len(b.keys)
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+	}
+	l.mu.Unlock()
+	return func() (V, error) {
+		l.dispatch(b)
+		return l.value(b, key)
+	}
+}
+
+// dispatch loads the batch, unless it is already loaded.
+func (l *loader[K, V]) dispatch(b *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		// the keys loaded from now on are loaded by the next batch
+		l.pending = nil
+	}
+	l.mu.Unlock()
+	b.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				b.values = nil
+				b.errs = []error{fmt.Errorf(`The batch function of the loader "%v" panicked: %v`, l.name, r)}
+			}
+		}()
+		b.values, b.errs = l.batch(l.ctx, b.keys)
+	})
+}
+
+// value returns the value of the key of the loaded batch.
+func (l *loader[K, V]) value(b *loaderBatch[K, V], key K) (V, error) {
+	var zero V
+	i := b.index[key]
+	switch len(b.errs) {
+	case 0:
+	case 1:
+		if b.errs[0] != nil {
+			return zero, b.errs[0]
+		}
+	case len(b.keys):
+		if b.errs[i] != nil {
+			return zero, b.errs[i]
+		}
+	default:
+		return zero, fmt.Errorf(`The batch function of the loader "%v" returned %d errors for %d keys.`, l.name, len(b.errs), len(b.keys))
+	}
+	if len(b.values) != len(b.keys) {
+		return zero, fmt.Errorf(`The batch function of the loader "%v" returned %d values for %d keys.`, l.name, len(b.values), len(b.keys))
+	}
+	return b.values[i], nil
+}
+
+// Init implements graphql.Extension.
+func (r *Registry) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+// Name implements graphql.Extension.
+func (r *Registry) Name() string {
+	return "DataLoader"
+}
+
+// ParseDidStart implements graphql.Extension.
+func (r *Registry) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (r *Registry) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart implements graphql.Extension, giving the execution its
+// own loaders.
+func (r *Registry) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	loaders := &executionLoaders{
+		registry: r,
+		ctx:      ctx,
+		loaders:  map[string]interface{}{},
+	}
+	return context.WithValue(ctx, loadersKey, loaders), func(*graphql.Result) {}
+}
+
+// ResolveFieldDidStart implements graphql.Extension.
+func (r *Registry) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+// HasResult implements graphql.Extension.
+func (r *Registry) HasResult() bool {
+	return false
+}
+
+// GetResult implements graphql.Extension.
+func (r *Registry) GetResult(context.Context) interface{} {
+	return nil
+}
@@ -0,0 +1,211 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/dataloader"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type user struct {
+	ID       string
+	Name     string
+	FriendID string
+}
+
+var users = map[string]*user{
+	"1": {ID: "1", Name: "Ada", FriendID: "2"},
+	"2": {ID: "2", Name: "Grace", FriendID: "3"},
+	"3": {ID: "3", Name: "Barbara", FriendID: "1"},
+}
+
+// batches records the keys of the batches.
+type batches struct {
+	mu   sync.Mutex
+	keys [][]string
+}
+
+func (b *batches) loadUsers(ctx context.Context, ids []string) ([]*user, []error) {
+	b.mu.Lock()
+	b.keys = append(b.keys, ids)
+	b.mu.Unlock()
+	loaded := make([]*user, len(ids))
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		if loaded[i] = users[id]; loaded[i] == nil {
+			errs[i] = errors.New("user " + id + " not found")
+		}
+	}
+	return loaded, errs
+}
+
+func newSchema(t *testing.T, loaders *dataloader.Registry) graphql.Schema {
+	var userType *graphql.Object
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+				"friend": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return dataloader.Load[string, *user](p.Context, "User", p.Source.(*user).FriendID), nil
+					},
+				},
+			}
+		}),
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(userType),
+					Args: graphql.FieldConfigArgument{
+						"ids": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						ids := []string{}
+						for _, id := range p.Args["ids"].([]interface{}) {
+							ids = append(ids, id.(string))
+						}
+						return dataloader.LoadMany[string, *user](p.Context, "User", ids), nil
+					},
+				},
+				"user": &graphql.Field{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return dataloader.Load[string, *user](p.Context, "User", p.Args["id"].(string)), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaders != nil {
+		schema.AddExtensions(loaders)
+	}
+	return schema
+}
+
+func TestLoad_BatchesTheKeysOfEachLevel(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		b := &batches{}
+		loaders := dataloader.NewRegistry()
+		dataloader.Register(loaders, "User", b.loadUsers)
+		schema := newSchema(t, loaders)
+
+		result := graphql.Do(graphql.Params{
+			Schema: schema,
+			RequestString: `{
+				first: user(id: "1") { name friend { name friend { name } } }
+				second: user(id: "2") { name friend { name } }
+			}`,
+			Concurrency: concurrency,
+		})
+		expected := &graphql.Result{
+			Data: map[string]interface{}{
+				"first": map[string]interface{}{
+					"name": "Ada",
+					"friend": map[string]interface{}{
+						"name":   "Grace",
+						"friend": map[string]interface{}{"name": "Barbara"},
+					},
+				},
+				"second": map[string]interface{}{
+					"name":   "Grace",
+					"friend": map[string]interface{}{"name": "Barbara"},
+				},
+			},
+		}
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+		// the loaded users are cached, the third level loads nothing
+		if len(b.keys) != 2 || len(b.keys[0]) != 2 || !reflect.DeepEqual(b.keys[1], []string{"3"}) {
+			t.Fatalf("expected the users to be loaded in 2 batches, got %v", b.keys)
+		}
+	}
+}
+
+func TestLoadMany(t *testing.T) {
+	b := &batches{}
+	loaders := dataloader.NewRegistry()
+	dataloader.Register(loaders, "User", b.loadUsers)
+	schema := newSchema(t, loaders)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users(ids: ["1", "2", "1"]) { friend { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{"friend": map[string]interface{}{"name": "Grace"}},
+				map[string]interface{}{"friend": map[string]interface{}{"name": "Barbara"}},
+				map[string]interface{}{"friend": map[string]interface{}{"name": "Grace"}},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if !reflect.DeepEqual(b.keys, [][]string{{"1", "2"}, {"3"}}) {
+		t.Fatalf("expected the users to be loaded in 2 batches, got %v", b.keys)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	b := &batches{}
+	loaders := dataloader.NewRegistry()
+	dataloader.Register(loaders, "User", b.loadUsers)
+	dataloader.Register(loaders, "Missing", func(ctx context.Context, ids []string) ([]*user, []error) {
+		return nil, nil
+	})
+	dataloader.Register(loaders, "Failing", func(ctx context.Context, ids []string) ([]*user, []error) {
+		return nil, []error{errors.New("the database is down")}
+	})
+
+	for _, test := range []struct {
+		loaders *dataloader.Registry
+		query   string
+		message string
+	}{
+		{loaders, `{ user(id: "4") { name } }`, "user 4 not found"},
+		{nil, `{ user(id: "1") { name } }`, `Cannot load from the loader "User" outside of the execution of an operation of a schema extended with its registry.`},
+		{dataloader.NewRegistry(), `{ user(id: "1") { name } }`, `Unknown loader "User".`},
+	} {
+		schema := newSchema(t, test.loaders)
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: test.query})
+		expected := []gqlerrors.FormattedError{{Message: test.message}}
+		if len(result.Errors) != 1 || result.Errors[0].Message != test.message {
+			t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, result.Errors))
+		}
+	}
+
+	ctx, _ := loaders.ExecutionDidStart(context.Background())
+	for name, message := range map[string]string{
+		"Missing": `The batch function of the loader "Missing" returned 0 values for 1 keys.`,
+		"Failing": "the database is down",
+	} {
+		_, err := dataloader.Load[string, *user](ctx, name, "1")()
+		if err == nil || err.Error() != message {
+			t.Fatalf("expected error %q, got %v", message, err)
+		}
+	}
+	_, err := dataloader.Load[int, *user](ctx, "User", 1)()
+	if expected := `The loader "User" does not load *dataloader_test.user values by int keys.`; err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
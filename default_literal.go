@@ -0,0 +1,30 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DefaultLiteral returns the literal of the default value of the argument, as
+// printed in the SDL and returned by introspection, or nil if it has none.
+// A default value given as a literal is returned verbatim, other default values
+// are converted to their canonical literal: enums by the name of their value,
+// input objects with their fields ordered by name.
+func (st *Argument) DefaultLiteral() ast.Value {
+	return defaultLiteral(st.DefaultValue, st.Type)
+}
+
+// DefaultLiteral returns the literal of the default value of the input field,
+// see Argument.DefaultLiteral.
+func (st *InputObjectField) DefaultLiteral() ast.Value {
+	return defaultLiteral(st.DefaultValue, st.Type)
+}
+
+func defaultLiteral(value interface{}, ttype Input) ast.Value {
+	if literal, ok := value.(ast.Value); ok {
+		return literal
+	}
+	if isNullish(value) {
+		return nil
+	}
+	return astFromValue(value, ttype)
+}
@@ -0,0 +1,207 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// durationType parses Int literals as seconds and String literals as Go
+// durations, telling them apart by the kind of the literal.
+var durationType = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Duration",
+	Serialize: func(value interface{}) interface{} {
+		return value.(time.Duration).String()
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if value, ok := value.(string); ok {
+			duration, _ := time.ParseDuration(value)
+			return duration
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			duration, _ := time.ParseDuration(valueAST.Value + "s")
+			return duration
+		case *ast.StringValue:
+			duration, _ := time.ParseDuration(valueAST.Value)
+			return duration
+		}
+		return nil
+	},
+})
+
+func parseLiteral(t *testing.T, literal string) ast.Value {
+	value, err := parser.ParseValue(parser.ParseParams{Source: literal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func defaultValuesSchema(t *testing.T) graphql.Schema {
+	optionsType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "WaitOptions",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"timeout": &graphql.InputObjectFieldConfig{
+				Type:         durationType,
+				DefaultValue: parseLiteral(t, `"2m"`),
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"wait": &graphql.Field{
+					Type: durationType,
+					Args: graphql.FieldConfigArgument{
+						"duration": &graphql.ArgumentConfig{
+							Type:         durationType,
+							DefaultValue: parseLiteral(t, `90`),
+						},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["duration"], nil
+					},
+				},
+				"timeout": &graphql.Field{
+					Type: durationType,
+					Args: graphql.FieldConfigArgument{
+						"options": &graphql.ArgumentConfig{Type: optionsType},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["options"].(map[string]interface{})["timeout"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestDefaultValues_LiteralsAreParsedByScalars(t *testing.T) {
+	schema := defaultValuesSchema(t)
+	tests := []struct {
+		query     string
+		variables map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{`{ wait }`, nil, map[string]interface{}{"wait": "1m30s"}},
+		{`{ wait(duration: 5) }`, nil, map[string]interface{}{"wait": "5s"}},
+		{`query ($d: Duration = 30) { wait(duration: $d) }`, nil, map[string]interface{}{"wait": "30s"}},
+		{`query ($d: Duration = "1h") { wait(duration: $d) }`, nil, map[string]interface{}{"wait": "1h0m0s"}},
+		{`query ($d: Duration = 30) { wait(duration: $d) }`, map[string]interface{}{"d": "10s"}, map[string]interface{}{"wait": "10s"}},
+		{`{ timeout(options: {}) }`, nil, map[string]interface{}{"timeout": "2m0s"}},
+		{`query ($o: WaitOptions) { timeout(options: $o) }`, map[string]interface{}{"o": map[string]interface{}{}}, map[string]interface{}{"timeout": "2m0s"}},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  test.query,
+			VariableValues: test.variables,
+		})
+		expected := &graphql.Result{Data: test.expected}
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("%v: unexpected result, Diff: %v", test.query, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestDefaultValues_LiteralsArePrinted(t *testing.T) {
+	schema := defaultValuesSchema(t)
+	sdl := graphql.PrintSchema(&schema)
+	for _, expected := range []string{`wait(duration: Duration = 90): Duration`, `timeout: Duration = "2m"`} {
+		if !strings.Contains(sdl, expected) {
+			t.Fatalf("expected the schema to contain %q, got:\n%v", expected, sdl)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "Query") { fields { args { defaultValue } } } }`,
+	})
+	fields := result.Data.(map[string]interface{})["__type"].(map[string]interface{})["fields"].([]interface{})
+	for _, field := range fields {
+		args := field.(map[string]interface{})["args"].([]interface{})
+		if len(args) == 1 && args[0].(map[string]interface{})["defaultValue"] == "90" {
+			return
+		}
+	}
+	t.Fatalf("expected the default value literal in the introspection, got %v", result)
+}
+
+func TestDefaultValues_GoValuesArePrintedAsCanonicalLiterals(t *testing.T) {
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: 0},
+			"BLUE": &graphql.EnumValueConfig{Value: 1},
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"colors": &graphql.InputObjectFieldConfig{Type: graphql.NewList(color)},
+			"name":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"search": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"color": &graphql.ArgumentConfig{Type: color, DefaultValue: 1},
+						"filter": &graphql.ArgumentConfig{
+							Type: filter,
+							DefaultValue: map[string]interface{}{
+								"name":   "a",
+								"colors": []interface{}{0, 1},
+							},
+						},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sdl := graphql.PrintSchema(&schema)
+	expected := `search(color: Color = BLUE, filter: Filter = {colors: [RED, BLUE], name: "a"}): String`
+	if !strings.Contains(sdl, expected) {
+		t.Fatalf("expected the schema to contain %q, got:\n%v", expected, sdl)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "Query") { fields { args { name defaultValue } } } }`,
+	})
+	expectedArgs := map[string]interface{}{
+		"color":  "BLUE",
+		"filter": `{colors: [RED, BLUE], name: "a"}`,
+	}
+	fields := result.Data.(map[string]interface{})["__type"].(map[string]interface{})["fields"].([]interface{})
+	args := map[string]interface{}{}
+	for _, arg := range fields[0].(map[string]interface{})["args"].([]interface{}) {
+		arg := arg.(map[string]interface{})
+		args[arg["name"].(string)] = arg["defaultValue"]
+	}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Fatalf("expected the introspected default values %v, got: %v", expectedArgs, args)
+	}
+}
@@ -170,6 +170,16 @@ var _ Named = (*Union)(nil)
 var _ Named = (*Enum)(nil)
 var _ Named = (*InputObject)(nil)
 
+// IsNamedType determines if given type is a named type, not a List or a NonNull
+func IsNamedType(ttype Type) bool {
+	switch ttype.(type) {
+	case *Scalar, *Object, *Interface, *Union, *Enum, *InputObject:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetNamed returns the Named type of the given GraphQL type
 func GetNamed(ttype Type) Named {
 	unmodifiedType := ttype
@@ -200,8 +210,10 @@ func GetNamed(ttype Type) Named {
 //	  }
 //	});
 type Scalar struct {
-	PrivateName        string `json:"name"`
-	PrivateDescription string `json:"description"`
+	PrivateName        string                 `json:"name"`
+	PrivateDescription string                 `json:"description"`
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	scalarConfig ScalarConfig
 	err          error
@@ -210,9 +222,17 @@ type Scalar struct {
 // SerializeFn is a function type for serializing a GraphQLScalar type value
 type SerializeFn func(value interface{}) interface{}
 
+// SerializeEFn is a function type for serializing a GraphQLScalar type value,
+// returning an error to reject the value with a specific message.
+type SerializeEFn func(value interface{}) (interface{}, error)
+
 // ParseValueFn is a function type for parsing the value of a GraphQLScalar type
 type ParseValueFn func(value interface{}) interface{}
 
+// ParseValueEFn is a function type for parsing the value of a GraphQLScalar
+// type, returning an error to reject the value with a specific message.
+type ParseValueEFn func(value interface{}) (interface{}, error)
+
 // ParseLiteralFn is a function type for parsing the literal value of a GraphQLScalar type
 type ParseLiteralFn func(valueAST ast.Value) interface{}
 
@@ -223,6 +243,25 @@ type ScalarConfig struct {
 	Serialize    SerializeFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+
+	// SerializeE serializes the value of the scalar like Serialize, in place
+	// of it, the error it returns resolving the field to a field error.
+	SerializeE SerializeEFn
+
+	// ParseValueE parses the value of the scalar like ParseValue, in place of
+	// it, the error it returns describing why the value is rejected.
+	ParseValueE ParseValueEFn
+
+	// Extensions hold custom metadata of the type, such as the configuration
+	// of the frameworks built on the schema, available on the built type and
+	// at runtime through TypeExtensions. They are neither printed nor
+	// introspected.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, such as
+	// `@cost(weight: 2)`, introspected by its appliedDirectives field when
+	// the schema sets SchemaConfig.IntrospectAppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -242,9 +281,11 @@ func NewScalar(config ScalarConfig) *Scalar {
 
 	st.PrivateName = config.Name
 	st.PrivateDescription = config.Description
+	st.Extensions = config.Extensions
+	st.AppliedDirectives = config.AppliedDirectives
 
 	err = invariantf(
-		config.Serialize != nil,
+		config.Serialize != nil || config.SerializeE != nil,
 		`%v must provide "serialize" function. If this custom Scalar is `+
 			`also used as an input type, ensure "parseValue" and "parseLiteral" `+
 			`functions are also provided.`, st,
@@ -253,9 +294,10 @@ func NewScalar(config ScalarConfig) *Scalar {
 		st.err = err
 		return st
 	}
-	if config.ParseValue != nil || config.ParseLiteral != nil {
+	hasParseValue := config.ParseValue != nil || config.ParseValueE != nil
+	if hasParseValue || config.ParseLiteral != nil {
 		err = invariantf(
-			config.ParseValue != nil && config.ParseLiteral != nil,
+			hasParseValue && config.ParseLiteral != nil,
 			`%v must provide both "parseValue" and "parseLiteral" functions.`, st,
 		)
 		if err != nil {
@@ -268,17 +310,49 @@ func NewScalar(config ScalarConfig) *Scalar {
 	return st
 }
 func (st *Scalar) Serialize(value interface{}) interface{} {
+	if st.scalarConfig.SerializeE != nil {
+		serialized, err := st.scalarConfig.SerializeE(value)
+		if err != nil {
+			return nil
+		}
+		return serialized
+	}
 	if st.scalarConfig.Serialize == nil {
 		return value
 	}
 	return st.scalarConfig.Serialize(value)
 }
+
+// SerializeE serializes the value like Serialize, along with the error
+// rejecting it when the scalar provides a SerializeE function.
+func (st *Scalar) SerializeE(value interface{}) (interface{}, error) {
+	if st.scalarConfig.SerializeE != nil {
+		return st.scalarConfig.SerializeE(value)
+	}
+	return st.Serialize(value), nil
+}
 func (st *Scalar) ParseValue(value interface{}) interface{} {
+	if st.scalarConfig.ParseValueE != nil {
+		parsed, err := st.scalarConfig.ParseValueE(value)
+		if err != nil {
+			return nil
+		}
+		return parsed
+	}
 	if st.scalarConfig.ParseValue == nil {
 		return value
 	}
 	return st.scalarConfig.ParseValue(value)
 }
+
+// ParseValueE parses the value like ParseValue, along with the error
+// rejecting it when the scalar provides a ParseValueE function.
+func (st *Scalar) ParseValueE(value interface{}) (interface{}, error) {
+	if st.scalarConfig.ParseValueE != nil {
+		return st.scalarConfig.ParseValueE(value)
+	}
+	return st.ParseValue(value), nil
+}
 func (st *Scalar) ParseLiteral(valueAST ast.Value) interface{} {
 	if st.scalarConfig.ParseLiteral == nil {
 		return nil
@@ -338,6 +412,8 @@ type Object struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	IsTypeOf           IsTypeOfFn
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	typeConfig            ObjectConfig
 	initialisedFields     bool
@@ -346,6 +422,12 @@ type Object struct {
 	interfaces            []*Interface
 	// Interim alternative to throwing an error during schema definition at run-time
 	err error
+
+	// fieldEdits are the fields appended, replaced or wrapped, see AppendField.
+	fieldEdits []fieldEdit
+
+	// frozen is set when a schema of the type is frozen, see Schema.Freeze.
+	frozen bool
 }
 
 // IsTypeOfParams Params for IsTypeOfFn()
@@ -373,6 +455,13 @@ type ObjectConfig struct {
 	Fields      interface{} `json:"fields"`
 	IsTypeOf    IsTypeOfFn  `json:"isTypeOf"`
 	Description string      `json:"description"`
+
+	// Extensions hold custom metadata of the type, see ScalarConfig.Extensions.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 type FieldsThunk func() Fields
@@ -394,6 +483,8 @@ func NewObject(config ObjectConfig) *Object {
 	objectType.PrivateName = config.Name
 	objectType.PrivateDescription = config.Description
 	objectType.IsTypeOf = config.IsTypeOf
+	objectType.Extensions = config.Extensions
+	objectType.AppliedDirectives = config.AppliedDirectives
 	objectType.typeConfig = config
 
 	return objectType
@@ -409,6 +500,9 @@ func (gt *Object) AddFieldConfig(fieldName string, fieldConfig *Field) {
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
+	if gt.frozen {
+		panic(newFrozenError(`Cannot add the field "%v.%v", the schema is frozen.`, gt, fieldName))
+	}
 	if fields, ok := gt.typeConfig.Fields.(Fields); ok {
 		fields[fieldName] = fieldConfig
 		gt.initialisedFields = false
@@ -435,6 +529,11 @@ func (gt *Object) Fields() FieldDefinitionMap {
 	case FieldsThunk:
 		configureFields = fields()
 	}
+	if configureFields, gt.err = applyFieldEdits(gt, configureFields, gt.fieldEdits); gt.err != nil {
+		gt.fields = FieldDefinitionMap{}
+		gt.initialisedFields = true
+		return gt.fields
+	}
 
 	gt.fields, gt.err = defineFieldMap(gt, configureFields)
 	gt.initialisedFields = true
@@ -528,13 +627,20 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 		if err = assertValidName(fieldName); err != nil {
 			return resultFieldMap, err
 		}
+		if err = assertSemanticNonNullLevels(ttype, fieldName, field.Type, field.SemanticNonNull); err != nil {
+			return resultFieldMap, err
+		}
 		fieldDef := &FieldDefinition{
 			Name:              fieldName,
 			Description:       field.Description,
 			Type:              field.Type,
 			Resolve:           field.Resolve,
 			Subscribe:         field.Subscribe,
+			OnEvent:           field.OnEvent,
 			DeprecationReason: field.DeprecationReason,
+			SemanticNonNull:   field.SemanticNonNull,
+			Extensions:        field.Extensions,
+			AppliedDirectives: field.AppliedDirectives,
 		}
 
 		fieldDef.Args = []*Argument{}
@@ -559,6 +665,9 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 				PrivateDescription: arg.Description,
 				Type:               arg.Type,
 				DefaultValue:       arg.DefaultValue,
+				DeprecationReason:  arg.DeprecationReason,
+				Extensions:         arg.Extensions,
+				AppliedDirectives:  arg.AppliedDirectives,
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
@@ -586,6 +695,13 @@ type ResolveParams struct {
 
 type FieldResolveFn func(p ResolveParams) (interface{}, error)
 
+// SubscriptionEventFn is called for each event of a subscription before its
+// selection set is executed, e.g. to re-authorize the subscriber whose token
+// may have expired over a long-lived connection. It returns the context and
+// the event to execute the selection set with. Returning ErrSkipEvent drops
+// the event, any other error is sent to the subscriber and ends the subscription.
+type SubscriptionEventFn func(ctx context.Context, event interface{}) (context.Context, interface{}, error)
+
 type ResolveInfo struct {
 	FieldName      string
 	FieldASTs      []*ast.Field
@@ -597,6 +713,20 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+
+	// ExecutionID identifies the operation being executed, see
+	// ExecuteParams.ExecutionID.
+	ExecutionID string
+
+	// fragments are the fragments through which the field is selected, see
+	// FragmentDirectives.
+	fragments []ast.Node
+
+	// progress is the progress of the execution, see Progress.
+	progress *executionProgress
+
+	// args are the values of the arguments of the field, see ArgumentValues.
+	args map[string]interface{}
 }
 
 type Fields map[string]*Field
@@ -607,27 +737,62 @@ type Field struct {
 	Args              FieldConfigArgument `json:"args"`
 	Resolve           FieldResolveFn      `json:"-"`
 	Subscribe         FieldResolveFn      `json:"-"`
+	OnEvent           SubscriptionEventFn `json:"-"`
 	DeprecationReason string              `json:"deprecationReason"`
 	Description       string              `json:"description"`
+
+	// SemanticNonNull lists the levels of the type of the field which are
+	// null only on error, 0 being the field itself and 1 the items of a list,
+	// see SemanticNonNullDirective.
+	SemanticNonNull []int `json:"semanticNonNull,omitempty"`
+
+	// Extensions hold custom metadata of the field, available on its
+	// definition and to its resolver through ResolveInfo.FieldExtensions.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the field, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
-	Type         Input       `json:"type"`
+	Type Input `json:"type"`
+
+	// DefaultValue is the internal value of the argument when it is not
+	// provided, or a literal, e.g. parsed with parser.ParseValue, coerced
+	// like the literals of the requests: the scalars parse it with their
+	// ParseLiteral, which is given the literal and its kind.
 	DefaultValue interface{} `json:"defaultValue"`
 	Description  string      `json:"description"`
+
+	// DeprecationReason deprecates the argument, printed and introspected.
+	DeprecationReason string `json:"deprecationReason"`
+
+	// Extensions hold custom metadata of the argument, available on its
+	// definition.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the argument, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
 type FieldDefinition struct {
-	Name              string         `json:"name"`
-	Description       string         `json:"description"`
-	Type              Output         `json:"type"`
-	Args              []*Argument    `json:"args"`
-	Resolve           FieldResolveFn `json:"-"`
-	Subscribe         FieldResolveFn `json:"-"`
-	DeprecationReason string         `json:"deprecationReason"`
+	Name              string              `json:"name"`
+	Description       string              `json:"description"`
+	Type              Output              `json:"type"`
+	Args              []*Argument         `json:"args"`
+	Resolve           FieldResolveFn      `json:"-"`
+	Subscribe         FieldResolveFn      `json:"-"`
+	OnEvent           SubscriptionEventFn `json:"-"`
+	DeprecationReason string              `json:"deprecationReason"`
+	SemanticNonNull   []int               `json:"semanticNonNull,omitempty"`
+
+	Extensions        map[string]interface{} `json:"-"`
+	AppliedDirectives []*AppliedDirective    `json:"-"`
 }
 
 type FieldArgument struct {
@@ -642,6 +807,10 @@ type Argument struct {
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+	DeprecationReason  string      `json:"deprecationReason"`
+
+	Extensions        map[string]interface{} `json:"-"`
+	AppliedDirectives []*AppliedDirective    `json:"-"`
 }
 
 func (st *Argument) Name() string {
@@ -673,21 +842,40 @@ func (st *Argument) Error() error {
 //	    name: { type: String }
 //	  }
 //	});
+//
+// The Resolve functions and the argument default values of the fields of an
+// interface are inherited by the fields of the objects implementing it which
+// do not define their own, e.g. a resolver shared by all the Node types.
 type Interface struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	typeConfig        InterfaceConfig
 	initialisedFields bool
 	fields            FieldDefinitionMap
 	err               error
+
+	// fieldEdits are the fields appended, replaced or wrapped, see AppendField.
+	fieldEdits []fieldEdit
+
+	// frozen is set when a schema of the type is frozen, see Schema.Freeze.
+	frozen bool
 }
 type InterfaceConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+
+	// Extensions hold custom metadata of the type, see ScalarConfig.Extensions.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -719,6 +907,8 @@ func NewInterface(config InterfaceConfig) *Interface {
 	it.PrivateName = config.Name
 	it.PrivateDescription = config.Description
 	it.ResolveType = config.ResolveType
+	it.Extensions = config.Extensions
+	it.AppliedDirectives = config.AppliedDirectives
 	it.typeConfig = config
 
 	return it
@@ -728,6 +918,9 @@ func (it *Interface) AddFieldConfig(fieldName string, fieldConfig *Field) {
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
+	if it.frozen {
+		panic(newFrozenError(`Cannot add the field "%v.%v", the schema is frozen.`, it, fieldName))
+	}
 	if fields, ok := it.typeConfig.Fields.(Fields); ok {
 		fields[fieldName] = fieldConfig
 		it.initialisedFields = false
@@ -754,6 +947,11 @@ func (it *Interface) Fields() (fields FieldDefinitionMap) {
 	case FieldsThunk:
 		configureFields = fields()
 	}
+	if configureFields, it.err = applyFieldEdits(it, configureFields, it.fieldEdits); it.err != nil {
+		it.fields = FieldDefinitionMap{}
+		it.initialisedFields = true
+		return it.fields
+	}
 
 	it.fields, it.err = defineFieldMap(it, configureFields)
 	it.initialisedFields = true
@@ -792,6 +990,8 @@ type Union struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	typeConfig      UnionConfig
 	initalizedTypes bool
@@ -799,6 +999,9 @@ type Union struct {
 	possibleTypes   map[string]bool
 
 	err error
+
+	// frozen is set when a schema of the type is frozen, see Schema.Freeze.
+	frozen bool
 }
 
 type UnionTypesThunk func() []*Object
@@ -808,6 +1011,18 @@ type UnionConfig struct {
 	Types       interface{} `json:"types"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+
+	// TypedValues declares that the fields of the union resolve Typed values,
+	// allowing a union without a ResolveType function whose possible types do
+	// not provide IsTypeOf functions.
+	TypedValues bool `json:"-"`
+
+	// Extensions hold custom metadata of the type, see ScalarConfig.Extensions.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 func NewUnion(config UnionConfig) *Union {
@@ -822,6 +1037,8 @@ func NewUnion(config UnionConfig) *Union {
 	objectType.PrivateName = config.Name
 	objectType.PrivateDescription = config.Description
 	objectType.ResolveType = config.ResolveType
+	objectType.Extensions = config.Extensions
+	objectType.AppliedDirectives = config.AppliedDirectives
 
 	objectType.typeConfig = config
 
@@ -868,7 +1085,7 @@ func defineUnionTypes(objectType *Union, unionTypes []*Object) ([]*Object, error
 		); err != nil {
 			return definedUnionTypes, err
 		}
-		if objectType.ResolveType == nil {
+		if objectType.ResolveType == nil && !objectType.typeConfig.TypedValues {
 			if err := invariantf(
 				ttype.IsTypeOf != nil,
 				`Union Type %v does not provide a "resolveType" function `+
@@ -885,6 +1102,33 @@ func defineUnionTypes(objectType *Union, unionTypes []*Object) ([]*Object, error
 	return definedUnionTypes, nil
 }
 
+// AppendType adds a member to the union, e.g. contributed by a module of the
+// schema. The schemas already containing the union must be refreshed with
+// Schema.AppendType(object), which also resets their possible type caches.
+func (ut *Union) AppendType(object *Object) error {
+	if ut.frozen {
+		return newFrozenError(`Cannot append the type "%v" to %v, the schema is frozen.`, object, ut)
+	}
+	types := ut.Types()
+	if ut.err != nil {
+		return ut.err
+	}
+	for _, ttype := range types {
+		if err := invariantf(
+			ttype.Name() != object.Name(),
+			`%v already contains the type %v.`, ut, object,
+		); err != nil {
+			return err
+		}
+	}
+	definedTypes, err := defineUnionTypes(ut, []*Object{object})
+	if err != nil {
+		return err
+	}
+	ut.types = append(ut.types, definedTypes...)
+	return nil
+}
+
 func (ut *Union) String() string {
 	return ut.PrivateName
 }
@@ -922,8 +1166,10 @@ func (ut *Union) Error() error {
 // will be used as its internal value.
 
 type Enum struct {
-	PrivateName        string `json:"name"`
-	PrivateDescription string `json:"description"`
+	PrivateName        string                 `json:"name"`
+	PrivateDescription string                 `json:"description"`
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	enumConfig   EnumConfig
 	values       []*EnumValueDefinition
@@ -937,17 +1183,51 @@ type EnumValueConfig struct {
 	Value             interface{} `json:"value"`
 	DeprecationReason string      `json:"deprecationReason"`
 	Description       string      `json:"description"`
+
+	// Metadata is custom data of the value, such as the replacement of a
+	// deprecated value, available to resolvers through Enum.ValueByName and
+	// Enum.ValueFor.
+	Metadata map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the value, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 type EnumConfig struct {
 	Name        string             `json:"name"`
 	Values      EnumValueConfigMap `json:"values"`
 	Description string             `json:"description"`
+
+	// Strict reports an error for the fields of the enum type resolving to a
+	// value which is not the internal value of one of its values, instead of
+	// resolving them to null.
+	Strict bool `json:"-"`
+
+	// OnUnknownEnumValue, when set, is called with the values resolved by the
+	// fields of the enum type which are not the internal value of one of its
+	// values, returning the name of the enum value to use instead, or an error.
+	OnUnknownEnumValue UnknownEnumValueFn `json:"-"`
+
+	// Extensions hold custom metadata of the type, see ScalarConfig.Extensions.
+	// The metadata of its values is their Metadata.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
+
+// UnknownEnumValueFn returns the name of the enum value to serialize in place
+// of a value unknown to an enum type, see EnumConfig.OnUnknownEnumValue.
+type UnknownEnumValueFn func(value interface{}) (string, error)
+
 type EnumValueDefinition struct {
-	Name              string      `json:"name"`
-	Value             interface{} `json:"value"`
-	DeprecationReason string      `json:"deprecationReason"`
-	Description       string      `json:"description"`
+	Name              string                 `json:"name"`
+	Value             interface{}            `json:"value"`
+	DeprecationReason string                 `json:"deprecationReason"`
+	Description       string                 `json:"description"`
+	Metadata          map[string]interface{} `json:"-"`
+	AppliedDirectives []*AppliedDirective    `json:"-"`
 }
 
 func NewEnum(config EnumConfig) *Enum {
@@ -960,6 +1240,8 @@ func NewEnum(config EnumConfig) *Enum {
 
 	gt.PrivateName = config.Name
 	gt.PrivateDescription = config.Description
+	gt.Extensions = config.Extensions
+	gt.AppliedDirectives = config.AppliedDirectives
 	if gt.values, gt.err = gt.defineEnumValues(config.Values); gt.err != nil {
 		return gt
 	}
@@ -993,6 +1275,8 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 			Value:             valueConfig.Value,
 			DeprecationReason: valueConfig.DeprecationReason,
 			Description:       valueConfig.Description,
+			Metadata:          valueConfig.Metadata,
+			AppliedDirectives: valueConfig.AppliedDirectives,
 		}
 		if value.Value == nil {
 			value.Value = valueName
@@ -1004,6 +1288,47 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 func (gt *Enum) Values() []*EnumValueDefinition {
 	return gt.values
 }
+
+// ValueByName returns the value of the enum with the given name, nil if there
+// is none.
+func (gt *Enum) ValueByName(name string) *EnumValueDefinition {
+	return gt.getNameLookup()[name]
+}
+
+// ValueFor returns the value of the enum with the given internal value, as
+// received by resolvers in their arguments, nil if there is none.
+func (gt *Enum) ValueFor(value interface{}) *EnumValueDefinition {
+	if value == nil || !reflect.TypeOf(value).Comparable() {
+		return nil
+	}
+	return gt.getValueLookup()[value]
+}
+
+// serializeUnknown serializes a value unknown to the enum with the
+// OnUnknownEnumValue hook, or fails for strict enums.
+func (gt *Enum) serializeUnknown(value interface{}) (interface{}, error) {
+	if gt.enumConfig.OnUnknownEnumValue != nil {
+		name, err := gt.enumConfig.OnUnknownEnumValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if gt.ValueByName(name) == nil {
+			return nil, fmt.Errorf(`Enum "%v" has no value named "%v".`, gt.Name(), name)
+		}
+		return name, nil
+	}
+	if gt.enumConfig.Strict {
+		return nil, fmt.Errorf(`Enum "%v" cannot represent value: %v`, gt.Name(), value)
+	}
+	return nil, nil
+}
+
+// appendValue adds a value to the enum, resetting its lookups.
+func (gt *Enum) appendValue(value *EnumValueDefinition) {
+	gt.values = append(gt.values, value)
+	gt.valuesLookup = nil
+	gt.nameLookup = nil
+}
 func (gt *Enum) Serialize(value interface{}) interface{} {
 	v := value
 	rv := reflect.ValueOf(v)
@@ -1095,24 +1420,50 @@ func (gt *Enum) getNameLookup() map[string]*EnumValueDefinition {
 //	  }
 //	});
 type InputObject struct {
-	PrivateName        string `json:"name"`
-	PrivateDescription string `json:"description"`
+	PrivateName        string                 `json:"name"`
+	PrivateDescription string                 `json:"description"`
+	Extensions         map[string]interface{} `json:"-"`
+	AppliedDirectives  []*AppliedDirective    `json:"-"`
 
 	typeConfig InputObjectConfig
 	fields     InputObjectFieldMap
 	init       bool
 	err        error
+
+	// frozen is set when a schema of the type is frozen, see Schema.Freeze.
+	frozen bool
 }
 type InputObjectFieldConfig struct {
-	Type         Input       `json:"type"`
+	Type Input `json:"type"`
+
+	// DefaultValue is the internal value of the field when it is not
+	// provided, or a literal, see ArgumentConfig.DefaultValue.
 	DefaultValue interface{} `json:"defaultValue"`
 	Description  string      `json:"description"`
+
+	// Transform transforms the values of the field once coerced, see
+	// InputFieldTransformFn.
+	Transform InputFieldTransformFn `json:"-"`
+
+	// Extensions hold custom metadata of the field, available on its
+	// definition.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the field, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 type InputObjectField struct {
 	PrivateName        string      `json:"name"`
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+
+	// Transform transforms the values of the field once coerced.
+	Transform InputFieldTransformFn `json:"-"`
+
+	Extensions        map[string]interface{} `json:"-"`
+	AppliedDirectives []*AppliedDirective    `json:"-"`
 }
 
 func (st *InputObjectField) Name() string {
@@ -1135,6 +1486,13 @@ type InputObjectConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	Description string      `json:"description"`
+
+	// Extensions hold custom metadata of the type, see ScalarConfig.Extensions.
+	Extensions map[string]interface{} `json:"-"`
+
+	// AppliedDirectives are the directives applied to the type, see
+	// ScalarConfig.AppliedDirectives.
+	AppliedDirectives []*AppliedDirective `json:"-"`
 }
 
 func NewInputObject(config InputObjectConfig) *InputObject {
@@ -1145,6 +1503,8 @@ func NewInputObject(config InputObjectConfig) *InputObject {
 
 	gt.PrivateName = config.Name
 	gt.PrivateDescription = config.Description
+	gt.Extensions = config.Extensions
+	gt.AppliedDirectives = config.AppliedDirectives
 	gt.typeConfig = config
 	return gt
 }
@@ -1187,6 +1547,9 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 		field.Type = fieldConfig.Type
 		field.PrivateDescription = fieldConfig.Description
 		field.DefaultValue = fieldConfig.DefaultValue
+		field.Transform = fieldConfig.Transform
+		field.Extensions = fieldConfig.Extensions
+		field.AppliedDirectives = fieldConfig.AppliedDirectives
 		resultFieldMap[fieldName] = field
 	}
 	gt.init = true
@@ -1197,6 +1560,9 @@ func (gt *InputObject) AddFieldConfig(fieldName string, fieldConfig *InputObject
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
+	if gt.frozen {
+		panic(newFrozenError(`Cannot add the field "%v.%v", the schema is frozen.`, gt, fieldName))
+	}
 	fieldMap, ok := gt.typeConfig.Fields.(InputObjectConfigFieldMap)
 	if gt.err = invariant(ok, "Cannot add field to a thunk"); gt.err != nil {
 		return
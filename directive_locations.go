@@ -0,0 +1,255 @@
+package graphql
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+)
+
+// DirectiveLocation is the name of a location where directives can be used,
+// such as DirectiveLocationField, see DirectiveLocations.
+type DirectiveLocation = string
+
+// DirectiveLocationDefinition describes a location where directives can be used.
+type DirectiveLocationDefinition struct {
+	Name        DirectiveLocation
+	Description string
+
+	// Executable is set for the locations of executable documents, as opposed
+	// to the locations of type system documents.
+	Executable bool
+
+	// AppliesTo reports whether a directive applied to the given node is at
+	// this location. Ancestors are the ancestors of the node, root first.
+	AppliesTo func(node ast.Node, ancestors []ast.Node) bool
+}
+
+var (
+	directiveLocationsMu sync.RWMutex
+	directiveLocations   = []*DirectiveLocationDefinition{
+		{
+			Name:        DirectiveLocationQuery,
+			Description: "Location adjacent to a query operation.",
+			Executable:  true,
+			AppliesTo:   appliesToOperation(ast.OperationTypeQuery),
+		},
+		{
+			Name:        DirectiveLocationMutation,
+			Description: "Location adjacent to a mutation operation.",
+			Executable:  true,
+			AppliesTo:   appliesToOperation(ast.OperationTypeMutation),
+		},
+		{
+			Name:        DirectiveLocationSubscription,
+			Description: "Location adjacent to a subscription operation.",
+			Executable:  true,
+			AppliesTo:   appliesToOperation(ast.OperationTypeSubscription),
+		},
+		{
+			Name:        DirectiveLocationField,
+			Description: "Location adjacent to a field.",
+			Executable:  true,
+			AppliesTo:   appliesToKind(kinds.Field),
+		},
+		{
+			Name:        DirectiveLocationFragmentDefinition,
+			Description: "Location adjacent to a fragment definition.",
+			Executable:  true,
+			AppliesTo:   appliesToKind(kinds.FragmentDefinition),
+		},
+		{
+			Name:        DirectiveLocationFragmentSpread,
+			Description: "Location adjacent to a fragment spread.",
+			Executable:  true,
+			AppliesTo:   appliesToKind(kinds.FragmentSpread),
+		},
+		{
+			Name:        DirectiveLocationInlineFragment,
+			Description: "Location adjacent to an inline fragment.",
+			Executable:  true,
+			AppliesTo:   appliesToKind(kinds.InlineFragment),
+		},
+		{
+			Name:        DirectiveLocationVariableDefinition,
+			Description: "Location adjacent to a variable definition.",
+			Executable:  true,
+			AppliesTo:   appliesToKind(kinds.VariableDefinition),
+		},
+		{
+			Name:        DirectiveLocationSchema,
+			Description: "Location adjacent to a schema definition.",
+			AppliesTo:   appliesToKind(kinds.SchemaDefinition),
+		},
+		{
+			Name:        DirectiveLocationScalar,
+			Description: "Location adjacent to a scalar definition.",
+			AppliesTo:   appliesToKind(kinds.ScalarDefinition),
+		},
+		{
+			Name:        DirectiveLocationObject,
+			Description: "Location adjacent to a object definition.",
+			AppliesTo:   appliesToKind(kinds.ObjectDefinition),
+		},
+		{
+			Name:        DirectiveLocationFieldDefinition,
+			Description: "Location adjacent to a field definition.",
+			AppliesTo:   appliesToKind(kinds.FieldDefinition),
+		},
+		{
+			Name:        DirectiveLocationArgumentDefinition,
+			Description: "Location adjacent to an argument definition.",
+			AppliesTo: func(node ast.Node, ancestors []ast.Node) bool {
+				return node.GetKind() == kinds.InputValueDefinition && !isInputFieldDefinition(ancestors)
+			},
+		},
+		{
+			Name:        DirectiveLocationInterface,
+			Description: "Location adjacent to an interface definition.",
+			AppliesTo:   appliesToKind(kinds.InterfaceDefinition),
+		},
+		{
+			Name:        DirectiveLocationUnion,
+			Description: "Location adjacent to a union definition.",
+			AppliesTo:   appliesToKind(kinds.UnionDefinition),
+		},
+		{
+			Name:        DirectiveLocationEnum,
+			Description: "Location adjacent to an enum definition.",
+			AppliesTo:   appliesToKind(kinds.EnumDefinition),
+		},
+		{
+			Name:        DirectiveLocationEnumValue,
+			Description: "Location adjacent to an enum value definition.",
+			AppliesTo:   appliesToKind(kinds.EnumValueDefinition),
+		},
+		{
+			Name:        DirectiveLocationInputObject,
+			Description: "Location adjacent to an input object type definition.",
+			AppliesTo:   appliesToKind(kinds.InputObjectDefinition),
+		},
+		{
+			Name:        DirectiveLocationInputFieldDefinition,
+			Description: "Location adjacent to an input object field definition.",
+			AppliesTo: func(node ast.Node, ancestors []ast.Node) bool {
+				return node.GetKind() == kinds.InputValueDefinition && isInputFieldDefinition(ancestors)
+			},
+		},
+	}
+)
+
+func appliesToKind(kind string) func(ast.Node, []ast.Node) bool {
+	return func(node ast.Node, ancestors []ast.Node) bool {
+		return node.GetKind() == kind
+	}
+}
+
+func appliesToOperation(operation string) func(ast.Node, []ast.Node) bool {
+	return func(node ast.Node, ancestors []ast.Node) bool {
+		definition, ok := node.(*ast.OperationDefinition)
+		return ok && definition.Operation == operation
+	}
+}
+
+// isInputFieldDefinition reports whether an input value definition with the
+// given ancestors is the field of an input object, rather than an argument.
+func isInputFieldDefinition(ancestors []ast.Node) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch ancestor := ancestors[i].(type) {
+		case *ast.InputObjectDefinition:
+			if ancestor != nil {
+				return true
+			}
+		case *ast.FieldDefinition:
+			if ancestor != nil {
+				return false
+			}
+		case *ast.DirectiveDefinition:
+			if ancestor != nil {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// DirectiveLocations returns the locations where directives can be used, the
+// locations of the specification first, followed by registered locations.
+func DirectiveLocations() []*DirectiveLocationDefinition {
+	directiveLocationsMu.RLock()
+	defer directiveLocationsMu.RUnlock()
+	return append([]*DirectiveLocationDefinition{}, directiveLocations...)
+}
+
+// LookupDirectiveLocation returns the definition of the location with the
+// given name, or nil if it is unknown.
+func LookupDirectiveLocation(name DirectiveLocation) *DirectiveLocationDefinition {
+	directiveLocationsMu.RLock()
+	defer directiveLocationsMu.RUnlock()
+	for _, location := range directiveLocations {
+		if location.Name == name {
+			return location
+		}
+	}
+	return nil
+}
+
+// RegisterDirectiveLocation adds a location where directives can be used, such
+// as an experimental location not yet part of the specification. The location
+// is exposed by introspection and accepted in the locations of directives.
+//
+// Locations must be registered before the schemas using them are created,
+// typically from an init function.
+func RegisterDirectiveLocation(location DirectiveLocationDefinition) error {
+	if err := assertValidName(location.Name); err != nil {
+		return err
+	}
+	if location.AppliesTo == nil {
+		return fmt.Errorf("directive location %v must define AppliesTo", location.Name)
+	}
+	directiveLocationsMu.Lock()
+	defer directiveLocationsMu.Unlock()
+	for _, known := range directiveLocations {
+		if known.Name == location.Name {
+			return fmt.Errorf("directive location %v is already registered", location.Name)
+		}
+	}
+	directiveLocations = append(directiveLocations, &location)
+	if DirectiveLocationEnumType != nil {
+		DirectiveLocationEnumType.appendValue(&EnumValueDefinition{
+			Name:        location.Name,
+			Value:       location.Name,
+			Description: location.Description,
+		})
+	}
+	return nil
+}
+
+// directiveLocationEnumValues returns the values of the __DirectiveLocation enum.
+func directiveLocationEnumValues() EnumValueConfigMap {
+	values := EnumValueConfigMap{}
+	for _, location := range DirectiveLocations() {
+		values[location.Name] = &EnumValueConfig{
+			Value:       location.Name,
+			Description: location.Description,
+		}
+	}
+	return values
+}
+
+// getDirectiveLocationsForASTPath returns the locations of a directive with
+// the given ancestors, the node the directive is applied to being the last.
+func getDirectiveLocationsForASTPath(ancestors []ast.Node) []DirectiveLocation {
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1] == nil {
+		return nil
+	}
+	appliedTo := ancestors[len(ancestors)-1]
+	locations := []DirectiveLocation{}
+	for _, location := range DirectiveLocations() {
+		if location.AppliesTo(appliedTo, ancestors[:len(ancestors)-1]) {
+			locations = append(locations, location.Name)
+		}
+	}
+	return locations
+}
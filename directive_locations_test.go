@@ -0,0 +1,165 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+var registerAliasedFieldLocation sync.Once
+
+// aliasedFieldLocationSchema returns a schema with a directive only allowed
+// on aliased fields, an experimental location registered for the test.
+func aliasedFieldLocationSchema(t *testing.T) graphql.Schema {
+	registerAliasedFieldLocation.Do(func() {
+		err := graphql.RegisterDirectiveLocation(graphql.DirectiveLocationDefinition{
+			Name:        "ALIASED_FIELD",
+			Description: "Location adjacent to an aliased field.",
+			Executable:  true,
+			AppliesTo: func(node ast.Node, ancestors []ast.Node) bool {
+				field, ok := node.(*ast.Field)
+				return ok && field.Alias != nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onAliasedField",
+				Locations: []string{"ALIASED_FIELD"},
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestDirectiveLocations_SpecifiedLocations(t *testing.T) {
+	locations := graphql.DirectiveLocations()
+	if len(locations) < 18 {
+		t.Fatalf("expected the locations of the specification, got: %v", len(locations))
+	}
+	field := graphql.LookupDirectiveLocation(graphql.DirectiveLocationField)
+	if field == nil || !field.Executable {
+		t.Fatalf("expected FIELD to be an executable location, got: %v", field)
+	}
+	object := graphql.LookupDirectiveLocation(graphql.DirectiveLocationObject)
+	if object == nil || object.Executable {
+		t.Fatalf("expected OBJECT to be a type system location, got: %v", object)
+	}
+	if graphql.LookupDirectiveLocation("NOWHERE") != nil {
+		t.Fatalf("expected NOWHERE to be unknown")
+	}
+}
+
+func TestDirectiveLocations_RejectsUnknownLocations(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "nowhere",
+				Locations: []string{graphql.DirectiveLocationField, "NOWHERE"},
+			}),
+		},
+	})
+	expected := `Directive "@nowhere" has unknown location "NOWHERE".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
+}
+
+func TestDirectiveLocations_RejectsDuplicateRegistrations(t *testing.T) {
+	err := graphql.RegisterDirectiveLocation(graphql.DirectiveLocationDefinition{
+		Name:      graphql.DirectiveLocationField,
+		AppliesTo: func(ast.Node, []ast.Node) bool { return false },
+	})
+	if err == nil {
+		t.Fatalf("expected an error registering FIELD again")
+	}
+}
+
+func TestDirectiveLocations_ValidatesRegisteredLocations(t *testing.T) {
+	schema := aliasedFieldLocationSchema(t)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ b: a @onAliasedField }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a @onAliasedField }`,
+	})
+	expected := []gqlerrors.FormattedError{
+		{
+			Message:    `Directive "onAliasedField" may not be used on FIELD.`,
+			Locations:  []location.SourceLocation{{Line: 1, Column: 5}},
+			Extensions: map[string]interface{}{"code": gqlerrors.CodeValidationFailed},
+		},
+	}
+	if !testutil.EqualFormattedErrors(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
+
+func TestDirectiveLocations_IntrospectsRegisteredLocations(t *testing.T) {
+	schema := aliasedFieldLocationSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			__type(name: "__DirectiveLocation") { enumValues { name } }
+			__schema { directives { name locations } }
+		}`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+
+	found := false
+	for _, value := range data["__type"].(map[string]interface{})["enumValues"].([]interface{}) {
+		if value.(map[string]interface{})["name"] == "ALIASED_FIELD" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected ALIASED_FIELD to be a value of __DirectiveLocation")
+	}
+
+	for _, directive := range data["__schema"].(map[string]interface{})["directives"].([]interface{}) {
+		directive := directive.(map[string]interface{})
+		if directive["name"] != "onAliasedField" {
+			continue
+		}
+		if expected := []interface{}{"ALIASED_FIELD"}; !reflect.DeepEqual(directive["locations"], expected) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, directive["locations"]))
+		}
+		return
+	}
+	t.Fatalf("expected the onAliasedField directive to be introspected")
+}
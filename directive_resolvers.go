@@ -0,0 +1,231 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ResolveDirective Used to map the fields of the object types of an SDL
+// document to the properties of their parents, see DirectiveResolvers.
+var ResolveDirective = NewDirective(DirectiveConfig{
+	Name:        "resolve",
+	Description: "Resolves the field from its parent, without a resolver written for it.",
+	Args: FieldConfigArgument{
+		"source": &ArgumentConfig{
+			Type:        String,
+			Description: "The property of the parent resolving the field, or a path of properties separated by dots.",
+		},
+		"expr": &ArgumentConfig{
+			Type: String,
+			Description: "An expression of the properties of the parent: paths and 'quoted strings' " +
+				"concatenated with +, falling back to the next expression with ?? when null.",
+		},
+		"resolver": &ArgumentConfig{
+			Type:        String,
+			Description: "The name of the registered resolver resolving the field.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// DirectiveResolvers returns the resolvers of the fields of the object types
+// of an SDL document annotated with the @resolve directive, see
+// ResolveDirective, to be bound to a schema with Schema.BindResolvers, e.g.
+//
+//	type User {
+//	  name: String @resolve(source: "full_name")
+//	  city: String @resolve(source: "address.city")
+//	  label: String @resolve(expr: "nickname ?? first_name + ' ' + last_name")
+//	  avatar: String @resolve(resolver: "gravatar")
+//	}
+//
+// The properties are read from the parents like DefaultResolveFn reads the
+// fields, and the named resolvers are taken from the given registry. It fails
+// when a directive is invalid, sets none or more than one of its arguments, or
+// names an unregistered resolver.
+func DirectiveResolvers(doc *ast.Document, registry map[string]FieldResolveFn) (ResolverMap, error) {
+	resolvers := ResolverMap{}
+	for _, definition := range doc.Definitions {
+		object, ok := definition.(*ast.ObjectDefinition)
+		if extension, isExtension := definition.(*ast.TypeExtensionDefinition); isExtension {
+			object, ok = extension.Definition, extension.Definition != nil
+		}
+		if !ok || object.Name == nil {
+			continue
+		}
+		for _, field := range object.Fields {
+			for _, directive := range field.Directives {
+				if directive.Name == nil || directive.Name.Value != ResolveDirective.Name {
+					continue
+				}
+				resolve, err := directiveResolver(directive, registry)
+				if err != nil {
+					return nil, NewLocatedError(
+						fmt.Sprintf(`Invalid @%v directive of "%v.%v": %v`, ResolveDirective.Name, object.Name.Value, field.Name.Value, err),
+						[]ast.Node{directive},
+					)
+				}
+				if resolvers[object.Name.Value] == nil {
+					resolvers[object.Name.Value] = map[string]FieldResolveFn{}
+				}
+				resolvers[object.Name.Value][field.Name.Value] = resolve
+			}
+		}
+	}
+	return resolvers, nil
+}
+
+// directiveResolver returns the resolver of a @resolve directive.
+func directiveResolver(directive *ast.Directive, registry map[string]FieldResolveFn) (FieldResolveFn, error) {
+	args := map[string]string{}
+	for _, arg := range directive.Arguments {
+		value, ok := arg.Value.(*ast.StringValue)
+		if !ok {
+			return nil, fmt.Errorf(`the argument "%v" must be a string`, arg.Name.Value)
+		}
+		if name := arg.Name.Value; name != "source" && name != "expr" && name != "resolver" {
+			return nil, fmt.Errorf(`unknown argument "%v"`, name)
+		}
+		args[arg.Name.Value] = value.Value
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf(`exactly one of "source", "expr" and "resolver" must be set`)
+	}
+	if name, ok := args["resolver"]; ok {
+		resolve, ok := registry[name]
+		if !ok || resolve == nil {
+			return nil, fmt.Errorf(`unknown resolver "%v"`, name)
+		}
+		return resolve, nil
+	}
+	text, ok := args["expr"]
+	if !ok {
+		text = args["source"]
+		if !isPropertyPath(text) {
+			return nil, fmt.Errorf(`invalid source "%v"`, text)
+		}
+	}
+	expr, err := parseResolveExpr(text)
+	if err != nil {
+		return nil, err
+	}
+	return func(p ResolveParams) (interface{}, error) {
+		return expr.eval(p)
+	}, nil
+}
+
+// resolveExpr is a parsed @resolve expression: the alternatives separated by
+// ??, each the concatenation of its operands, a path or a string.
+type resolveExpr [][]resolveOperand
+
+type resolveOperand struct {
+	path    []string
+	literal string
+}
+
+// eval returns the value of the first alternative which is not null.
+func (expr resolveExpr) eval(p ResolveParams) (interface{}, error) {
+	for _, operands := range expr {
+		var value interface{}
+		var concatenated strings.Builder
+		for _, operand := range operands {
+			if operand.path == nil {
+				value = operand.literal
+			} else {
+				var err error
+				if value, err = resolveProperty(p, operand.path); err != nil {
+					return nil, err
+				}
+			}
+			if value == nil {
+				break
+			}
+			if len(operands) > 1 {
+				fmt.Fprint(&concatenated, value)
+			}
+		}
+		if value == nil {
+			continue
+		}
+		if len(operands) > 1 {
+			return concatenated.String(), nil
+		}
+		return value, nil
+	}
+	return nil, nil
+}
+
+// resolveProperty reads the path of properties from the parent of the field.
+func resolveProperty(p ResolveParams, path []string) (interface{}, error) {
+	value := p.Source
+	for _, name := range path {
+		params := p
+		params.Source = value
+		params.Info.FieldName = name
+		var err error
+		if value, err = DefaultResolveFn(params); err != nil || value == nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// parseResolveExpr parses a @resolve expression.
+func parseResolveExpr(text string) (resolveExpr, error) {
+	expr := resolveExpr{}
+	for _, alternative := range splitOutsideQuotes(text, "??") {
+		operands := []resolveOperand{}
+		for _, operand := range splitOutsideQuotes(alternative, "+") {
+			operand = strings.TrimSpace(operand)
+			switch {
+			case len(operand) >= 2 && operand[0] == '\'' && operand[len(operand)-1] == '\'':
+				operands = append(operands, resolveOperand{literal: operand[1 : len(operand)-1]})
+			case isPropertyPath(operand):
+				operands = append(operands, resolveOperand{path: strings.Split(operand, ".")})
+			default:
+				return nil, fmt.Errorf(`invalid expression "%v"`, text)
+			}
+		}
+		expr = append(expr, operands)
+	}
+	return expr, nil
+}
+
+// splitOutsideQuotes splits the text around the separators which are not
+// within single quotes.
+func splitOutsideQuotes(text string, separator string) []string {
+	parts := []string{}
+	quoted, start := false, 0
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\'':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(text[i:], separator):
+			parts = append(parts, text[start:i])
+			start = i + len(separator)
+			i = start - 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// isPropertyPath reports whether the text is a path of names separated by
+// dots.
+func isPropertyPath(text string) bool {
+	for _, name := range strings.Split(text, ".") {
+		if name == "" {
+			return false
+		}
+		for i, r := range name {
+			if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
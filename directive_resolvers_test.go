@@ -0,0 +1,139 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const directiveResolversSDL = `
+type User {
+  name: String @resolve(source: "full_name")
+  city: String @resolve(source: "address.city")
+  label: String @resolve(expr: "nickname ?? first_name + ' (' + address.city + ')'")
+  avatar: String @resolve(resolver: "avatar")
+}
+`
+
+func TestDirectiveResolvers(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: directiveResolversSDL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolvers, err := graphql.DirectiveResolvers(doc, map[string]graphql.FieldResolveFn{
+		"avatar": func(p graphql.ResolveParams) (interface{}, error) {
+			return "https://example.com/" + p.Source.(map[string]interface{})["first_name"].(string), nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name":   &graphql.Field{Type: graphql.String},
+			"city":   &graphql.Field{Type: graphql.String},
+			"label":  &graphql.Field{Type: graphql.String},
+			"avatar": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(user),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							map[string]interface{}{
+								"full_name":  "Jane Doe",
+								"first_name": "Jane",
+								"address":    map[string]interface{}{"city": "Paris"},
+							},
+							map[string]interface{}{
+								"full_name":  "John Doe",
+								"first_name": "John",
+								"nickname":   "Johnny",
+							},
+						}, nil
+					},
+				},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, graphql.ResolveDirective),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.BindResolvers(resolvers); err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users { name city label avatar } }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{
+				"name":   "Jane Doe",
+				"city":   "Paris",
+				"label":  "Jane (Paris)",
+				"avatar": "https://example.com/Jane",
+			},
+			map[string]interface{}{
+				"name":   "John Doe",
+				"city":   nil,
+				"label":  "Johnny",
+				"avatar": "https://example.com/John",
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got: %v", expected, result.Data)
+	}
+}
+
+func TestDirectiveResolvers_Errors(t *testing.T) {
+	tests := []struct {
+		sdl      string
+		expected string
+	}{
+		{
+			`type User { name: String @resolve }`,
+			`Invalid @resolve directive of "User.name": exactly one of "source", "expr" and "resolver" must be set`,
+		},
+		{
+			`type User { name: String @resolve(source: "a", expr: "b") }`,
+			`Invalid @resolve directive of "User.name": exactly one of "source", "expr" and "resolver" must be set`,
+		},
+		{
+			`type User { name: String @resolve(source: "first name") }`,
+			`Invalid @resolve directive of "User.name": invalid source "first name"`,
+		},
+		{
+			`type User { name: String @resolve(expr: "first + ") }`,
+			`Invalid @resolve directive of "User.name": invalid expression "first + "`,
+		},
+		{
+			`extend type User { name: String @resolve(resolver: "unknown") }`,
+			`Invalid @resolve directive of "User.name": unknown resolver "unknown"`,
+		},
+	}
+	for _, test := range tests {
+		doc, err := parser.Parse(parser.ParseParams{Source: test.sdl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = graphql.DirectiveResolvers(doc, nil)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%v: expected error %q, got: %v", test.sdl, test.expected, err)
+		}
+	}
+}
@@ -1,5 +1,8 @@
 package graphql
 
+import "sort"
+
+// Directive locations of the specification, see DirectiveLocations.
 const (
 	// Operations
 	DirectiveLocationQuery              = "QUERY"
@@ -9,6 +12,7 @@ const (
 	DirectiveLocationFragmentDefinition = "FRAGMENT_DEFINITION"
 	DirectiveLocationFragmentSpread     = "FRAGMENT_SPREAD"
 	DirectiveLocationInlineFragment     = "INLINE_FRAGMENT"
+	DirectiveLocationVariableDefinition = "VARIABLE_DEFINITION"
 
 	// Schema Definitions
 	DirectiveLocationSchema               = "SCHEMA"
@@ -42,15 +46,34 @@ type Directive struct {
 	Locations   []string    `json:"locations"`
 	Args        []*Argument `json:"args"`
 
+	// Repeatable directives can be applied more than once at a location.
+	Repeatable bool `json:"isRepeatable"`
+
+	// CoerceVariable transforms the values of the variables whose definition
+	// has the directive, see VariableDirectiveFn.
+	CoerceVariable VariableDirectiveFn `json:"-"`
+
+	// Extensions hold custom metadata of the directive, for the tooling
+	// built on the schema. They are neither printed nor introspected.
+	Extensions map[string]interface{} `json:"-"`
+
 	err error
 }
 
+// VariableDirectiveFn transforms the coerced value of a variable whose
+// definition has the directive, given the arguments of the directive. The
+// returned error is reported as an invalid value of the variable.
+type VariableDirectiveFn func(value interface{}, args map[string]interface{}) (interface{}, error)
+
 // DirectiveConfig options for creating a new GraphQLDirective
 type DirectiveConfig struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	Locations   []string            `json:"locations"`
-	Args        FieldConfigArgument `json:"args"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Locations      []string               `json:"locations"`
+	Args           FieldConfigArgument    `json:"args"`
+	Repeatable     bool                   `json:"isRepeatable"`
+	CoerceVariable VariableDirectiveFn    `json:"-"`
+	Extensions     map[string]interface{} `json:"-"`
 }
 
 func NewDirective(config DirectiveConfig) *Directive {
@@ -71,6 +94,16 @@ func NewDirective(config DirectiveConfig) *Directive {
 		return dir
 	}
 
+	// Ensure locations are known
+	for _, location := range config.Locations {
+		if dir.err = invariantf(
+			LookupDirectiveLocation(location) != nil,
+			`Directive "@%v" has unknown location "%v".`, config.Name, location,
+		); dir.err != nil {
+			return dir
+		}
+	}
+
 	args := []*Argument{}
 
 	for argName, argConfig := range config.Args {
@@ -82,13 +115,20 @@ func NewDirective(config DirectiveConfig) *Directive {
 			PrivateDescription: argConfig.Description,
 			Type:               argConfig.Type,
 			DefaultValue:       argConfig.DefaultValue,
+			DeprecationReason:  argConfig.DeprecationReason,
+			Extensions:         argConfig.Extensions,
 		})
 	}
+	// the arguments are ordered by name, as printed
+	sort.Slice(args, func(i, j int) bool { return args[i].PrivateName < args[j].PrivateName })
 
 	dir.Name = config.Name
 	dir.Description = config.Description
 	dir.Locations = config.Locations
 	dir.Args = args
+	dir.Repeatable = config.Repeatable
+	dir.CoerceVariable = config.CoerceVariable
+	dir.Extensions = config.Extensions
 	return dir
 }
 
@@ -143,6 +183,32 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 	},
 	Locations: []string{
 		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
 		DirectiveLocationEnumValue,
 	},
 })
+
+// DeferDirective is used to defer the fields of fragments to subsequent
+// payloads of responses delivered incrementally, see ExecuteIncremental. It is
+// not part of SpecifiedDirectives and is added to the directives of the
+// schemas supporting it, other executions ignore it.
+var DeferDirective = NewDirective(DirectiveConfig{
+	Name: "defer",
+	Description: "Directs the executor to deliver this fragment in a subsequent payload " +
+		"of the response when the `if` argument is true.",
+	Args: FieldConfigArgument{
+		"if": &ArgumentConfig{
+			Type:         Boolean,
+			Description:  "Deferred unless false.",
+			DefaultValue: true,
+		},
+		"label": &ArgumentConfig{
+			Type:        String,
+			Description: "Identifies the payload of the fragment.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+})
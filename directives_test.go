@@ -6,6 +6,7 @@ import (
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -514,3 +515,25 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_NoIncludeOrSkip(t *testing.
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestDirectives_ShouldIncludeNode(t *testing.T) {
+	doc := testutil.TestParse(t, `query ($skip: Boolean!, $include: Boolean!) {
+		a
+		b @skip(if: $skip)
+		c: a @include(if: $include)
+		d: a @skip(if: false) @include(if: $include)
+		e: a @skip(if: $skip) @include(if: true)
+		... @skip(if: $skip) { f: a }
+	}`)
+	operation := doc.Definitions[0].(*ast.OperationDefinition)
+	variables := map[string]interface{}{"skip": true, "include": false}
+	expected := []bool{true, false, false, false, false, false}
+	for i, selection := range operation.SelectionSet.Selections {
+		if included := graphql.ShouldIncludeNode(selection, variables); included != expected[i] {
+			t.Fatalf("selection %d: expected %v, got %v", i, expected[i], included)
+		}
+	}
+	if !graphql.ShouldIncludeNode(operation, variables) {
+		t.Fatalf("expected the nodes without conditions to be included")
+	}
+}
@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// MultiResult is the result of the execution of all the operations of a
+// document, see DoAll.
+type MultiResult struct {
+	// Results are the results of the operations by operation name, the
+	// anonymous operation of a document being reported under the empty name.
+	Results map[string]*Result
+
+	// Errors are the errors of the document when it cannot be parsed, in which
+	// case no operation is executed.
+	Errors []gqlerrors.FormattedError
+}
+
+// HasErrors reports whether the document or any of its operations has errors.
+func (r *MultiResult) HasErrors() bool {
+	if len(r.Errors) > 0 {
+		return true
+	}
+	for _, result := range r.Results {
+		if result.HasErrors() {
+			return true
+		}
+	}
+	return false
+}
+
+// DoAll executes every operation of the document described by the given
+// params, e.g. for dashboards or test harnesses keeping related operations in
+// a single document. The document is parsed once, and each operation is then
+// executed as by DoContext with its name as p.OperationName: the mutations
+// serially in the order of the document, then the other operations
+// concurrently.
+func DoAll(ctx context.Context, p Params) *MultiResult {
+	document, err := parseAll(&p)
+	if err != nil {
+		return &MultiResult{Errors: gqlerrors.FormatErrors(err)}
+	}
+	p.parsed = &parsedRequest{request: p.RequestString, options: p.ParseOptions, document: document}
+
+	var mutations, others []string
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		name := ""
+		if operation.Name != nil {
+			name = operation.Name.Value
+		}
+		if operation.Operation == ast.OperationTypeMutation {
+			mutations = append(mutations, name)
+		} else {
+			others = append(others, name)
+		}
+	}
+
+	results := make(map[string]*Result, len(mutations)+len(others))
+	execute := func(name string) *Result {
+		operationParams := p
+		operationParams.OperationName = name
+		return DoContext(ctx, operationParams)
+	}
+	for _, name := range mutations {
+		results[name] = execute(name)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range others {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			result := execute(name)
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return &MultiResult{Results: results}
+}
+
+// parseAll parses the request of the params, unless parsed ahead of time.
+func parseAll(p *Params) (*ast.Document, error) {
+	if p.parsed != nil && p.parsed.request == p.RequestString && p.parsed.options == p.ParseOptions {
+		return p.parsed.document, nil
+	}
+	return parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(p.RequestString),
+			Name: "GraphQL request",
+		}),
+		Options: p.ParseOptions,
+	})
+}
@@ -0,0 +1,120 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func doAllSchema(t *testing.T, events *[]string) graphql.Schema {
+	var mu sync.Mutex
+	count := 0
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		*events = append(*events, event)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"count": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						record("count")
+						mu.Lock()
+						defer mu.Unlock()
+						return count, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"increment": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"by": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						record("increment")
+						mu.Lock()
+						defer mu.Unlock()
+						count += p.Args["by"].(int)
+						return count, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestDoAll_ExecutesEveryOperation(t *testing.T) {
+	events := []string{}
+	schema := doAllSchema(t, &events)
+	result := graphql.DoAll(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `
+			query Before { count }
+			mutation First { increment(by: 1) }
+			mutation Second { increment(by: 10) }
+			query After { count }
+		`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v %v", result.Errors, result.Results)
+	}
+	expected := map[string]interface{}{
+		"Before": map[string]interface{}{"count": 11},
+		"First":  map[string]interface{}{"increment": 1},
+		"Second": map[string]interface{}{"increment": 11},
+		"After":  map[string]interface{}{"count": 11},
+	}
+	if len(result.Results) != len(expected) {
+		t.Fatalf("expected %d results, got: %v", len(expected), result.Results)
+	}
+	for name, data := range expected {
+		if !reflect.DeepEqual(result.Results[name].Data, data) {
+			t.Fatalf("%s: expected %v, got: %v", name, data, result.Results[name].Data)
+		}
+	}
+	if !reflect.DeepEqual(events[:2], []string{"increment", "increment"}) {
+		t.Fatalf("expected mutations to run first, got: %v", events)
+	}
+}
+
+func TestDoAll_ReportsErrorsByOperation(t *testing.T) {
+	schema := doAllSchema(t, &[]string{})
+	result := graphql.DoAll(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Valid { count } query Invalid { unknown }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected document errors: %v", result.Errors)
+	}
+	if len(result.Results["Invalid"].Errors) == 0 {
+		t.Fatalf("expected errors for the invalid operation")
+	}
+	if len(result.Results["Valid"].Errors) == 0 {
+		t.Fatalf("expected the document validation errors for every operation")
+	}
+}
+
+func TestDoAll_ParseError(t *testing.T) {
+	schema := doAllSchema(t, &[]string{})
+	result := graphql.DoAll(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query A { count`,
+	})
+	if len(result.Errors) != 1 || len(result.Results) != 0 {
+		t.Fatalf("expected a single document error, got: %v %v", result.Errors, result.Results)
+	}
+}
@@ -0,0 +1,284 @@
+// Package docs renders reference documentation of a built schema, in
+// Markdown or as a static HTML page: its types, fields, arguments,
+// deprecations and directives, with links between types.
+//
+//     var buf bytes.Buffer
+//     if err := docs.WriteMarkdown(&buf, &schema, docs.Options{Title: "API"}); err != nil {
+//         return err
+//     }
+package docs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// Options configures the rendering of the documentation.
+type Options struct {
+	// Title is the title of the document, defaults to "Schema".
+	Title string
+
+	// IncludeIntrospection includes the introspection types, such as __Type.
+	IncludeIntrospection bool
+
+	// IncludeSpecified includes the scalars and directives of the
+	// specification, such as String and @include.
+	IncludeSpecified bool
+}
+
+// section is a group of types of the documentation.
+type section struct {
+	Title string
+	Types []*typeDoc
+}
+
+type document struct {
+	Title      string
+	Sections   []section
+	Directives []*directiveDoc
+}
+
+type typeDoc struct {
+	Name        string
+	Kind        string
+	Description string
+
+	Interfaces    []typeRef
+	PossibleTypes []typeRef
+	Fields        []*fieldDoc
+	InputFields   []*fieldDoc
+	EnumValues    []*enumValueDoc
+}
+
+type fieldDoc struct {
+	Name         string
+	Description  string
+	Type         typeRef
+	Args         []*fieldDoc
+	DefaultValue string
+	Deprecation  string
+	Example      string
+	Since        string
+}
+
+type enumValueDoc struct {
+	Name        string
+	Description string
+	Deprecation string
+}
+
+type directiveDoc struct {
+	Name        string
+	Description string
+	Locations   []string
+	Args        []*fieldDoc
+}
+
+// typeRef is a reference to a type, such as [User!]!, linking to the
+// documentation of the named type when it is documented.
+type typeRef struct {
+	Text   string
+	Anchor string
+}
+
+var specifiedScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// anchor returns the anchor of the documentation of the named type, the
+// lower case name as generated by the usual Markdown renderers.
+func anchor(name string) string {
+	return strings.ToLower(name)
+}
+
+// newDocument builds the model rendered by the writers.
+func newDocument(schema *graphql.Schema, opts Options) *document {
+	doc := &document{Title: opts.Title}
+	if doc.Title == "" {
+		doc.Title = "Schema"
+	}
+	documented := map[string]bool{}
+	names := []string{}
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") && !opts.IncludeIntrospection {
+			continue
+		}
+		if _, ok := ttype.(*graphql.Scalar); ok && specifiedScalars[name] && !opts.IncludeSpecified {
+			continue
+		}
+		documented[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ref := func(ttype graphql.Type) typeRef {
+		r := typeRef{Text: ttype.String()}
+		if named, ok := graphql.GetNamed(ttype).(graphql.Type); ok && documented[named.Name()] {
+			r.Anchor = anchor(named.Name())
+		}
+		return r
+	}
+	args := func(arguments []*graphql.Argument) []*fieldDoc {
+		docs := []*fieldDoc{}
+		for _, arg := range arguments {
+			docs = append(docs, &fieldDoc{
+				Name:         arg.Name(),
+				Description:  arg.Description(),
+				Type:         ref(arg.Type),
+				DefaultValue: defaultValue(arg.DefaultLiteral()),
+			})
+		}
+		sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+		return docs
+	}
+	fields := func(fieldMap graphql.FieldDefinitionMap) []*fieldDoc {
+		docs := []*fieldDoc{}
+		for _, field := range fieldMap {
+			example, since := graphql.FieldDocumentation(field)
+			docs = append(docs, &fieldDoc{
+				Name:        field.Name,
+				Description: field.Description,
+				Type:        ref(field.Type),
+				Args:        args(field.Args),
+				Deprecation: field.DeprecationReason,
+				Example:     example,
+				Since:       since,
+			})
+		}
+		sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+		return docs
+	}
+
+	bySection := map[string]*section{}
+	sectionTitles := []string{"Objects", "Interfaces", "Unions", "Enums", "Input objects", "Scalars"}
+	for _, title := range sectionTitles {
+		bySection[title] = &section{Title: title}
+	}
+	newTypeDoc := func(name string) (*typeDoc, string) {
+		ttype := schema.Type(name)
+		t := &typeDoc{Name: name, Description: ttype.Description()}
+		switch ttype := ttype.(type) {
+		case *graphql.Object:
+			t.Kind = "object"
+			t.Fields = fields(ttype.Fields())
+			for _, iface := range ttype.Interfaces() {
+				t.Interfaces = append(t.Interfaces, ref(iface))
+			}
+			return t, "Objects"
+		case *graphql.Interface:
+			t.Kind = "interface"
+			t.Fields = fields(ttype.Fields())
+			for _, object := range schema.PossibleTypes(ttype) {
+				t.PossibleTypes = append(t.PossibleTypes, ref(object))
+			}
+			sort.Slice(t.PossibleTypes, func(i, j int) bool { return t.PossibleTypes[i].Text < t.PossibleTypes[j].Text })
+			return t, "Interfaces"
+		case *graphql.Union:
+			t.Kind = "union"
+			for _, object := range ttype.Types() {
+				t.PossibleTypes = append(t.PossibleTypes, ref(object))
+			}
+			return t, "Unions"
+		case *graphql.Enum:
+			t.Kind = "enum"
+			for _, value := range ttype.Values() {
+				t.EnumValues = append(t.EnumValues, &enumValueDoc{
+					Name:        value.Name,
+					Description: value.Description,
+					Deprecation: value.DeprecationReason,
+				})
+			}
+			sort.Slice(t.EnumValues, func(i, j int) bool { return t.EnumValues[i].Name < t.EnumValues[j].Name })
+			return t, "Enums"
+		case *graphql.InputObject:
+			t.Kind = "input"
+			for _, field := range ttype.Fields() {
+				t.InputFields = append(t.InputFields, &fieldDoc{
+					Name:         field.Name(),
+					Description:  field.Description(),
+					Type:         ref(field.Type),
+					DefaultValue: defaultValue(field.DefaultLiteral()),
+				})
+			}
+			sort.Slice(t.InputFields, func(i, j int) bool { return t.InputFields[i].Name < t.InputFields[j].Name })
+			return t, "Input objects"
+		}
+		t.Kind = "scalar"
+		return t, "Scalars"
+	}
+
+	// the root types are documented first, in the order of the operations
+	operations := section{Title: "Operations"}
+	for _, root := range rootTypes(schema) {
+		t, _ := newTypeDoc(root.Name())
+		operations.Types = append(operations.Types, t)
+	}
+	for _, name := range names {
+		if isRoot(schema, name) {
+			continue
+		}
+		t, title := newTypeDoc(name)
+		bySection[title].Types = append(bySection[title].Types, t)
+	}
+	doc.Sections = append(doc.Sections, operations)
+	for _, title := range sectionTitles {
+		if s := bySection[title]; len(s.Types) > 0 {
+			doc.Sections = append(doc.Sections, *s)
+		}
+	}
+
+	specified := map[*graphql.Directive]bool{}
+	for _, directive := range graphql.SpecifiedDirectives {
+		specified[directive] = true
+	}
+	for _, directive := range schema.Directives() {
+		if specified[directive] && !opts.IncludeSpecified {
+			continue
+		}
+		doc.Directives = append(doc.Directives, &directiveDoc{
+			Name:        directive.Name,
+			Description: directive.Description,
+			Locations:   directive.Locations,
+			Args:        args(directive.Args),
+		})
+	}
+	sort.Slice(doc.Directives, func(i, j int) bool { return doc.Directives[i].Name < doc.Directives[j].Name })
+	return doc
+}
+
+// rootTypes returns the defined root operation types of the schema.
+func rootTypes(schema *graphql.Schema) []*graphql.Object {
+	roots := []*graphql.Object{}
+	for _, root := range []*graphql.Object{schema.QueryType(), schema.MutationType(), schema.SubscriptionType()} {
+		if root != nil {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+func isRoot(schema *graphql.Schema, name string) bool {
+	for _, root := range rootTypes(schema) {
+		if root.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultValue formats the literal of a default value, empty if there is none.
+func defaultValue(literal ast.Value) string {
+	if literal == nil {
+		return ""
+	}
+	return fmt.Sprint(printer.Print(literal))
+}
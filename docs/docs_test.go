@@ -0,0 +1,175 @@
+package docs_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/docs"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func docsTestSchema(t *testing.T) *graphql.Schema {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Node",
+		Description: "An object with an ID.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	role := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Role",
+		Values: graphql.EnumValueConfigMap{
+			"ADMIN": &graphql.EnumValueConfig{Value: "admin", Description: "Can do anything."},
+			"ROOT":  &graphql.EnumValueConfig{Value: "root", DeprecationReason: "Use ADMIN."},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "A <registered> user.",
+		Interfaces:  []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"role":     &graphql.Field{Type: role, AppliedDirectives: []*graphql.AppliedDirective{graphql.ExampleAnnotation("ADMIN")}},
+			"fullName": &graphql.Field{Type: graphql.String, DeprecationReason: "Use name."},
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"first": &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 10},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type:        graphql.NewList(graphql.NewNonNull(user)),
+					Description: "The users.",
+					AppliedDirectives: []*graphql.AppliedDirective{
+						graphql.SinceAnnotation("1.2"),
+					},
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filter, Description: "Filters the users."},
+					},
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:        "cached",
+				Description: "Caches the field.",
+				Locations:   []string{graphql.DirectiveLocationField, graphql.DirectiveLocationQuery},
+				Args: graphql.FieldConfigArgument{
+					"ttl": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 60},
+				},
+			}),
+		}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := docs.WriteMarkdown(&buf, docsTestSchema(t), docs.Options{Title: "API"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# API\n" +
+		"\n## Operations\n" +
+		"\n### Query\n" +
+		"\n*object*\n" +
+		"\n#### Fields\n\n" +
+		"- `users`: [`[User!]`](#user) — The users.\n" +
+		"  - Arguments:\n" +
+		"    - `filter`: [`UserFilter`](#userfilter) — Filters the users.\n" +
+		"  - **Since:** 1.2\n" +
+		"\n## Objects\n" +
+		"\n### User\n" +
+		"\n*object*\n" +
+		"\nA <registered> user.\n" +
+		"\n**Implements:** [`Node`](#node)\n" +
+		"\n#### Fields\n\n" +
+		"- `fullName`: `String`\n" +
+		"  - **Deprecated:** Use name.\n" +
+		"- `id`: `ID!`\n" +
+		"- `role`: [`Role`](#role)\n" +
+		"  - **Example:** `ADMIN`\n" +
+		"\n## Interfaces\n" +
+		"\n### Node\n" +
+		"\n*interface*\n" +
+		"\nAn object with an ID.\n" +
+		"\n**Possible types:** [`User`](#user)\n" +
+		"\n#### Fields\n\n" +
+		"- `id`: `ID!`\n" +
+		"\n## Enums\n" +
+		"\n### Role\n" +
+		"\n*enum*\n" +
+		"\n#### Values\n\n" +
+		"- `ADMIN` — Can do anything.\n" +
+		"- `ROOT`\n" +
+		"  - **Deprecated:** Use ADMIN.\n" +
+		"\n## Input objects\n" +
+		"\n### UserFilter\n" +
+		"\n*input*\n" +
+		"\n#### Input fields\n\n" +
+		"- `first`: `Int` = `10`\n" +
+		"\n## Directives\n" +
+		"\n### @cached\n" +
+		"\nCaches the field.\n" +
+		"\n**Locations:** FIELD, QUERY\n" +
+		"\n#### Arguments\n\n" +
+		"- `ttl`: `Int` = `60`\n"
+	if buf.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, buf.String()))
+	}
+}
+
+func TestWriteMarkdown_IncludesSpecified(t *testing.T) {
+	var buf bytes.Buffer
+	if err := docs.WriteMarkdown(&buf, docsTestSchema(t), docs.Options{IncludeSpecified: true, IncludeIntrospection: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"# Schema\n",
+		"- `id`: [`ID!`](#id)\n",
+		"\n### @include\n",
+		"\n### __Type\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected the documentation to contain %q, got:\n%v", expected, buf.String())
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := docs.WriteHTML(&buf, docsTestSchema(t), docs.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"<title>Schema</title>",
+		`<article id="user">`,
+		"<p>A &lt;registered&gt; user.</p>",
+		`<dt><code>users</code>: <a href="#user"><code>[User!]</code></a></dt>`,
+		`<li><code>filter</code>: <a href="#userfilter"><code>UserFilter</code></a> — Filters the users.</li>`,
+		"<dd><strong>Deprecated:</strong> Use name.</dd>",
+		"<dd><strong>Example:</strong> <code>ADMIN</code></dd>",
+		"<dd><strong>Since:</strong> 1.2</dd>",
+		`<p>Possible types: <a href="#user"><code>User</code></a></p>`,
+		`<dt><code>first</code>: <code>Int</code> = <code>10</code></dt>`,
+		`<article id="directive-cached">`,
+		"<p>Locations: FIELD, QUERY</p>",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected the documentation to contain %q, got:\n%v", expected, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "__Type") {
+		t.Fatalf("expected the introspection types to be excluded")
+	}
+}
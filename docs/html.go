@@ -0,0 +1,119 @@
+package docs
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WriteHTML writes the documentation of the schema as a static HTML page, an
+// element per type whose id is the lower case type name, such as #user.
+func WriteHTML(w io.Writer, schema *graphql.Schema, opts Options) error {
+	return htmlTemplate.Execute(w, newDocument(schema, opts))
+}
+
+// fieldList is a titled list of fields rendered by the "fields" template.
+type fieldList struct {
+	Title  string
+	Fields []*fieldDoc
+}
+
+var htmlTemplate = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"anchor": anchor,
+	"fields": func(title string, fields []*fieldDoc) fieldList {
+		return fieldList{Title: title, Fields: fields}
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- range .Sections}}
+<section>
+<h2>{{.Title}}</h2>
+{{- range .Types}}
+<article id="{{anchor .Name}}">
+<h3>{{.Name}} <small>{{.Kind}}</small></h3>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Interfaces}}
+<p>Implements: {{range $i, $ref := .Interfaces}}{{if $i}}, {{end}}{{template "ref" $ref}}{{end}}</p>
+{{- end}}
+{{- if .PossibleTypes}}
+<p>Possible types: {{range $i, $ref := .PossibleTypes}}{{if $i}}, {{end}}{{template "ref" $ref}}{{end}}</p>
+{{- end}}
+{{- template "fields" fields "Fields" .Fields}}
+{{- template "fields" fields "Input fields" .InputFields}}
+{{- if .EnumValues}}
+<h4>Values</h4>
+<dl>
+{{- range .EnumValues}}
+<dt><code>{{.Name}}</code></dt>
+{{- if .Description}}
+<dd>{{.Description}}</dd>
+{{- end}}
+{{- if .Deprecation}}
+<dd><strong>Deprecated:</strong> {{.Deprecation}}</dd>
+{{- end}}
+{{- end}}
+</dl>
+{{- end}}
+</article>
+{{- end}}
+</section>
+{{- end}}
+{{- if .Directives}}
+<section>
+<h2>Directives</h2>
+{{- range .Directives}}
+<article id="directive-{{anchor .Name}}">
+<h3>@{{.Name}}</h3>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+<p>Locations: {{range $i, $location := .Locations}}{{if $i}}, {{end}}{{$location}}{{end}}</p>
+{{- template "fields" fields "Arguments" .Args}}
+</article>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+{{define "ref"}}{{if .Anchor}}<a href="#{{.Anchor}}"><code>{{.Text}}</code></a>{{else}}<code>{{.Text}}</code>{{end}}{{end}}
+{{- define "fields"}}
+{{- if .Fields}}
+<h4>{{.Title}}</h4>
+<dl>
+{{- range .Fields}}
+<dt><code>{{.Name}}</code>: {{template "ref" .Type}}{{if .DefaultValue}} = <code>{{.DefaultValue}}</code>{{end}}</dt>
+{{- if .Description}}
+<dd>{{.Description}}</dd>
+{{- end}}
+{{- if .Args}}
+<dd>Arguments:
+<ul>
+{{- range .Args}}
+<li><code>{{.Name}}</code>: {{template "ref" .Type}}{{if .DefaultValue}} = <code>{{.DefaultValue}}</code>{{end}}{{if .Description}} — {{.Description}}{{end}}</li>
+{{- end}}
+</ul>
+</dd>
+{{- end}}
+{{- if .Example}}
+<dd><strong>Example:</strong> <code>{{.Example}}</code></dd>
+{{- end}}
+{{- if .Since}}
+<dd><strong>Since:</strong> {{.Since}}</dd>
+{{- end}}
+{{- if .Deprecation}}
+<dd><strong>Deprecated:</strong> {{.Deprecation}}</dd>
+{{- end}}
+{{- end}}
+</dl>
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,121 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WriteMarkdown writes the documentation of the schema as Markdown, a
+// heading per type whose anchor is the lower case type name, such as #user.
+func WriteMarkdown(w io.Writer, schema *graphql.Schema, opts Options) error {
+	doc := newDocument(schema, opts)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %v\n", doc.Title)
+	for _, s := range doc.Sections {
+		fmt.Fprintf(&b, "\n## %v\n", s.Title)
+		for _, t := range s.Types {
+			writeMarkdownType(&b, t)
+		}
+	}
+	if len(doc.Directives) > 0 {
+		b.WriteString("\n## Directives\n")
+		for _, d := range doc.Directives {
+			fmt.Fprintf(&b, "\n### @%v\n", d.Name)
+			if d.Description != "" {
+				fmt.Fprintf(&b, "\n%v\n", d.Description)
+			}
+			fmt.Fprintf(&b, "\n**Locations:** %v\n", strings.Join(d.Locations, ", "))
+			writeMarkdownFields(&b, "Arguments", d.Args)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownType(b *strings.Builder, t *typeDoc) {
+	fmt.Fprintf(b, "\n### %v\n", t.Name)
+	fmt.Fprintf(b, "\n*%v*\n", t.Kind)
+	if t.Description != "" {
+		fmt.Fprintf(b, "\n%v\n", t.Description)
+	}
+	if len(t.Interfaces) > 0 {
+		fmt.Fprintf(b, "\n**Implements:** %v\n", markdownRefs(t.Interfaces))
+	}
+	if len(t.PossibleTypes) > 0 {
+		fmt.Fprintf(b, "\n**Possible types:** %v\n", markdownRefs(t.PossibleTypes))
+	}
+	writeMarkdownFields(b, "Fields", t.Fields)
+	writeMarkdownFields(b, "Input fields", t.InputFields)
+	if len(t.EnumValues) > 0 {
+		b.WriteString("\n#### Values\n\n")
+		for _, v := range t.EnumValues {
+			fmt.Fprintf(b, "- `%v`", v.Name)
+			if v.Description != "" {
+				fmt.Fprintf(b, " — %v", inline(v.Description))
+			}
+			b.WriteString("\n")
+			if v.Deprecation != "" {
+				fmt.Fprintf(b, "  - **Deprecated:** %v\n", inline(v.Deprecation))
+			}
+		}
+	}
+}
+
+func writeMarkdownFields(b *strings.Builder, title string, fields []*fieldDoc) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n#### %v\n\n", title)
+	for _, f := range fields {
+		writeMarkdownField(b, "", f)
+		if len(f.Args) > 0 {
+			b.WriteString("  - Arguments:\n")
+			for _, arg := range f.Args {
+				writeMarkdownField(b, "    ", arg)
+			}
+		}
+		if f.Example != "" {
+			fmt.Fprintf(b, "  - **Example:** `%v`\n", inline(f.Example))
+		}
+		if f.Since != "" {
+			fmt.Fprintf(b, "  - **Since:** %v\n", f.Since)
+		}
+		if f.Deprecation != "" {
+			fmt.Fprintf(b, "  - **Deprecated:** %v\n", inline(f.Deprecation))
+		}
+	}
+}
+
+func writeMarkdownField(b *strings.Builder, indent string, f *fieldDoc) {
+	fmt.Fprintf(b, "%v- `%v`: %v", indent, f.Name, markdownRef(f.Type))
+	if f.DefaultValue != "" {
+		fmt.Fprintf(b, " = `%v`", f.DefaultValue)
+	}
+	if f.Description != "" {
+		fmt.Fprintf(b, " — %v", inline(f.Description))
+	}
+	b.WriteString("\n")
+}
+
+func markdownRef(ref typeRef) string {
+	if ref.Anchor == "" {
+		return fmt.Sprintf("`%v`", ref.Text)
+	}
+	return fmt.Sprintf("[`%v`](#%v)", ref.Text, ref.Anchor)
+}
+
+func markdownRefs(refs []typeRef) string {
+	texts := []string{}
+	for _, ref := range refs {
+		texts = append(texts, markdownRef(ref))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// inline joins the lines of a description for list items.
+func inline(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ExampleDirective is the @example documentation directive, an example value
+// of a field, applied with ExampleAnnotation.
+//
+// Like SinceDirective it is not part of SpecifiedDirectives, schemas exposing
+// it in SDL add it to SchemaConfig.Directives.
+var ExampleDirective = NewDirective(DirectiveConfig{
+	Name:        "example",
+	Description: "Documents an example `value` of the field, such as a JSON literal.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+	Args: FieldConfigArgument{
+		"value": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The example value.",
+		},
+	},
+})
+
+// SinceDirective is the @since documentation directive, the version of the
+// schema which introduced a field, applied with SinceAnnotation.
+var SinceDirective = NewDirective(DirectiveConfig{
+	Name:        "since",
+	Description: "Documents the `version` of the schema which introduced the field.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+	Args: FieldConfigArgument{
+		"version": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The version which introduced the field.",
+		},
+	},
+})
+
+// ExampleAnnotation returns the @example directive applied to a field with the
+// given example value, documented in introspection, e.g.
+//
+//	&graphql.Field{
+//		Type:              graphql.Float,
+//		AppliedDirectives: []*graphql.AppliedDirective{graphql.ExampleAnnotation("9.99")},
+//	}
+func ExampleAnnotation(value string) *AppliedDirective {
+	return &AppliedDirective{
+		Name: ExampleDirective.Name,
+		Args: map[string]interface{}{"value": value},
+	}
+}
+
+// SinceAnnotation returns the @since directive applied to a field with the
+// version of the schema which introduced it, documented in introspection.
+func SinceAnnotation(version string) *AppliedDirective {
+	return &AppliedDirective{
+		Name: SinceDirective.Name,
+		Args: map[string]interface{}{"version": version},
+	}
+}
+
+// FieldDocumentation returns the example and since version of the @example
+// and @since directives applied to the field, empty when the field does not
+// have the directive, see ExampleAnnotation and SinceAnnotation.
+func FieldDocumentation(field *FieldDefinition) (example string, since string) {
+	if field == nil {
+		return "", ""
+	}
+	for _, directive := range field.AppliedDirectives {
+		if directive == nil {
+			continue
+		}
+		switch directive.Name {
+		case ExampleDirective.Name:
+			example, _ = directive.Args["value"].(string)
+		case SinceDirective.Name:
+			since, _ = directive.Args["version"].(string)
+		}
+	}
+	return example, since
+}
+
+// DocumentationAnnotations returns the example and since version of the
+// @example and @since directives of a field definition parsed from SDL,
+// empty when the field does not have the directive.
+func DocumentationAnnotations(fieldAST *ast.FieldDefinition) (example string, since string) {
+	if fieldAST == nil {
+		return "", ""
+	}
+	for _, directive := range fieldAST.Directives {
+		if directive == nil || directive.Name == nil {
+			continue
+		}
+		switch directive.Name.Value {
+		case ExampleDirective.Name:
+			example = directiveStringArgument(directive, "value")
+		case SinceDirective.Name:
+			since = directiveStringArgument(directive, "version")
+		}
+	}
+	return example, since
+}
+
+func directiveStringArgument(directive *ast.Directive, name string) string {
+	for _, arg := range directive.Arguments {
+		if arg.Name == nil || arg.Name.Value != name {
+			continue
+		}
+		if value, ok := arg.Value.(*ast.StringValue); ok {
+			return value.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestDocumentationAnnotations_AreIntrospected(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"price": &graphql.Field{
+					Type: graphql.Float,
+					AppliedDirectives: []*graphql.AppliedDirective{
+						graphql.ExampleAnnotation("9.99"),
+						graphql.SinceAnnotation("2.1"),
+					},
+				},
+				"name": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{graphql.ExampleDirective, graphql.SinceDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "Query") { fields { name example since } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"name": "name", "example": nil, "since": nil},
+					map[string]interface{}{"name": "price", "example": "9.99", "since": "2.1"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDocumentationAnnotations_ReadsSDLDirectives(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `type Query {
+  price: Float @example(value: "9.99") @since(version: "2.1")
+  name: String @deprecated
+}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := doc.Definitions[0].(*ast.ObjectDefinition).Fields
+	if example, since := graphql.DocumentationAnnotations(fields[0]); example != "9.99" || since != "2.1" {
+		t.Fatalf("unexpected annotations: %q, %q", example, since)
+	}
+	if example, since := graphql.DocumentationAnnotations(fields[1]); example != "" || since != "" {
+		t.Fatalf("unexpected annotations: %q, %q", example, since)
+	}
+}
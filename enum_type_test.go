@@ -179,7 +179,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptStringLiterals(t *testing.T) {
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromEnum\" has invalid value \"GREEN\".\nExpected type \"Color\", found \"GREEN\".",
+				Message: "Expected type \"Color\", found \"GREEN\".",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
@@ -209,7 +209,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueInPlaceOfEnumLiteral(t
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromEnum\" has invalid value 1.\nExpected type \"Color\", found 1.",
+				Message: "Expected type \"Color\", found 1.",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
@@ -228,7 +228,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptEnumLiteralInPlaceOfInt(t *testing.T
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromInt\" has invalid value GREEN.\nExpected type \"Int\", found GREEN.",
+				Message: "Expected type \"Int\", found GREEN.",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
@@ -460,3 +460,102 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNilPointer(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func enumTypeTestOutputSchema(t *testing.T, config graphql.EnumConfig) graphql.Schema {
+	config.Name = "Level"
+	config.Values = graphql.EnumValueConfigMap{
+		"LOW":   &graphql.EnumValueConfig{Value: 1},
+		"HIGH":  &graphql.EnumValueConfig{Value: 2},
+		"OTHER": &graphql.EnumValueConfig{Value: 0},
+		"MAX": &graphql.EnumValueConfig{
+			Value:             3,
+			DeprecationReason: "Use HIGH.",
+			Metadata:          map[string]interface{}{"replacement": "HIGH"},
+		},
+	}
+	level := graphql.NewEnum(config)
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"level": &graphql.Field{
+					Type: level,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"], nil
+					},
+				},
+				"replacement": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"level": &graphql.ArgumentConfig{Type: level},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if value := level.ValueFor(p.Args["level"]); value != nil {
+							return value.Metadata["replacement"], nil
+						}
+						return nil, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestTypeSystem_EnumValues_StrictEnumReportsUnknownValues(t *testing.T) {
+	query := `{ known: level(value: 2) unknown: level(value: 7) }`
+	result := g(t, graphql.Params{Schema: enumTypeTestOutputSchema(t, graphql.EnumConfig{}), RequestString: query})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"known": "HIGH", "unknown": nil},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = g(t, graphql.Params{Schema: enumTypeTestOutputSchema(t, graphql.EnumConfig{Strict: true}), RequestString: query})
+	expected = &graphql.Result{
+		Data: map[string]interface{}{"known": "HIGH", "unknown": nil},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Enum "Level" cannot represent value: 7`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 26}},
+				Path:      []interface{}{"unknown"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_EnumValues_OnUnknownEnumValueProvidesFallback(t *testing.T) {
+	schema := enumTypeTestOutputSchema(t, graphql.EnumConfig{
+		Strict: true,
+		OnUnknownEnumValue: func(value interface{}) (string, error) {
+			return "OTHER", nil
+		},
+	})
+	result := g(t, graphql.Params{Schema: schema, RequestString: `{ known: level(value: 2) unknown: level(value: 7) }`})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"known": "HIGH", "unknown": "OTHER"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_EnumValues_MetadataIsAvailableToResolvers(t *testing.T) {
+	result := g(t, graphql.Params{Schema: enumTypeTestOutputSchema(t, graphql.EnumConfig{}), RequestString: `{ replacement(level: MAX) }`})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"replacement": "HIGH"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
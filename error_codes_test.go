@@ -0,0 +1,147 @@
+package graphql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+func errorCodesTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"secret": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						err := gqlerrors.Forbidden("not allowed to read the secret")
+						err.Ext = map[string]interface{}{"reason": "scope"}
+						return nil, err
+					},
+				},
+				"failing": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("failed")
+					},
+				},
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	return schema
+}
+
+func TestErrorCodes(t *testing.T) {
+	schema := errorCodesTestSchema(t)
+	tests := []struct {
+		name          string
+		params        graphql.Params
+		expectedCode  string
+		expectedExtra map[string]interface{}
+	}{
+		{
+			name:         "parse error",
+			params:       graphql.Params{RequestString: `{ echo(`},
+			expectedCode: gqlerrors.CodeParseFailed,
+		},
+		{
+			name:         "validation error",
+			params:       graphql.Params{RequestString: `{ unknown }`},
+			expectedCode: gqlerrors.CodeValidationFailed,
+		},
+		{
+			name: "invalid variable",
+			params: graphql.Params{
+				RequestString:  `query ($v: Int) { echo(value: $v) }`,
+				VariableValues: map[string]interface{}{"v": "one"},
+			},
+			expectedCode: gqlerrors.CodeBadUserInput,
+		},
+		{
+			name: "missing variable",
+			params: graphql.Params{
+				RequestString: `query ($v: Int!) { echo(value: $v) }`,
+			},
+			expectedCode: gqlerrors.CodeBadUserInput,
+		},
+		{
+			name: "invalid JSON variables",
+			params: graphql.Params{
+				RequestString: `query ($v: Int) { echo(value: $v) }`,
+				RawVariables:  []byte(`{"v":`),
+			},
+			expectedCode: gqlerrors.CodeBadUserInput,
+		},
+		{
+			name:          "coded resolver error",
+			params:        graphql.Params{RequestString: `{ secret }`},
+			expectedCode:  gqlerrors.CodeForbidden,
+			expectedExtra: map[string]interface{}{"reason": "scope"},
+		},
+		{
+			name:         "resolver error",
+			params:       graphql.Params{RequestString: `{ failing }`},
+			expectedCode: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.params.Schema = schema
+			result := graphql.Do(test.params)
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected one error, got: %v", result.Errors)
+			}
+			err := result.Errors[0]
+			if code := gqlerrors.Code(err); code != test.expectedCode {
+				t.Fatalf("expected code %q, got: %q", test.expectedCode, code)
+			}
+			for k, v := range test.expectedExtra {
+				if err.Extensions[k] != v {
+					t.Fatalf("expected extension %q to be %v, got: %v", k, v, err.Extensions[k])
+				}
+			}
+		})
+	}
+}
+
+func TestErrorCodes_WithCodeKeepsExistingCodes(t *testing.T) {
+	errs := []gqlerrors.FormattedError{
+		gqlerrors.NewFormattedError("no code"),
+		gqlerrors.FormatError(gqlerrors.Unauthenticated("who are you?")),
+	}
+	coded := gqlerrors.WithCode(errs, gqlerrors.CodeInternalServerError)
+	if code := gqlerrors.Code(coded[0]); code != gqlerrors.CodeInternalServerError {
+		t.Fatalf("expected code %q, got: %q", gqlerrors.CodeInternalServerError, code)
+	}
+	if code := gqlerrors.Code(coded[1]); code != gqlerrors.CodeUnauthenticated {
+		t.Fatalf("expected code %q, got: %q", gqlerrors.CodeUnauthenticated, code)
+	}
+	if errs[0].Extensions != nil {
+		t.Fatalf("expected the given errors to be left unchanged, got: %v", errs[0].Extensions)
+	}
+}
+
+func TestErrorCodes_CodedErrorUnwraps(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := gqlerrors.WrapWithCode(gqlerrors.CodeInternalServerError, cause)
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the coded error to wrap its cause")
+	}
+	if err.Error() != cause.Error() {
+		t.Fatalf("expected message %q, got: %q", cause.Error(), err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestExcerpt_WindowAroundLocation(t *testing.T) {
+	src := source.NewSource(&source.Source{Body: []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj")})
+	tests := []struct {
+		name     string
+		location location.SourceLocation
+		opts     gqlerrors.ExcerptOptions
+		expected string
+	}{
+		{
+			name:     "default context",
+			location: location.SourceLocation{Line: 5, Column: 1},
+			expected: "4: d\n5: e\n   ^\n6: f\n",
+		},
+		{
+			name:     "larger context",
+			location: location.SourceLocation{Line: 9, Column: 1},
+			opts:     gqlerrors.ExcerptOptions{Context: 2},
+			expected: " 7: g\n 8: h\n 9: i\n    ^\n10: j\n",
+		},
+		{
+			name:     "no context",
+			location: location.SourceLocation{Line: 2, Column: 1},
+			opts:     gqlerrors.ExcerptOptions{Context: -1},
+			expected: "2: b\n   ^\n",
+		},
+		{
+			name:     "out of range",
+			location: location.SourceLocation{Line: 11, Column: 1},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if excerpt := gqlerrors.Excerpt(src, test.location, test.opts); excerpt != test.expected {
+				t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, excerpt))
+			}
+		})
+	}
+}
+
+func TestExcerpt_ExpandsTabs(t *testing.T) {
+	src := source.NewSource(&source.Source{Body: []byte("{\n\t\tfield\n}")})
+	l := location.SourceLocation{Line: 2, Column: 3}
+
+	expected := "2:         field\n           ^^^^^\n"
+	excerpt := gqlerrors.Excerpt(src, l, gqlerrors.ExcerptOptions{Context: -1, Length: 5, TabWidth: gqlerrors.DefaultTabWidth})
+	if excerpt != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, excerpt))
+	}
+
+	expected = "2:     field\n       ^\n"
+	excerpt = gqlerrors.Excerpt(src, l, gqlerrors.ExcerptOptions{Context: -1, TabWidth: 2})
+	if excerpt != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, excerpt))
+	}
+}
+
+func TestExcerpt_EscapesTabsUnlessExpanded(t *testing.T) {
+	src := source.NewSource(&source.Source{Body: []byte("{\n\t\tfield\n}")})
+	l := location.SourceLocation{Line: 2, Column: 3}
+
+	expected := "2: \\u0009\\u0009field\n     ^\n"
+	excerpt := gqlerrors.Excerpt(src, l, gqlerrors.ExcerptOptions{Context: -1})
+	if excerpt != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, excerpt))
+	}
+}
+
+func TestExcerpt_Color(t *testing.T) {
+	src := source.NewSource(&source.Source{Body: []byte("{ field }")})
+	expected := "\x1b[2m1:\x1b[0m { field }\n     \x1b[1;31m^\x1b[0m\n"
+	excerpt := gqlerrors.Excerpt(src, location.SourceLocation{Line: 1, Column: 3}, gqlerrors.ExcerptOptions{Color: true})
+	if excerpt != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, excerpt))
+	}
+}
+
+func TestExcerpt_MarksTheNodesOfValidationErrors(t *testing.T) {
+	src := source.NewSource(&source.Source{Body: []byte("{\n  unknownField\n}")})
+	AST, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.ValidateDocument(&testutil.StarWarsSchema, AST, nil)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got: %v", result.Errors)
+	}
+	gqlErr, ok := result.Errors[0].OriginalError().(*gqlerrors.Error)
+	if !ok {
+		t.Fatalf("expected a *gqlerrors.Error, got: %T", result.Errors[0].OriginalError())
+	}
+	expected := "1: {\n2:   unknownField\n     ^^^^^^^^^^^^\n3: }\n"
+	if excerpt := gqlErr.Excerpt(gqlerrors.ExcerptOptions{}); excerpt != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, excerpt))
+	}
+}
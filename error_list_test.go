@@ -0,0 +1,142 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func errorAt(message string, line, column int) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:   message,
+		Locations: []location.SourceLocation{{Line: line, Column: column}},
+	}
+}
+
+func errorListMessages(l gqlerrors.List) []string {
+	messages := []string{}
+	for _, err := range l {
+		messages = append(messages, err.Message)
+	}
+	return messages
+}
+
+func TestErrorList_SortsByLocation(t *testing.T) {
+	list := gqlerrors.List{
+		gqlerrors.NewFormattedError("no location"),
+		errorAt("b", 2, 1),
+		errorAt("c", 1, 5),
+		errorAt("a", 1, 5),
+		errorAt("d", 1, 1),
+	}
+	sorted := list.Sort()
+	expected := []string{"d", "c", "a", "b", "no location"}
+	if messages := errorListMessages(sorted); !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+	if list[0].Message != "no location" {
+		t.Fatalf("expected the list to be left unchanged, got: %v", errorListMessages(list))
+	}
+}
+
+func TestErrorList_Dedupe(t *testing.T) {
+	list := gqlerrors.List{
+		errorAt("a", 1, 1),
+		errorAt("a", 1, 2),
+		errorAt("a", 1, 1),
+		errorAt("b", 1, 1),
+	}
+	deduped := list.Dedupe()
+	expected := gqlerrors.List{
+		errorAt("a", 1, 1),
+		errorAt("a", 1, 2),
+		errorAt("b", 1, 1),
+	}
+	if !testutil.EqualFormattedErrors(expected, deduped) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, deduped))
+	}
+}
+
+func TestErrorList_Limit(t *testing.T) {
+	list := gqlerrors.List{errorAt("a", 1, 1), errorAt("b", 1, 2), errorAt("c", 1, 3)}
+	if limited := list.Limit(2); len(limited) != 2 || limited[1].Message != "b" {
+		t.Fatalf("expected the first two errors, got: %v", errorListMessages(limited))
+	}
+	if limited := list.Limit(5); len(limited) != 3 {
+		t.Fatalf("expected the whole list, got: %v", errorListMessages(limited))
+	}
+	if limited := list.Limit(-1); len(limited) != 3 {
+		t.Fatalf("expected the whole list, got: %v", errorListMessages(limited))
+	}
+}
+
+func TestErrorList_AppendFlattensLists(t *testing.T) {
+	var list gqlerrors.List
+	if list.Err() != nil {
+		t.Fatalf("expected an empty list to be a nil error")
+	}
+	list = list.Append(errors.New("a"), nil, gqlerrors.List{errorAt("b", 1, 1), errorAt("c", 1, 2)})
+	expected := []string{"a", "b", "c"}
+	if messages := errorListMessages(list); !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+	if list.Error() != "a\nb\nc" {
+		t.Fatalf("unexpected message: %q", list.Error())
+	}
+	if formatted := gqlerrors.FormatErrors(list.Err()); len(formatted) != 3 {
+		t.Fatalf("expected the list to be formatted as 3 errors, got: %v", formatted)
+	}
+}
+
+func TestErrorList_ReportsAllInvalidVariables(t *testing.T) {
+	schema := errorCodesTestSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($a: Int!, $b: Int, $c: Int!) { echo(value: $b) a: echo(value: $a) c: echo(value: $c) }`,
+		VariableValues: map[string]interface{}{
+			"b": "two",
+		},
+	})
+	expected := []string{
+		`Variable "$a" of required type "Int!" was not provided.`,
+		"Variable \"$b\" got invalid value \"two\".\nExpected type \"Int\", found \"two\".",
+		`Variable "$c" of required type "Int!" was not provided.`,
+	}
+	messages := []string{}
+	for _, err := range result.Errors {
+		messages = append(messages, err.Message)
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+}
+
+func TestErrorList_ValidationErrorsAreSorted(t *testing.T) {
+	schema := errorCodesTestSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `
+			query ($unused: Int) {
+				b: unknownB
+				a: unknownA
+			}
+		`,
+	})
+	expected := []string{
+		`Variable "$unused" is never used.`,
+		`Cannot query field "unknownB" on type "Query".`,
+		`Cannot query field "unknownA" on type "Query".`,
+	}
+	messages := []string{}
+	for _, err := range result.Errors {
+		messages = append(messages, err.Message)
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+}
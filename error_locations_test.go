@@ -0,0 +1,87 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+func errorLocationsSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"unlocated": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, gqlerrors.NewError("unlocated failure", nil, "", nil, nil, nil)
+					},
+				},
+				"page": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, p.Info.ArgumentError("first", errors.New("first must not exceed 100"))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestErrorLocations_LocatesErrorsReturnedWithoutLocation(t *testing.T) {
+	schema := errorLocationsSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+  unlocated
+}`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected an error, got: %v", result.Errors)
+	}
+	err := result.Errors[0]
+	if expected := []location.SourceLocation{{Line: 2, Column: 3}}; !reflect.DeepEqual(err.Locations, expected) {
+		t.Fatalf("expected locations %v, got: %v", expected, err.Locations)
+	}
+	if expected := []interface{}{"unlocated"}; !reflect.DeepEqual(err.Path, expected) {
+		t.Fatalf("expected path %v, got: %v", expected, err.Path)
+	}
+}
+
+func TestErrorLocations_ArgumentError(t *testing.T) {
+	schema := errorLocationsSchema(t)
+	tests := []struct {
+		request  string
+		expected []location.SourceLocation
+	}{
+		{`{ page(first: 1000) }`, []location.SourceLocation{{Line: 1, Column: 8}}},
+		{`{ page }`, []location.SourceLocation{{Line: 1, Column: 3}}},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: test.request})
+		if len(result.Errors) != 1 {
+			t.Fatalf("expected an error, got: %v", result.Errors)
+		}
+		err := result.Errors[0]
+		if err.Message != "first must not exceed 100" {
+			t.Fatalf("unexpected message %q", err.Message)
+		}
+		if !reflect.DeepEqual(err.Locations, test.expected) {
+			t.Fatalf("%s: expected locations %v, got: %v", test.request, test.expected, err.Locations)
+		}
+		if expected := []interface{}{"page"}; !reflect.DeepEqual(err.Path, expected) {
+			t.Fatalf("expected path %v, got: %v", expected, err.Path)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type maskingUser struct {
+	Name string
+}
+
+func errorMaskingSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"crash": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						var user *maskingUser
+						return user.Name, nil
+					},
+				},
+				"panicking": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						panic(fmt.Errorf("cannot connect to dsn=%v", "secret"))
+					},
+				},
+				"panickingString": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						panic("cannot connect to dsn=secret")
+					},
+				},
+				"internal": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, gqlerrors.InternalServerError("database password rejected")
+					},
+				},
+				"failing": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("not found")
+					},
+				},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"crash": &graphql.Field{
+					Type: graphql.String,
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						events := make(chan interface{}, 1)
+						events <- "event"
+						close(events)
+						return events, nil
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						panic("cannot connect to dsn=secret")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestErrorMasking_MasksInternalErrorsByDefault(t *testing.T) {
+	schema := errorMaskingSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ crash panicking panickingString internal failing }`,
+	})
+	if len(result.Errors) != 5 {
+		t.Fatalf("expected 5 errors, got: %v", result.Errors)
+	}
+	for _, err := range result.Errors {
+		if err.Path[0] == "failing" {
+			if err.Message != "not found" {
+				t.Fatalf("expected the message of other errors to be kept, got: %q", err.Message)
+			}
+			continue
+		}
+		if err.Message != gqlerrors.MaskedMessage {
+			t.Fatalf("expected masked message, got: %q", err.Message)
+		}
+		if code := gqlerrors.Code(err); code != gqlerrors.CodeInternalServerError {
+			t.Fatalf("expected code %q, got: %q", gqlerrors.CodeInternalServerError, code)
+		}
+		if _, ok := err.Extensions["stacktrace"]; ok {
+			t.Fatalf("unexpected stacktrace in masked error: %v", err.Extensions)
+		}
+		if len(err.Locations) != 1 || len(err.Path) != 1 {
+			t.Fatalf("expected the location and path to be kept, got: %v %v", err.Locations, err.Path)
+		}
+	}
+}
+
+func TestErrorMasking_DebugIncludesDetails(t *testing.T) {
+	schema := errorMaskingSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ crash internal }`,
+		Debug:         true,
+	})
+	if len(result.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got: %v", result.Errors)
+	}
+	crash, internal := result.Errors[0], result.Errors[1]
+	if crash.Path[0] != "crash" {
+		crash, internal = internal, crash
+	}
+	if !strings.Contains(crash.Message, "nil pointer dereference") {
+		t.Fatalf("expected the original message, got: %q", crash.Message)
+	}
+	stack, ok := crash.Extensions["stacktrace"].([]string)
+	if !ok || len(stack) == 0 {
+		t.Fatalf("expected a stacktrace, got: %v", crash.Extensions)
+	}
+	if excerpt, _ := crash.Extensions["excerpt"].(string); !strings.Contains(excerpt, "^^^^^") {
+		t.Fatalf("expected the excerpt of the field, got: %q", excerpt)
+	}
+	if internal.Message != "database password rejected" {
+		t.Fatalf("expected the original message, got: %q", internal.Message)
+	}
+	if _, ok := internal.Extensions["stacktrace"]; ok {
+		t.Fatalf("unexpected stacktrace for a returned error: %v", internal.Extensions)
+	}
+}
+
+func TestErrorMasking_MasksSubscriptionErrors(t *testing.T) {
+	schema := errorMaskingSchema(t)
+	for _, debug := range []bool{false, true} {
+		results := graphql.SubscribeContext(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `subscription { crash }`,
+			Debug:         debug,
+		})
+		count := 0
+		for result := range results {
+			count++
+			expected := gqlerrors.MaskedMessage
+			if debug {
+				expected = "cannot connect to dsn=secret"
+			}
+			if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+				t.Fatalf("expected the error %q, got: %v", expected, result.Errors)
+			}
+		}
+		if count != 1 {
+			t.Fatalf("expected a result, got %v", count)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestMessageCatalog_OverridesMessages(t *testing.T) {
+	gqlerrors.SetMessageCatalog(gqlerrors.MessageCatalog{
+		gqlerrors.MsgUndefinedField:  `Le champ "%[1]v" n'existe pas sur le type "%[2]v".`,
+		gqlerrors.MsgDidYouMean:      `%v Vouliez-vous dire %v ?`,
+		gqlerrors.MsgOrList:          `%v ou %v`,
+		gqlerrors.MsgUnexpectedToken: "Inattendu : %v",
+	})
+	defer gqlerrors.SetMessageCatalog(nil)
+
+	schema := errorCodesTestSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ secrets }`,
+	})
+	expected := []gqlerrors.FormattedError{
+		{
+			Message:    `Le champ "secrets" n'existe pas sur le type "Query". Vouliez-vous dire "secret" ?`,
+			Locations:  []location.SourceLocation{{Line: 1, Column: 3}},
+			Extensions: map[string]interface{}{"code": gqlerrors.CodeValidationFailed},
+		},
+	}
+	if !testutil.EqualFormattedErrors(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ secret } }`,
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "Inattendu : }") {
+		t.Fatalf("expected a localized syntax error, got: %v", result.Errors)
+	}
+	if code := gqlerrors.Code(result.Errors[0]); code != gqlerrors.CodeParseFailed {
+		t.Fatalf("expected code %q, got: %q", gqlerrors.CodeParseFailed, code)
+	}
+}
+
+func TestMessageCatalog_RestoresDefaultMessages(t *testing.T) {
+	gqlerrors.SetMessageCatalog(gqlerrors.MessageCatalog{
+		gqlerrors.MsgUnknownFragment: `Fragment inconnu "%v".`,
+	})
+	gqlerrors.SetMessageCatalog(nil)
+
+	expected := `Unknown fragment "Foo".`
+	if message := gqlerrors.Message(gqlerrors.MsgUnknownFragment, "Foo"); message != expected {
+		t.Fatalf("expected %q, got: %q", expected, message)
+	}
+	if template := gqlerrors.DefaultMessage(gqlerrors.MsgUnknownFragment); template != `Unknown fragment "%v".` {
+		t.Fatalf("unexpected default template: %q", template)
+	}
+}
@@ -0,0 +1,58 @@
+//go:build js && wasm
+
+// Command wasm exposes the parser, printer and validation of the package to
+// JavaScript, for the tooling running in browsers or at the edge.
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func main() {
+	// graphqlFormat(query) returns the query printed in its canonical form.
+	js.Global().Set("graphqlFormat", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		doc, err := parser.Parse(parser.ParseParams{Source: args[0].String()})
+		if err != nil {
+			return response(nil, gqlerrors.FormatErrors(err))
+		}
+		return response(printer.Print(doc), nil)
+	}))
+
+	// graphqlValidate(sdl, query) returns the validation errors of the query
+	// against the schema built from the SDL.
+	js.Global().Set("graphqlValidate", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		schema, err := graphql.BuildSchema(args[0].String(), nil)
+		if err != nil {
+			return response(nil, gqlerrors.FormatErrors(err))
+		}
+		doc, err := parser.Parse(parser.ParseParams{Source: args[1].String()})
+		if err != nil {
+			return response(nil, gqlerrors.FormatErrors(err))
+		}
+		result := graphql.ValidateDocument(&schema, doc, nil)
+		return response(result.IsValid, result.Errors)
+	}))
+
+	// keep the functions available to JavaScript
+	select {}
+}
+
+// response returns the JSON of the result and errors.
+func response(result interface{}, errs []gqlerrors.FormattedError) string {
+	b, err := json.Marshal(map[string]interface{}{
+		"result": result,
+		"errors": errs,
+	})
+	if err != nil {
+		b, _ = json.Marshal(map[string]interface{}{
+			"errors": []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+		})
+	}
+	return string(b)
+}
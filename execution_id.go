@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// ExecutionIDHeader is the header set by PropagateCorrelation to the
+// execution ID of the operation making an outgoing request.
+const ExecutionIDHeader = "X-Graphql-Execution-Id"
+
+// CorrelationHeaders are the headers of an incoming request kept by
+// WithCorrelationHeaders and set by PropagateCorrelation on the outgoing
+// requests, such as the requests to a remote schema the operation is
+// delegated to: the W3C trace context and the usual request IDs.
+var CorrelationHeaders = []string{
+	"Traceparent",
+	"Tracestate",
+	"X-Request-Id",
+	"X-Correlation-Id",
+}
+
+type executionIDKey struct{}
+
+// fallbackExecutionIDs numbers the execution IDs when no random ID can be
+// read.
+var fallbackExecutionIDs uint64
+
+// NewExecutionID returns a new random execution ID, 32 hexadecimal digits.
+func NewExecutionID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		n := atomic.AddUint64(&fallbackExecutionIDs, 1)
+		return strconv.FormatInt(time.Now().UnixNano(), 16) + strconv.FormatUint(n, 16)
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithExecutionID returns a context carrying the execution ID, which is used
+// by the executions of the context instead of generating one.
+func WithExecutionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, executionIDKey{}, id)
+}
+
+// ExecutionIDFromContext returns the execution ID carried by the context, as
+// the contexts given to resolvers do, empty if none.
+func ExecutionIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(executionIDKey{}).(string)
+	return id
+}
+
+// withExecutionID returns the execution ID of the params, from the context or
+// generated when not set, and the context carrying it.
+func withExecutionID(ctx context.Context, id string) (context.Context, string) {
+	if id == "" {
+		id = ExecutionIDFromContext(ctx)
+	}
+	if id == "" {
+		id = NewExecutionID()
+	}
+	if ExecutionIDFromContext(ctx) != id {
+		ctx = WithExecutionID(ctx, id)
+	}
+	return ctx, id
+}
+
+// reportExecutionID returns a copy of the result with the execution ID in its
+// extensions and in the extensions of its errors, as "executionId".
+func reportExecutionID(id string, result *Result) *Result {
+	reported := *result
+	reported.Extensions = make(map[string]interface{}, len(result.Extensions)+1)
+	for key, value := range result.Extensions {
+		reported.Extensions[key] = value
+	}
+	reported.Extensions["executionId"] = id
+	if len(result.Errors) > 0 {
+		reported.Errors = make([]gqlerrors.FormattedError, len(result.Errors))
+		for i, err := range result.Errors {
+			extensions := make(map[string]interface{}, len(err.Extensions)+1)
+			for key, value := range err.Extensions {
+				extensions[key] = value
+			}
+			extensions["executionId"] = id
+			err.Extensions = extensions
+			reported.Errors[i] = err
+		}
+	}
+	return &reported
+}
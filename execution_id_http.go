@@ -0,0 +1,45 @@
+//go:build !tinygo
+
+package graphql
+
+import (
+	"context"
+	"net/http"
+)
+
+type correlationHeadersKey struct{}
+
+// WithCorrelationHeaders returns a context carrying the CorrelationHeaders of
+// an incoming request, for PropagateCorrelation.
+func WithCorrelationHeaders(ctx context.Context, incoming http.Header) context.Context {
+	headers := http.Header{}
+	for _, name := range CorrelationHeaders {
+		name = http.CanonicalHeaderKey(name)
+		if values := incoming[name]; len(values) > 0 {
+			headers[name] = append([]string{}, values...)
+		}
+	}
+	return context.WithValue(ctx, correlationHeadersKey{}, headers)
+}
+
+// CorrelationHeadersFromContext returns the headers kept by
+// WithCorrelationHeaders, nil if none.
+func CorrelationHeadersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(correlationHeadersKey{}).(http.Header)
+	return headers
+}
+
+// PropagateCorrelation sets the correlation headers carried by the context
+// and its execution ID, see ExecutionIDHeader, on an outgoing request made by
+// a resolver, e.g.
+//
+//	req, _ := http.NewRequestWithContext(p.Context, "POST", url, body)
+//	graphql.PropagateCorrelation(p.Context, req)
+func PropagateCorrelation(ctx context.Context, outgoing *http.Request) {
+	for name, values := range CorrelationHeadersFromContext(ctx) {
+		outgoing.Header[name] = append([]string{}, values...)
+	}
+	if id := ExecutionIDFromContext(ctx); id != "" {
+		outgoing.Header.Set(ExecutionIDHeader, id)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !tinygo
+
+package graphql_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestPropagateCorrelation(t *testing.T) {
+	incoming := http.Header{}
+	incoming.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	incoming.Set("X-Request-Id", "req-1")
+	incoming.Set("Authorization", "Bearer secret")
+
+	ctx := graphql.WithCorrelationHeaders(context.Background(), incoming)
+	ctx = graphql.WithExecutionID(ctx, "42")
+	outgoing, err := http.NewRequest("POST", "http://remote.example/graphql", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphql.PropagateCorrelation(ctx, outgoing)
+
+	expected := http.Header{
+		"Traceparent":            []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		"X-Request-Id":           []string{"req-1"},
+		"X-Graphql-Execution-Id": []string{"42"},
+	}
+	if !reflect.DeepEqual(outgoing.Header, expected) {
+		t.Fatalf("unexpected headers %v", outgoing.Header)
+	}
+}
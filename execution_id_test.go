@@ -0,0 +1,99 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func executionIDSchema(t *testing.T, ids chan<- string) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						ids <- graphql.ExecutionIDFromContext(p.Context)
+						return p.Info.ExecutionID, nil
+					},
+				},
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("failed")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestExecutionID_GeneratedPerOperation(t *testing.T) {
+	ids := make(chan string, 2)
+	schema := executionIDSchema(t, ids)
+
+	first := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ id }`})
+	second := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ id }`})
+	firstID := first.Data.(map[string]interface{})["id"].(string)
+	secondID := second.Data.(map[string]interface{})["id"].(string)
+	if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(firstID) {
+		t.Fatalf("unexpected execution ID %q", firstID)
+	}
+	if firstID == secondID {
+		t.Fatalf("expected a new execution ID per operation, got %q twice", firstID)
+	}
+	if fromContext := <-ids; fromContext != firstID {
+		t.Fatalf("expected the context to carry the execution ID %q, got %q", firstID, fromContext)
+	}
+}
+
+func TestExecutionID_FromParamsOrContext(t *testing.T) {
+	ids := make(chan string, 2)
+	schema := executionIDSchema(t, ids)
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ id }`, ExecutionID: "from-params"})
+	if id := result.Data.(map[string]interface{})["id"]; id != "from-params" {
+		t.Fatalf("expected the execution ID of the params, got %v", id)
+	}
+	ctx := graphql.WithExecutionID(context.Background(), "from-context")
+	result = graphql.DoContext(ctx, graphql.Params{Schema: schema, RequestString: `{ id }`})
+	if id := result.Data.(map[string]interface{})["id"]; id != "from-context" {
+		t.Fatalf("expected the execution ID of the context, got %v", id)
+	}
+}
+
+func TestExecutionID_Reported(t *testing.T) {
+	schema := executionIDSchema(t, make(chan string, 1))
+
+	result := graphql.Do(graphql.Params{
+		Schema:            schema,
+		RequestString:     `{ fail }`,
+		ExecutionID:       "42",
+		ReportExecutionID: true,
+	})
+	if !reflect.DeepEqual(result.Extensions, map[string]interface{}{"executionId": "42"}) {
+		t.Fatalf("unexpected extensions %v", result.Extensions)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["executionId"] != "42" {
+		t.Fatalf("expected the execution ID in the errors, got %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:            schema,
+		RequestString:     `{ unknown }`,
+		ExecutionID:       "43",
+		ReportExecutionID: true,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["executionId"] != "43" {
+		t.Fatalf("expected the execution ID in the validation errors, got %v", result.Errors)
+	}
+}
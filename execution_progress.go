@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"context"
+	"time"
+)
+
+// ExecutionProgress describes how far the execution of an operation went
+// when a field is resolved, see ResolveInfo.Progress.
+type ExecutionProgress struct {
+	// FieldsResolved is the number of fields resolved so far by the
+	// operation, the field being resolved excluded.
+	FieldsResolved int
+
+	// Elapsed is the time elapsed since the execution started.
+	Elapsed time.Duration
+
+	// Deadline is the deadline of the context of the execution, zero when it
+	// has none, and Remaining the time left until then, zero once it passed.
+	Deadline  time.Time
+	Remaining time.Duration
+}
+
+// HasDeadline reports whether the execution has a deadline.
+func (progress ExecutionProgress) HasDeadline() bool {
+	return !progress.Deadline.IsZero()
+}
+
+// executionProgress tracks the progress of an execution.
+type executionProgress struct {
+	started        time.Time
+	deadline       time.Time
+	fieldsResolved int
+}
+
+func newExecutionProgress(ctx context.Context) *executionProgress {
+	progress := &executionProgress{started: time.Now()}
+	if ctx != nil {
+		progress.deadline, _ = ctx.Deadline()
+	}
+	return progress
+}
+
+// fieldResolved counts a resolved field.
+func (progress *executionProgress) fieldResolved() {
+	if progress != nil {
+		progress.fieldsResolved++
+	}
+}
+
+// Progress returns the progress of the execution of the operation when the
+// field is resolved, for resolvers to limit their work as the request runs
+// out of time, e.g. truncating the lists they return:
+//
+//	if progress := p.Info.Progress(); progress.HasDeadline() && progress.Remaining < 50*time.Millisecond {
+//		items = items[:min(len(items), 10)]
+//	}
+//
+// It is the zero ExecutionProgress outside of the execution of an operation.
+func (info ResolveInfo) Progress() ExecutionProgress {
+	if info.progress == nil {
+		return ExecutionProgress{}
+	}
+	now := time.Now()
+	progress := ExecutionProgress{
+		FieldsResolved: info.progress.fieldsResolved,
+		Elapsed:        now.Sub(info.progress.started),
+		Deadline:       info.progress.deadline,
+	}
+	if progress.HasDeadline() && progress.Deadline.After(now) {
+		progress.Remaining = progress.Deadline.Sub(now)
+	}
+	return progress
+}
@@ -0,0 +1,79 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestResolveInfo_Progress(t *testing.T) {
+	progresses := map[string]graphql.ExecutionProgress{}
+	record := func(p graphql.ResolveParams) (interface{}, error) {
+		progresses[p.Info.FieldName] = p.Info.Progress()
+		return "value", nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String, Resolve: record},
+				"b": &graphql.Field{Type: graphql.String, Resolve: record},
+				"items": &graphql.Field{
+					Type: graphql.NewList(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						// truncate the list when the request is nearly out of time
+						items := []int{1, 2, 3, 4, 5}
+						if progress := p.Info.Progress(); progress.HasDeadline() && progress.Remaining < time.Hour {
+							items = items[:2]
+						}
+						return items, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	result := graphql.DoContext(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a b items }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a":     "value",
+			"b":     "value",
+			"items": []interface{}{1, 2},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	// the fields are resolved one after the other, in no particular order
+	a, b := progresses["a"], progresses["b"]
+	if a.FieldsResolved == b.FieldsResolved || a.FieldsResolved > 2 || b.FieldsResolved > 2 {
+		t.Fatalf("expected the fields resolved before each field, got %+v", progresses)
+	}
+	deadline, _ := ctx.Deadline()
+	if !b.Deadline.Equal(deadline) || b.Remaining <= 0 || b.Remaining > time.Minute || b.Elapsed <= 0 {
+		t.Fatalf("unexpected progress: %+v", b)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a items }`,
+	})
+	if items := result.Data.(map[string]interface{})["items"]; !reflect.DeepEqual([]interface{}{1, 2, 3, 4, 5}, items) {
+		t.Fatalf("expected the complete list without deadline, got %v", items)
+	}
+	if a := progresses["a"]; a.HasDeadline() || a.Remaining != 0 {
+		t.Fatalf("expected no deadline, got %+v", a)
+	}
+}
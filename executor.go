@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"runtime/debug"
 	"sort"
 	"strings"
 
@@ -19,17 +21,105 @@ type ExecuteParams struct {
 	OperationName string
 	Args          map[string]interface{}
 
+	// VariablePresets are applied to Args before they are coerced, by variable name.
+	VariablePresets map[string]VariablePreset
+
+	// VariableTransforms are applied in order to Args before the presets.
+	VariableTransforms []VariableTransform
+
+	// VariableLimits bound the values of Args, see VariableLimits.
+	VariableLimits VariableLimits
+
+	// ResponseLimits bound the size of the response, see ResponseLimits.
+	ResponseLimits ResponseLimits
+
+	// Codec is used to encode the variables reported in errors, defaults to
+	// StdCodec.
+	Codec Codec
+
+	// CollectStats sets Result.Stats, and CollectAllocations additionally
+	// records the allocations made during the execution, which is costlier.
+	CollectStats       bool
+	CollectAllocations bool
+
+	// SemanticNullability enables the experimental semantic nullability mode,
+	// where the semantically non-null fields, see Field.SemanticNonNull, report
+	// an error when they resolve to null without one. Unlike for Non-Null
+	// fields, the error does not propagate to the parent field.
+	SemanticNullability bool
+
+	// Concurrency is the maximum number of resolve functions of sibling fields
+	// called concurrently, the fields being resolved one after the other when
+	// it is 1 or less. The top level fields of a mutation are always resolved
+	// serially. The resolve functions must then be safe for concurrent use,
+	// as must the ResolveFieldDidStart hooks of the extensions, notified
+	// around the call of each resolve function, while the values they return
+	// are completed in the goroutine of the execution.
+	Concurrency int
+
+	// ExecutionID identifies the operation in ResolveInfo.ExecutionID and in
+	// the contexts given to resolvers, see ExecutionIDFromContext. It
+	// defaults to the execution ID of the context, or to a new one.
+	ExecutionID string
+
+	// ReportExecutionID adds the execution ID to the extensions of the result
+	// and of its errors, as "executionId".
+	ReportExecutionID bool
+
+	// ReportResponseShape adds the signature of the shape of the response to
+	// its extensions, as "responseShape": the response keys, names and types
+	// of its fields and the lengths of its lists, without their values, e.g.
+	// to detect the drift of the responses of critical operations between
+	// deployments.
+	ReportResponseShape bool
+
+	// ReportResponseSize adds the estimated size of the JSON encoding of the
+	// data of the response, before compression, and the number of list items
+	// of each top level field, by response key, to its extensions, as
+	// "responseSize": {"bytes": ..., "listItems": {...}}, so that operators
+	// can alert on bloated payloads and set informed ResponseLimits. They are
+	// also recorded in the stats of the result, when collected.
+	ReportResponseSize bool
+
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
+	//
+	// Deprecated: pass the context to ExecuteContext instead. Context is kept
+	// for compatibility and is only consulted by Execute.
 	Context context.Context
+
+	// plan is the plan of the operation compiled ahead of time, set by
+	// DefaultExecutor.
+	plan *compiledOperation
+
+	// deferred holds the fragments deferred with @defer, set by
+	// ExecuteIncrementalContext.
+	deferred *deferQueue
+}
+
+// Execute executes the given document using p.Context.
+//
+// Execute is kept for compatibility, new code should prefer ExecuteContext.
+func Execute(p ExecuteParams) *Result {
+	return ExecuteContext(p.Context, p)
 }
 
-func Execute(p ExecuteParams) (result *Result) {
+// ExecuteContext implements the "Evaluating requests" section of the spec.
+// The context is made available to extensions and resolve functions and
+// cancels the execution when done.
+func ExecuteContext(ctx context.Context, p ExecuteParams) (result *Result) {
 	// Use background context if no context was provided
-	ctx := p.Context
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, p.ExecutionID = withExecutionID(ctx, p.ExecutionID)
+	p.Context = ctx
+	if p.ReportExecutionID {
+		defer func() {
+			result = reportExecutionID(p.ExecutionID, result)
+		}()
+	}
+
 	// run executionDidStart functions from extensions
 	extErrs, executionFinishFn := handleExtensionsExecutionDidStart(&p)
 	if len(extErrs) != 0 {
@@ -52,34 +142,83 @@ func Execute(p ExecuteParams) (result *Result) {
 	go func() {
 		result := &Result{}
 
+		var stats *Stats
+		var allocations allocationCounter
+		if p.CollectStats || p.CollectAllocations {
+			stats = &Stats{}
+		}
+		if p.CollectAllocations {
+			allocations = readAllocationCounter()
+		}
+
 		defer func() {
 			if err := recover(); err != nil {
+				if budgetErr, ok := err.(responseBudgetError); ok {
+					err = budgetErr.Error
+				}
 				result.Errors = append(result.Errors, gqlerrors.FormatError(err.(error)))
 			}
 			resultChannel <- result
 		}()
 
 		exeContext, err := buildExecutionContext(buildExecutionCtxParams{
-			Schema:        p.Schema,
-			Root:          p.Root,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Result:        result,
-			Context:       p.Context,
+			Schema:          p.Schema,
+			Root:            p.Root,
+			AST:             p.AST,
+			OperationName:   p.OperationName,
+			Args:            p.Args,
+			VariablePresets: p.VariablePresets,
+			VariableLimits:  p.VariableLimits,
+			ResponseLimits:  p.ResponseLimits,
+			Result:          result,
+			Context:         p.Context,
+			Codec:           p.Codec,
+			Stats:           stats,
+			ExecutionID:     p.ExecutionID,
+			Plan:            p.plan,
+			Deferred:        p.deferred,
+
+			VariableTransforms:  p.VariableTransforms,
+			SemanticNullability: p.SemanticNullability,
+			ReportResponseShape: p.ReportResponseShape,
+			Concurrency:         p.Concurrency,
 		})
 
 		if err != nil {
-			result.Errors = append(result.Errors, gqlerrors.FormatError(err.(error)))
+			result.Errors = append(result.Errors, gqlerrors.FormatErrors(err)...)
 			resultChannel <- result
 			return
 		}
 
-		resultChannel <- executeOperation(executeOperationParams{
+		r := executeOperation(executeOperationParams{
 			ExecutionContext: exeContext,
 			Root:             p.Root,
 			Operation:        exeContext.Operation,
 		})
+		if p.CollectAllocations {
+			allocations.since(stats)
+		}
+		r.Stats = stats
+		if stats != nil || p.ReportResponseSize {
+			size := measureResponse(r.Data)
+			if stats != nil {
+				stats.ResponseBytes = size.bytes
+				stats.ListItems = size.listItems
+			}
+			if p.ReportResponseSize {
+				if r.Extensions == nil {
+					r.Extensions = map[string]interface{}{}
+				}
+				r.Extensions["responseSize"] = size.extension()
+			}
+		}
+		if exeContext.shape != nil {
+			if r.Extensions == nil {
+				r.Extensions = map[string]interface{}{}
+			}
+			r.Extensions["responseShape"] = exeContext.shape.signature(r.Data)
+		}
+		resultChannel <- r
 	}()
 
 	select {
@@ -93,13 +232,26 @@ func Execute(p ExecuteParams) (result *Result) {
 }
 
 type buildExecutionCtxParams struct {
-	Schema        Schema
-	Root          interface{}
-	AST           *ast.Document
-	OperationName string
-	Args          map[string]interface{}
-	Result        *Result
-	Context       context.Context
+	Schema          Schema
+	Root            interface{}
+	AST             *ast.Document
+	OperationName   string
+	Args            map[string]interface{}
+	VariablePresets map[string]VariablePreset
+	VariableLimits  VariableLimits
+	ResponseLimits  ResponseLimits
+	Result          *Result
+	Context         context.Context
+	Codec           Codec
+	Stats           *Stats
+	ExecutionID     string
+	Plan            *compiledOperation
+	Deferred        *deferQueue
+
+	VariableTransforms  []VariableTransform
+	SemanticNullability bool
+	ReportResponseShape bool
+	Concurrency         int
 }
 
 type executionContext struct {
@@ -110,6 +262,38 @@ type executionContext struct {
 	VariableValues map[string]interface{}
 	Errors         []gqlerrors.FormattedError
 	Context        context.Context
+	Stats          *Stats
+	ExecutionID    string
+
+	SemanticNullability bool
+
+	// fragmentSelections caches the selection sets of the spreads of
+	// fragments with variables, see fragmentSelectionSet.
+	fragmentSelections map[*ast.FragmentSpread]*ast.SelectionSet
+
+	// fieldFragments are the fragments through which the fields were last
+	// collected, see ResolveInfo.FragmentDirectives.
+	fieldFragments map[*ast.Field][]ast.Node
+
+	// plan is the plan of the operation compiled ahead of time, if any.
+	plan *compiledOperation
+
+	// budget tracks the size of the response when it is limited.
+	budget *responseBudget
+
+	// shape records the fields of the response when it is reported.
+	shape responseShape
+
+	// progress tracks the progress of the execution, see ResolveInfo.Progress.
+	progress *executionProgress
+
+	// deferred holds the fragments deferred with @defer when the response is
+	// delivered incrementally.
+	deferred *deferQueue
+
+	// concurrency is the maximum number of resolve functions called
+	// concurrently, see ExecuteParams.Concurrency.
+	concurrency int
 }
 
 func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error) {
@@ -144,7 +328,11 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 		return nil, fmt.Errorf(`Must provide an operation.`)
 	}
 
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
+	args, err := transformVariables(p.Context, p.Schema, operation, p.VariableTransforms, p.Args)
+	if err != nil {
+		return nil, err
+	}
+	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), args, p.VariablePresets, p.VariableLimits, p.Codec)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +343,22 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 	eCtx.Operation = operation
 	eCtx.VariableValues = variableValues
 	eCtx.Context = p.Context
+	eCtx.Stats = p.Stats
+	eCtx.ExecutionID = p.ExecutionID
+	eCtx.plan = p.Plan
+	eCtx.SemanticNullability = p.SemanticNullability
+	if p.ResponseLimits != (ResponseLimits{}) {
+		eCtx.budget = &responseBudget{limits: p.ResponseLimits}
+	}
+	if p.ReportResponseShape {
+		eCtx.shape = responseShape{}
+	}
+	eCtx.progress = newExecutionProgress(p.Context)
+	eCtx.concurrency = p.Concurrency
+	if p.Deferred != nil {
+		p.Deferred.eCtx = eCtx
+		eCtx.deferred = p.Deferred
+	}
 	return eCtx, nil
 }
 
@@ -170,11 +374,15 @@ func executeOperation(p executeOperationParams) *Result {
 		return &Result{Errors: gqlerrors.FormatErrors(err)}
 	}
 
-	fields := collectFields(collectFieldsParams{
-		ExeContext:   p.ExecutionContext,
-		RuntimeType:  operationType,
-		SelectionSet: p.Operation.GetSelectionSet(),
-	})
+	fields, ok := p.ExecutionContext.plan.collectedFields(nil, operationType)
+	if !ok {
+		fields = collectFields(collectFieldsParams{
+			ExeContext:   p.ExecutionContext,
+			RuntimeType:  operationType,
+			SelectionSet: p.Operation.GetSelectionSet(),
+		})
+	}
+	p.ExecutionContext.deferred.enqueue(operationType, p.Root, nil)
 
 	executeFieldsParams := executeFieldsParams{
 		ExecutionContext: p.ExecutionContext,
@@ -293,6 +501,9 @@ func executeSubFields(p executeFieldsParams) map[string]interface{} {
 	if p.Fields == nil {
 		p.Fields = map[string][]*ast.Field{}
 	}
+	if p.ExecutionContext.concurrency > 1 && len(p.Fields) > 1 {
+		return executeSubFieldsConcurrently(p)
+	}
 
 	finalResults := make(map[string]interface{}, len(p.Fields))
 	for responseName, fieldASTs := range p.Fields {
@@ -401,6 +612,10 @@ type collectFieldsParams struct {
 	SelectionSet         *ast.SelectionSet
 	Fields               map[string][]*ast.Field
 	VisitedFragmentNames map[string]bool
+
+	// Fragments are the fragment spreads, definitions and inline fragments
+	// enclosing the selection set, outermost first.
+	Fragments []ast.Node
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -431,18 +646,29 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 				fields[name] = []*ast.Field{}
 			}
 			fields[name] = append(fields[name], selection)
+			if len(p.Fragments) > 0 && p.ExeContext != nil {
+				if p.ExeContext.fieldFragments == nil {
+					p.ExeContext.fieldFragments = map[*ast.Field][]ast.Node{}
+				}
+				p.ExeContext.fieldFragments[selection] = p.Fragments
+			}
 		case *ast.InlineFragment:
 
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) ||
 				!doesFragmentConditionMatch(p.ExeContext, selection, p.RuntimeType) {
 				continue
 			}
+			if label, ok := deferLabel(p.ExeContext, selection.Directives); ok {
+				p.ExeContext.deferred.collect(label, selection.SelectionSet, appendFragments(p.Fragments, selection))
+				continue
+			}
 			innerParams := collectFieldsParams{
 				ExeContext:           p.ExeContext,
 				RuntimeType:          p.RuntimeType,
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				Fragments:            appendFragments(p.Fragments, selection),
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -464,12 +690,17 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 				if !doesFragmentConditionMatch(p.ExeContext, fragment, p.RuntimeType) {
 					continue
 				}
+				if label, ok := deferLabel(p.ExeContext, selection.Directives); ok {
+					p.ExeContext.deferred.collect(label, fragmentSelectionSet(p.ExeContext, fragment, selection), appendFragments(p.Fragments, selection, fragment))
+					continue
+				}
 				innerParams := collectFieldsParams{
 					ExeContext:           p.ExeContext,
 					RuntimeType:          p.RuntimeType,
-					SelectionSet:         fragment.GetSelectionSet(),
+					SelectionSet:         fragmentSelectionSet(p.ExeContext, fragment, selection),
 					Fields:               fields,
 					VisitedFragmentNames: p.VisitedFragmentNames,
+					Fragments:            appendFragments(p.Fragments, selection, fragment),
 				}
 				collectFields(innerParams)
 			}
@@ -478,13 +709,39 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 	return fields
 }
 
+// appendFragments returns a copy of the enclosing fragments followed by the
+// nodes, not to share the backing array of the fragments of the siblings.
+func appendFragments(fragments []ast.Node, nodes ...ast.Node) []ast.Node {
+	enclosing := make([]ast.Node, 0, len(fragments)+len(nodes))
+	enclosing = append(enclosing, fragments...)
+	return append(enclosing, nodes...)
+}
+
+// ShouldIncludeNode reports whether a field, fragment spread or inline
+// fragment is included by its @skip and @include directives, given the
+// coerced variable values of the operation, @skip having higher precedence
+// than @include. It is the evaluation of the executor, for the planners and
+// lookahead helpers walking selection sets not to diverge from it.
+func ShouldIncludeNode(selection ast.Selection, variableValues map[string]interface{}) bool {
+	switch node := selection.(type) {
+	case *ast.Field:
+		return shouldIncludeDirectives(node.Directives, variableValues)
+	case *ast.FragmentSpread:
+		return shouldIncludeDirectives(node.Directives, variableValues)
+	case *ast.InlineFragment:
+		return shouldIncludeDirectives(node.Directives, variableValues)
+	}
+	return true
+}
+
 // Determines if a field should be included based on the @include and @skip
 // directives, where @skip has higher precedence than @include.
 func shouldIncludeNode(eCtx *executionContext, directives []*ast.Directive) bool {
-	var (
-		skipAST, includeAST *ast.Directive
-		argValues           map[string]interface{}
-	)
+	return shouldIncludeDirectives(directives, eCtx.VariableValues)
+}
+
+func shouldIncludeDirectives(directives []*ast.Directive, variableValues map[string]interface{}) bool {
+	var skipAST, includeAST *ast.Directive
 	for _, directive := range directives {
 		if directive == nil || directive.Name == nil {
 			continue
@@ -498,20 +755,44 @@ func shouldIncludeNode(eCtx *executionContext, directives []*ast.Directive) bool
 	}
 	// precedence: skipAST > includeAST
 	if skipAST != nil {
-		argValues = getArgumentValues(SkipDirective.Args, skipAST.Arguments, eCtx.VariableValues)
-		if skipIf, ok := argValues["if"].(bool); ok && skipIf {
+		if skipIf, ok := conditionValue(SkipDirective, skipAST, variableValues); ok && skipIf {
 			return false // excluded selectionSet's fields
 		}
 	}
 	if includeAST != nil {
-		argValues = getArgumentValues(IncludeDirective.Args, includeAST.Arguments, eCtx.VariableValues)
-		if includeIf, ok := argValues["if"].(bool); ok && !includeIf {
+		if includeIf, ok := conditionValue(IncludeDirective, includeAST, variableValues); ok && !includeIf {
 			return false // excluded selectionSet's fields
 		}
 	}
 	return true
 }
 
+// conditionValue returns the `if` argument of the @skip or @include directive
+// and whether it is set. A literal condition, which does not depend on the
+// variables, or a boolean variable is read without coercing the arguments of
+// the directive.
+func conditionValue(directive *Directive, directiveAST *ast.Directive, variableValues map[string]interface{}) (bool, bool) {
+	for _, arg := range directiveAST.Arguments {
+		if arg != nil && arg.Name != nil && arg.Name.Value == "if" {
+			switch value := arg.Value.(type) {
+			case *ast.BooleanValue:
+				return value.Value, true
+			case *ast.Variable:
+				// the variables are coerced
+				if value.Name != nil {
+					if value, ok := variableValues[value.Name.Value].(bool); ok {
+						return value, true
+					}
+				}
+			}
+			break
+		}
+	}
+	argValues := getArgumentValues(directive.Args, directiveAST.Arguments, variableValues)
+	value, ok := argValues["if"].(bool)
+	return value, ok
+}
+
 // Determines if a fragment is applicable to the given type.
 func doesFragmentConditionMatch(eCtx *executionContext, fragment ast.Node, ttype *Object) bool {
 
@@ -581,12 +862,38 @@ type resolveFieldResultState struct {
 }
 
 func handleFieldError(r interface{}, fieldNodes []ast.Node, path *ResponsePath, returnType Output, eCtx *executionContext) {
+	// a response exceeding its budget aborts the execution
+	if _, ok := r.(responseBudgetError); ok {
+		panic(r)
+	}
+	// the panics of the resolvers are internal errors, see resolveCatchingPanic,
+	// as are the unexpected panics raised while completing their values
+	switch r.(type) {
+	case runtime.Error, string:
+		r = newPanicError(r)
+	}
 	err := NewLocatedErrorWithPath(r, fieldNodes, path.AsArray())
 	// send panic upstream
 	if _, ok := returnType.(*NonNull); ok {
 		panic(err)
 	}
 	eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(err))
+	// the fragments deferred within the nulled field are not delivered
+	eCtx.deferred.discard(path)
+}
+
+// newPanicError returns the recovered panic as an internal error, along with
+// the stack at which it was raised, for debugging. Its details are only
+// reported in debug mode, see Params.Debug.
+func newPanicError(r interface{}) *gqlerrors.PanicError {
+	if panicErr, ok := r.(*gqlerrors.PanicError); ok {
+		return panicErr
+	}
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	return &gqlerrors.PanicError{Err: err, Stack: debug.Stack()}
 }
 
 // Resolves the field on the given source object. In particular, this
@@ -604,19 +911,40 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 		return result, resultState
 	}()
 
-	fieldAST := fieldASTs[0]
-	fieldName := ""
-	if fieldAST.Name != nil {
-		fieldName = fieldAST.Name.Value
-	}
-
-	fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
+	fieldDef := getFieldDef(eCtx.Schema, parentType, fieldASTName(fieldASTs[0]))
 	if fieldDef == nil {
 		resultState.hasNoFieldDefs = true
 		return nil, resultState
 	}
 	returnType = fieldDef.Type
-	resolveFn := fieldDef.Resolve
+
+	field := prepareField(eCtx, parentType, fieldDef, source, fieldASTs, path)
+	field.resolveCatchingPanic()
+	return completeField(eCtx, field), resultState
+}
+
+// preparedField is a field ready to be resolved, see prepareField.
+type preparedField struct {
+	resolveFn  FieldResolveFn
+	params     ResolveParams
+	extensions []Extension
+
+	// result and err are returned by the resolve function, panicked is its
+	// recovered panic and extErrs are the errors of the extensions notified
+	// around its call, see resolveCatchingPanic.
+	result   interface{}
+	err      error
+	panicked interface{}
+	extErrs  []gqlerrors.FormattedError
+}
+
+// prepareField gets the resolve function of the field and its parameters.
+func prepareField(eCtx *executionContext, parentType *Object, fieldDef *FieldDefinition, source interface{}, fieldASTs []*ast.Field, path *ResponsePath) *preparedField {
+	fieldAST := fieldASTs[0]
+	fieldName := fieldASTName(fieldAST)
+	returnType := fieldDef.Type
+	eCtx.shape.record(path, fieldName, returnType)
+	resolveFn := eCtx.Schema.fieldResolver(parentType, fieldDef)
 	if resolveFn == nil {
 		resolveFn = DefaultResolveFn
 	}
@@ -624,7 +952,10 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args, ok := eCtx.plan.argumentValues(fieldAST, fieldDef)
+	if !ok {
+		args = getArgumentValues(eCtx.Schema.fieldArgs(parentType, fieldDef), fieldAST.Arguments, eCtx.VariableValues)
+	}
 
 	info := ResolveInfo{
 		FieldName:      fieldName,
@@ -637,33 +968,57 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 		RootValue:      eCtx.Root,
 		Operation:      eCtx.Operation,
 		VariableValues: eCtx.VariableValues,
+		ExecutionID:    eCtx.ExecutionID,
+		fragments:      fieldFragments(eCtx, fieldASTs),
+		progress:       eCtx.progress,
+		args:           args,
 	}
 
-	var resolveFnError error
+	if eCtx.Stats != nil {
+		eCtx.Stats.ResolverCalls++
+	}
+	return &preparedField{
+		resolveFn: resolveFn,
+		params: ResolveParams{
+			Source:  source,
+			Args:    args,
+			Info:    info,
+			Context: eCtx.Context,
+		},
+		extensions: eCtx.Schema.extensions,
+	}
+}
 
-	extErrs, resolveFieldFinishFn := handleExtensionsResolveFieldDidStart(eCtx.Schema.extensions, eCtx, &info)
-	if len(extErrs) != 0 {
-		eCtx.Errors = append(eCtx.Errors, extErrs...)
+// completeField completes the value of the resolved field, or panics with its
+// error.
+func completeField(eCtx *executionContext, field *preparedField) interface{} {
+	if len(field.extErrs) != 0 {
+		eCtx.Errors = append(eCtx.Errors, field.extErrs...)
+	}
+	if field.panicked != nil {
+		panic(field.panicked)
 	}
 
-	result, resolveFnError = resolveFn(ResolveParams{
-		Source:  source,
-		Args:    args,
-		Info:    info,
-		Context: eCtx.Context,
-	})
+	eCtx.progress.fieldResolved()
 
-	extErrs = resolveFieldFinishFn(result, resolveFnError)
-	if len(extErrs) != 0 {
-		eCtx.Errors = append(eCtx.Errors, extErrs...)
+	info := field.params.Info
+	if typed, ok := eCtx.Schema.errorTypes.typed(info.ReturnType, field.err); ok {
+		// the error is a value of the union of the field
+		field.result, field.err = typed, nil
 	}
-
-	if resolveFnError != nil {
-		panic(resolveFnError)
+	if field.err != nil {
+		panic(field.err)
 	}
 
-	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, path, result)
-	return completed, resultState
+	return completeValueCatchingError(eCtx, info.ReturnType, info.FieldASTs, info, info.Path, field.result)
+}
+
+// fieldASTName returns the name of the field.
+func fieldASTName(fieldAST *ast.Field) string {
+	if fieldAST.Name != nil {
+		return fieldAST.Name.Value
+	}
+	return ""
 }
 
 func completeValueCatchingError(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) (completed interface{}) {
@@ -708,8 +1063,22 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 		return completed
 	}
 
-	// If result value is null-ish (null, undefined, or NaN) then return null.
+	if eCtx.Stats != nil {
+		eCtx.Stats.CompletedValues++
+	}
+
+	// If result value is null-ish (null, undefined, or NaN) then return null,
+	// raising a field error first for semantically non-null values.
 	if isNullish(result) {
+		eCtx.budget.complete(nil, fieldASTs, path)
+		if eCtx.SemanticNullability && isSemanticNonNull(info, path) {
+			err := NewLocatedErrorWithPath(
+				fmt.Sprintf("Cannot return null for semantically non-nullable field %v.%v.", info.ParentType, info.FieldName),
+				FieldASTsToNodeASTs(fieldASTs),
+				path.AsArray(),
+			)
+			panic(gqlerrors.FormatError(err))
+		}
 		return nil
 	}
 
@@ -720,12 +1089,14 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
-	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
-	}
-	if returnType, ok := returnType.(*Enum); ok {
-		return completeLeafValue(returnType, result)
+	if returnType, ok := returnType.(Leaf); ok {
+		completed := completeLeafValue(returnType, result)
+		if err := eCtx.budget.complete(completed, fieldASTs, path); err != nil {
+			panic(gqlerrors.FormatError(err))
+		}
+		return completed
 	}
+	eCtx.budget.complete(delimiters{}, fieldASTs, path)
 
 	// If field type is an abstract type, Interface or Union, determine the
 	// runtime Object type and complete for that type.
@@ -792,7 +1163,29 @@ func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldAST
 		Info:    info,
 		Context: eCtx.Context,
 	}
-	if unionReturnType, ok := returnType.(*Union); ok && unionReturnType.ResolveType != nil {
+	if union, ok := returnType.(*Union); ok && eCtx.Schema.errorTypes != nil {
+		if err, ok := result.(error); ok {
+			typed, ok := eCtx.Schema.errorTypes.typed(union, err)
+			if !ok {
+				panic(err)
+			}
+			result = typed
+		}
+	}
+	if typed, ok := typedValue(result); ok {
+		// the value is resolved along with the name of its type
+		if isNullish(typed.Value) {
+			return nil
+		}
+		runtimeType, _ = eCtx.Schema.Type(typed.TypeName).(*Object)
+		err := invariantf(runtimeType != nil, `Abstract type %v must resolve to an Object type at runtime `+
+			`for field %v.%v with value "%v", received "%v".`, returnType, info.ParentType, info.FieldName, typed.Value, typed.TypeName,
+		)
+		if err != nil {
+			panic(err)
+		}
+		result = typed.Value
+	} else if unionReturnType, ok := returnType.(*Union); ok && unionReturnType.ResolveType != nil {
 		runtimeType = unionReturnType.ResolveType(resolveTypeParams)
 	} else if interfaceReturnType, ok := returnType.(*Interface); ok && interfaceReturnType.ResolveType != nil {
 		runtimeType = interfaceReturnType.ResolveType(resolveTypeParams)
@@ -836,25 +1229,30 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 		}
 	}
 
-	// Collect sub-fields to execute to complete this value.
-	subFieldASTs := map[string][]*ast.Field{}
-	visitedFragmentNames := map[string]bool{}
-	for _, fieldAST := range fieldASTs {
-		if fieldAST == nil {
-			continue
-		}
-		selectionSet := fieldAST.SelectionSet
-		if selectionSet != nil {
-			innerParams := collectFieldsParams{
-				ExeContext:           eCtx,
-				RuntimeType:          returnType,
-				SelectionSet:         selectionSet,
-				Fields:               subFieldASTs,
-				VisitedFragmentNames: visitedFragmentNames,
+	// Collect sub-fields to execute to complete this value, unless collected
+	// ahead of time.
+	subFieldASTs, ok := eCtx.plan.collectedFields(fieldASTs, returnType)
+	if !ok {
+		subFieldASTs = map[string][]*ast.Field{}
+		visitedFragmentNames := map[string]bool{}
+		for _, fieldAST := range fieldASTs {
+			if fieldAST == nil {
+				continue
+			}
+			selectionSet := fieldAST.SelectionSet
+			if selectionSet != nil {
+				innerParams := collectFieldsParams{
+					ExeContext:           eCtx,
+					RuntimeType:          returnType,
+					SelectionSet:         selectionSet,
+					Fields:               subFieldASTs,
+					VisitedFragmentNames: visitedFragmentNames,
+				}
+				subFieldASTs = collectFields(innerParams)
 			}
-			subFieldASTs = collectFields(innerParams)
 		}
 	}
+	eCtx.deferred.enqueue(returnType, result, path)
 	executeFieldsParams := executeFieldsParams{
 		ExecutionContext: eCtx,
 		ParentType:       returnType,
@@ -867,7 +1265,21 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
 func completeLeafValue(returnType Leaf, result interface{}) interface{} {
-	serializedResult := returnType.Serialize(result)
+	var serializedResult interface{}
+	if scalar, ok := returnType.(*Scalar); ok && scalar.scalarConfig.SerializeE != nil {
+		var err error
+		if serializedResult, err = scalar.SerializeE(result); err != nil {
+			panic(gqlerrors.FormatError(err))
+		}
+	} else {
+		serializedResult = returnType.Serialize(result)
+	}
+	if enum, ok := returnType.(*Enum); ok && isNullish(serializedResult) && !isNullish(result) {
+		var err error
+		if serializedResult, err = enum.serializeUnknown(result); err != nil {
+			panic(gqlerrors.FormatError(err))
+		}
+	}
 	if isNullish(serializedResult) {
 		return nil
 	}
@@ -0,0 +1,43 @@
+package graphql
+
+import (
+	"context"
+)
+
+// Executor is the engine executing the operations of the requests once they
+// are parsed and validated, see Params.Executor. Alternative engines, such as
+// compiled resolvers, query planners or federation gateways, implement it to
+// reuse Do, the extensions and the validation.
+type Executor interface {
+	// Plan prepares the execution of the operation of the params, returning
+	// the plan given to Execute. An error fails the request before any
+	// resolver is called.
+	Plan(p ExecuteParams) (ExecutionPlan, error)
+
+	// Execute executes the plan returned by Plan for the same params.
+	Execute(ctx context.Context, plan ExecutionPlan, p ExecuteParams) *Result
+}
+
+// ExecutionPlan is the plan of an operation, specific to its Executor.
+type ExecutionPlan interface{}
+
+// DefaultExecutor is the tree-walking executor of ExecuteContext, used by Do
+// when no executor is set.
+var DefaultExecutor Executor = treeExecutor{}
+
+// treeExecutor resolves the fields by walking the selection sets of the
+// operation. Its plans are the operations compiled by Schema.Warmup, nil for
+// the other operations.
+type treeExecutor struct{}
+
+func (treeExecutor) Plan(p ExecuteParams) (ExecutionPlan, error) {
+	if plan := p.Schema.plans.get(p.AST, p.OperationName); plan != nil {
+		return plan, nil
+	}
+	return nil, nil
+}
+
+func (treeExecutor) Execute(ctx context.Context, plan ExecutionPlan, p ExecuteParams) *Result {
+	p.plan, _ = plan.(*compiledOperation)
+	return ExecuteContext(ctx, p)
+}
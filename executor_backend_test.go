@@ -0,0 +1,82 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// rootFieldsExecutor plans the names of the root fields of the operation and
+// delegates the execution to the default executor.
+type rootFieldsExecutor struct {
+	plans []graphql.ExecutionPlan
+}
+
+func (e *rootFieldsExecutor) Plan(p graphql.ExecuteParams) (graphql.ExecutionPlan, error) {
+	names := []string{}
+	for _, definition := range p.AST.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			for _, selection := range operation.SelectionSet.Selections {
+				if field, ok := selection.(*ast.Field); ok {
+					if field.Name.Value == "droid" {
+						return nil, errors.New("the field droid is not planned")
+					}
+					names = append(names, field.Name.Value)
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+func (e *rootFieldsExecutor) Execute(ctx context.Context, plan graphql.ExecutionPlan, p graphql.ExecuteParams) *graphql.Result {
+	e.plans = append(e.plans, plan)
+	return graphql.DefaultExecutor.Execute(ctx, nil, p)
+}
+
+func TestExecutor_DoDelegatesToExecutor(t *testing.T) {
+	executor := &rootFieldsExecutor{}
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero { name } }`,
+		Executor:      executor,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"hero": map[string]interface{}{"name": "R2-D2"}},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if len(executor.plans) != 1 || len(executor.plans[0].([]string)) != 1 || executor.plans[0].([]string)[0] != "hero" {
+		t.Fatalf("expected the plan of the operation to be executed, got %v", executor.plans)
+	}
+}
+
+func TestExecutor_PlanErrorsFailTheRequest(t *testing.T) {
+	executor := &rootFieldsExecutor{}
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ droid(id: "2001") { name } }`,
+		Executor:      executor,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "the field droid is not planned" || result.Data != nil {
+		t.Fatalf("expected the error of the plan, got %v", result)
+	}
+	if len(executor.plans) != 0 {
+		t.Fatalf("expected no execution, got %v", executor.plans)
+	}
+
+	// the invalid requests do not reach the executor
+	result = graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ unknown }`,
+		Executor:      executor,
+	})
+	if len(result.Errors) != 1 || len(executor.plans) != 0 {
+		t.Fatalf("expected a validation error, got %v", result.Errors)
+	}
+}
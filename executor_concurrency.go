@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"sync"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// executeSubFieldsConcurrently executes the fields like executeSubFields, but
+// calls their resolve functions concurrently, see ExecuteParams.Concurrency.
+//
+// Only the resolve functions, along with the ResolveFieldDidStart hooks of the
+// extensions timing them, run in the workers: the fields are prepared, and
+// their values completed, in the goroutine of the execution, so that the
+// state of the execution and the lazily defined types of the schema are not
+// shared between goroutines. The values of the objects are
+// completed one after the other, each resolving the fields of its own
+// selection set concurrently, so that at most Concurrency resolve functions
+// run at once.
+func executeSubFieldsConcurrently(p executeFieldsParams) map[string]interface{} {
+	eCtx := p.ExecutionContext
+	finalResults := make(map[string]interface{}, len(p.Fields))
+
+	responseNames := make([]string, 0, len(p.Fields))
+	fields := make([]*preparedField, 0, len(p.Fields))
+	for responseName, fieldASTs := range p.Fields {
+		fieldPath := p.Path.WithKey(responseName)
+		field, state := prepareFieldCatchingError(eCtx, p.ParentType, p.Source, fieldASTs, fieldPath)
+		if state.hasNoFieldDefs {
+			continue
+		}
+		if field == nil {
+			// nulled by its error
+			finalResults[responseName] = nil
+			continue
+		}
+		responseNames = append(responseNames, responseName)
+		fields = append(fields, field)
+	}
+
+	resolveFieldsConcurrently(fields, eCtx.concurrency)
+
+	for i, field := range fields {
+		finalResults[responseNames[i]] = completeFieldCatchingError(eCtx, field)
+	}
+	return finalResults
+}
+
+// prepareFieldCatchingError prepares the field like resolveField, handling its
+// errors, see prepareField.
+func prepareFieldCatchingError(eCtx *executionContext, parentType *Object, source interface{}, fieldASTs []*ast.Field, path *ResponsePath) (field *preparedField, resultState resolveFieldResultState) {
+	var returnType Output
+	defer func() {
+		if r := recover(); r != nil {
+			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, returnType, eCtx)
+		}
+	}()
+
+	fieldDef := getFieldDef(eCtx.Schema, parentType, fieldASTName(fieldASTs[0]))
+	if fieldDef == nil {
+		resultState.hasNoFieldDefs = true
+		return nil, resultState
+	}
+	returnType = fieldDef.Type
+	return prepareField(eCtx, parentType, fieldDef, source, fieldASTs, path), resultState
+}
+
+// completeFieldCatchingError completes the resolved field like resolveField,
+// handling its errors, see completeField.
+func completeFieldCatchingError(eCtx *executionContext, field *preparedField) (completed interface{}) {
+	info := field.params.Info
+	defer func() {
+		if r := recover(); r != nil {
+			handleFieldError(r, FieldASTsToNodeASTs(info.FieldASTs), info.Path, info.ReturnType, eCtx)
+		}
+	}()
+	return completeField(eCtx, field)
+}
+
+// resolveFieldsConcurrently calls the resolve functions of the fields with at
+// most concurrency workers, and waits for them to return.
+func resolveFieldsConcurrently(fields []*preparedField, concurrency int) {
+	if concurrency > len(fields) {
+		concurrency = len(fields)
+	}
+	queue := make(chan *preparedField)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for field := range queue {
+				field.resolveCatchingPanic()
+			}
+		}()
+	}
+	for _, field := range fields {
+		queue <- field
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// resolveCatchingPanic calls the resolve function of the field, keeping its
+// panic, whatever its value, as an internal error to be handled along with
+// the field, see completeField. The extensions are notified right around the
+// call, in the goroutine calling it, so that they time the resolver alone.
+func (field *preparedField) resolveCatchingPanic() {
+	var finishFn resolveFieldFinishFuncHandler
+	field.params.Context, field.extErrs, finishFn = handleExtensionsResolveFieldDidStart(field.extensions, field.params.Context, &field.params.Info)
+	defer func() {
+		if r := recover(); r != nil {
+			field.panicked = newPanicError(r)
+			return
+		}
+		field.extErrs = append(field.extErrs, finishFn(field.result, field.err)...)
+	}()
+	field.result, field.err = field.resolveFn(field.params)
+}
@@ -0,0 +1,200 @@
+package graphql_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// inFlight counts the resolve functions running at once.
+type inFlight struct {
+	running int32
+	max     int32
+}
+
+func (f *inFlight) resolve(value interface{}, wait time.Duration) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		running := atomic.AddInt32(&f.running, 1)
+		defer atomic.AddInt32(&f.running, -1)
+		for {
+			max := atomic.LoadInt32(&f.max)
+			if running <= max || atomic.CompareAndSwapInt32(&f.max, max, running) {
+				break
+			}
+		}
+		time.Sleep(wait)
+		return value, nil
+	}
+}
+
+func concurrencyTestSchema(t *testing.T, f *inFlight) graphql.Schema {
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{Type: graphql.String, Resolve: f.resolve("a", 10*time.Millisecond)},
+			"b": &graphql.Field{Type: graphql.String, Resolve: f.resolve("b", 10*time.Millisecond)},
+		},
+	})
+	fields := graphql.Fields{}
+	for i := 0; i < 6; i++ {
+		fields[fmt.Sprintf("item%v", i)] = &graphql.Field{
+			Type:    item,
+			Resolve: f.resolve(map[string]interface{}{}, 20*time.Millisecond),
+		}
+	}
+	fields["fail"] = &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return nil, errors.New("fail failed")
+		},
+	}
+	fields["panic"] = &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			panic(errors.New("panic panicked"))
+		},
+	}
+	fields["nonNull"] = &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "NonNullParent",
+			Fields: graphql.Fields{
+				"value": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, nil
+					},
+				},
+				"other": &graphql.Field{Type: graphql.String, Resolve: f.resolve("other", 0)},
+			},
+		}),
+		Resolve: f.resolve(map[string]interface{}{}, 0),
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+const concurrencyTestQuery = `{
+	item0 { a b } item1 { a b } item2 { a b } item3 { a b } item4 { a b } item5 { a b }
+}`
+
+func TestExecute_ResolvesSiblingFieldsConcurrently(t *testing.T) {
+	f := &inFlight{}
+	schema := concurrencyTestSchema(t, f)
+	serial := graphql.Do(graphql.Params{Schema: schema, RequestString: concurrencyTestQuery})
+	if f.max != 1 {
+		t.Fatalf("expected the fields to be resolved serially, got %v at once", f.max)
+	}
+
+	f = &inFlight{}
+	schema = concurrencyTestSchema(t, f)
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: concurrencyTestQuery, Concurrency: 4})
+	if f.max != 4 {
+		t.Fatalf("expected 4 fields to be resolved at once, got %v", f.max)
+	}
+	if !testutil.EqualResults(serial, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(serial, result))
+	}
+}
+
+func TestExecute_ResolvesMutationFieldsSerially(t *testing.T) {
+	f := &inFlight{}
+	schema := concurrencyTestSchema(t, f)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { item0 { a } item1 { a } item2 { a } }`,
+		Concurrency:   4,
+	})
+	if len(result.Errors) != 0 || f.max != 1 {
+		t.Fatalf("expected the top level fields to be resolved serially, got %v at once, %v", f.max, result.Errors)
+	}
+
+	f = &inFlight{}
+	schema = concurrencyTestSchema(t, f)
+	graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { item0 { a b } }`,
+		Concurrency:   4,
+	})
+	if f.max != 2 {
+		t.Fatalf("expected the nested fields to be resolved concurrently, got %v at once", f.max)
+	}
+}
+
+func TestExecute_ConcurrentFieldsErrors(t *testing.T) {
+	query := `{ item0 { a } fail panic nonNull { value other } item1 { b } }`
+	schema := concurrencyTestSchema(t, &inFlight{})
+	serial := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(serial.Errors) != 3 {
+		t.Fatalf("expected 3 errors, got %v", serial.Errors)
+	}
+	var wg sync.WaitGroup
+	results := make([]*graphql.Result, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = graphql.Do(graphql.Params{Schema: schema, RequestString: query, Concurrency: 3})
+		}(i)
+	}
+	wg.Wait()
+	sort.Sort(gqlerrors.FormattedErrors(serial.Errors))
+	for _, result := range results {
+		// the errors are reported in the order the fields are completed
+		sort.Sort(gqlerrors.FormattedErrors(result.Errors))
+		if !reflect.DeepEqual(serial.Data, result.Data) || !testutil.EqualFormattedErrors(serial.Errors, result.Errors) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(serial, result))
+		}
+	}
+}
+
+func TestExecute_TimesConcurrentResolversAlone(t *testing.T) {
+	f := &inFlight{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"slow": &graphql.Field{Type: graphql.String, Resolve: f.resolve("slow", 100*time.Millisecond)},
+				"fast": &graphql.Field{Type: graphql.String, Resolve: f.resolve("fast", 0)},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ slow fast }`,
+		Concurrency:   2,
+		EnableTracing: true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	execution := result.Extensions["tracing"].(map[string]interface{})["execution"].(map[string]interface{})
+	durations := map[string]time.Duration{}
+	for _, resolver := range execution["resolvers"].([]map[string]interface{}) {
+		durations[resolver["fieldName"].(string)] = time.Duration(resolver["duration"].(int64))
+	}
+	if durations["slow"] < 100*time.Millisecond {
+		t.Fatalf("expected the slow resolver to take its time, got %v", durations["slow"])
+	}
+	// the fast resolver is not timed until its slow sibling returns
+	if durations["fast"] >= 50*time.Millisecond {
+		t.Fatalf("expected the fast resolver to be timed alone, got %v", durations["fast"])
+	}
+}
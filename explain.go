@@ -0,0 +1,227 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// ExplainListSize is the number of items lists are assumed to contain when
+// estimating the cost of a plan.
+const ExplainListSize = 10
+
+// Plan describes how a request would be executed, see Explain.
+type Plan struct {
+	// Operation is the type of the operation, e.g. "query".
+	Operation     string
+	OperationName string
+
+	// Serial is set when the root fields are executed one after the other, as
+	// for mutations.
+	Serial bool
+
+	// Steps are the root fields of the operation, in execution order.
+	Steps []*PlanStep
+
+	// Cost is the estimated cost of the whole request, see PlanStep.Cost.
+	Cost int
+
+	Errors []gqlerrors.FormattedError
+}
+
+// PlanStep describes the resolution of a field.
+type PlanStep struct {
+	// Path is the path of the field in the response, using "[]" for list items.
+	Path         []string
+	ResponseName string
+	FieldName    string
+	ParentType   string
+	ReturnType   string
+	Args         map[string]interface{}
+
+	// Phase is the depth of the field in the response, the fields of a phase
+	// are resolved once the values of the previous phase are completed.
+	Phase int
+
+	// Trivial is set when the field uses the default resolver, which reads
+	// the value from its source without any side effect.
+	Trivial bool
+
+	// Batched is set when the resolver runs once per item of a list, in which
+	// case returning thunks lets the calls be deferred and batched together.
+	Batched bool
+
+	// Cost is the estimated number of resolver calls of the field and its
+	// children, assuming lists contain ExplainListSize items and not counting
+	// trivial resolvers.
+	Cost int
+
+	// Children are the fields of the selection set of the field, in
+	// execution order. For fields of abstract types the children of each
+	// possible type are listed, see ParentType.
+	Children []*PlanStep
+}
+
+// Explain returns the plan of the request described by the given params
+// against the given schema, which takes precedence over p.Schema.
+// The request is parsed with p.ParseOptions and validated, but neither the
+// resolvers nor p.VariableTransforms, which may have side effects, are called.
+func Explain(schema Schema, p Params) *Plan {
+	p.Schema = schema
+	plan := &Plan{}
+
+	src := source.NewSource(&source.Source{
+		Body: []byte(p.RequestString),
+		Name: "GraphQL request",
+	})
+	AST, err := parser.Parse(parser.ParseParams{Source: src, Options: p.ParseOptions})
+	if err != nil {
+		plan.Errors = gqlerrors.FormatErrors(err)
+		return plan
+	}
+	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	if !validationResult.IsValid {
+		plan.Errors = validationResult.Errors
+		return plan
+	}
+	variableValues, err := p.variableValues()
+	if err != nil {
+		plan.Errors = gqlerrors.FormatErrors(err)
+		return plan
+	}
+
+	eCtx, err := buildExecutionContext(buildExecutionCtxParams{
+		Schema:          p.Schema,
+		Root:            p.RootObject,
+		AST:             AST,
+		OperationName:   p.OperationName,
+		Args:            variableValues,
+		VariablePresets: p.VariablePresets,
+		VariableLimits:  p.VariableLimits,
+		Result:          &Result{},
+		Context:         p.Context,
+		Codec:           p.Codec,
+	})
+	if err != nil {
+		plan.Errors = gqlerrors.FormatErrors(err)
+		return plan
+	}
+	operationType, err := getOperationRootType(eCtx.Schema, eCtx.Operation)
+	if err != nil {
+		plan.Errors = gqlerrors.FormatErrors(err)
+		return plan
+	}
+
+	plan.Operation = eCtx.Operation.GetOperation()
+	if operation, ok := eCtx.Operation.(*ast.OperationDefinition); ok && operation.Name != nil {
+		plan.OperationName = operation.Name.Value
+	}
+	plan.Serial = plan.Operation == ast.OperationTypeMutation
+
+	fields := collectFields(collectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  operationType,
+		SelectionSet: eCtx.Operation.GetSelectionSet(),
+	})
+	plan.Steps = explainFields(eCtx, operationType, fields, nil, 1, false)
+	for _, step := range plan.Steps {
+		plan.Cost += step.Cost
+	}
+	return plan
+}
+
+// explainFields returns the steps resolving the given fields of parentType.
+func explainFields(eCtx *executionContext, parentType *Object, fields map[string][]*ast.Field, path []string, phase int, batched bool) []*PlanStep {
+	steps := []*PlanStep{}
+	for _, field := range orderedFields(fields) {
+		fieldAST := field.fieldASTs[0]
+		fieldName := ""
+		if fieldAST.Name != nil {
+			fieldName = fieldAST.Name.Value
+		}
+		fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
+		if fieldDef == nil {
+			continue
+		}
+		step := &PlanStep{
+			Path:         append(append([]string{}, path...), field.responseName),
+			ResponseName: field.responseName,
+			FieldName:    fieldName,
+			ParentType:   parentType.Name(),
+			ReturnType:   fieldDef.Type.String(),
+			Args:         getArgumentValues(eCtx.Schema.fieldArgs(parentType, fieldDef), fieldAST.Arguments, eCtx.VariableValues),
+			Phase:        phase,
+			Trivial:      eCtx.Schema.fieldResolver(parentType, fieldDef) == nil,
+			Batched:      batched,
+		}
+		if !step.Trivial {
+			step.Cost = 1
+		}
+		step.Children, step.Cost = explainChildren(eCtx, fieldDef.Type, field.fieldASTs, step, batched)
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// explainChildren returns the steps completing the value of the given step
+// along with the cost of the step including its children.
+func explainChildren(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, step *PlanStep, batched bool) ([]*PlanStep, int) {
+	path := step.Path
+	multiplier := 1
+	for {
+		if nonNull, ok := returnType.(*NonNull); ok {
+			returnType = nonNull.OfType
+			continue
+		}
+		if list, ok := returnType.(*List); ok {
+			returnType = list.OfType
+			path = append(append([]string{}, path...), "[]")
+			multiplier *= ExplainListSize
+			batched = true
+			continue
+		}
+		break
+	}
+
+	var runtimeTypes []*Object
+	switch ttype := returnType.(type) {
+	case *Object:
+		runtimeTypes = []*Object{ttype}
+	case *Interface:
+		runtimeTypes = eCtx.Schema.PossibleTypes(ttype)
+	case *Union:
+		runtimeTypes = eCtx.Schema.PossibleTypes(ttype)
+	default:
+		return nil, step.Cost
+	}
+
+	// a value only has one of the runtime types, so the costliest is retained.
+	children := []*PlanStep{}
+	childrenCost := 0
+	for _, runtimeType := range runtimeTypes {
+		subFieldASTs := map[string][]*ast.Field{}
+		visitedFragmentNames := map[string]bool{}
+		for _, fieldAST := range fieldASTs {
+			if fieldAST == nil || fieldAST.SelectionSet == nil {
+				continue
+			}
+			subFieldASTs = collectFields(collectFieldsParams{
+				ExeContext:           eCtx,
+				RuntimeType:          runtimeType,
+				SelectionSet:         fieldAST.SelectionSet,
+				Fields:               subFieldASTs,
+				VisitedFragmentNames: visitedFragmentNames,
+			})
+		}
+		runtimeTypeCost := 0
+		for _, child := range explainFields(eCtx, runtimeType, subFieldASTs, path, step.Phase+1, batched) {
+			children = append(children, child)
+			runtimeTypeCost += child.Cost
+		}
+		if runtimeTypeCost > childrenCost {
+			childrenCost = runtimeTypeCost
+		}
+	}
+	return children, step.Cost + multiplier*childrenCost
+}
@@ -0,0 +1,173 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestExplain(t *testing.T) {
+	calls := 0
+	resolve := func(p graphql.ResolveParams) (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+	pet := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dog := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{pet},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"barks": &graphql.Field{Type: graphql.Boolean, Resolve: resolve},
+		},
+	})
+	cat := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{pet},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	person := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Person",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"pets": &graphql.Field{Type: graphql.NewList(pet), Resolve: resolve},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"person": &graphql.Field{
+					Type: person,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: resolve,
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"first":  &graphql.Field{Type: graphql.String, Resolve: resolve},
+				"second": &graphql.Field{Type: graphql.String, Resolve: resolve},
+			},
+		}),
+		Types: []graphql.Type{dog, cat},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan := graphql.Explain(schema, graphql.Params{
+		RequestString: `
+			query Owner($id: String) {
+				person(id: $id) {
+					name
+					pets { name ... on Dog { barks } }
+				}
+			}
+		`,
+		VariableValues: map[string]interface{}{"id": "1"},
+	})
+	if len(plan.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", plan.Errors)
+	}
+	if plan.Operation != "query" || plan.OperationName != "Owner" || plan.Serial {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if len(plan.Steps) != 1 {
+		t.Fatalf("expected a single root step, got: %v", len(plan.Steps))
+	}
+	root := plan.Steps[0]
+	if root.FieldName != "person" || root.Trivial || root.Batched || root.Phase != 1 {
+		t.Fatalf("unexpected root step: %+v", root)
+	}
+	if !reflect.DeepEqual(root.Args, map[string]interface{}{"id": "1"}) {
+		t.Fatalf("unexpected args: %v", root.Args)
+	}
+	if len(root.Children) != 2 || !root.Children[0].Trivial || root.Children[1].FieldName != "pets" {
+		t.Fatalf("unexpected children: %+v", root.Children)
+	}
+	pets := root.Children[1]
+	paths := [][]string{}
+	for _, child := range pets.Children {
+		if !child.Batched || child.Phase != 3 {
+			t.Fatalf("expected the fields of the pets to be batched in phase 3: %+v", child)
+		}
+		paths = append(paths, append([]string{child.ParentType}, child.Path...))
+	}
+	expectedPaths := [][]string{
+		{"Dog", "person", "pets", "[]", "name"},
+		{"Dog", "person", "pets", "[]", "barks"},
+		{"Cat", "person", "pets", "[]", "name"},
+	}
+	if len(paths) != len(expectedPaths) {
+		t.Fatalf("unexpected pets children: %v", paths)
+	}
+	for _, expected := range expectedPaths {
+		found := false
+		for _, path := range paths {
+			if reflect.DeepEqual(path, expected) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected path %v in %v", expected, paths)
+		}
+	}
+	// person, pets, and barks for each of the pets.
+	if plan.Cost != 1+1+graphql.ExplainListSize {
+		t.Fatalf("unexpected cost: %v", plan.Cost)
+	}
+
+	plan = graphql.Explain(schema, graphql.Params{
+		RequestString: `mutation { second first }`,
+	})
+	if len(plan.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", plan.Errors)
+	}
+	if !plan.Serial || len(plan.Steps) != 2 || plan.Steps[0].FieldName != "second" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no resolver to be called, got: %v", calls)
+	}
+
+	plan = graphql.Explain(schema, graphql.Params{
+		RequestString: `{ ...Person(id: "1") } fragment Person($id: String) on Query { person(id: $id) { name } }`,
+		ParseOptions:  parser.ParseOptions{ExperimentalFragmentVariables: true},
+		VariableTransforms: []graphql.VariableTransform{{
+			Name: "count",
+			Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+				calls++
+				return p.Variables, nil
+			},
+		}},
+	})
+	if len(plan.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", plan.Errors)
+	}
+	if len(plan.Steps) != 1 || !reflect.DeepEqual(plan.Steps[0].Args, map[string]interface{}{"id": "1"}) {
+		t.Fatalf("unexpected plan: %+v", plan.Steps)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no variable transform to be called, got: %v", calls)
+	}
+
+	plan = graphql.Explain(schema, graphql.Params{
+		RequestString: `{ unknown }`,
+	})
+	if len(plan.Errors) != 1 {
+		t.Fatalf("expected a validation error, got: %v", plan.Errors)
+	}
+}
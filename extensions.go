@@ -192,14 +192,10 @@ func handleExtensionsExecutionDidStart(p *ExecuteParams) ([]gqlerrors.FormattedE
 }
 
 // handleResolveFieldDidStart handles the notification of the extensions about the start of a resolve function
-func handleExtensionsResolveFieldDidStart(exts []Extension, p *executionContext, i *ResolveInfo) ([]gqlerrors.FormattedError, resolveFieldFinishFuncHandler) {
+func handleExtensionsResolveFieldDidStart(exts []Extension, ctx context.Context, i *ResolveInfo) (context.Context, []gqlerrors.FormattedError, resolveFieldFinishFuncHandler) {
 	fs := map[string]ResolveFieldFinishFunc{}
 	errs := gqlerrors.FormattedErrors{}
-	for _, ext := range p.Schema.extensions {
-		var (
-			ctx      context.Context
-			finishFn ResolveFieldFinishFunc
-		)
+	for _, ext := range exts {
 		// catch panic from an extension's resolveFieldDidStart function
 		func() {
 			defer func() {
@@ -207,13 +203,13 @@ func handleExtensionsResolveFieldDidStart(exts []Extension, p *executionContext,
 					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ResolveFieldDidStart: %v", ext.Name(), r.(error))))
 				}
 			}()
-			ctx, finishFn = ext.ResolveFieldDidStart(p.Context, i)
+			extCtx, finishFn := ext.ResolveFieldDidStart(ctx, i)
 			// update context
-			p.Context = ctx
+			ctx = extCtx
 			fs[ext.Name()] = finishFn
 		}()
 	}
-	return errs, func(val interface{}, err error) []gqlerrors.FormattedError {
+	return ctx, errs, func(val interface{}, err error) []gqlerrors.FormattedError {
 		extErrs := gqlerrors.FormattedErrors{}
 		for name, finishFn := range fs {
 			func() {
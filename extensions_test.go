@@ -377,6 +377,56 @@ func TestExtensionGetResultPanic(t *testing.T) {
 	}
 }
 
+func TestParamsExtensions(t *testing.T) {
+	calls := []string{}
+	record := func(ext *testExt) *testExt {
+		ext.executionDidStartFn = func(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+			calls = append(calls, ext.name)
+			return ctx, func(*graphql.Result) {}
+		}
+		ext.hasResultFn = func() bool {
+			return true
+		}
+		ext.getResultFn = func(context.Context) interface{} {
+			return ext.name
+		}
+		return ext
+	}
+	schema := tinit(t)
+	schema.AddExtensions(record(newtestExt("schemaExt")))
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query Example { a }`,
+		Extensions:    []graphql.Extension{record(newtestExt("requestExt"))},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "foo",
+		},
+		Extensions: map[string]interface{}{
+			"schemaExt":  "schemaExt",
+			"requestExt": "requestExt",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if !reflect.DeepEqual(calls, []string{"schemaExt", "requestExt"}) {
+		t.Fatalf("expected the extensions of the request to follow those of the schema, got %v", calls)
+	}
+
+	// the extensions of the request do not extend the schema
+	calls = nil
+	graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query Example { a }`,
+	})
+	if !reflect.DeepEqual(calls, []string{"schemaExt"}) {
+		t.Fatalf("expected only the extensions of the schema, got %v", calls)
+	}
+}
+
 func newtestExt(name string) *testExt {
 	ext := &testExt{
 		name: name,
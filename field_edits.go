@@ -0,0 +1,177 @@
+package graphql
+
+import (
+	"sort"
+)
+
+// FieldWrapFn decorates a field of an Object or Interface, see
+// Object.WrapField. It is given a copy of the field, which it may modify and
+// return, e.g. with a Resolve function calling the previous one.
+type FieldWrapFn func(field *Field) *Field
+
+// fieldEdit is a field appended, replaced or wrapped once its type is
+// defined, applied whenever the fields of the type are defined, so that it
+// also applies to the fields given by a thunk.
+type fieldEdit struct {
+	name    string
+	field   *Field
+	replace bool
+	wrap    FieldWrapFn
+}
+
+// AppendField adds the field to the object, which has an error, see Error,
+// when it already has a field of that name.
+//
+// Unlike AddFieldConfig, it applies to the objects whose fields are given by
+// a thunk, and it fails once the object is frozen, see Schema.Freeze. The
+// schemas already built with the object are updated by Schema.Rebuild.
+func (gt *Object) AppendField(name string, field *Field) error {
+	return gt.editField(fieldEdit{name: name, field: field})
+}
+
+// ReplaceField replaces the field of the object, which has an error, see
+// Error, when it has no field of that name. See AppendField.
+func (gt *Object) ReplaceField(name string, field *Field) error {
+	return gt.editField(fieldEdit{name: name, field: field, replace: true})
+}
+
+// WrapField replaces the field of the object by the field returned by wrap,
+// e.g. to decorate its Resolve function, which is nil for the default
+// resolver, see DefaultResolveFn. The resolvers bound to the schema, see
+// Schema.BindResolvers, take precedence over the wrapped Resolve function.
+// The object has an error, see Error, when it has no field of that name. See
+// AppendField.
+func (gt *Object) WrapField(name string, wrap FieldWrapFn) error {
+	return gt.editField(fieldEdit{name: name, wrap: wrap})
+}
+
+func (gt *Object) editField(edit fieldEdit) error {
+	if err := checkFieldEdit(gt, edit, gt.frozen); err != nil {
+		return err
+	}
+	gt.fieldEdits = append(gt.fieldEdits, edit)
+	gt.initialisedFields = false
+	return nil
+}
+
+// AppendField adds the field to the interface, see Object.AppendField.
+func (it *Interface) AppendField(name string, field *Field) error {
+	return it.editField(fieldEdit{name: name, field: field})
+}
+
+// ReplaceField replaces the field of the interface, see Object.ReplaceField.
+func (it *Interface) ReplaceField(name string, field *Field) error {
+	return it.editField(fieldEdit{name: name, field: field, replace: true})
+}
+
+// WrapField replaces the field of the interface by the field returned by
+// wrap, see Object.WrapField.
+func (it *Interface) WrapField(name string, wrap FieldWrapFn) error {
+	return it.editField(fieldEdit{name: name, wrap: wrap})
+}
+
+func (it *Interface) editField(edit fieldEdit) error {
+	if err := checkFieldEdit(it, edit, it.frozen); err != nil {
+		return err
+	}
+	it.fieldEdits = append(it.fieldEdits, edit)
+	it.initialisedFields = false
+	return nil
+}
+
+// checkFieldEdit checks the edit of a field of the type, before the fields of
+// the type are defined.
+func checkFieldEdit(ttype Named, edit fieldEdit, frozen bool) error {
+	if frozen {
+		return newFrozenError(`Cannot edit the field "%v.%v", the schema is frozen.`, ttype, edit.name)
+	}
+	if err := assertValidName(edit.name); err != nil {
+		return err
+	}
+	if edit.wrap == nil {
+		return invariantf(edit.field != nil, `Cannot edit the field "%v.%v" without a field.`, ttype, edit.name)
+	}
+	return nil
+}
+
+// applyFieldEdits applies the edits to a copy of the fields of the type.
+func applyFieldEdits(ttype Named, fields Fields, edits []fieldEdit) (Fields, error) {
+	if len(edits) == 0 {
+		return fields, nil
+	}
+	edited := make(Fields, len(fields)+len(edits))
+	for name, field := range fields {
+		if field != nil {
+			edited[name] = field
+		}
+	}
+	for _, edit := range edits {
+		field, exists := edited[edit.name]
+		switch {
+		case edit.wrap != nil:
+			if err := invariantf(exists, `Cannot wrap the field "%v.%v", it does not exist.`, ttype, edit.name); err != nil {
+				return fields, err
+			}
+			copied := *field
+			wrapped := edit.wrap(&copied)
+			if err := invariantf(wrapped != nil, `The wrapper of the field "%v.%v" must return a field.`, ttype, edit.name); err != nil {
+				return fields, err
+			}
+			edited[edit.name] = wrapped
+		case edit.replace:
+			if err := invariantf(exists, `Cannot replace the field "%v.%v", it does not exist.`, ttype, edit.name); err != nil {
+				return fields, err
+			}
+			edited[edit.name] = edit.field
+		default:
+			if err := invariantf(!exists, `Cannot append the field "%v.%v", it already exists.`, ttype, edit.name); err != nil {
+				return fields, err
+			}
+			edited[edit.name] = edit.field
+		}
+	}
+	return edited, nil
+}
+
+// Rebuild updates the schema once the fields of its types are appended,
+// replaced or wrapped, see Object.AppendField: it adds the types the fields
+// refer to, checks again that the objects implement their interfaces, and
+// invalidates the caches of the schema, such as its introspection. It fails
+// when a type has an error, or when the schema is frozen, see Freeze.
+func (gq *Schema) Rebuild() error {
+	if err := gq.guard.lock("rebuild it"); err != nil {
+		return err
+	}
+	defer gq.guard.unlock()
+	gq.ensureOwnTypeMap()
+
+	names := make([]string, 0, len(gq.typeMap))
+	for name := range gq.typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var fields FieldDefinitionMap
+		switch ttype := gq.typeMap[name].(type) {
+		case *Object:
+			fields = ttype.Fields()
+		case *Interface:
+			fields = ttype.Fields()
+		}
+		if err := gq.typeMap[name].Error(); err != nil {
+			return err
+		}
+		var err error
+		for _, field := range fields {
+			for _, arg := range field.Args {
+				if gq.typeMap, err = typeMapReducer(gq, gq.typeMap, arg.Type); err != nil {
+					return err
+				}
+			}
+			if gq.typeMap, err = typeMapReducer(gq, gq.typeMap, field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return gq.addImplementation()
+}
@@ -0,0 +1,176 @@
+package graphql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func fieldEditsTestTypes() (*graphql.Interface, *graphql.Object) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.ID},
+				"name": &graphql.Field{Type: graphql.String},
+				"age":  &graphql.Field{Type: graphql.String},
+			}
+		}),
+	})
+	return node, user
+}
+
+func fieldEditsTestSchema(t *testing.T, user *graphql.Object) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"id": "1", "name": "ada", "age": 36}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestObject_EditFields(t *testing.T) {
+	_, user := fieldEditsTestTypes()
+	for _, err := range []error{
+		user.AppendField("greeting", &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return "hello " + p.Source.(map[string]interface{})["name"].(string), nil
+			},
+		}),
+		user.ReplaceField("age", &graphql.Field{Type: graphql.Int}),
+		user.WrapField("name", func(field *graphql.Field) *graphql.Field {
+			field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+				name, err := graphql.DefaultResolveFn(p)
+				return strings.ToUpper(name.(string)), err
+			}
+			return field
+		}),
+	} {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	schema := fieldEditsTestSchema(t, user)
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { name age greeting } }`})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"name": "ADA", "age": 36, "greeting": "hello ada"},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchema_Rebuild(t *testing.T) {
+	node, user := fieldEditsTestTypes()
+	schema := fieldEditsTestSchema(t, user)
+
+	// the interface requires a field the object does not have yet
+	if err := node.AppendField("createdAt", &graphql.Field{Type: graphql.DateTime}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `"Node" expects field "createdAt" but "User" does not provide it.`
+	if err := schema.Rebuild(); err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+
+	address := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Address",
+		Fields: graphql.Fields{"city": &graphql.Field{Type: graphql.String}},
+	})
+	if err := user.AppendField("createdAt", &graphql.Field{Type: graphql.DateTime}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := user.AppendField("address", &graphql.Field{Type: address}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := schema.Rebuild(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Type("Address") != address || schema.Type("DateTime") != graphql.DateTime {
+		t.Fatalf("expected the types of the appended fields")
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { address { city } } }`})
+	expectedResult := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"address": nil},
+		},
+	}
+	if !testutil.EqualResults(expectedResult, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedResult, result))
+	}
+
+	if err := schema.Freeze(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := user.AppendField("email", &graphql.Field{Type: graphql.String})
+	if expected := `Cannot edit the field "User.email", the schema is frozen.`; err == nil || err.Error() != expected || !errors.Is(err, graphql.ErrSchemaFrozen) {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+	if err := schema.Rebuild(); !errors.Is(err, graphql.ErrSchemaFrozen) {
+		t.Fatalf("expected the frozen schema not to be rebuilt, got %v", err)
+	}
+}
+
+func TestObject_EditFieldsErrors(t *testing.T) {
+	for expected, edit := range map[string]func(user *graphql.Object) error{
+		`Cannot append the field "User.name", it already exists.`: func(user *graphql.Object) error {
+			return user.AppendField("name", &graphql.Field{Type: graphql.String})
+		},
+		`Cannot replace the field "User.email", it does not exist.`: func(user *graphql.Object) error {
+			return user.ReplaceField("email", &graphql.Field{Type: graphql.String})
+		},
+		`Cannot wrap the field "User.email", it does not exist.`: func(user *graphql.Object) error {
+			return user.WrapField("email", func(field *graphql.Field) *graphql.Field { return field })
+		},
+		`The wrapper of the field "User.name" must return a field.`: func(user *graphql.Object) error {
+			return user.WrapField("name", func(field *graphql.Field) *graphql.Field { return nil })
+		},
+	} {
+		_, user := fieldEditsTestTypes()
+		if err := edit(user); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name:   "Query",
+				Fields: graphql.Fields{"user": &graphql.Field{Type: user}},
+			}),
+		})
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q, got %v", expected, err)
+		}
+	}
+
+	_, user := fieldEditsTestTypes()
+	if err := user.AppendField("email", nil); err == nil || err.Error() != `Cannot edit the field "User.email" without a field.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := user.AppendField("e-mail", &graphql.Field{Type: graphql.String}); err == nil {
+		t.Fatalf("expected an invalid name error")
+	}
+}
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// fragmentSelectionSet returns the selection set of a fragment spread to the
+// given fragment. When the fragment defines variables, see the experimental
+// parser.ParseOptions.ExperimentalFragmentVariables, they are scoped to the
+// fragment: their usages are replaced by the arguments of the spread, or by
+// their default value, leaving the operation variables of the same name out
+// of reach.
+func fragmentSelectionSet(eCtx *executionContext, fragment *ast.FragmentDefinition, spread *ast.FragmentSpread) *ast.SelectionSet {
+	if len(fragment.VariableDefinitions) == 0 {
+		return fragment.SelectionSet
+	}
+	if selectionSet, ok := eCtx.fragmentSelections[spread]; ok {
+		return selectionSet
+	}
+	scope := fragmentScope{}
+	for _, varDef := range fragment.VariableDefinitions {
+		if varDef == nil || varDef.Variable == nil || varDef.Variable.Name == nil {
+			continue
+		}
+		scope[varDef.Variable.Name.Value] = varDef.DefaultValue
+	}
+	for _, arg := range spread.Arguments {
+		if arg == nil || arg.Name == nil {
+			continue
+		}
+		if _, ok := scope[arg.Name.Value]; ok {
+			scope[arg.Name.Value] = arg.Value
+		}
+	}
+	selectionSet := scope.selectionSet(fragment.SelectionSet)
+	if eCtx.fragmentSelections == nil {
+		eCtx.fragmentSelections = map[*ast.FragmentSpread]*ast.SelectionSet{}
+	}
+	eCtx.fragmentSelections[spread] = selectionSet
+	return selectionSet
+}
+
+// fragmentScope maps the variables of a fragment to their value, nil for the
+// variables which are neither provided by the spread nor have a default value.
+type fragmentScope map[string]ast.Value
+
+func (scope fragmentScope) selectionSet(selectionSet *ast.SelectionSet) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	selections := make([]ast.Selection, 0, len(selectionSet.Selections))
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			field := *selection
+			field.Arguments = scope.arguments(selection.Arguments)
+			field.Directives = scope.directives(selection.Directives)
+			field.SelectionSet = scope.selectionSet(selection.SelectionSet)
+			selections = append(selections, &field)
+		case *ast.FragmentSpread:
+			spread := *selection
+			spread.Arguments = scope.arguments(selection.Arguments)
+			spread.Directives = scope.directives(selection.Directives)
+			selections = append(selections, &spread)
+		case *ast.InlineFragment:
+			fragment := *selection
+			fragment.Directives = scope.directives(selection.Directives)
+			fragment.SelectionSet = scope.selectionSet(selection.SelectionSet)
+			selections = append(selections, &fragment)
+		default:
+			selections = append(selections, selection)
+		}
+	}
+	return ast.NewSelectionSet(&ast.SelectionSet{
+		Loc:        selectionSet.Loc,
+		Selections: selections,
+	})
+}
+
+func (scope fragmentScope) directives(directives []*ast.Directive) []*ast.Directive {
+	if len(directives) == 0 {
+		return directives
+	}
+	result := make([]*ast.Directive, 0, len(directives))
+	for _, directive := range directives {
+		if directive == nil {
+			continue
+		}
+		d := *directive
+		d.Arguments = scope.arguments(directive.Arguments)
+		result = append(result, &d)
+	}
+	return result
+}
+
+// arguments leaves out the arguments set to an unset fragment variable, as if
+// they were not provided.
+func (scope fragmentScope) arguments(args []*ast.Argument) []*ast.Argument {
+	if len(args) == 0 {
+		return args
+	}
+	result := make([]*ast.Argument, 0, len(args))
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		value, ok := scope.value(arg.Value)
+		if !ok {
+			continue
+		}
+		a := *arg
+		a.Value = value
+		result = append(result, &a)
+	}
+	return result
+}
+
+// value returns the value with the fragment variables replaced, and false
+// when the value is an unset fragment variable.
+func (scope fragmentScope) value(value ast.Value) (ast.Value, bool) {
+	switch value := value.(type) {
+	case *ast.Variable:
+		if value == nil || value.Name == nil {
+			return value, true
+		}
+		scoped, ok := scope[value.Name.Value]
+		if !ok {
+			return value, true
+		}
+		return scoped, scoped != nil
+	case *ast.ListValue:
+		list := *value
+		list.Values = make([]ast.Value, 0, len(value.Values))
+		for _, item := range value.Values {
+			if item, ok := scope.value(item); ok {
+				list.Values = append(list.Values, item)
+			}
+		}
+		return &list, true
+	case *ast.ObjectValue:
+		object := *value
+		object.Fields = make([]*ast.ObjectField, 0, len(value.Fields))
+		for _, field := range value.Fields {
+			if field == nil {
+				continue
+			}
+			if fieldValue, ok := scope.value(field.Value); ok {
+				f := *field
+				f.Value = fieldValue
+				object.Fields = append(object.Fields, &f)
+			}
+		}
+		return &object, true
+	}
+	return value, true
+}
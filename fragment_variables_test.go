@@ -0,0 +1,143 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func fragmentVariablesTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{
+							Type:         graphql.String,
+							DefaultValue: "none",
+						},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"], nil
+					},
+				},
+				"count": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"n": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["n"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func doWithFragmentVariables(t *testing.T, query string, variables map[string]interface{}) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         fragmentVariablesTestSchema(t),
+		RequestString:  query,
+		VariableValues: variables,
+		ParseOptions:   parser.ParseOptions{ExperimentalFragmentVariables: true},
+	})
+}
+
+func TestFragmentVariables_RequireTheParseOption(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        fragmentVariablesTestSchema(t),
+		RequestString: `{ ...F(value: "a") } fragment F($value: String) on Query { echo(value: $value) }`,
+	})
+	if len(result.Errors) != 1 || gqlerrors.Code(result.Errors[0]) != gqlerrors.CodeParseFailed {
+		t.Fatalf("expected a syntax error, got: %v", result.Errors)
+	}
+}
+
+func TestFragmentVariables_AreScopedToTheFragment(t *testing.T) {
+	result := doWithFragmentVariables(t, `
+		query ($value: String) {
+			outer: echo(value: $value)
+			...F(value: "fragment")
+			...G
+		}
+		fragment F($value: String) on Query {
+			inner: echo(value: $value)
+		}
+		fragment G($value: String = "default", $unset: String) on Query {
+			withDefault: echo(value: $value)
+			unset: echo(value: $unset)
+		}
+	`, map[string]interface{}{"value": "operation"})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"outer":       "operation",
+			"inner":       "fragment",
+			"withDefault": "default",
+			"unset":       "none",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestFragmentVariables_PassOperationVariablesThroughSpreads(t *testing.T) {
+	result := doWithFragmentVariables(t, `
+		query ($n: Int!) {
+			...F(count: $n)
+		}
+		fragment F($count: Int!) on Query {
+			count(n: $count)
+			...G(value: "nested")
+		}
+		fragment G($value: String) on Query {
+			echo(value: $value) @include(if: true)
+		}
+	`, map[string]interface{}{"n": 3})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"count": 3,
+			"echo":  "nested",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestFragmentVariables_Validation(t *testing.T) {
+	result := doWithFragmentVariables(t, `
+		query ($s: String) {
+			...F(vaule: "a", count: $s)
+			...G
+		}
+		fragment F($value: String, $count: Int) on Query {
+			echo(value: $value)
+			count(n: $count)
+		}
+		fragment G($n: Int!, $unused: Int) on Query {
+			required: count(n: $n)
+		}
+	`, nil)
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$s" of type "String" used in position expecting type "Int".`, 2, 10, 3, 28),
+		testutil.RuleError(`Unknown argument "vaule" on fragment "F". Did you mean "value"?`, 3, 9),
+		testutil.RuleError(`Fragment "G" argument "n" of type "Int!" is required but not provided.`, 4, 4),
+		testutil.RuleError(`Variable "$count" of type "Int" used in position expecting type "Int!".`, 6, 30, 8, 13),
+		testutil.RuleError(`Variable "$unused" is never used in fragment "G".`, 10, 24),
+	}
+	if !testutil.EqualFormattedErrors(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
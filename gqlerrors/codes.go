@@ -0,0 +1,127 @@
+package gqlerrors
+
+// Standard error codes, set in the "code" extension of errors.
+const (
+	// CodeParseFailed is set on syntax errors of the request.
+	CodeParseFailed = "GRAPHQL_PARSE_FAILED"
+	// CodeValidationFailed is set on errors of the request against the schema.
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	// CodeBadUserInput is set on invalid variables provided by the client.
+	CodeBadUserInput = "BAD_USER_INPUT"
+	// CodeUnauthenticated is set by user code on errors of unauthenticated requests.
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	// CodeForbidden is set by user code on errors of unauthorized requests.
+	CodeForbidden = "FORBIDDEN"
+	// CodeInternalServerError is set by user code on unexpected errors.
+	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
+	// CodePersistedQueryNotFound is set when the query of a persisted query
+	// sent by its hash is unknown, for the client to send it again with its
+	// query.
+	CodePersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+)
+
+// CodedError is an error with a code, reported in the "code" extension of the
+// formatted error. It can be returned by resolvers, e.g.
+//
+//	return nil, gqlerrors.Forbidden("not allowed to read this field")
+type CodedError struct {
+	Code    string
+	Message string
+
+	// Ext holds additional extensions of the error.
+	Ext map[string]interface{}
+
+	// Err is the error that caused this error, if any.
+	Err error
+}
+
+// NewCodedError returns an error with the given code and message.
+func NewCodedError(code string, message string) *CodedError {
+	return &CodedError{Code: code, Message: message}
+}
+
+// BadUserInput returns an error with the BAD_USER_INPUT code.
+func BadUserInput(message string) *CodedError {
+	return NewCodedError(CodeBadUserInput, message)
+}
+
+// Unauthenticated returns an error with the UNAUTHENTICATED code.
+func Unauthenticated(message string) *CodedError {
+	return NewCodedError(CodeUnauthenticated, message)
+}
+
+// Forbidden returns an error with the FORBIDDEN code.
+func Forbidden(message string) *CodedError {
+	return NewCodedError(CodeForbidden, message)
+}
+
+// InternalServerError returns an error with the INTERNAL_SERVER_ERROR code.
+func InternalServerError(message string) *CodedError {
+	return NewCodedError(CodeInternalServerError, message)
+}
+
+// WrapWithCode returns an error with the given code wrapping err, using its message.
+func WrapWithCode(code string, err error) *CodedError {
+	return &CodedError{Code: code, Message: err.Error(), Err: err}
+}
+
+// Error implements the error interface.
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error that caused this error.
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// Extensions implements ExtendedError, the code is set in the "code" extension.
+func (e *CodedError) Extensions() map[string]interface{} {
+	extensions := make(map[string]interface{}, len(e.Ext)+1)
+	for k, v := range e.Ext {
+		extensions[k] = v
+	}
+	extensions["code"] = e.Code
+	return extensions
+}
+
+// Code returns the code of the given error, or an empty string if it has none.
+func Code(err error) string {
+	var extensions map[string]interface{}
+	switch err := err.(type) {
+	case FormattedError:
+		extensions = err.Extensions
+	case *FormattedError:
+		extensions = err.Extensions
+	case *Error:
+		extensions = FormatError(err).Extensions
+	case ExtendedError:
+		extensions = err.Extensions()
+	}
+	code, _ := extensions["code"].(string)
+	return code
+}
+
+// withCode returns extensions with the given code unless they already have one.
+func withCode(extensions map[string]interface{}, code string) map[string]interface{} {
+	if _, ok := extensions["code"]; ok {
+		return extensions
+	}
+	withCode := make(map[string]interface{}, len(extensions)+1)
+	for k, v := range extensions {
+		withCode[k] = v
+	}
+	withCode["code"] = code
+	return withCode
+}
+
+// WithCode returns the given errors with the given code set in their "code"
+// extension, unless they already have a code.
+func WithCode(errs []FormattedError, code string) []FormattedError {
+	coded := make([]FormattedError, len(errs))
+	for i, err := range errs {
+		err.Extensions = withCode(err.Extensions, code)
+		coded[i] = err
+	}
+	return coded
+}
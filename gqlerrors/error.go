@@ -18,6 +18,10 @@ type Error struct {
 	Locations     []location.SourceLocation
 	OriginalError error
 	Path          []interface{}
+
+	// Extensions are reported along with the extensions of OriginalError,
+	// which take precedence.
+	Extensions map[string]interface{}
 }
 
 // implements Golang's built-in `error` interface
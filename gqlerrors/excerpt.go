@@ -0,0 +1,182 @@
+package gqlerrors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// DefaultTabWidth is a common width of tab stops, see ExcerptOptions.TabWidth.
+const DefaultTabWidth = 4
+
+// ANSI escape sequences used by colored excerpts.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiDim    = "\x1b[2m"
+	ansiMarker = "\x1b[1;31m"
+)
+
+var lineSeparator = regexp.MustCompile("\r\n|[\n\r]")
+
+// ExcerptOptions configures the rendering of source excerpts, see Excerpt.
+type ExcerptOptions struct {
+	// Context is the number of lines shown before and after the line of the
+	// location, 1 when zero. No other line is shown when negative.
+	Context int
+
+	// TabWidth is the width of tab stops, tabs being expanded to spaces so the
+	// marker lines up with the source. When zero, tabs are not expanded: like
+	// the other control characters, they are escaped, e.g. `\u0009`, and the
+	// marker is placed at the column of the location, as in syntax errors.
+	TabWidth int
+
+	// Length is the number of characters marked from the location, e.g. the
+	// length of the token in error, 1 when zero.
+	Length int
+
+	// Color highlights the excerpt with ANSI escape sequences, for terminals.
+	Color bool
+}
+
+// Excerpt renders the lines of the source around the given location, with the
+// location marked under its line, e.g.
+//
+//	1: query {
+//	2:   user(id: 1) {
+//	       ^^^^
+//	3:     name
+func Excerpt(s *source.Source, l location.SourceLocation, opts ExcerptOptions) string {
+	if s == nil {
+		return ""
+	}
+	lines := lineSeparator.Split(string(s.Body), -1)
+	if l.Line < 1 || l.Line > len(lines) {
+		return ""
+	}
+	context := opts.Context
+	switch {
+	case context == 0:
+		context = 1
+	case context < 0:
+		context = 0
+	}
+	length := opts.Length
+	if length <= 0 {
+		length = 1
+	}
+
+	first := l.Line - context
+	if first < 1 {
+		first = 1
+	}
+	last := l.Line + context
+	if last > len(lines) {
+		last = len(lines)
+	}
+	padLen := len(fmt.Sprintf("%d", l.Line+context))
+
+	var b strings.Builder
+	for n := first; n <= last; n++ {
+		line, offsets := renderLine(lines[n-1], opts.TabWidth)
+		gutter := lpad(padLen, fmt.Sprintf("%d", n)) + ":"
+		if opts.Color {
+			gutter = ansiDim + gutter + ansiReset
+		}
+		b.WriteString(gutter + " " + line + "\n")
+		if n != l.Line {
+			continue
+		}
+
+		start := clamp(l.Column-1, 0, len(offsets)-1)
+		end := clamp(start+length, 0, len(offsets)-1)
+		width := 1
+		if length > 1 && offsets[end]-offsets[start] > 1 {
+			width = offsets[end] - offsets[start]
+		}
+		marker := strings.Repeat("^", width)
+		if opts.Color {
+			marker = ansiMarker + marker + ansiReset
+		}
+		b.WriteString(strings.Repeat(" ", padLen+2+offsets[start]) + marker + "\n")
+	}
+	return b.String()
+}
+
+// Excerpt renders the excerpts of the locations of the error, see Excerpt.
+// Unless a length is configured, the whole node at each location is marked.
+func (g *Error) Excerpt(opts ExcerptOptions) string {
+	if g.Source == nil {
+		return ""
+	}
+	excerpts := []string{}
+	for i, position := range g.Positions {
+		if i >= len(g.Locations) {
+			break
+		}
+		locOpts := opts
+		if locOpts.Length == 0 {
+			locOpts.Length = g.nodeLength(position)
+		}
+		excerpts = append(excerpts, Excerpt(g.Source, g.Locations[i], locOpts))
+	}
+	return strings.Join(excerpts, "\n")
+}
+
+// nodeLength returns the length of the node of the error starting at the given position, or 0.
+func (g *Error) nodeLength(position int) int {
+	for _, node := range g.Nodes {
+		if node == nil {
+			continue
+		}
+		if loc := node.GetLoc(); loc != nil && loc.Start == position {
+			return loc.End - loc.Start
+		}
+	}
+	return 0
+}
+
+// renderLine returns the printable form of the line, with tabs expanded when
+// tabWidth is positive, along with the column at which each of its characters
+// is marked: the column at which it is printed when tabs are expanded, its own
+// column otherwise.
+func renderLine(line string, tabWidth int) (string, []int) {
+	var b strings.Builder
+	offsets := make([]int, 0, len(line)+1)
+	width, column := 0, 0
+	for _, r := range line {
+		if tabWidth > 0 {
+			offsets = append(offsets, width)
+		} else {
+			offsets = append(offsets, column)
+		}
+		var printed string
+		if r == '\t' && tabWidth > 0 {
+			printed = strings.Repeat(" ", tabWidth-width%tabWidth)
+		} else {
+			printed = printCharCode(r)
+		}
+		b.WriteString(printed)
+		width += utf8.RuneCountInString(printed)
+		column++
+	}
+	if tabWidth > 0 {
+		offsets = append(offsets, width)
+	} else {
+		offsets = append(offsets, column)
+	}
+	return b.String(), offsets
+}
+
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -48,21 +48,39 @@ func FormatError(err error) FormattedError {
 				ret.Extensions = extended.Extensions()
 			}
 		}
+		if len(err.Extensions) != 0 {
+			extensions := make(map[string]interface{}, len(err.Extensions)+len(ret.Extensions))
+			for k, v := range err.Extensions {
+				extensions[k] = v
+			}
+			for k, v := range ret.Extensions {
+				extensions[k] = v
+			}
+			ret.Extensions = extensions
+		}
 		return ret
 	case Error:
 		return FormatError(&err)
 	default:
-		return FormattedError{
+		ret := FormattedError{
 			Message:       err.Error(),
 			Locations:     []location.SourceLocation{},
 			originalError: err,
 		}
+		if extended, ok := err.(ExtendedError); ok {
+			ret.Extensions = extended.Extensions()
+		}
+		return ret
 	}
 }
 
 func FormatErrors(errs ...error) []FormattedError {
 	formattedErrors := []FormattedError{}
 	for _, err := range errs {
+		if list, ok := err.(List); ok {
+			formattedErrors = append(formattedErrors, list...)
+			continue
+		}
 		formattedErrors = append(formattedErrors, FormatError(err))
 	}
 	return formattedErrors
@@ -0,0 +1,94 @@
+package gqlerrors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// List is a list of errors implementing the error interface, returned where
+// several errors can be reported at once, such as validation and the coercion
+// of variables.
+type List []FormattedError
+
+// Error implements the error interface, joining the messages of the errors.
+func (l List) Error() string {
+	messages := make([]string, len(l))
+	for i, err := range l {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Append returns the list with the given errors appended, flattening lists.
+func (l List) Append(errs ...error) List {
+	for _, err := range errs {
+		switch err := err.(type) {
+		case nil:
+		case List:
+			l = append(l, err...)
+		default:
+			l = append(l, FormatError(err))
+		}
+	}
+	return l
+}
+
+// Err returns the list as an error, or nil if it is empty.
+func (l List) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Sort returns a copy of the list sorted by the location of the errors, errors
+// without location last. The order of errors at the same location is kept.
+func (l List) Sort() List {
+	if l == nil {
+		return nil
+	}
+	sorted := append(List{}, l...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Locations, sorted[j].Locations
+		if len(a) == 0 || len(b) == 0 {
+			return len(a) != 0
+		}
+		if a[0].Line != b[0].Line {
+			return a[0].Line < b[0].Line
+		}
+		return a[0].Column < b[0].Column
+	})
+	return sorted
+}
+
+// Dedupe returns a copy of the list without the errors having the same message
+// and locations as a previous error.
+func (l List) Dedupe() List {
+	if l == nil {
+		return nil
+	}
+	seen := make(map[string]bool, len(l))
+	deduped := make(List, 0, len(l))
+	for _, err := range l {
+		key := err.Message
+		for _, loc := range err.Locations {
+			key += fmt.Sprintf("\x00%d:%d", loc.Line, loc.Column)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, err)
+	}
+	return deduped
+}
+
+// Limit returns the first n errors of the list, or the whole list if it has
+// no more than n errors or n is negative.
+func (l List) Limit(n int) List {
+	if n < 0 || len(l) <= n {
+		return l
+	}
+	return l[:n]
+}
@@ -0,0 +1,97 @@
+package gqlerrors
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaskedMessage replaces the message of internal errors when they are masked.
+const MaskedMessage = "Internal server error"
+
+// PanicError is an unexpected panic recovered while executing a request, such
+// as a nil pointer dereference in a resolver, along with the stack at which it
+// was raised. Its message is the message of the recovered error.
+type PanicError struct {
+	Err   error
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the recovered error.
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
+// IsInternal reports whether the error is an internal error, whose details
+// should not be shown to clients: a recovered panic, or an error with the
+// INTERNAL_SERVER_ERROR code.
+func IsInternal(err FormattedError) bool {
+	if Code(err) == CodeInternalServerError {
+		return true
+	}
+	var panicErr *PanicError
+	return errors.As(cause(err), &panicErr)
+}
+
+// Mask returns the given errors with the details of internal errors removed:
+// their message is replaced by MaskedMessage and only their code is kept in
+// their extensions. Other errors are returned as is.
+func Mask(errs []FormattedError) []FormattedError {
+	masked := make([]FormattedError, len(errs))
+	for i, err := range errs {
+		if IsInternal(err) {
+			err = FormattedError{
+				Message:       MaskedMessage,
+				Locations:     err.Locations,
+				Path:          err.Path,
+				Extensions:    map[string]interface{}{"code": CodeInternalServerError},
+				originalError: err.originalError,
+			}
+		}
+		masked[i] = err
+	}
+	return masked
+}
+
+// WithDebugDetails returns the given errors with their details added to their
+// extensions, for development: the source excerpt of their locations as
+// "excerpt", and the stack of recovered panics as "stacktrace".
+func WithDebugDetails(errs []FormattedError) []FormattedError {
+	detailed := make([]FormattedError, len(errs))
+	for i, err := range errs {
+		details := map[string]interface{}{}
+		if located, ok := err.originalError.(*Error); ok {
+			if excerpt := located.Excerpt(ExcerptOptions{}); excerpt != "" {
+				details["excerpt"] = excerpt
+			}
+		}
+		var panicErr *PanicError
+		if errors.As(cause(err), &panicErr) && len(panicErr.Stack) != 0 {
+			details["stacktrace"] = strings.Split(strings.TrimSpace(string(panicErr.Stack)), "\n")
+		}
+		if len(details) != 0 {
+			extensions := make(map[string]interface{}, len(err.Extensions)+len(details))
+			for k, v := range err.Extensions {
+				extensions[k] = v
+			}
+			for k, v := range details {
+				extensions[k] = v
+			}
+			err.Extensions = extensions
+		}
+		detailed[i] = err
+	}
+	return detailed
+}
+
+// cause returns the error at the origin of the formatted error.
+func cause(err FormattedError) error {
+	if located, ok := err.originalError.(*Error); ok && located.OriginalError != nil {
+		return located.OriginalError
+	}
+	return err.originalError
+}
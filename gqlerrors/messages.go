@@ -0,0 +1,291 @@
+package gqlerrors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MessageID identifies a user-facing message of the parser or the validator.
+// Identifiers are stable, so products can override the text of messages with a
+// MessageCatalog while error codes and locations stay unchanged.
+type MessageID string
+
+// Messages of the lexer and the parser.
+const (
+	// MsgSyntaxError formats syntax errors: source name, line, column, description and excerpt.
+	MsgSyntaxError MessageID = "SyntaxError"
+	// MsgUnexpectedDigitAfterZero: character.
+	MsgUnexpectedDigitAfterZero MessageID = "UnexpectedDigitAfterZero"
+	// MsgExpectedDigit: character.
+	MsgExpectedDigit MessageID = "ExpectedDigit"
+	// MsgInvalidCharacterInString: character.
+	MsgInvalidCharacterInString MessageID = "InvalidCharacterInString"
+	// MsgInvalidUnicodeEscape: escaped characters.
+	MsgInvalidUnicodeEscape MessageID = "InvalidUnicodeEscape"
+	// MsgInvalidEscape: escaped character.
+	MsgInvalidEscape MessageID = "InvalidEscape"
+	// MsgUnterminatedString has no argument.
+	MsgUnterminatedString MessageID = "UnterminatedString"
+	// MsgInvalidCharacter: character.
+	MsgInvalidCharacter MessageID = "InvalidCharacter"
+	// MsgUnexpectedCharacter: character.
+	MsgUnexpectedCharacter MessageID = "UnexpectedCharacter"
+	// MsgExpectedToken: expected token kind and found token.
+	MsgExpectedToken MessageID = "ExpectedToken"
+	// MsgExpectedKeyword: expected keyword and found token.
+	MsgExpectedKeyword MessageID = "ExpectedKeyword"
+	// MsgUnexpectedToken: token.
+	MsgUnexpectedToken MessageID = "UnexpectedToken"
+	// MsgUnexpectedEmpty: open and close token kinds.
+	MsgUnexpectedEmpty MessageID = "UnexpectedEmpty"
+)
+
+// Messages of the validator.
+const (
+	// MsgOrList joins the last item of a list of suggestions: items and last item.
+	MsgOrList MessageID = "OrList"
+	// MsgOrListSerial joins the last item of a list of more than two suggestions: items and last item.
+	MsgOrListSerial MessageID = "OrListSerial"
+	// MsgDidYouMean: message and suggestions.
+	MsgDidYouMean MessageID = "DidYouMean"
+	// MsgDidYouMeanInlineFragment: message and suggested types.
+	MsgDidYouMeanInlineFragment MessageID = "DidYouMeanInlineFragment"
+
+	// MsgBadArgValue: argument, value and details.
+	MsgBadArgValue MessageID = "BadArgValue"
+	// MsgDefaultForNonNullVariable: variable, type and nullable type.
+	MsgDefaultForNonNullVariable MessageID = "DefaultForNonNullVariable"
+	// MsgBadVariableDefault: variable, value and details.
+	MsgBadVariableDefault MessageID = "BadVariableDefault"
+	// MsgUndefinedField: field and type.
+	MsgUndefinedField MessageID = "UndefinedField"
+	// MsgInlineFragmentOnNonComposite: type.
+	MsgInlineFragmentOnNonComposite MessageID = "InlineFragmentOnNonComposite"
+	// MsgFragmentOnNonComposite: fragment and type.
+	MsgFragmentOnNonComposite MessageID = "FragmentOnNonComposite"
+	// MsgUnknownArg: argument, field and type.
+	MsgUnknownArg MessageID = "UnknownArg"
+	// MsgUnknownDirectiveArg: argument and directive.
+	MsgUnknownDirectiveArg MessageID = "UnknownDirectiveArg"
+	// MsgUnknownFragmentArg: argument and fragment.
+	MsgUnknownFragmentArg MessageID = "UnknownFragmentArg"
+	// MsgMisplacedDirective: directive and location.
+	MsgMisplacedDirective MessageID = "MisplacedDirective"
+	// MsgUnknownDirective: directive.
+	MsgUnknownDirective MessageID = "UnknownDirective"
+	// MsgUnknownDirectiveLocation: location.
+	MsgUnknownDirectiveLocation MessageID = "UnknownDirectiveLocation"
+	// MsgUnknownFragment: fragment.
+	MsgUnknownFragment MessageID = "UnknownFragment"
+	// MsgUnknownType: type.
+	MsgUnknownType MessageID = "UnknownType"
+	// MsgAnonymousOperationNotAlone has no argument.
+	MsgAnonymousOperationNotAlone MessageID = "AnonymousOperationNotAlone"
+	// MsgFragmentCycle: fragment and path, see MsgFragmentCyclePath.
+	MsgFragmentCycle MessageID = "FragmentCycle"
+	// MsgFragmentCyclePath: spread fragments.
+	MsgFragmentCyclePath MessageID = "FragmentCyclePath"
+	// MsgUndefinedVariableInOperation: variable and operation.
+	MsgUndefinedVariableInOperation MessageID = "UndefinedVariableInOperation"
+	// MsgUndefinedVariable: variable.
+	MsgUndefinedVariable MessageID = "UndefinedVariable"
+	// MsgUnusedFragment: fragment.
+	MsgUnusedFragment MessageID = "UnusedFragment"
+	// MsgUnusedVariableInOperation: variable and operation.
+	MsgUnusedVariableInOperation MessageID = "UnusedVariableInOperation"
+	// MsgUnusedVariable: variable.
+	MsgUnusedVariable MessageID = "UnusedVariable"
+	// MsgUnusedFragmentVariable: variable and fragment.
+	MsgUnusedFragmentVariable MessageID = "UnusedFragmentVariable"
+	// MsgImpossibleInlineFragment: parent type and fragment type.
+	MsgImpossibleInlineFragment MessageID = "ImpossibleInlineFragment"
+	// MsgImpossibleFragment: fragment, parent type and fragment type.
+	MsgImpossibleFragment MessageID = "ImpossibleFragment"
+	// MsgMissingFieldArg: field, argument and type.
+	MsgMissingFieldArg MessageID = "MissingFieldArg"
+	// MsgMissingDirectiveArg: directive, argument and type.
+	MsgMissingDirectiveArg MessageID = "MissingDirectiveArg"
+	// MsgMissingFragmentArg: fragment, argument and type.
+	MsgMissingFragmentArg MessageID = "MissingFragmentArg"
+	// MsgNoSubselectionAllowed: field and type.
+	MsgNoSubselectionAllowed MessageID = "NoSubselectionAllowed"
+	// MsgRequiredSubselection: field and type.
+	MsgRequiredSubselection MessageID = "RequiredSubselection"
+	// MsgDuplicateArg: argument.
+	MsgDuplicateArg MessageID = "DuplicateArg"
+	// MsgDuplicateFragment: fragment.
+	MsgDuplicateFragment MessageID = "DuplicateFragment"
+	// MsgDuplicateInputField: field.
+	MsgDuplicateInputField MessageID = "DuplicateInputField"
+	// MsgDuplicateOperation: operation.
+	MsgDuplicateOperation MessageID = "DuplicateOperation"
+	// MsgDuplicateVariable: variable.
+	MsgDuplicateVariable MessageID = "DuplicateVariable"
+	// MsgNonInputTypeOnVariable: variable and type.
+	MsgNonInputTypeOnVariable MessageID = "NonInputTypeOnVariable"
+	// MsgBadVariablePosition: variable, type and expected type.
+	MsgBadVariablePosition MessageID = "BadVariablePosition"
+	// MsgFieldsConflict: response name and reason.
+	MsgFieldsConflict MessageID = "FieldsConflict"
+	// MsgSubfieldsConflict: response name and reason, joined with MsgConflictReasonsSeparator.
+	MsgSubfieldsConflict MessageID = "SubfieldsConflict"
+	// MsgConflictReasonsSeparator has no argument.
+	MsgConflictReasonsSeparator MessageID = "ConflictReasonsSeparator"
+	// MsgDifferentFields: field names.
+	MsgDifferentFields MessageID = "DifferentFields"
+	// MsgDifferingArguments has no argument.
+	MsgDifferingArguments MessageID = "DifferingArguments"
+	// MsgConflictingTypes: types.
+	MsgConflictingTypes MessageID = "ConflictingTypes"
+	// MsgSubscriptionMultipleRootFields: subscription, see MsgNamedSubscription.
+	MsgSubscriptionMultipleRootFields MessageID = "SubscriptionMultipleRootFields"
+	// MsgSubscriptionIntrospectionRootField: subscription, see
+	// MsgNamedSubscription.
+	MsgSubscriptionIntrospectionRootField MessageID = "SubscriptionIntrospectionRootField"
+	// MsgNamedSubscription: operation.
+	MsgNamedSubscription MessageID = "NamedSubscription"
+	// MsgAnonymousSubscription has no argument.
+	MsgAnonymousSubscription MessageID = "AnonymousSubscription"
+	// MsgMaxDepthExceeded: field and maximum depth.
+	MsgMaxDepthExceeded MessageID = "MaxDepthExceeded"
+
+	// MsgExpectedNonNullType: type.
+	MsgExpectedNonNullType MessageID = "ExpectedNonNullType"
+	// MsgExpectedNonNull has no argument.
+	MsgExpectedNonNull MessageID = "ExpectedNonNull"
+	// MsgInElement: index and message.
+	MsgInElement MessageID = "InElement"
+	// MsgExpectedObject: type.
+	MsgExpectedObject MessageID = "ExpectedObject"
+	// MsgUnknownInputField: field.
+	MsgUnknownInputField MessageID = "UnknownInputField"
+	// MsgInField: field and message.
+	MsgInField MessageID = "InField"
+	// MsgExpectedType: type and value.
+	MsgExpectedType MessageID = "ExpectedType"
+)
+
+var defaultMessages = map[MessageID]string{
+	MsgSyntaxError:              "Syntax Error %v (%d:%d) %v\n\n%v",
+	MsgUnexpectedDigitAfterZero: "Invalid number, unexpected digit after 0: %v.",
+	MsgExpectedDigit:            "Invalid number, expected digit but got: %v.",
+	MsgInvalidCharacterInString: "Invalid character within String: %v.",
+	MsgInvalidUnicodeEscape:     "Invalid character escape sequence: \\u%v",
+	MsgInvalidEscape:            `Invalid character escape sequence: \\%c.`,
+	MsgUnterminatedString:       "Unterminated string.",
+	MsgInvalidCharacter:         "Invalid character %v",
+	MsgUnexpectedCharacter:      "Unexpected character %v.",
+	MsgExpectedToken:            "Expected %v, found %v",
+	MsgExpectedKeyword:          `Expected "%v", found %v`,
+	MsgUnexpectedToken:          "Unexpected %v",
+	MsgUnexpectedEmpty:          "Unexpected empty IN %v%v",
+
+	MsgOrList:                   "%v or %v",
+	MsgOrListSerial:             "%v, or %v",
+	MsgDidYouMean:               "%v Did you mean %v?",
+	MsgDidYouMeanInlineFragment: "%v Did you mean to use an inline fragment on %v?",
+
+	MsgBadArgValue:                  `Argument "%v" has invalid value %v.%v`,
+	MsgDefaultForNonNullVariable:    `Variable "$%v" of type "%v" is required and will not use the default value. Perhaps you meant to use type "%v".`,
+	MsgBadVariableDefault:           `Variable "$%v" has invalid default value: %v.%v`,
+	MsgUndefinedField:               `Cannot query field "%v" on type "%v".`,
+	MsgInlineFragmentOnNonComposite: `Fragment cannot condition on non composite type "%v".`,
+	MsgFragmentOnNonComposite:       `Fragment "%v" cannot condition on non composite type "%v".`,
+	MsgUnknownArg:                   `Unknown argument "%v" on field "%v" of type "%v".`,
+	MsgUnknownDirectiveArg:          `Unknown argument "%v" on directive "@%v".`,
+	MsgUnknownFragmentArg:           `Unknown argument "%v" on fragment "%v".`,
+	MsgMisplacedDirective:           `Directive "%v" may not be used on %v.`,
+	MsgUnknownDirective:             `Unknown directive "%v".`,
+	MsgUnknownDirectiveLocation:     `Unknown directive location "%v".`,
+	MsgUnknownFragment:              `Unknown fragment "%v".`,
+	MsgUnknownType:                  `Unknown type "%v".`,
+	MsgAnonymousOperationNotAlone:   `This anonymous operation must be the only defined operation.`,
+	MsgFragmentCycle:                `Cannot spread fragment "%v" within itself%v.`,
+	MsgFragmentCyclePath:            ` via %v`,
+	MsgUndefinedVariableInOperation: `Variable "$%v" is not defined by operation "%v".`,
+	MsgUndefinedVariable:            `Variable "$%v" is not defined.`,
+	MsgUnusedFragment:               `Fragment "%v" is never used.`,
+	MsgUnusedVariableInOperation:    `Variable "$%v" is never used in operation "%v".`,
+	MsgUnusedVariable:               `Variable "$%v" is never used.`,
+	MsgUnusedFragmentVariable:       `Variable "$%v" is never used in fragment "%v".`,
+	MsgImpossibleInlineFragment:     `Fragment cannot be spread here as objects of type "%v" can never be of type "%v".`,
+	MsgImpossibleFragment:           `Fragment "%v" cannot be spread here as objects of type "%v" can never be of type "%v".`,
+	MsgMissingFieldArg:              `Field "%v" argument "%v" of type "%v" is required but not provided.`,
+	MsgMissingDirectiveArg:          `Directive "@%v" argument "%v" of type "%v" is required but not provided.`,
+	MsgMissingFragmentArg:           `Fragment "%v" argument "%v" of type "%v" is required but not provided.`,
+	MsgNoSubselectionAllowed:        `Field "%v" of type "%v" must not have a sub selection.`,
+	MsgRequiredSubselection:         `Field "%v" of type "%v" must have a sub selection.`,
+	MsgDuplicateArg:                 `There can be only one argument named "%v".`,
+	MsgDuplicateFragment:            `There can only be one fragment named "%v".`,
+	MsgDuplicateInputField:          `There can be only one input field named "%v".`,
+	MsgDuplicateOperation:           `There can only be one operation named "%v".`,
+	MsgDuplicateVariable:            `There can only be one variable named "%v".`,
+	MsgNonInputTypeOnVariable:       `Variable "$%v" cannot be non-input type "%v".`,
+	MsgBadVariablePosition:          `Variable "$%v" of type "%v" used in position expecting type "%v".`,
+	MsgFieldsConflict:               `Fields "%v" conflict because %v. Use different aliases on the fields to fetch both if this was intentional.`,
+	MsgSubfieldsConflict:            `subfields "%v" conflict because %v`,
+	MsgConflictReasonsSeparator:     ` and `,
+	MsgDifferentFields:              `%v and %v are different fields`,
+	MsgDifferingArguments:           `they have differing arguments`,
+	MsgConflictingTypes:             `they return conflicting types %v and %v`,
+
+	MsgSubscriptionMultipleRootFields:     `%v must select only one top level field.`,
+	MsgSubscriptionIntrospectionRootField: `%v must not select an introspection top level field.`,
+	MsgNamedSubscription:                  `Subscription "%v"`,
+	MsgAnonymousSubscription:              `Anonymous Subscription`,
+	MsgMaxDepthExceeded:                   `Field "%v" exceeds the maximum depth of %v.`,
+
+	MsgExpectedNonNullType: `Expected "%v!", found null.`,
+	MsgExpectedNonNull:     `Expected non-null value, found null.`,
+	MsgInElement:           `In element #%v: %v`,
+	MsgExpectedObject:      `Expected "%v", found not an object.`,
+	MsgUnknownInputField:   `In field "%v": Unknown field.`,
+	MsgInField:             `In field "%v": %v`,
+	MsgExpectedType:        `Expected type "%v", found %v.`,
+}
+
+// MessageCatalog maps messages to the fmt templates used in place of their
+// default text. The arguments of each message are documented along with its
+// identifier, templates can use explicit argument indexes such as %[2]v to
+// reorder them.
+type MessageCatalog map[MessageID]string
+
+var (
+	catalogMu sync.RWMutex
+	catalog   MessageCatalog
+)
+
+// SetMessageCatalog overrides the text of the messages of the given catalog
+// for the whole process, passing nil restores the default messages. It is
+// meant to be called once at startup, before requests are served.
+func SetMessageCatalog(c MessageCatalog) {
+	copied := make(MessageCatalog, len(c))
+	for id, template := range c {
+		copied[id] = template
+	}
+	catalogMu.Lock()
+	catalog = copied
+	catalogMu.Unlock()
+}
+
+// DefaultMessage returns the default template of the given message.
+func DefaultMessage(id MessageID) string {
+	return defaultMessages[id]
+}
+
+// Message returns the text of the given message formatted with the given arguments.
+func Message(id MessageID, args ...interface{}) string {
+	catalogMu.RLock()
+	template, ok := catalog[id]
+	catalogMu.RUnlock()
+	if !ok {
+		template, ok = defaultMessages[id]
+	}
+	if !ok {
+		return string(id)
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
@@ -2,8 +2,10 @@ package graphql
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 )
@@ -23,39 +25,199 @@ type Params struct {
 	// defined in the requestString.
 	VariableValues map[string]interface{}
 
+	// RawVariables is the JSON encoded object of variables, as received from
+	// the client. It is decoded with DecodeVariables, preserving numbers as
+	// json.Number and explicit nulls, then coerced like VariableValues, over
+	// which it takes precedence when set.
+	RawVariables []byte
+
+	// VariablePresets are server-defined rules applied to the variables
+	// provided by the client before they are coerced, by variable name, see
+	// OperationRegistry.
+	VariablePresets map[string]VariablePreset
+
+	// VariableTransforms are applied in order to the variables provided by
+	// the client before the presets, see VariableTransform.
+	VariableTransforms []VariableTransform
+
+	// VariableLimits bound the values provided for the variables, see
+	// VariableLimits.
+	VariableLimits VariableLimits
+
+	// ResponseLimits bound the size of the response, see ResponseLimits.
+	ResponseLimits ResponseLimits
+
+	// ParseOptions are the options used to parse RequestString, such as the
+	// experimental fragment variables.
+	ParseOptions parser.ParseOptions
+
 	// The name of the operation to use if requestString contains multiple
 	// possible operations. Can be omitted if requestString contains only
 	// one operation.
 	OperationName string
 
+	// Codec is used to decode RawVariables, to encode the variables reported in
+	// errors, and to encode the results served by the handler package, defaults
+	// to StdCodec.
+	Codec Codec
+
+	// CollectStats sets Result.Stats, and CollectAllocations additionally
+	// records the allocations made during the execution, which is costlier.
+	CollectStats       bool
+	CollectAllocations bool
+
+	// SemanticNullability enables the experimental semantic nullability
+	// mode, see ExecuteParams.SemanticNullability.
+	SemanticNullability bool
+
+	// Concurrency is the maximum number of resolve functions of sibling
+	// fields called concurrently, see ExecuteParams.Concurrency.
+	Concurrency int
+
+	// ExecutionID identifies the operation in ResolveInfo.ExecutionID and in
+	// the contexts given to resolvers, see ExecutionIDFromContext. It
+	// defaults to the execution ID of the context, or to a new one.
+	ExecutionID string
+
+	// ReportExecutionID adds the execution ID to the extensions of the result
+	// and of its errors, as "executionId".
+	ReportExecutionID bool
+
+	// ReportResponseShape adds the signature of the shape of the response to
+	// its extensions, see ExecuteParams.ReportResponseShape.
+	ReportResponseShape bool
+
+	// ReportResponseSize adds the estimated size of the data of the response
+	// and the number of list items of its top level fields to its extensions,
+	// see ExecuteParams.ReportResponseSize.
+	ReportResponseSize bool
+
+	// Extensions are added to the extensions of the schema for this request
+	// only, after them, e.g. to trace, log or cache some requests without
+	// extending the schema, see Extension. Their names must differ from
+	// those of the extensions of the schema.
+	Extensions []Extension
+
+	// EnableTracing records the timing of the parsing, the validation and the
+	// resolvers of the request and adds it to the extensions of the result in
+	// the Apollo Tracing format, as "tracing", for performance debugging.
+	EnableTracing bool
+
+	// Debug includes the details of internal errors in the result, for
+	// development: their original message, the stack of recovered panics and
+	// the source excerpts of the errors, see gqlerrors.WithDebugDetails.
+	// Internal errors are masked otherwise, see gqlerrors.Mask, by Do and by
+	// Subscribe. The results of the executors taking ExecuteParams, such as
+	// Execute and ExecuteIncremental, are not masked.
+	Debug bool
+
+	// AllowTypeSystemDefinitions ignores the type system definitions of the
+	// request, such as type definitions or schema extensions, instead of
+	// failing, and reports each of them as a warning in the "warnings"
+	// extension of the result. It eases the migration of tooling sending
+	// mixed documents.
+	AllowTypeSystemDefinitions bool
+
+	// Executor executes the operation once the request is parsed and
+	// validated, defaults to DefaultExecutor.
+	Executor Executor
+
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
+	//
+	// Deprecated: pass the context to DoContext instead. Context is kept for
+	// compatibility and is only consulted by Do.
 	Context context.Context
+
+	// parsed is the document of RequestString parsed ahead of time, set by
+	// OperationRegistry.Params for the operations of a warmed up registry.
+	parsed *parsedRequest
 }
 
+// parsedRequest is a request parsed ahead of time with the given options.
+type parsedRequest struct {
+	request  string
+	options  parser.ParseOptions
+	document *ast.Document
+}
+
+// WithDocument returns the params with the document of RequestString parsed
+// ahead of time with ParseOptions, e.g. by an HTTP handler inspecting the
+// operation of the request, so that it is not parsed again.
+func (p Params) WithDocument(document *ast.Document) Params {
+	p.parsed = &parsedRequest{request: p.RequestString, options: p.ParseOptions, document: document}
+	return p
+}
+
+// Do executes the request described by the given params using p.Context.
+//
+// Do is kept for compatibility, new code should prefer DoContext.
 func Do(p Params) *Result {
+	return DoContext(p.Context, p)
+}
+
+// DoContext parses, validates and executes the request described by the given
+// params. The context is made available to extensions and resolve functions
+// and cancels the execution when done.
+func DoContext(ctx context.Context, p Params) *Result {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, p.ExecutionID = withExecutionID(ctx, p.ExecutionID)
+	p.Context = ctx
+
+	result := do(&p)
+	result = reportErrors(p.Debug, result)
+	if p.ReportExecutionID {
+		return reportExecutionID(p.ExecutionID, result)
+	}
+	return result
+}
+
+// do parses, validates and executes the request described by the params,
+// with their context.
+func do(p *Params) *Result {
+	if len(p.Extensions) != 0 || p.EnableTracing {
+		// the extensions of the request extend a copy of those of the schema
+		extensions := p.Schema.extensions
+		extensions = append(extensions[:len(extensions):len(extensions)], p.Extensions...)
+		if p.EnableTracing {
+			extensions = append(extensions, tracingExtension{})
+		}
+		p.Schema.extensions = extensions
+	}
+	if result := cachedIntrospection(p); result != nil {
+		return result
+	}
+
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
 		Name: "GraphQL request",
 	})
 
 	// run init on the extensions
-	extErrs := handleExtensionsInits(&p)
+	extErrs := handleExtensionsInits(p)
 	if len(extErrs) != 0 {
 		return &Result{
 			Errors: extErrs,
 		}
 	}
 
-	extErrs, parseFinishFn := handleExtensionsParseDidStart(&p)
+	extErrs, parseFinishFn := handleExtensionsParseDidStart(p)
 	if len(extErrs) != 0 {
 		return &Result{
 			Errors: extErrs,
 		}
 	}
 
-	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	// parse the source, unless parsed ahead of time
+	var AST *ast.Document
+	var err error
+	if p.parsed != nil && p.parsed.request == p.RequestString && p.parsed.options == p.ParseOptions {
+		AST = p.parsed.document
+	} else {
+		AST, err = parser.Parse(parser.ParseParams{Source: source, Options: p.ParseOptions})
+	}
 	if err != nil {
 		// run parseFinishFuncs for extensions
 		extErrs = parseFinishFn(err)
@@ -75,8 +237,14 @@ func Do(p Params) *Result {
 		}
 	}
 
+	// ignore the type system definitions, reporting them as warnings
+	var warnings []gqlerrors.FormattedError
+	if p.AllowTypeSystemDefinitions {
+		AST, warnings = executableDocument(source, AST)
+	}
+
 	// notify extensions about the start of the validation
-	extErrs, validationFinishFn := handleExtensionsValidationDidStart(&p)
+	extErrs, validationFinishFn := handleExtensionsValidationDidStart(p)
 	if len(extErrs) != 0 {
 		return &Result{
 			Errors: extErrs,
@@ -92,9 +260,9 @@ func Do(p Params) *Result {
 
 		// merge the errors from extensions and the original error from parser
 		extErrs = append(extErrs, validationResult.Errors...)
-		return &Result{
+		return reportWarnings(warnings, &Result{
 			Errors: extErrs,
-		}
+		})
 	}
 
 	// run the validationFinishFuncs for extensions
@@ -105,12 +273,115 @@ func Do(p Params) *Result {
 		}
 	}
 
-	return Execute(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
-	})
+	variableValues, err := p.variableValues()
+	if err != nil {
+		return &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}
+	}
+
+	executeParams := ExecuteParams{
+		Schema:             p.Schema,
+		Root:               p.RootObject,
+		AST:                AST,
+		OperationName:      p.OperationName,
+		Args:               variableValues,
+		VariablePresets:    p.VariablePresets,
+		VariableLimits:     p.VariableLimits,
+		ResponseLimits:     p.ResponseLimits,
+		Codec:              p.Codec,
+		CollectStats:       p.CollectStats,
+		CollectAllocations: p.CollectAllocations,
+		ExecutionID:        p.ExecutionID,
+
+		VariableTransforms:  p.VariableTransforms,
+		SemanticNullability: p.SemanticNullability,
+		ReportResponseShape: p.ReportResponseShape,
+		ReportResponseSize:  p.ReportResponseSize,
+		Concurrency:         p.Concurrency,
+	}
+	executor := p.Executor
+	if executor == nil {
+		executor = DefaultExecutor
+	}
+	plan, err := executor.Plan(executeParams)
+	if err != nil {
+		return &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}
+	}
+	result := executor.Execute(p.Context, plan, executeParams)
+	cacheIntrospection(p, AST, result)
+	return reportWarnings(warnings, result)
+}
+
+// executableDocument returns the document without its type system
+// definitions, along with a warning for each of them.
+func executableDocument(source *source.Source, document *ast.Document) (*ast.Document, []gqlerrors.FormattedError) {
+	var warnings []gqlerrors.FormattedError
+	definitions := make([]ast.Node, 0, len(document.Definitions))
+	for _, definition := range document.Definitions {
+		switch definition.(type) {
+		case *ast.OperationDefinition, *ast.FragmentDefinition:
+			definitions = append(definitions, definition)
+		default:
+			var positions []int
+			if loc := definition.GetLoc(); loc != nil {
+				positions = []int{loc.Start}
+			}
+			message := fmt.Sprintf("GraphQL ignored a type system definition of the request: %v", definition.GetKind())
+			warnings = append(warnings, gqlerrors.FormatError(gqlerrors.NewError(message, nil, "", source, positions, nil)))
+		}
+	}
+	if len(warnings) == 0 {
+		return document, nil
+	}
+	executable := *document
+	executable.Definitions = definitions
+	return &executable, warnings
+}
+
+// reportWarnings returns the result with the given warnings in its "warnings"
+// extension.
+func reportWarnings(warnings []gqlerrors.FormattedError, result *Result) *Result {
+	if len(warnings) == 0 {
+		return result
+	}
+	reported := *result
+	reported.Extensions = make(map[string]interface{}, len(result.Extensions)+1)
+	for key, value := range result.Extensions {
+		reported.Extensions[key] = value
+	}
+	reported.Extensions["warnings"] = warnings
+	return &reported
+}
+
+// reportErrors returns the result with its internal errors masked, or with the
+// details of its errors in debug mode.
+func reportErrors(debug bool, result *Result) *Result {
+	if len(result.Errors) == 0 {
+		return result
+	}
+	reported := *result
+	if debug {
+		reported.Errors = gqlerrors.WithDebugDetails(result.Errors)
+	} else {
+		reported.Errors = gqlerrors.Mask(result.Errors)
+	}
+	return &reported
+}
+
+// variableValues returns the variables of the request, decoding RawVariables if set.
+func (p *Params) variableValues() (map[string]interface{}, error) {
+	if p.RawVariables == nil {
+		return p.VariableValues, nil
+	}
+	if err := p.VariableLimits.checkSize(p.RawVariables); err != nil {
+		return nil, gqlerrors.BadUserInput(err.Error())
+	}
+	variableValues, err := DecodeVariables(p.Codec, p.RawVariables)
+	if err != nil {
+		return nil, gqlerrors.BadUserInput(fmt.Sprintf("Variables are invalid JSON: %v", err))
+	}
+	return variableValues, nil
 }
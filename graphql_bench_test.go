@@ -122,3 +122,89 @@ func nFieldsyItemsQueryBenchmark(x int, y int) func(b *testing.B) {
 		}
 	}
 }
+
+// Benchmark deeply nested objects, selected directly or through fragments.
+func BenchmarkDeepQuery_5_2(b *testing.B) {
+	deepQueryBenchmark(5, 2, benchutil.DeepSchemaQuery(5))(b)
+}
+
+func BenchmarkDeepQuery_10_2(b *testing.B) {
+	deepQueryBenchmark(10, 2, benchutil.DeepSchemaQuery(10))(b)
+}
+
+func BenchmarkDeepFragmentsQuery_5_2(b *testing.B) {
+	deepQueryBenchmark(5, 2, benchutil.DeepSchemaFragmentsQuery(5))(b)
+}
+
+func BenchmarkDeepFragmentsQuery_10_2(b *testing.B) {
+	deepQueryBenchmark(10, 2, benchutil.DeepSchemaFragmentsQuery(10))(b)
+}
+
+func deepQueryBenchmark(x int, y int, query string) func(b *testing.B) {
+	return func(b *testing.B) {
+		schema := benchutil.DeepSchemaWithXLevelsAndYChildren(x, y)
+
+		bench := B{
+			Query:  query,
+			Schema: schema,
+		}
+
+		for i := 0; i < b.N; i++ {
+			params := graphql.Params{
+				Schema:        schema,
+				RequestString: bench.Query,
+			}
+			benchGraphql(bench, params, b)
+		}
+	}
+}
+
+// Benchmark fragments and fields conditioned by @skip and @include, with
+// literals and variables.
+func BenchmarkDeepConditionalFragmentsQuery_10_2(b *testing.B) {
+	schema := benchutil.DeepSchemaWithXLevelsAndYChildren(10, 2)
+	bench := B{
+		Query:  benchutil.DeepSchemaConditionalFragmentsQuery(10),
+		Schema: schema,
+	}
+
+	for i := 0; i < b.N; i++ {
+		params := graphql.Params{
+			Schema:         schema,
+			RequestString:  bench.Query,
+			VariableValues: map[string]interface{}{"withName": true},
+		}
+		benchGraphql(bench, params, b)
+	}
+}
+
+// Benchmark the phases of a request separately.
+func BenchmarkDeepQueryPhases_10_2(b *testing.B) {
+	schema := benchutil.DeepSchemaWithXLevelsAndYChildren(10, 2)
+	profiler := benchutil.NewPhaseProfiler()
+	schema.AddExtensions(profiler)
+
+	bench := B{
+		Query:  benchutil.DeepSchemaFragmentsQuery(10),
+		Schema: schema,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		params := graphql.Params{
+			Schema:        schema,
+			RequestString: bench.Query,
+		}
+		benchGraphql(bench, params, b)
+	}
+	b.StopTimer()
+
+	for _, phase := range []string{benchutil.PhaseParse, benchutil.PhaseValidation, benchutil.PhaseExecution} {
+		profile := profiler.Profile(phase)
+		if profile.Count == 0 {
+			continue
+		}
+		b.ReportMetric(float64(profile.Duration.Nanoseconds())/float64(profile.Count), phase+"-ns/op")
+		b.ReportMetric(float64(profile.Mallocs)/float64(profile.Count), phase+"-allocs/op")
+	}
+}
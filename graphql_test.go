@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -194,6 +196,77 @@ func TestThreadsContextFromParamsThrough(t *testing.T) {
 
 }
 
+func TestDoContextPassesContextToResolvers(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"value": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Context.Value("a"), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	query := `{ value }`
+
+	result := graphql.DoContext(context.WithValue(context.TODO(), "a", "xyz"), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		// the explicit context takes precedence over the deprecated field
+		Context: context.WithValue(context.TODO(), "a", "ignored"),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{"value": "xyz"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
+	}
+
+	result = graphql.DoContext(nil, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestDoContextCancelled(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"value": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						<-p.Context.Done()
+						return nil, p.Context.Err()
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := graphql.DoContext(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ value }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != context.Canceled.Error() {
+		t.Fatalf("expected cancellation error, got: %v", result.Errors)
+	}
+}
+
 func TestNewErrorChecksNilNodes(t *testing.T) {
 	schema, err := graphql.NewSchema(graphql.SchemaConfig{
 		Query: graphql.NewObject(graphql.ObjectConfig{
@@ -268,3 +341,49 @@ func TestEmptyStringIsNotNull(t *testing.T) {
 		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
 	}
 }
+
+func TestDoReportsTypeSystemDefinitionsAsWarnings(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := graphql.Params{
+		Schema: schema,
+		RequestString: `{ hello }
+type Query { hello: String }`,
+	}
+	if result := graphql.Do(params); len(result.Errors) != 1 || result.Data != nil {
+		t.Fatalf("expected the request to fail by default, got: %v", result)
+	}
+
+	params.AllowTypeSystemDefinitions = true
+	result := graphql.Do(params)
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if expected := map[string]interface{}{"hello": "world"}; !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+	warnings, _ := result.Extensions["warnings"].([]gqlerrors.FormattedError)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got: %v", result.Extensions)
+	}
+	if expected := "GraphQL ignored a type system definition of the request: ObjectDefinition"; warnings[0].Message != expected {
+		t.Fatalf("expected warning %q, got %q", expected, warnings[0].Message)
+	}
+	if expected := []location.SourceLocation{{Line: 2, Column: 1}}; !reflect.DeepEqual(expected, warnings[0].Locations) {
+		t.Fatalf("expected locations %v, got %v", expected, warnings[0].Locations)
+	}
+}
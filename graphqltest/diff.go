@@ -0,0 +1,275 @@
+// Package graphqltest provides helpers for the tests of applications built
+// on graphql, such as comparing execution results.
+package graphqltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Matcher matches the actual values at the positions of an expected result
+// where it is used in place of a value, such as Any.
+type Matcher interface {
+	Match(value interface{}) bool
+	String() string
+}
+
+type matcher struct {
+	match       func(value interface{}) bool
+	description string
+}
+
+func (m *matcher) Match(value interface{}) bool { return m.match(value) }
+func (m *matcher) String() string               { return m.description }
+
+// MatchFunc returns a Matcher matching the values for which match returns
+// true, described by description in the diffs.
+func MatchFunc(description string, match func(value interface{}) bool) Matcher {
+	return &matcher{match: match, description: description}
+}
+
+// Any matches any value, including null and a missing field.
+var Any = MatchFunc("<any>", func(value interface{}) bool { return true })
+
+// NotNull matches any value but null.
+var NotNull = MatchFunc("<not null>", func(value interface{}) bool { return value != nil })
+
+// MatchRegexp returns a Matcher matching the strings matching the pattern,
+// such as generated identifiers.
+func MatchRegexp(pattern string) Matcher {
+	re := regexp.MustCompile(pattern)
+	return MatchFunc(fmt.Sprintf("<matching %q>", pattern), func(value interface{}) bool {
+		s, ok := value.(string)
+		return ok && re.MatchString(s)
+	})
+}
+
+// DiffOptions configures DiffResults.
+type DiffOptions struct {
+	// FloatTolerance is the absolute difference under which two numbers are
+	// equal, numbers being otherwise compared exactly.
+	FloatTolerance float64
+
+	// IgnoreExtensions skips the comparison of the extensions of the results.
+	IgnoreExtensions bool
+}
+
+// DiffResults compares an expected and an actual result, returning a line per
+// difference addressed by its path, such as
+//
+//     data.hero.friends[1].name: expected "Leia", got "Han"
+//
+// and no line when they are equal. The order of the fields of objects does not
+// matter, numbers of any type are equal when their values are, and Matcher
+// values of the expected data match the actual values at their positions. The
+// locations and path of an expected error are only compared when set.
+func DiffResults(expected, actual *graphql.Result, opts DiffOptions) []string {
+	if expected == nil {
+		expected = &graphql.Result{}
+	}
+	if actual == nil {
+		actual = &graphql.Result{}
+	}
+	d := &differ{opts: opts}
+	d.diff("data", normalize(expected.Data), normalize(actual.Data))
+	d.diff("errors", normalize(formatErrors(expected.Errors, nil)), normalize(formatErrors(actual.Errors, expected.Errors)))
+	if !opts.IgnoreExtensions {
+		d.diff("extensions", normalize(expected.Extensions), normalize(actual.Extensions))
+	}
+	return d.lines
+}
+
+// formatErrors returns the JSON form of the errors, without the locations and
+// path of the errors whose expected counterpart leaves them unset.
+func formatErrors(errs []gqlerrors.FormattedError, expected []gqlerrors.FormattedError) interface{} {
+	if len(errs) == 0 {
+		return nil
+	}
+	formatted := []interface{}{}
+	for i, err := range errs {
+		e := map[string]interface{}{"message": err.Message}
+		checkLocations, checkPath := true, true
+		if expected != nil && i < len(expected) {
+			checkLocations, checkPath = expected[i].Locations != nil, expected[i].Path != nil
+		}
+		if checkLocations && err.Locations != nil {
+			locations := []interface{}{}
+			for _, loc := range err.Locations {
+				locations = append(locations, map[string]interface{}{"line": loc.Line, "column": loc.Column})
+			}
+			e["locations"] = locations
+		}
+		if checkPath && err.Path != nil {
+			e["path"] = err.Path
+		}
+		if err.Extensions != nil {
+			e["extensions"] = err.Extensions
+		}
+		formatted = append(formatted, e)
+	}
+	return formatted
+}
+
+type differ struct {
+	opts  DiffOptions
+	lines []string
+}
+
+func (d *differ) report(path string, format string, args ...interface{}) {
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (d *differ) diff(path string, expected, actual interface{}) {
+	if m, ok := expected.(Matcher); ok {
+		if !m.Match(actual) {
+			d.report(path, "expected %v, got %v", m, show(actual))
+		}
+		return
+	}
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok {
+			d.report(path, "expected an object, got %v", show(actual))
+			return
+		}
+		for _, key := range sortedKeys(expected, actual) {
+			value, inExpected := expected[key]
+			actualValue, inActual := actual[key]
+			fieldPath := path + "." + key
+			switch {
+			case !inActual:
+				if m, ok := value.(Matcher); !ok || !m.Match(nil) {
+					d.report(fieldPath, "missing field, expected %v", show(value))
+				}
+			case !inExpected:
+				d.report(fieldPath, "unexpected field, got %v", show(actualValue))
+			default:
+				d.diff(fieldPath, value, actualValue)
+			}
+		}
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok {
+			d.report(path, "expected a list, got %v", show(actual))
+			return
+		}
+		if len(expected) != len(actual) {
+			d.report(path, "expected %d items, got %d", len(expected), len(actual))
+		}
+		for i := 0; i < len(expected) && i < len(actual); i++ {
+			d.diff(fmt.Sprintf("%v[%d]", path, i), expected[i], actual[i])
+		}
+	case float64:
+		actual, ok := actual.(float64)
+		if !ok || math.Abs(expected-actual) > d.opts.FloatTolerance {
+			d.report(path, "expected %v, got %v", show(expected), show(actual))
+		}
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			d.report(path, "expected %v, got %v", show(expected), show(actual))
+		}
+	}
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func show(value interface{}) string {
+	if m, ok := value.(Matcher); ok {
+		return m.String()
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+// normalize converts a value to its JSON form, maps with string keys, lists
+// and float64 numbers, keeping the matchers.
+func normalize(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if m, ok := value.(Matcher); ok {
+		return m
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+			return normalizeJSON(value)
+		}
+		return normalize(v.Elem().Interface())
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := map[string]interface{}{}
+		for _, key := range v.MapKeys() {
+			m[fmt.Sprint(key.Interface())] = normalize(v.MapIndex(key).Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return normalizeJSON(value)
+		}
+		list := []interface{}{}
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, normalize(v.Index(i).Interface()))
+		}
+		return list
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Struct:
+		return normalizeJSON(value)
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return value
+}
+
+// normalizeJSON normalizes values through their JSON encoding, such as structs.
+func normalizeJSON(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return value
+	}
+	return normalize(decoded)
+}
@@ -0,0 +1,101 @@
+package graphqltest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/graphqltest"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestDiffResults_EqualResults(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"id":      graphqltest.MatchRegexp(`^[0-9]+$`),
+				"name":    "Luke",
+				"height":  1.72,
+				"friends": []interface{}{map[string]interface{}{"name": "Han"}},
+				"ship":    graphqltest.Any,
+				"mass":    graphqltest.NotNull,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{{Message: "boom"}},
+	}
+	actual := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"mass":    77,
+				"friends": []map[string]interface{}{{"name": "Han"}},
+				"height":  1.72000001,
+				"name":    "Luke",
+				"id":      "1000",
+			},
+		},
+		Errors: []gqlerrors.FormattedError{{
+			Message:   "boom",
+			Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+			Path:      []interface{}{"hero", "ship"},
+		}},
+	}
+	if lines := graphqltest.DiffResults(expected, actual, graphqltest.DiffOptions{FloatTolerance: 1e-6}); len(lines) != 0 {
+		t.Fatalf("expected no differences, got: %v", lines)
+	}
+}
+
+func TestDiffResults_ReportsDifferencesByPath(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"id":      graphqltest.MatchRegexp(`^[0-9]+$`),
+				"name":    "Luke",
+				"height":  1.72,
+				"friends": []interface{}{map[string]interface{}{"name": "Leia"}, map[string]interface{}{"name": "Han"}},
+				"ship":    "X-Wing",
+			},
+		},
+		Errors: []gqlerrors.FormattedError{{
+			Message:   "boom",
+			Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+		}},
+		Extensions: map[string]interface{}{"cost": 2},
+	}
+	actual := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"id":      "R2",
+				"name":    "Luke",
+				"height":  1.8,
+				"friends": []interface{}{map[string]interface{}{"name": "Han"}},
+				"mass":    77,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{{
+			Message:   "bang",
+			Locations: []location.SourceLocation{{Line: 1, Column: 4}},
+		}},
+		Extensions: map[string]interface{}{"cost": 2.0},
+	}
+	expectedLines := []string{
+		`data.hero.friends: expected 2 items, got 1`,
+		`data.hero.friends[0].name: expected "Leia", got "Han"`,
+		`data.hero.height: expected 1.72, got 1.8`,
+		`data.hero.id: expected <matching "^[0-9]+$">, got "R2"`,
+		`data.hero.mass: unexpected field, got 77`,
+		`data.hero.ship: missing field, expected "X-Wing"`,
+		`errors[0].locations[0].column: expected 3, got 4`,
+		`errors[0].message: expected "boom", got "bang"`,
+	}
+	lines := graphqltest.DiffResults(expected, actual, graphqltest.DiffOptions{})
+	if !reflect.DeepEqual(expectedLines, lines) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedLines, lines))
+	}
+
+	lines = graphqltest.DiffResults(&graphql.Result{Extensions: map[string]interface{}{"cost": 1}}, actual, graphqltest.DiffOptions{IgnoreExtensions: true})
+	if len(lines) != 2 || lines[0] != "data: expected null, got {\"hero\":{\"friends\":[{\"name\":\"Han\"}],\"height\":1.8,\"id\":\"R2\",\"mass\":77,\"name\":\"Luke\"}}" {
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+}
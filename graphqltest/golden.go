@@ -0,0 +1,169 @@
+package graphqltest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+// update is the -update flag of the tests rewriting the golden files instead
+// of comparing them, as in
+//
+//     go test ./... -update
+var update = flag.Bool("update", false, "update the golden files of graphqltest")
+
+// AssertSchemaGolden compares the canonical form of the schema, printed by
+// graphql.PrintSchema, to the golden file at path, failing the test with a
+// unified diff of the changes. With the -update flag, the golden file is
+// written instead, after reviewing the schema changes.
+func AssertSchemaGolden(t testing.TB, schema *graphql.Schema, path string) {
+	t.Helper()
+	AssertGolden(t, graphql.PrintSchema(schema), path)
+}
+
+// AssertGolden compares the actual content to the golden file at path,
+// failing the test with a unified diff of the changes. With the -update flag,
+// the golden file is written instead.
+func AssertGolden(t testing.TB, actual string, path string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to create the golden file", err)
+	}
+	if string(expected) != actual {
+		t.Fatalf("%v differs from the golden file, run the tests with -update to update it:\n%v",
+			path, UnifiedDiff(path, path+" (actual)", string(expected), actual))
+	}
+}
+
+// diffContext is the number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// UnifiedDiff returns the unified diff of two texts, empty when they are equal.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+	a, b := splitLines(from), splitLines(to)
+	edits := diffLines(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %v\n+++ %v\n", fromName, toName)
+	for start := 0; start < len(edits); {
+		// skip to the next change
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		// extend the hunk over the changes separated by less than twice the context
+		first := start - diffContext
+		if first < 0 {
+			first = 0
+		}
+		end, unchanged := start, 0
+		for end < len(edits) && unchanged <= 2*diffContext {
+			if edits[end].op == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+			end++
+		}
+		end -= unchanged
+		last := end + diffContext
+		if last > len(edits) {
+			last = len(edits)
+		}
+		hunk := edits[first:last]
+		fromLine, toLine := hunk[0].fromLine, hunk[0].toLine
+		fromCount, toCount := 0, 0
+		for _, e := range hunk {
+			if e.op != '+' {
+				fromCount++
+			}
+			if e.op != '-' {
+				toCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", fromLine+1, fromCount, toLine+1, toCount)
+		for _, e := range hunk {
+			sb.WriteByte(e.op)
+			sb.WriteString(e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = last
+	}
+	return sb.String()
+}
+
+// splitLines splits a text after its line feeds.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+type edit struct {
+	op       byte
+	line     string
+	fromLine int
+	toLine   int
+}
+
+// diffLines returns the edits turning a into b, from their longest common
+// subsequence.
+func diffLines(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			edits = append(edits, edit{'+', b[j], i, j})
+			j++
+		default:
+			edits = append(edits, edit{'-', a[i], i, j})
+			i++
+		}
+	}
+	return edits
+}
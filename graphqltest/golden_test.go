@@ -0,0 +1,39 @@
+package graphqltest_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/graphqltest"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestAssertSchemaGolden(t *testing.T) {
+	graphqltest.AssertSchemaGolden(t, &testutil.StarWarsSchema, "testdata/starwars.graphql")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm"
+	expected := `--- schema.graphql
++++ schema.graphql (actual)
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+\ No newline at end of file
+`
+	if diff := graphqltest.UnifiedDiff("schema.graphql", "schema.graphql (actual)", from, to); diff != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diff))
+	}
+	if diff := graphqltest.UnifiedDiff("a", "b", from, from); diff != "" {
+		t.Fatalf("expected no diff, got: %v", diff)
+	}
+}
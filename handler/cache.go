@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CacheHint is the cache policy of a field or of the fields of a type, set
+// in their extensions as "cacheControl", e.g.
+//
+//	&graphql.Field{
+//		Type:       graphql.NewList(postType),
+//		Extensions: map[string]interface{}{"cacheControl": handler.CacheHint{MaxAge: time.Minute}},
+//	}
+//
+// The hint of a field takes precedence over the hint of its type. The fields
+// without hint returning leaf types inherit the hint of their parent, the
+// other fields and the root fields are not cached.
+type CacheHint struct {
+	MaxAge time.Duration
+
+	// Private restricts the caching to the clients, e.g. for the data of the
+	// user of the request.
+	Private bool
+}
+
+// HeaderValue returns the value of the Cache-Control header of the hint.
+func (hint CacheHint) HeaderValue() string {
+	scope := "public"
+	if hint.Private {
+		scope = "private"
+	}
+	return scope + ", max-age=" + strconv.Itoa(int(hint.MaxAge/time.Second))
+}
+
+// OperationCacheHint returns the cache hint of the operation of the document,
+// the shortest MaxAge of its fields, private if any of them is.
+func OperationCacheHint(schema *graphql.Schema, doc *ast.Document, operation *ast.OperationDefinition) CacheHint {
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = schema.SubscriptionType()
+	}
+	if root == nil {
+		return CacheHint{}
+	}
+	c := &cachePolicy{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		visited:   map[string]bool{},
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	c.selectionSet(root, operation.SelectionSet, nil)
+	if c.hint == nil {
+		return CacheHint{}
+	}
+	return *c.hint
+}
+
+// cachePolicy restricts its hint with the hints of the selected fields.
+type cachePolicy struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	visited   map[string]bool
+	hint      *CacheHint
+}
+
+func (c *cachePolicy) restrict(hint CacheHint) {
+	if c.hint == nil {
+		c.hint = &hint
+		return
+	}
+	if hint.MaxAge < c.hint.MaxAge {
+		c.hint.MaxAge = hint.MaxAge
+	}
+	c.hint.Private = c.hint.Private || hint.Private
+}
+
+// selectionSet restricts the policy with the fields of the selection set of
+// the given type, whose parent field has the given hint, nil for the root.
+func (c *cachePolicy) selectionSet(parentType graphql.Named, selectionSet *ast.SelectionSet, parentHint *CacheHint) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c.field(parentType, selection, parentHint)
+		case *ast.InlineFragment:
+			c.selectionSet(c.typeCondition(parentType, selection.TypeCondition), selection.SelectionSet, parentHint)
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[selection.Name.Value]
+			if !ok || c.visited[selection.Name.Value] {
+				continue
+			}
+			c.visited[selection.Name.Value] = true
+			c.selectionSet(c.typeCondition(parentType, fragment.TypeCondition), fragment.SelectionSet, parentHint)
+			c.visited[selection.Name.Value] = false
+		}
+	}
+}
+
+func (c *cachePolicy) field(parentType graphql.Named, field *ast.Field, parentHint *CacheHint) {
+	if field.Name.Value == "__typename" {
+		return
+	}
+	var fieldDef *graphql.FieldDefinition
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		fieldDef = parentType.Fields()[field.Name.Value]
+	case *graphql.Interface:
+		fieldDef = parentType.Fields()[field.Name.Value]
+	}
+	if fieldDef == nil {
+		return
+	}
+	hint, ok := fieldDef.Extensions["cacheControl"].(CacheHint)
+	if !ok {
+		hint, ok = graphql.TypeExtensions(fieldDef.Type)["cacheControl"].(CacheHint)
+	}
+	_, isLeaf := graphql.GetNamed(fieldDef.Type).(graphql.Leaf)
+	switch {
+	case ok:
+	case isLeaf && parentHint != nil:
+		hint = *parentHint
+	default:
+		hint = CacheHint{}
+	}
+	c.restrict(hint)
+	c.selectionSet(graphql.GetNamed(fieldDef.Type), field.SelectionSet, &hint)
+}
+
+// typeCondition returns the type of a type condition, parentType when there
+// is none.
+func (c *cachePolicy) typeCondition(parentType graphql.Named, condition *ast.Named) graphql.Named {
+	if condition == nil || condition.Name == nil {
+		return parentType
+	}
+	if ttype := c.schema.Type(condition.Name.Value); ttype != nil {
+		return ttype
+	}
+	return parentType
+}
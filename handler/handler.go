@@ -0,0 +1,242 @@
+// Package handler serves a GraphQL schema over HTTP, with POST requests and
+// with GET requests which can be cached by CDNs: the automatic persisted
+// queries let the clients send the hash of a query instead of its text, and
+// the responses of the queries are sent with Cache-Control and ETag headers
+// derived from the cache hints of the schema, see CacheHint.
+//
+//	http.Handle("/graphql", handler.New(handler.Config{Schema: &schema}))
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Config configures a handler.
+type Config struct {
+	Schema *graphql.Schema
+
+	// PersistedQueries holds the operations persisted ahead of time under
+	// the hash of their query, if any.
+	PersistedQueries *graphql.OperationRegistry
+
+	// MaxPersistedQueries is the number of queries sent by the clients along
+	// with their hash kept for the automatic persisted queries, the least
+	// recently used ones being evicted, defaults to
+	// DefaultMaxPersistedQueries. A query is kept once parsed and validated.
+	MaxPersistedQueries int
+
+	// Params returns the params of the request given, e.g. to set its root
+	// object or its executor, defaults to the params given.
+	Params func(r *http.Request, p graphql.Params) graphql.Params
+}
+
+// Handler serves the requests of a schema, see New.
+type Handler struct {
+	config  Config
+	queries *queryCache
+}
+
+// New returns a handler serving the schema of the config.
+func New(config Config) *Handler {
+	if config.PersistedQueries == nil {
+		config.PersistedQueries = graphql.NewOperationRegistry()
+	}
+	if config.MaxPersistedQueries <= 0 {
+		config.MaxPersistedQueries = DefaultMaxPersistedQueries
+	}
+	return &Handler{config: config, queries: newQueryCache(config.MaxPersistedQueries)}
+}
+
+// request is the body of a POST request, or the query parameters of a GET
+// request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    struct {
+		PersistedQuery *persistedQuery `json:"persistedQuery"`
+	} `json:"extensions"`
+}
+
+type persistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		for name, target := range map[string]interface{}{
+			"variables":  &req.Variables,
+			"extensions": &req.Extensions,
+		} {
+			if value := query.Get(name); value != "" {
+				if err := json.Unmarshal([]byte(value), target); err != nil {
+					writeError(w, http.StatusBadRequest, gqlerrors.BadUserInput(fmt.Sprintf("Invalid %v: %v", name, err)))
+					return
+				}
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, gqlerrors.BadUserInput(fmt.Sprintf("Invalid request body: %v", err)))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("Unsupported method %v.", r.Method))
+		return
+	}
+
+	hash, err := h.persistedQuery(&req)
+	if err != nil {
+		writeError(w, http.StatusOK, err)
+		return
+	}
+
+	p := graphql.Params{
+		Schema:         *h.config.Schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+	}
+	if h.config.Params != nil {
+		p = h.config.Params(r, p)
+	}
+	doc, operation := parseOperation(p)
+	if doc != nil {
+		p = p.WithDocument(doc)
+		// the query sent along with its hash is persisted once valid
+		if hash != "" && p.RequestString == req.Query && graphql.ValidateDocument(&p.Schema, doc, nil).IsValid {
+			h.queries.add(hash, req.Query)
+		}
+	}
+	if r.Method == http.MethodGet && operation != nil && operation.Operation != ast.OperationTypeQuery {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("Only queries can be sent with GET requests."))
+		return
+	}
+
+	result := graphql.DoContext(contextOf(r), p)
+	body, err := graphql.EncodeResult(p.Codec, result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet && operation != nil && !result.HasErrors() {
+		if hint := OperationCacheHint(h.config.Schema, doc, operation); hint.MaxAge > 0 {
+			w.Header().Set("Cache-Control", hint.HeaderValue())
+		}
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		w.Header().Set("ETag", etag)
+		if matchesETag(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.Write(body)
+}
+
+// persistedQuery sets the query of an automatic persisted query sent by its
+// hash. It returns the hash of the query sent along with its hash when not
+// persisted yet, to be persisted once the query is validated.
+func (h *Handler) persistedQuery(req *request) (string, error) {
+	persisted := req.Extensions.PersistedQuery
+	if persisted == nil {
+		return "", nil
+	}
+	if persisted.Version != 1 {
+		return "", gqlerrors.BadUserInput("Unsupported persisted query version.")
+	}
+	hash := strings.ToLower(persisted.Sha256Hash)
+	if req.Query == "" {
+		if operation, ok := h.config.PersistedQueries.Operation(hash); ok {
+			req.Query = operation.Query
+			return "", nil
+		}
+		query, ok := h.queries.get(hash)
+		if !ok {
+			return "", gqlerrors.NewCodedError(gqlerrors.CodePersistedQueryNotFound, "PersistedQueryNotFound")
+		}
+		req.Query = query
+		return "", nil
+	}
+	sum := sha256.Sum256([]byte(req.Query))
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", gqlerrors.BadUserInput("The provided sha256Hash does not match the query.")
+	}
+	if _, ok := h.config.PersistedQueries.Operation(hash); ok {
+		return "", nil
+	}
+	return hash, nil
+}
+
+// parseOperation returns the document of the request parsed with the options
+// of the params, nil when it is invalid, in which case its errors are
+// reported by the execution, and its operation, nil when ambiguous.
+func parseOperation(p graphql.Params) (*ast.Document, *ast.OperationDefinition) {
+	doc, err := parser.Parse(parser.ParseParams{Source: p.RequestString, Options: p.ParseOptions})
+	if err != nil {
+		return nil, nil
+	}
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		definition, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if p.OperationName == "" && operation != nil {
+			return doc, nil
+		}
+		if p.OperationName == "" || definition.Name != nil && definition.Name.Value == p.OperationName {
+			operation = definition
+		}
+	}
+	return doc, operation
+}
+
+// matchesETag reports whether the If-None-Match header matches the ETag.
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError writes a response with the error, formatted like the errors of
+// the results.
+func writeError(w http.ResponseWriter, status int, err error) {
+	body, _ := json.Marshal(&graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// contextOf returns the context of the request, with its correlation headers.
+func contextOf(r *http.Request) context.Context {
+	return graphql.WithCorrelationHeaders(r.Context(), r.Header)
+}
@@ -0,0 +1,247 @@
+package handler_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+)
+
+// newHandler returns a handler serving the test schema with the given config.
+func newHandler(t *testing.T, config handler.Config) *handler.Handler {
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+		},
+		Extensions: map[string]interface{}{"cacheControl": handler.CacheHint{MaxAge: 5 * time.Minute}},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"posts": &graphql.Field{
+					Type: graphql.NewList(postType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{map[string]interface{}{"title": "Hello"}}, nil
+					},
+					Extensions: map[string]interface{}{"cacheControl": handler.CacheHint{MaxAge: time.Minute}},
+				},
+				"me": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "Jane"}, nil
+					},
+					Extensions: map[string]interface{}{"cacheControl": handler.CacheHint{MaxAge: 30 * time.Second, Private: true}},
+				},
+				"now": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "now", nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"like": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Schema = &schema
+	return handler.New(config)
+}
+
+func get(h http.Handler, params url.Values, header http.Header) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/graphql?"+params.Encode(), nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	h.ServeHTTP(recorder, r)
+	return recorder
+}
+
+func persistedQueryExtensions(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return `{"persistedQuery":{"version":1,"sha256Hash":"` + hex.EncodeToString(sum[:]) + `"}}`
+}
+
+func decodeResult(t *testing.T, recorder *httptest.ResponseRecorder) *graphql.Result {
+	var result graphql.Result
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response %q: %v", recorder.Body.String(), err)
+	}
+	return &result
+}
+
+func TestHandler_AutomaticPersistedQueries(t *testing.T) {
+	h := newHandler(t, handler.Config{})
+	query := `{ posts { title } }`
+	extensions := persistedQueryExtensions(query)
+
+	recorder := get(h, url.Values{"extensions": {extensions}}, nil)
+	result := decodeResult(t, recorder)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "PersistedQueryNotFound" ||
+		result.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("expected the persisted query not to be found, got: %v", recorder.Body.String())
+	}
+
+	recorder = get(h, url.Values{"query": {query}, "extensions": {extensions}}, nil)
+	if body := recorder.Body.String(); body != `{"data":{"posts":[{"title":"Hello"}]}}` {
+		t.Fatalf("unexpected response: %v", body)
+	}
+
+	recorder = get(h, url.Values{"extensions": {extensions}}, nil)
+	if body := recorder.Body.String(); body != `{"data":{"posts":[{"title":"Hello"}]}}` {
+		t.Fatalf("expected the persisted query to be executed, got: %v", body)
+	}
+
+	recorder = get(h, url.Values{"query": {`{ now }`}, "extensions": {extensions}}, nil)
+	result = decodeResult(t, recorder)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "The provided sha256Hash does not match the query." {
+		t.Fatalf("expected the hash to be checked, got: %v", recorder.Body.String())
+	}
+}
+
+func TestHandler_PersistsOnlyValidQueries(t *testing.T) {
+	h := newHandler(t, handler.Config{})
+	query := `{ unknown }`
+	extensions := persistedQueryExtensions(query)
+
+	recorder := get(h, url.Values{"query": {query}, "extensions": {extensions}}, nil)
+	if result := decodeResult(t, recorder); len(result.Errors) != 1 {
+		t.Fatalf("expected a validation error, got: %v", recorder.Body.String())
+	}
+	recorder = get(h, url.Values{"extensions": {extensions}}, nil)
+	if result := decodeResult(t, recorder); len(result.Errors) != 1 || result.Errors[0].Message != "PersistedQueryNotFound" {
+		t.Fatalf("expected the invalid query not to be persisted, got: %v", recorder.Body.String())
+	}
+}
+
+func TestHandler_EvictsLeastRecentlyUsedPersistedQueries(t *testing.T) {
+	h := newHandler(t, handler.Config{MaxPersistedQueries: 2})
+	queries := []string{`{ posts { title } }`, `{ now }`, `{ me { name } }`}
+	for _, query := range queries[:2] {
+		get(h, url.Values{"query": {query}, "extensions": {persistedQueryExtensions(query)}}, nil)
+	}
+	// the first query is used again, the second one is the least recently used
+	get(h, url.Values{"extensions": {persistedQueryExtensions(queries[0])}}, nil)
+	get(h, url.Values{"query": {queries[2]}, "extensions": {persistedQueryExtensions(queries[2])}}, nil)
+
+	for i, expected := range []bool{true, false, true} {
+		recorder := get(h, url.Values{"extensions": {persistedQueryExtensions(queries[i])}}, nil)
+		if persisted := len(decodeResult(t, recorder).Errors) == 0; persisted != expected {
+			t.Fatalf("expected the query %q to be persisted: %v, got: %v", queries[i], expected, recorder.Body.String())
+		}
+	}
+}
+
+func TestHandler_ParsesWithTheOptionsOfTheParams(t *testing.T) {
+	h := newHandler(t, handler.Config{
+		Params: func(r *http.Request, p graphql.Params) graphql.Params {
+			p.ParseOptions.ExperimentalFragmentVariables = true
+			return p
+		},
+	})
+	query := `{ ...Feed(show: true) } fragment Feed($show: Boolean!) on Query { posts @include(if: $show) { title } }`
+	recorder := get(h, url.Values{"query": {query}}, nil)
+	if body := recorder.Body.String(); body != `{"data":{"posts":[{"title":"Hello"}]}}` {
+		t.Fatalf("unexpected response: %v", body)
+	}
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "public, max-age=60" {
+		t.Fatalf("unexpected Cache-Control: %q", cacheControl)
+	}
+}
+
+func TestHandler_CacheHeaders(t *testing.T) {
+	h := newHandler(t, handler.Config{})
+
+	recorder := get(h, url.Values{"query": {`{ posts { title } }`}}, nil)
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "public, max-age=60" {
+		t.Fatalf("unexpected Cache-Control: %q", cacheControl)
+	}
+	etag := recorder.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("expected an ETag")
+	}
+	recorder = get(h, url.Values{"query": {`{ posts { title } }`}}, http.Header{"If-None-Match": {"W/" + etag}})
+	if recorder.Code != http.StatusNotModified || recorder.Body.Len() != 0 {
+		t.Fatalf("expected the response not to be modified, got: %v %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = get(h, url.Values{"query": {`query Feed { posts { title } ...Me } fragment Me on Query { me { name } }`}}, nil)
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "private, max-age=30" {
+		t.Fatalf("unexpected Cache-Control: %q", cacheControl)
+	}
+
+	recorder = get(h, url.Values{"query": {`{ posts { title } now }`}}, nil)
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "" {
+		t.Fatalf("expected the fields without hint not to be cached, got: %q", cacheControl)
+	}
+}
+
+func TestHandler_Methods(t *testing.T) {
+	h := newHandler(t, handler.Config{})
+
+	recorder := get(h, url.Values{"query": {`mutation { like }`}}, nil)
+	if recorder.Code != http.StatusMethodNotAllowed || recorder.Header().Get("Allow") != "POST" {
+		t.Fatalf("expected mutations to be rejected with GET, got: %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "mutation { like }"}`)))
+	if body := recorder.Body.String(); recorder.Code != http.StatusOK || body != `{"data":{"like":null}}` {
+		t.Fatalf("unexpected response: %v %v", recorder.Code, body)
+	}
+	if recorder.Header().Get("Cache-Control") != "" || recorder.Header().Get("ETag") != "" {
+		t.Fatalf("expected POST responses not to be cached")
+	}
+
+	recorder = get(h, url.Values{"query": {`{ now }`}, "variables": {`{`}}, nil)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected invalid variables to be rejected, got: %v", recorder.Code)
+	}
+}
+
+type upperCodec struct{}
+
+func (upperCodec) Marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	return []byte(strings.ToUpper(string(b))), err
+}
+
+func (upperCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func TestHandler_EncodesResultsWithTheCodecOfTheParams(t *testing.T) {
+	h := newHandler(t, handler.Config{
+		Params: func(r *http.Request, p graphql.Params) graphql.Params {
+			p.Codec = upperCodec{}
+			return p
+		},
+	})
+	recorder := get(h, url.Values{"query": {`{ now }`}}, nil)
+	if body := recorder.Body.String(); body != `{"DATA":{"NOW":"NOW"}}` {
+		t.Fatalf("unexpected response: %v", body)
+	}
+}
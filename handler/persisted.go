@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultMaxPersistedQueries is the number of automatic persisted queries
+// kept by default, see Config.MaxPersistedQueries.
+const DefaultMaxPersistedQueries = 1000
+
+// queryCache holds the automatic persisted queries by hash, evicting the
+// least recently used ones beyond its capacity. It is safe for concurrent
+// use.
+type queryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type queryCacheEntry struct {
+	hash  string
+	query string
+}
+
+func newQueryCache(capacity int) *queryCache {
+	return &queryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// get returns the query persisted with the hash, marking it as recently used.
+func (c *queryCache) get(hash string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[hash]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*queryCacheEntry).query, true
+}
+
+// add persists the query with its hash, evicting the least recently used
+// query when the cache is full.
+func (c *queryCache) add(hash string, query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[hash]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[hash] = c.order.PushFront(&queryCacheEntry{hash: hash, query: query})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queryCacheEntry).hash)
+	}
+}
@@ -0,0 +1,246 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// InitialIncrementalResult is the first payload of a response delivered
+// incrementally with @defer and @stream. HasNext is set when subsequent
+// payloads follow.
+type InitialIncrementalResult struct {
+	Data       interface{}                `json:"data"`
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Extensions map[string]interface{}     `json:"extensions,omitempty"`
+	HasNext    bool                       `json:"hasNext"`
+}
+
+// SubsequentIncrementalResult is a payload following an InitialIncrementalResult,
+// HasNext is unset on the last payload of the response.
+type SubsequentIncrementalResult struct {
+	Incremental []*IncrementalResult   `json:"incremental,omitempty"`
+	Extensions  map[string]interface{} `json:"extensions,omitempty"`
+	HasNext     bool                   `json:"hasNext"`
+}
+
+// IncrementalResult is the result of a deferred fragment, in which case Data
+// is merged into the object at Path, or of streamed list items, in which case
+// Items are appended to the list at Path whose last element is the index of
+// the first item.
+type IncrementalResult struct {
+	Data       interface{}                `json:"data,omitempty"`
+	Items      []interface{}              `json:"items,omitempty"`
+	Path       []interface{}              `json:"path"`
+	Label      string                     `json:"label,omitempty"`
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Extensions map[string]interface{}     `json:"extensions,omitempty"`
+}
+
+// IsStream reports whether the result holds streamed list items rather than
+// the data of a deferred fragment.
+func (r *IncrementalResult) IsStream() bool {
+	return r.Items != nil
+}
+
+// ExecuteIncremental executes the given document using p.Context, delivering
+// the fragments deferred with @defer incrementally, see
+// ExecuteIncrementalContext.
+func ExecuteIncremental(p ExecuteParams) (*InitialIncrementalResult, <-chan *SubsequentIncrementalResult) {
+	return ExecuteIncrementalContext(p.Context, p)
+}
+
+// ExecuteIncrementalContext executes the given document like ExecuteContext,
+// except that the fragments deferred with @defer, see DeferDirective, are
+// left out of the initial payload returned once the rest of the operation is
+// executed. They are then executed in order, each delivered in a subsequent
+// payload on the channel, which is closed after the payload without HasNext,
+// or right away when nothing was deferred.
+//
+// The fragments deferred within a field nulled because of an error are not
+// delivered, and a deferred fragment whose Non-Null field errors is
+// delivered with its errors and without data. Cancelling the context stops
+// the delivery and closes the channel.
+//
+// Like those of ExecuteContext, the errors of the payloads are not masked,
+// see gqlerrors.Mask to mask their internal errors.
+func ExecuteIncrementalContext(ctx context.Context, p ExecuteParams) (*InitialIncrementalResult, <-chan *SubsequentIncrementalResult) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	queue := &deferQueue{}
+	p.deferred = queue
+	// the deferred fragments are collected while executing
+	p.plan = nil
+
+	result := ExecuteContext(ctx, p)
+	initial := &InitialIncrementalResult{
+		Data:       result.Data,
+		Errors:     result.Errors,
+		Extensions: result.Extensions,
+	}
+	if ctx.Err() != nil || queue.eCtx == nil || result.Data == nil || len(queue.records) == 0 {
+		subsequent := make(chan *SubsequentIncrementalResult)
+		close(subsequent)
+		return initial, subsequent
+	}
+	initial.HasNext = true
+
+	subsequent := make(chan *SubsequentIncrementalResult)
+	go func() {
+		defer close(subsequent)
+		for len(queue.records) > 0 && ctx.Err() == nil {
+			record := queue.records[0]
+			queue.records = queue.records[1:]
+			incremental := queue.execute(record)
+			payload := &SubsequentIncrementalResult{
+				Incremental: []*IncrementalResult{incremental},
+				HasNext:     len(queue.records) > 0,
+			}
+			select {
+			case subsequent <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return initial, subsequent
+}
+
+// deferredRecord is a fragment deferred with @defer, pending its execution on
+// an object of the response.
+type deferredRecord struct {
+	label        string
+	selectionSet *ast.SelectionSet
+	fragments    []ast.Node
+
+	parentType *Object
+	source     interface{}
+	path       *ResponsePath
+}
+
+// deferQueue holds the deferred fragments of an incremental execution: the
+// fragments collected from the selection sets of the object being completed,
+// then the records pending their execution, in order.
+type deferQueue struct {
+	eCtx      *executionContext
+	collected []*deferredRecord
+	records   []*deferredRecord
+}
+
+// deferLabel returns the label of the fragment and whether it is deferred
+// with @defer, which is only the case for incremental executions.
+func deferLabel(eCtx *executionContext, directives []*ast.Directive) (string, bool) {
+	if eCtx == nil || eCtx.deferred == nil {
+		return "", false
+	}
+	for _, directive := range directives {
+		if directive == nil || directive.Name == nil || directive.Name.Value != DeferDirective.Name {
+			continue
+		}
+		args := getArgumentValues(DeferDirective.Args, directive.Arguments, eCtx.VariableValues)
+		if deferIf, ok := args["if"].(bool); ok && !deferIf {
+			return "", false
+		}
+		label, _ := args["label"].(string)
+		return label, true
+	}
+	return "", false
+}
+
+// collect records a fragment deferred while collecting fields, until the
+// object it applies to is known, see enqueue.
+func (q *deferQueue) collect(label string, selectionSet *ast.SelectionSet, fragments []ast.Node) {
+	q.collected = append(q.collected, &deferredRecord{
+		label:        label,
+		selectionSet: selectionSet,
+		fragments:    fragments,
+	})
+}
+
+// enqueue schedules the fragments collected for the given object.
+func (q *deferQueue) enqueue(parentType *Object, source interface{}, path *ResponsePath) {
+	if q == nil {
+		return
+	}
+	for _, record := range q.collected {
+		record.parentType = parentType
+		record.source = source
+		record.path = path
+		q.records = append(q.records, record)
+	}
+	q.collected = nil
+}
+
+// discard drops the fragments deferred within the field at path, nulled
+// because of an error.
+func (q *deferQueue) discard(path *ResponsePath) {
+	if q == nil || len(q.records) == 0 {
+		return
+	}
+	records := q.records[:0]
+	for _, record := range q.records {
+		if !hasPathPrefix(record.path, path) {
+			records = append(records, record)
+		}
+	}
+	q.records = records
+}
+
+// hasPathPrefix reports whether prefix is path or one of its ancestors.
+func hasPathPrefix(path, prefix *ResponsePath) bool {
+	for ; path != nil; path = path.Prev {
+		if path == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// execute executes the deferred fragment, enqueuing the fragments deferred
+// within it.
+func (q *deferQueue) execute(record *deferredRecord) *IncrementalResult {
+	eCtx := q.eCtx
+	eCtx.Errors = nil
+	result := &IncrementalResult{
+		Path:  record.path.AsArray(),
+		Label: record.label,
+	}
+	if result.Path == nil {
+		result.Path = []interface{}{}
+	}
+
+	pending := len(q.records)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// the fragment is nulled, with the fragments deferred within it
+				q.collected = nil
+				q.records = q.records[:pending]
+				if budgetErr, ok := r.(responseBudgetError); ok {
+					r = budgetErr.Error
+				}
+				eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(r.(error)))
+			}
+		}()
+		fields := collectFields(collectFieldsParams{
+			ExeContext:   eCtx,
+			RuntimeType:  record.parentType,
+			SelectionSet: record.selectionSet,
+			Fragments:    record.fragments,
+		})
+		q.enqueue(record.parentType, record.source, record.path)
+		data := executeSubFields(executeFieldsParams{
+			ExecutionContext: eCtx,
+			ParentType:       record.parentType,
+			Source:           record.source,
+			Fields:           fields,
+			Path:             record.path,
+		})
+		dethunkMapWithBreadthFirstTraversal(data)
+		result.Data = data
+	}()
+	result.Errors = eCtx.Errors
+	return result
+}
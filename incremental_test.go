@@ -0,0 +1,224 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func incrementalTestSchema(t *testing.T) graphql.Schema {
+	planet := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Planet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	person := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Person",
+		Fields: graphql.Fields{
+			"name":      &graphql.Field{Type: graphql.String},
+			"homeWorld": &graphql.Field{Type: planet},
+			"secret": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nil, errors.New("secret is classified")
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"person": &graphql.Field{
+					Type: person,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{
+							"name":      "Luke Skywalker",
+							"homeWorld": map[string]interface{}{"name": "Tatooine"},
+						}, nil
+					},
+				},
+				"version": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "1.0", nil
+					},
+				},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, graphql.DeferDirective),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func executeIncremental(t *testing.T, schema graphql.Schema, query string, args map[string]interface{}) (*graphql.InitialIncrementalResult, []*graphql.SubsequentIncrementalResult) {
+	ast, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result := graphql.ValidateDocument(&schema, ast, nil); !result.IsValid {
+		t.Fatalf("unexpected validation errors: %v", result.Errors)
+	}
+	initial, payloads := graphql.ExecuteIncremental(graphql.ExecuteParams{Schema: schema, AST: ast, Args: args})
+	subsequent := []*graphql.SubsequentIncrementalResult{}
+	for payload := range payloads {
+		subsequent = append(subsequent, payload)
+	}
+	return initial, subsequent
+}
+
+func TestExecuteIncremental_DefersFragments(t *testing.T) {
+	schema := incrementalTestSchema(t)
+	query := `{
+		person {
+			name
+			... on Person @defer(label: "homeWorldDefer") {
+				homeWorld { ...PlanetFragment @defer }
+			}
+		}
+		...VersionFragment @defer(label: "versionDefer")
+	}
+	fragment PlanetFragment on Planet { name }
+	fragment VersionFragment on Query { version }`
+	initial, subsequent := executeIncremental(t, schema, query, nil)
+
+	expectedInitial := &graphql.InitialIncrementalResult{
+		Data: map[string]interface{}{
+			"person": map[string]interface{}{"name": "Luke Skywalker"},
+		},
+		HasNext: true,
+	}
+	if !reflect.DeepEqual(expectedInitial, initial) {
+		t.Fatalf("Unexpected initial payload, Diff: %v", testutil.Diff(expectedInitial, initial))
+	}
+	expectedSubsequent := []*graphql.SubsequentIncrementalResult{
+		{
+			Incremental: []*graphql.IncrementalResult{{
+				Data:  map[string]interface{}{"version": "1.0"},
+				Path:  []interface{}{},
+				Label: "versionDefer",
+			}},
+			HasNext: true,
+		},
+		{
+			Incremental: []*graphql.IncrementalResult{{
+				Data:  map[string]interface{}{"homeWorld": map[string]interface{}{}},
+				Path:  []interface{}{"person"},
+				Label: "homeWorldDefer",
+			}},
+			HasNext: true,
+		},
+		{
+			Incremental: []*graphql.IncrementalResult{{
+				Data: map[string]interface{}{"name": "Tatooine"},
+				Path: []interface{}{"person", "homeWorld"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(expectedSubsequent, subsequent) {
+		t.Fatalf("Unexpected subsequent payloads, Diff: %v", testutil.Diff(expectedSubsequent, subsequent))
+	}
+
+	data, err := testutil.CheckIncrementalPayloads(initial, subsequent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) != 0 || !reflect.DeepEqual(result.Data, data) {
+		t.Fatalf("expected the merged payloads to match the result of Do, Diff: %v", testutil.Diff(result.Data, data))
+	}
+}
+
+func TestExecuteIncremental_DeferIf(t *testing.T) {
+	schema := incrementalTestSchema(t)
+	initial, subsequent := executeIncremental(t, schema, `query ($defer: Boolean!) {
+		... @defer(if: $defer) { version }
+	}`, map[string]interface{}{"defer": false})
+
+	expected := &graphql.InitialIncrementalResult{Data: map[string]interface{}{"version": "1.0"}}
+	if !reflect.DeepEqual(expected, initial) || len(subsequent) != 0 {
+		t.Fatalf("Unexpected payloads, Diff: %v, %v", testutil.Diff(expected, initial), subsequent)
+	}
+}
+
+func TestExecuteIncremental_Errors(t *testing.T) {
+	schema := incrementalTestSchema(t)
+	initial, subsequent := executeIncremental(t, schema, `{
+		person {
+			... @defer(label: "secretDefer") { secret }
+			... @defer(label: "nameDefer") { name }
+		}
+		... @defer { captured: person { secret ... @defer(label: "discarded") { name } } }
+	}`, nil)
+	if _, err := testutil.CheckIncrementalPayloads(initial, subsequent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := []*graphql.IncrementalResult{}
+	for _, payload := range subsequent {
+		for _, result := range payload.Incremental {
+			result.Errors = formattedMessages(result.Errors)
+			results = append(results, result)
+		}
+	}
+	classified := []gqlerrors.FormattedError{{Message: "secret is classified"}}
+	expected := []*graphql.IncrementalResult{
+		{
+			Data:   map[string]interface{}{"captured": nil},
+			Path:   []interface{}{},
+			Errors: classified,
+		},
+		{
+			Path:   []interface{}{"person"},
+			Label:  "secretDefer",
+			Errors: classified,
+		},
+		{
+			Data:  map[string]interface{}{"name": "Luke Skywalker"},
+			Path:  []interface{}{"person"},
+			Label: "nameDefer",
+		},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected incremental results, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+// formattedMessages keeps the messages of the errors, for comparison.
+func formattedMessages(errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	if errs == nil {
+		return nil
+	}
+	messages := []gqlerrors.FormattedError{}
+	for _, err := range errs {
+		messages = append(messages, gqlerrors.FormattedError{Message: err.Message})
+	}
+	return messages
+}
+
+func TestExecuteIncremental_StopsWhenTheContextIsCancelled(t *testing.T) {
+	schema := incrementalTestSchema(t)
+	ast, err := parser.Parse(parser.ParseParams{Source: `{ ... @defer { version } ... @defer { person { name } } }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	initial, payloads := graphql.ExecuteIncrementalContext(ctx, graphql.ExecuteParams{Schema: schema, AST: ast})
+	if !initial.HasNext {
+		t.Fatalf("expected subsequent payloads")
+	}
+	<-payloads
+	cancel()
+	for range payloads {
+	}
+}
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// InputFieldTransformFn transforms the coerced value of an input object field
+// before it is given to resolvers, keeping normalization logic with the type,
+// e.g. trimming strings, normalizing emails or parsing composite IDs:
+//
+//	"email": &graphql.InputObjectFieldConfig{
+//		Type: graphql.String,
+//		Transform: func(value interface{}) (interface{}, error) {
+//			return strings.ToLower(strings.TrimSpace(value.(string))), nil
+//		},
+//	},
+//
+// It is called with the non-null values of the field, including its default
+// value, from variables and literals alike. An error rejects the value with a
+// BAD_USER_INPUT error: for variables, an error of the request, and for
+// literals, a field error of the field whose argument holds the value.
+type InputFieldTransformFn func(value interface{}) (interface{}, error)
+
+// inputFieldTransformError is raised by the coercion of an input object field
+// whose Transform failed.
+type inputFieldTransformError struct {
+	field string
+	err   error
+}
+
+func (e *inputFieldTransformError) Error() string {
+	return fmt.Sprintf(`In field "%v": %v`, e.field, e.err)
+}
+
+func (e *inputFieldTransformError) Unwrap() error {
+	return e.err
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *inputFieldTransformError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": gqlerrors.CodeBadUserInput}
+}
+
+// transformInputField returns the coerced, non-null value of the field of the
+// input object transformed by its Transform, panicking with an
+// inputFieldTransformError if it fails.
+func transformInputField(ttype *InputObject, field *InputObjectField, value interface{}) interface{} {
+	if field.Transform == nil {
+		return value
+	}
+	transformed, err := field.Transform(value)
+	if err != nil {
+		panic(&inputFieldTransformError{field: ttype.Name() + "." + field.Name(), err: err})
+	}
+	return transformed
+}
+
+// coerceTransformedValue is coerceValue returning the error of a failed
+// Transform of an input object field.
+func coerceTransformedValue(ttype Input, value interface{}) (coerced interface{}, err error) {
+	defer recoverTransformError(&err)
+	return coerceValue(ttype, value), nil
+}
+
+// transformedArgumentValues is getArgumentValues returning the error of a
+// failed Transform of an input object field.
+func transformedArgumentValues(argDefs []*Argument, argASTs []*ast.Argument) (values map[string]interface{}, err error) {
+	defer recoverTransformError(&err)
+	return getArgumentValues(argDefs, argASTs, nil), nil
+}
+
+func recoverTransformError(err *error) {
+	if r := recover(); r != nil {
+		transformErr, ok := r.(*inputFieldTransformError)
+		if !ok {
+			panic(r)
+		}
+		*err = transformErr
+	}
+}
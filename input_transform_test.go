@@ -0,0 +1,134 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+func inputTransformSchema(t *testing.T) graphql.Schema {
+	userInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"email": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+				Transform: func(value interface{}) (interface{}, error) {
+					email := strings.ToLower(strings.TrimSpace(value.(string)))
+					if !strings.Contains(email, "@") {
+						return nil, errors.New("invalid email")
+					}
+					return email, nil
+				},
+			},
+			"tags": &graphql.InputObjectFieldConfig{
+				Type:         graphql.NewList(graphql.String),
+				DefaultValue: []interface{}{"b", "a"},
+				Transform: func(value interface{}) (interface{}, error) {
+					tags := []string{}
+					for _, tag := range value.([]interface{}) {
+						tags = append(tags, tag.(string))
+					}
+					return strings.Join(tags, ","), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"createUser": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: userInput},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						input := p.Args["input"].(map[string]interface{})
+						return []interface{}{input["email"], input["tags"]}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestInputFieldTransform_Literals(t *testing.T) {
+	schema := inputTransformSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ createUser(input: {email: "  Jane@Example.COM "}) }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{"createUser": []interface{}{"jane@example.com", "b,a"}}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got: %v", expected, result.Data)
+	}
+}
+
+func TestInputFieldTransform_Variables(t *testing.T) {
+	schema := inputTransformSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($input: UserInput) { createUser(input: $input) }`,
+		VariableValues: map[string]interface{}{
+			"input": map[string]interface{}{"email": "JOE@example.com", "tags": []interface{}{"x"}},
+		},
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{"createUser": []interface{}{"joe@example.com", "x"}}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got: %v", expected, result.Data)
+	}
+}
+
+func TestInputFieldTransform_Errors(t *testing.T) {
+	schema := inputTransformSchema(t)
+	tests := []struct {
+		name     string
+		params   graphql.Params
+		expected string
+	}{
+		{
+			name: "literal",
+			params: graphql.Params{
+				RequestString: `{ createUser(input: {email: "nope"}) }`,
+			},
+			expected: `In field "UserInput.email": invalid email`,
+		},
+		{
+			name: "variable",
+			params: graphql.Params{
+				RequestString:  `query ($input: UserInput) { createUser(input: $input) }`,
+				VariableValues: map[string]interface{}{"input": map[string]interface{}{"email": "nope"}},
+			},
+			expected: `Variable "$input" got invalid value: In field "UserInput.email": invalid email`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.params.Schema = schema
+			result := graphql.Do(test.params)
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected an error, got: %v", result.Errors)
+			}
+			if result.Errors[0].Message != test.expected {
+				t.Fatalf("expected %q, got: %q", test.expected, result.Errors[0].Message)
+			}
+			if code := gqlerrors.Code(result.Errors[0]); code != gqlerrors.CodeBadUserInput {
+				t.Fatalf("expected code %q, got: %q", gqlerrors.CodeBadUserInput, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package graphql_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestInterfaceDefaults_AreInheritedUnlessOverridden(t *testing.T) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.ID,
+				Args: graphql.FieldConfigArgument{
+					"prefix": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "node"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fmt.Sprintf("%v:%v:%v", p.Args["prefix"], p.Info.ParentType.Name(), p.Source.(map[string]interface{})["key"]), nil
+				},
+			},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.ID,
+				Args: graphql.FieldConfigArgument{
+					"prefix": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+			},
+		},
+	})
+	post := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Post",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.ID,
+				Args: graphql.FieldConfigArgument{
+					"prefix": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "post"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fmt.Sprintf("%v-%v", p.Args["prefix"], p.Source.(map[string]interface{})["key"]), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"key": 1}, nil
+					},
+				},
+				"post": &graphql.Field{
+					Type: post,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"key": 2}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { id other: id(prefix: "u") } post { id } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"id": "node:User:1", "other": "u:User:1"},
+			"post": map[string]interface{}{"id": "post-2"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// the defaults are inherited by the schema, the fields are left untouched
+	if id := user.Fields()["id"]; id.Resolve != nil || id.Args[0].DefaultValue != nil {
+		t.Fatalf("expected the fields of User to be left untouched")
+	}
+}
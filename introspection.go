@@ -38,6 +38,12 @@ var InputValueType *Object
 // EnumValueType is type definition for __EnumValue
 var EnumValueType *Object
 
+// AppliedDirectiveType is type definition for __AppliedDirective
+var AppliedDirectiveType *Object
+
+// DirectiveArgumentType is type definition for __DirectiveArgument
+var DirectiveArgumentType *Object
+
 // TypeKindEnumType is type definition for __TypeKind
 var TypeKindEnumType *Enum
 
@@ -107,78 +113,38 @@ func init() {
 		Name: "__DirectiveLocation",
 		Description: "A Directive can be adjacent to many parts of the GraphQL language, a " +
 			"__DirectiveLocation describes one such possible adjacencies.",
-		Values: EnumValueConfigMap{
-			"QUERY": &EnumValueConfig{
-				Value:       DirectiveLocationQuery,
-				Description: "Location adjacent to a query operation.",
-			},
-			"MUTATION": &EnumValueConfig{
-				Value:       DirectiveLocationMutation,
-				Description: "Location adjacent to a mutation operation.",
-			},
-			"SUBSCRIPTION": &EnumValueConfig{
-				Value:       DirectiveLocationSubscription,
-				Description: "Location adjacent to a subscription operation.",
-			},
-			"FIELD": &EnumValueConfig{
-				Value:       DirectiveLocationField,
-				Description: "Location adjacent to a field.",
-			},
-			"FRAGMENT_DEFINITION": &EnumValueConfig{
-				Value:       DirectiveLocationFragmentDefinition,
-				Description: "Location adjacent to a fragment definition.",
-			},
-			"FRAGMENT_SPREAD": &EnumValueConfig{
-				Value:       DirectiveLocationFragmentSpread,
-				Description: "Location adjacent to a fragment spread.",
-			},
-			"INLINE_FRAGMENT": &EnumValueConfig{
-				Value:       DirectiveLocationInlineFragment,
-				Description: "Location adjacent to an inline fragment.",
-			},
-			"SCHEMA": &EnumValueConfig{
-				Value:       DirectiveLocationSchema,
-				Description: "Location adjacent to a schema definition.",
-			},
-			"SCALAR": &EnumValueConfig{
-				Value:       DirectiveLocationScalar,
-				Description: "Location adjacent to a scalar definition.",
-			},
-			"OBJECT": &EnumValueConfig{
-				Value:       DirectiveLocationObject,
-				Description: "Location adjacent to a object definition.",
-			},
-			"FIELD_DEFINITION": &EnumValueConfig{
-				Value:       DirectiveLocationFieldDefinition,
-				Description: "Location adjacent to a field definition.",
-			},
-			"ARGUMENT_DEFINITION": &EnumValueConfig{
-				Value:       DirectiveLocationArgumentDefinition,
-				Description: "Location adjacent to an argument definition.",
-			},
-			"INTERFACE": &EnumValueConfig{
-				Value:       DirectiveLocationInterface,
-				Description: "Location adjacent to an interface definition.",
-			},
-			"UNION": &EnumValueConfig{
-				Value:       DirectiveLocationUnion,
-				Description: "Location adjacent to a union definition.",
-			},
-			"ENUM": &EnumValueConfig{
-				Value:       DirectiveLocationEnum,
-				Description: "Location adjacent to an enum definition.",
+		Values: directiveLocationEnumValues(),
+	})
+
+	// NOTE: extension of introspection, see SchemaConfig.IntrospectAppliedDirectives.
+	DirectiveArgumentType = NewObject(ObjectConfig{
+		Name:        "__DirectiveArgument",
+		Description: "An argument of a directive applied to an element of the schema.",
+		Fields: Fields{
+			"name": &Field{
+				Type: NewNonNull(String),
 			},
-			"ENUM_VALUE": &EnumValueConfig{
-				Value:       DirectiveLocationEnumValue,
-				Description: "Location adjacent to an enum value definition.",
+			"value": &Field{
+				Type:        NewNonNull(String),
+				Description: "A GraphQL-formatted string representing the value of the argument.",
 			},
-			"INPUT_OBJECT": &EnumValueConfig{
-				Value:       DirectiveLocationInputObject,
-				Description: "Location adjacent to an input object type definition.",
+		},
+	})
+	AppliedDirectiveType = NewObject(ObjectConfig{
+		Name:        "__AppliedDirective",
+		Description: "A directive applied to a type, a field, an input value or an enum value of the schema.",
+		Fields: Fields{
+			"name": &Field{
+				Type: NewNonNull(String),
 			},
-			"INPUT_FIELD_DEFINITION": &EnumValueConfig{
-				Value:       DirectiveLocationInputFieldDefinition,
-				Description: "Location adjacent to an input object field definition.",
+			"args": &Field{
+				Type: NewNonNull(NewList(NewNonNull(DirectiveArgumentType))),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if directive, ok := p.Source.(*AppliedDirective); ok {
+						return appliedDirectiveArgs(p.Info.Schema.Directive(directive.Name), directive.Args), nil
+					}
+					return nil, nil
+				},
 			},
 		},
 	})
@@ -255,22 +221,33 @@ func init() {
 				Description: "A GraphQL-formatted string representing the default value for this " +
 					"input value.",
 				Resolve: func(p ResolveParams) (interface{}, error) {
-					if inputVal, ok := p.Source.(*Argument); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						if isNullish(inputVal.DefaultValue) {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+					var literal ast.Value
+					switch inputVal := p.Source.(type) {
+					case *Argument:
+						literal = inputVal.DefaultLiteral()
+					case *InputObjectField:
+						literal = inputVal.DefaultLiteral()
 					}
-					if inputVal, ok := p.Source.(*InputObjectField); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+					if literal == nil {
+						return nil, nil
+					}
+					return printer.Print(literal), nil
+				},
+			},
+			"isDeprecated": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if arg, ok := p.Source.(*Argument); ok {
+						return (arg.DeprecationReason != ""), nil
+					}
+					return false, nil
+				},
+			},
+			"deprecationReason": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if arg, ok := p.Source.(*Argument); ok && arg.DeprecationReason != "" {
+						return arg.DeprecationReason, nil
 					}
 					return nil, nil
 				},
@@ -321,6 +298,43 @@ func init() {
 					return nil, nil
 				},
 			},
+			// NOTE: experimental extension of the semantic nullability proposal.
+			"semanticNonNullLevels": &Field{
+				Description: "The levels of the type of the field which are null only on error, " +
+					"see the @semanticNonNull directive.",
+				Type: NewList(NewNonNull(Int)),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if field, ok := p.Source.(*FieldDefinition); ok && len(field.SemanticNonNull) > 0 {
+						return field.SemanticNonNull, nil
+					}
+					return nil, nil
+				},
+			},
+			// NOTE: documentation extensions, see the @example and @since directives.
+			"example": &Field{
+				Description: "An example value of the field.",
+				Type:        String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if field, ok := p.Source.(*FieldDefinition); ok {
+						if example, _ := FieldDocumentation(field); example != "" {
+							return example, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+			"since": &Field{
+				Description: "The version of the schema which introduced the field.",
+				Type:        String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if field, ok := p.Source.(*FieldDefinition); ok {
+						if _, since := FieldDocumentation(field); since != "" {
+							return since, nil
+						}
+					}
+					return nil, nil
+				},
+			},
 		},
 	})
 
@@ -349,6 +363,9 @@ func init() {
 					NewNonNull(InputValueType),
 				)),
 			},
+			"isRepeatable": &Field{
+				Type: NewNonNull(Boolean),
+			},
 			// NOTE: the following three fields are deprecated and are no longer part
 			// of the GraphQL specification.
 			"onOperation": &Field{
@@ -423,7 +440,7 @@ func init() {
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if schema, ok := p.Source.(Schema); ok {
 						results := []Type{}
-						for _, ttype := range schema.TypeMap() {
+						for _, ttype := range schema.introspectedTypes() {
 							results = append(results, ttype)
 						}
 						return results, nil
@@ -627,12 +644,24 @@ func init() {
 		Type: TypeType,
 	})
 
+	// NOTE: extension of introspection, see SchemaConfig.IntrospectAppliedDirectives.
+	for _, ttype := range []*Object{TypeType, FieldType, InputValueType, EnumValueType} {
+		ttype.AddFieldConfig("appliedDirectives", &Field{
+			Description: "The directives applied to the element of the schema, null unless the " +
+				"schema exposes them.",
+			Type:    NewList(NewNonNull(AppliedDirectiveType)),
+			Resolve: resolveAppliedDirectives,
+		})
+	}
+
 	SchemaType.ensureCache()
 	DirectiveType.ensureCache()
 	TypeType.ensureCache()
 	FieldType.ensureCache()
 	InputValueType.ensureCache()
 	EnumValueType.ensureCache()
+	AppliedDirectiveType.ensureCache()
+	DirectiveArgumentType.ensureCache()
 
 	// Note that these are FieldDefinition and not FieldConfig,
 	// so the format for args is different.
@@ -660,6 +689,9 @@ func init() {
 			if !ok {
 				return nil, nil
 			}
+			if p.Info.Schema.pruneUnreachableTypes {
+				return p.Info.Schema.introspectedTypes()[name], nil
+			}
 			return p.Info.Schema.Type(name), nil
 		},
 	}
@@ -676,6 +708,69 @@ func init() {
 
 }
 
+// resolveAppliedDirectives resolves the directives applied to a type, a field,
+// an input value or an enum value, when the schema exposes them.
+func resolveAppliedDirectives(p ResolveParams) (interface{}, error) {
+	if !p.Info.Schema.introspectAppliedDirectives {
+		return nil, nil
+	}
+	directives := []*AppliedDirective{}
+	switch source := p.Source.(type) {
+	case *Scalar:
+		directives = append(directives, source.AppliedDirectives...)
+	case *Object:
+		directives = append(directives, source.AppliedDirectives...)
+	case *Interface:
+		directives = append(directives, source.AppliedDirectives...)
+	case *Union:
+		directives = append(directives, source.AppliedDirectives...)
+	case *Enum:
+		directives = append(directives, source.AppliedDirectives...)
+	case *InputObject:
+		directives = append(directives, source.AppliedDirectives...)
+	case *FieldDefinition:
+		directives = append(directives, source.AppliedDirectives...)
+	case *Argument:
+		directives = append(directives, source.AppliedDirectives...)
+	case *InputObjectField:
+		directives = append(directives, source.AppliedDirectives...)
+	case *EnumValueDefinition:
+		directives = append(directives, source.AppliedDirectives...)
+	}
+	return directives, nil
+}
+
+// appliedDirectiveArgs returns the arguments of an applied directive ordered
+// by name, their values printed with the types of the arguments of its
+// definition, nil when the schema does not define the directive.
+func appliedDirectiveArgs(definition *Directive, args map[string]interface{}) []map[string]interface{} {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := []map[string]interface{}{}
+	for _, name := range names {
+		var ttype Type
+		if definition != nil {
+			for _, arg := range definition.Args {
+				if arg.Name() == name {
+					ttype = arg.Type
+				}
+			}
+		}
+		value := "null"
+		if literal := astFromValue(args[name], ttype); literal != nil {
+			value = fmt.Sprint(printer.Print(literal))
+		}
+		result = append(result, map[string]interface{}{
+			"name":  name,
+			"value": value,
+		})
+	}
+	return result
+}
+
 // Produces a GraphQL Value AST given a Golang value.
 //
 // Optionally, a GraphQL type may be provided, which will be used to
@@ -690,6 +785,10 @@ func init() {
 // | Number        | Int / Float          |
 
 func astFromValue(value interface{}, ttype Type) ast.Value {
+	// default values may be given as literals
+	if value, ok := value.(ast.Value); ok {
+		return value
+	}
 
 	if ttype, ok := ttype.(*NonNull); ok {
 		// Note: we're not checking that the result is non-null.
@@ -735,8 +834,41 @@ func astFromValue(value interface{}, ttype Type) ast.Value {
 		return val
 	}
 
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to Value
+	// enums are printed by the name of their internal value
+	if ttype, ok := ttype.(*Enum); ok {
+		if name, ok := ttype.Serialize(value).(string); ok {
+			return ast.NewEnumValue(&ast.EnumValue{
+				Value: name,
+			})
+		}
+	}
+
+	// input objects are printed with their fields ordered by name
+	if ttype, ok := ttype.(*InputObject); ok {
+		if value, ok := value.(map[string]interface{}); ok {
+			fields := ttype.Fields()
+			names := make([]string, 0, len(value))
+			for name := range value {
+				if _, ok := fields[name]; ok {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			objectFields := []*ast.ObjectField{}
+			for _, name := range names {
+				fieldAST := astFromValue(value[name], fields[name].Type)
+				if fieldAST == nil {
+					continue
+				}
+				objectFields = append(objectFields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: name}),
+					Value: fieldAST,
+				}))
+			}
+			return ast.NewObjectValue(&ast.ObjectValue{
+				Fields: objectFields,
+			})
+		}
 	}
 
 	if value, ok := value.(bool); ok {
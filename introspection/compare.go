@@ -0,0 +1,380 @@
+// Package introspection compares a schema with the result of the
+// introspection query of a running server, so deployment pipelines can verify
+// the server matches the intended schema:
+//
+//	report, err := introspection.Compare(&schema, introspectionJSON)
+//	if err != nil {
+//		return err
+//	}
+//	if report.Drifted() {
+//		return fmt.Errorf("schema drift:\n%v", report)
+//	}
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ChangeKind is the kind of a difference between two schemas.
+type ChangeKind string
+
+// The kinds of differences reported by Compare.
+const (
+	RootTypeChanged          ChangeKind = "ROOT_TYPE_CHANGED"
+	TypeMissing              ChangeKind = "TYPE_MISSING"
+	TypeUnexpected           ChangeKind = "TYPE_UNEXPECTED"
+	TypeKindChanged          ChangeKind = "TYPE_KIND_CHANGED"
+	FieldMissing             ChangeKind = "FIELD_MISSING"
+	FieldUnexpected          ChangeKind = "FIELD_UNEXPECTED"
+	FieldTypeChanged         ChangeKind = "FIELD_TYPE_CHANGED"
+	ArgumentMissing          ChangeKind = "ARGUMENT_MISSING"
+	ArgumentUnexpected       ChangeKind = "ARGUMENT_UNEXPECTED"
+	ArgumentTypeChanged      ChangeKind = "ARGUMENT_TYPE_CHANGED"
+	DefaultValueChanged      ChangeKind = "DEFAULT_VALUE_CHANGED"
+	EnumValueMissing         ChangeKind = "ENUM_VALUE_MISSING"
+	EnumValueUnexpected      ChangeKind = "ENUM_VALUE_UNEXPECTED"
+	InterfaceMissing         ChangeKind = "INTERFACE_MISSING"
+	InterfaceUnexpected      ChangeKind = "INTERFACE_UNEXPECTED"
+	PossibleTypeMissing      ChangeKind = "POSSIBLE_TYPE_MISSING"
+	PossibleTypeUnexpected   ChangeKind = "POSSIBLE_TYPE_UNEXPECTED"
+	DirectiveMissing         ChangeKind = "DIRECTIVE_MISSING"
+	DirectiveUnexpected      ChangeKind = "DIRECTIVE_UNEXPECTED"
+	DirectiveLocationChanged ChangeKind = "DIRECTIVE_LOCATIONS_CHANGED"
+)
+
+// Change is a difference between the local schema and the remote one. Missing
+// elements are defined locally but not by the remote schema, unexpected
+// elements are only defined by the remote schema.
+type Change struct {
+	Kind ChangeKind
+
+	// Path is the coordinate of the element, such as "Query.user(id:)".
+	Path string
+
+	Message string
+}
+
+// String returns the kind, path and message of the change.
+func (c Change) String() string {
+	return fmt.Sprintf("%v %v: %v", c.Kind, c.Path, c.Message)
+}
+
+// Report lists the differences between two schemas, ordered by path.
+type Report struct {
+	Changes []Change
+}
+
+// Drifted reports whether the schemas differ.
+func (r *Report) Drifted() bool {
+	return len(r.Changes) > 0
+}
+
+// String returns the changes of the report, one per line.
+func (r *Report) String() string {
+	lines := make([]string, len(r.Changes))
+	for i, change := range r.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare compares the local schema with the schema described by the remote
+// introspection JSON, the result of graphql.IntrospectionQuery with or
+// without its "data" envelope. Descriptions and deprecations are not compared.
+func Compare(local *graphql.Schema, remoteIntrospectionJSON []byte) (*Report, error) {
+	localJSON, err := local.IntrospectionJSON()
+	if err != nil {
+		return nil, fmt.Errorf("introspection: local schema: %v", err)
+	}
+	localSchema, err := decodeSchema(localJSON)
+	if err != nil {
+		return nil, fmt.Errorf("introspection: local schema: %v", err)
+	}
+	remoteSchema, err := decodeSchema(remoteIntrospectionJSON)
+	if err != nil {
+		return nil, fmt.Errorf("introspection: remote schema: %v", err)
+	}
+	c := &comparator{}
+	c.compare(localSchema, remoteSchema)
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Path < c.changes[j].Path
+	})
+	return &Report{Changes: c.changes}, nil
+}
+
+// schema is the part of the introspection result compared.
+type schema struct {
+	QueryType        *typeRef     `json:"queryType"`
+	MutationType     *typeRef     `json:"mutationType"`
+	SubscriptionType *typeRef     `json:"subscriptionType"`
+	Types            []*fullType  `json:"types"`
+	Directives       []*directive `json:"directives"`
+}
+
+type fullType struct {
+	Kind          string        `json:"kind"`
+	Name          string        `json:"name"`
+	Fields        []*field      `json:"fields"`
+	InputFields   []*inputValue `json:"inputFields"`
+	Interfaces    []*typeRef    `json:"interfaces"`
+	EnumValues    []*enumValue  `json:"enumValues"`
+	PossibleTypes []*typeRef    `json:"possibleTypes"`
+}
+
+type field struct {
+	Name string        `json:"name"`
+	Args []*inputValue `json:"args"`
+	Type *typeRef      `json:"type"`
+}
+
+type inputValue struct {
+	Name         string   `json:"name"`
+	Type         *typeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+type enumValue struct {
+	Name string `json:"name"`
+}
+
+type directive struct {
+	Name      string        `json:"name"`
+	Locations []string      `json:"locations"`
+	Args      []*inputValue `json:"args"`
+}
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+// String returns the type reference in the SDL notation, such as "[Int!]".
+func (t *typeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+func decodeSchema(introspectionJSON []byte) (*schema, error) {
+	var result struct {
+		Data struct {
+			Schema *schema `json:"__schema"`
+		} `json:"data"`
+		Schema *schema `json:"__schema"`
+	}
+	if err := json.Unmarshal(introspectionJSON, &result); err != nil {
+		return nil, fmt.Errorf("invalid introspection JSON: %v", err)
+	}
+	if result.Schema == nil {
+		result.Schema = result.Data.Schema
+	}
+	if result.Schema == nil {
+		return nil, fmt.Errorf("the introspection JSON has no __schema")
+	}
+	return result.Schema, nil
+}
+
+type comparator struct {
+	changes []Change
+}
+
+func (c *comparator) report(kind ChangeKind, path string, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{Kind: kind, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *comparator) compare(local, remote *schema) {
+	c.compareRootType("query", local.QueryType, remote.QueryType)
+	c.compareRootType("mutation", local.MutationType, remote.MutationType)
+	c.compareRootType("subscription", local.SubscriptionType, remote.SubscriptionType)
+
+	remoteTypes := map[string]*fullType{}
+	for _, ttype := range remote.Types {
+		remoteTypes[ttype.Name] = ttype
+	}
+	localTypes := map[string]bool{}
+	for _, ttype := range local.Types {
+		localTypes[ttype.Name] = true
+		remoteType, ok := remoteTypes[ttype.Name]
+		if !ok {
+			c.report(TypeMissing, ttype.Name, "%v %v is not defined by the remote schema", ttype.Kind, ttype.Name)
+			continue
+		}
+		c.compareType(ttype, remoteType)
+	}
+	for _, ttype := range remote.Types {
+		if !localTypes[ttype.Name] {
+			c.report(TypeUnexpected, ttype.Name, "%v %v is only defined by the remote schema", ttype.Kind, ttype.Name)
+		}
+	}
+
+	remoteDirectives := map[string]*directive{}
+	for _, dir := range remote.Directives {
+		remoteDirectives[dir.Name] = dir
+	}
+	localDirectives := map[string]bool{}
+	for _, dir := range local.Directives {
+		localDirectives[dir.Name] = true
+		path := "@" + dir.Name
+		remoteDir, ok := remoteDirectives[dir.Name]
+		if !ok {
+			c.report(DirectiveMissing, path, "directive is not defined by the remote schema")
+			continue
+		}
+		localLocations, remoteLocations := sortedCopy(dir.Locations), sortedCopy(remoteDir.Locations)
+		if strings.Join(localLocations, ",") != strings.Join(remoteLocations, ",") {
+			c.report(DirectiveLocationChanged, path, "locations changed from %v to %v", localLocations, remoteLocations)
+		}
+		c.compareInputValues(path, dir.Args, remoteDir.Args, true)
+	}
+	for _, dir := range remote.Directives {
+		if !localDirectives[dir.Name] {
+			c.report(DirectiveUnexpected, "@"+dir.Name, "directive is only defined by the remote schema")
+		}
+	}
+}
+
+func (c *comparator) compareRootType(operation string, local, remote *typeRef) {
+	localName, remoteName := "", ""
+	if local != nil {
+		localName = local.Name
+	}
+	if remote != nil {
+		remoteName = remote.Name
+	}
+	if localName != remoteName {
+		c.report(RootTypeChanged, "schema."+operation, "root type changed from %q to %q", localName, remoteName)
+	}
+}
+
+func (c *comparator) compareType(local, remote *fullType) {
+	if local.Kind != remote.Kind {
+		c.report(TypeKindChanged, local.Name, "kind changed from %v to %v", local.Kind, remote.Kind)
+		return
+	}
+
+	remoteFields := map[string]*field{}
+	for _, f := range remote.Fields {
+		remoteFields[f.Name] = f
+	}
+	localFields := map[string]bool{}
+	for _, f := range local.Fields {
+		localFields[f.Name] = true
+		path := local.Name + "." + f.Name
+		remoteField, ok := remoteFields[f.Name]
+		if !ok {
+			c.report(FieldMissing, path, "field is not defined by the remote schema")
+			continue
+		}
+		if f.Type.String() != remoteField.Type.String() {
+			c.report(FieldTypeChanged, path, "type changed from %v to %v", f.Type, remoteField.Type)
+		}
+		c.compareInputValues(path, f.Args, remoteField.Args, true)
+	}
+	for _, f := range remote.Fields {
+		if !localFields[f.Name] {
+			c.report(FieldUnexpected, local.Name+"."+f.Name, "field is only defined by the remote schema")
+		}
+	}
+
+	c.compareInputValues(local.Name, local.InputFields, remote.InputFields, false)
+	c.compareNames(local.Name, enumNames(local.EnumValues), enumNames(remote.EnumValues), EnumValueMissing, EnumValueUnexpected, "enum value")
+	c.compareNames(local.Name, typeNames(local.Interfaces), typeNames(remote.Interfaces), InterfaceMissing, InterfaceUnexpected, "interface")
+	c.compareNames(local.Name, typeNames(local.PossibleTypes), typeNames(remote.PossibleTypes), PossibleTypeMissing, PossibleTypeUnexpected, "possible type")
+}
+
+// compareInputValues compares the arguments, or the input fields, of the
+// element at the given path.
+func (c *comparator) compareInputValues(path string, local, remote []*inputValue, arguments bool) {
+	missing, unexpected, typeChanged := FieldMissing, FieldUnexpected, FieldTypeChanged
+	valuePath := func(name string) string { return path + "." + name }
+	if arguments {
+		missing, unexpected, typeChanged = ArgumentMissing, ArgumentUnexpected, ArgumentTypeChanged
+		valuePath = func(name string) string { return path + "(" + name + ":)" }
+	}
+	remoteValues := map[string]*inputValue{}
+	for _, value := range remote {
+		remoteValues[value.Name] = value
+	}
+	localValues := map[string]bool{}
+	for _, value := range local {
+		localValues[value.Name] = true
+		remoteValue, ok := remoteValues[value.Name]
+		if !ok {
+			c.report(missing, valuePath(value.Name), "not defined by the remote schema")
+			continue
+		}
+		if value.Type.String() != remoteValue.Type.String() {
+			c.report(typeChanged, valuePath(value.Name), "type changed from %v to %v", value.Type, remoteValue.Type)
+		}
+		if defaultString(value.DefaultValue) != defaultString(remoteValue.DefaultValue) {
+			c.report(DefaultValueChanged, valuePath(value.Name), "default value changed from %v to %v",
+				defaultString(value.DefaultValue), defaultString(remoteValue.DefaultValue))
+		}
+	}
+	for _, value := range remote {
+		if !localValues[value.Name] {
+			c.report(unexpected, valuePath(value.Name), "only defined by the remote schema")
+		}
+	}
+}
+
+func (c *comparator) compareNames(path string, local, remote []string, missing, unexpected ChangeKind, what string) {
+	remoteNames := map[string]bool{}
+	for _, name := range remote {
+		remoteNames[name] = true
+	}
+	localNames := map[string]bool{}
+	for _, name := range local {
+		localNames[name] = true
+		if !remoteNames[name] {
+			c.report(missing, path+"."+name, "%v is not defined by the remote schema", what)
+		}
+	}
+	for _, name := range remote {
+		if !localNames[name] {
+			c.report(unexpected, path+"."+name, "%v is only defined by the remote schema", what)
+		}
+	}
+}
+
+func defaultString(value *string) string {
+	if value == nil {
+		return "<none>"
+	}
+	return *value
+}
+
+func enumNames(values []*enumValue) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = value.Name
+	}
+	return result
+}
+
+func typeNames(refs []*typeRef) []string {
+	result := make([]string, len(refs))
+	for i, ref := range refs {
+		result[i] = ref.Name
+	}
+	return result
+}
+
+func sortedCopy(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
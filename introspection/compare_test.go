@@ -0,0 +1,101 @@
+package introspection_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/introspection"
+)
+
+func compareSchema(t *testing.T, remote bool) graphql.Schema {
+	statusValues := graphql.EnumValueConfigMap{
+		"ACTIVE":   &graphql.EnumValueConfig{Value: "active"},
+		"INACTIVE": &graphql.EnumValueConfig{Value: "inactive"},
+	}
+	userFields := graphql.Fields{
+		"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name": &graphql.Field{Type: graphql.String},
+	}
+	queryArgs := graphql.FieldConfigArgument{
+		"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+	}
+	if remote {
+		delete(statusValues, "INACTIVE")
+		userFields["name"] = &graphql.Field{Type: graphql.NewNonNull(graphql.String)}
+		userFields["email"] = &graphql.Field{Type: graphql.String}
+		queryArgs["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20}
+	}
+	status := graphql.NewEnum(graphql.EnumConfig{Name: "Status", Values: statusValues})
+	userFields["status"] = &graphql.Field{Type: status}
+	user := graphql.NewObject(graphql.ObjectConfig{Name: "User", Fields: userFields})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{Type: user, Args: queryArgs},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestCompare_ReportsDrift(t *testing.T) {
+	local := compareSchema(t, false)
+	remote := compareSchema(t, true)
+	remoteJSON, err := remote.IntrospectionJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := introspection.Compare(&local, remoteJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []introspection.Change{
+		{Kind: introspection.DefaultValueChanged, Path: "Query.user(limit:)", Message: "default value changed from 10 to 20"},
+		{Kind: introspection.EnumValueMissing, Path: "Status.INACTIVE", Message: "enum value is not defined by the remote schema"},
+		{Kind: introspection.FieldUnexpected, Path: "User.email", Message: "field is only defined by the remote schema"},
+		{Kind: introspection.FieldTypeChanged, Path: "User.name", Message: "type changed from String to String!"},
+	}
+	if !reflect.DeepEqual(report.Changes, expected) {
+		t.Fatalf("unexpected changes:\n%v", report)
+	}
+	if !report.Drifted() {
+		t.Fatalf("expected the report to have drifted")
+	}
+}
+
+func TestCompare_MatchingSchemas(t *testing.T) {
+	local := compareSchema(t, false)
+	remote := compareSchema(t, false)
+	remoteJSON, err := remote.IntrospectionJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without the "data" envelope
+	var result struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(remoteJSON, &result); err != nil {
+		t.Fatal(err)
+	}
+	report, err := introspection.Compare(&local, result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Drifted() {
+		t.Fatalf("unexpected changes:\n%v", report)
+	}
+}
+
+func TestCompare_InvalidJSON(t *testing.T) {
+	local := compareSchema(t, false)
+	if _, err := introspection.Compare(&local, []byte(`{}`)); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
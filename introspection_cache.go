@@ -0,0 +1,297 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// IntrospectionQuery is the standard query used by tools to introspect a schema.
+const IntrospectionQuery = `
+  query IntrospectionQuery {
+    __schema {
+      queryType { name }
+      mutationType { name }
+      subscriptionType { name }
+      types {
+        ...FullType
+      }
+      directives {
+        name
+        description
+        locations
+        args {
+          ...InputValue
+        }
+      }
+    }
+  }
+
+  fragment FullType on __Type {
+    kind
+    name
+    description
+    fields(includeDeprecated: true) {
+      name
+      description
+      args {
+        ...InputValue
+      }
+      type {
+        ...TypeRef
+      }
+      isDeprecated
+      deprecationReason
+    }
+    inputFields {
+      ...InputValue
+    }
+    interfaces {
+      ...TypeRef
+    }
+    enumValues(includeDeprecated: true) {
+      name
+      description
+      isDeprecated
+      deprecationReason
+    }
+    possibleTypes {
+      ...TypeRef
+    }
+  }
+
+  fragment InputValue on __InputValue {
+    name
+    description
+    type { ...TypeRef }
+    defaultValue
+  }
+
+  fragment TypeRef on __Type {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+                ofType {
+                  kind
+                  name
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+`
+
+// maxCachedIntrospectionResults bounds the number of distinct introspection
+// requests cached per schema, so clients can't grow the cache indefinitely.
+const maxCachedIntrospectionResults = 16
+
+// introspectionCache holds the results of introspection requests executed against a schema.
+// It is shared by copies of a schema and reset whenever the schema is modified.
+type introspectionCache struct {
+	mu      sync.Mutex
+	json    []byte
+	results map[string]*Result
+}
+
+func newIntrospectionCache() *introspectionCache {
+	return &introspectionCache{}
+}
+
+func (c *introspectionCache) get(key string) *Result {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[key]
+	if !ok {
+		return nil
+	}
+	return &Result{Data: copyData(result.Data)}
+}
+
+func (c *introspectionCache) set(key string, result *Result) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil {
+		c.results = map[string]*Result{}
+	}
+	if len(c.results) >= maxCachedIntrospectionResults {
+		return
+	}
+	c.results[key] = &Result{Data: copyData(result.Data)}
+}
+
+// copyData returns a deep copy of the data of a result, so that the callers
+// sharing a cached result can't modify it.
+func copyData(data interface{}) interface{} {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(data))
+		for key, value := range data {
+			copied[key] = copyData(value)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(data))
+		for i, value := range data {
+			copied[i] = copyData(value)
+		}
+		return copied
+	}
+	return data
+}
+
+func (c *introspectionCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.json = nil
+	c.results = nil
+}
+
+// IntrospectionJSON returns the JSON encoded result of IntrospectionQuery
+// against the schema. The result is computed once and cached until the schema
+// is modified, e.g. with AppendType or AddExtensions.
+func (gq *Schema) IntrospectionJSON() ([]byte, error) {
+	cache := gq.introspection
+	if cache != nil {
+		cache.mu.Lock()
+		b := cache.json
+		cache.mu.Unlock()
+		if b != nil {
+			return append([]byte{}, b...), nil
+		}
+	}
+
+	result := DoContext(context.Background(), Params{
+		Schema:        *gq,
+		RequestString: IntrospectionQuery,
+	})
+	if result.HasErrors() {
+		return nil, result.Errors[0]
+	}
+	b, err := EncodeResult(StdCodec, result)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.mu.Lock()
+		cache.json = b
+		cache.mu.Unlock()
+	}
+	return append([]byte{}, b...), nil
+}
+
+// introspectionCacheKey returns the key used to cache the result of the given params.
+func introspectionCacheKey(p *Params) string {
+	return p.OperationName + "\x00" + p.RequestString
+}
+
+// introspectionCacheable reports whether the result of the request described
+// by the given params may be cached, or served from the cache: the options
+// changing the result, or how it is computed, bypass the cache.
+func introspectionCacheable(p *Params) bool {
+	return p.Schema.introspection != nil &&
+		len(p.Schema.extensions) == 0 &&
+		!p.CollectStats && !p.CollectAllocations &&
+		p.ResponseLimits == (ResponseLimits{}) &&
+		!p.ReportResponseShape && !p.ReportResponseSize &&
+		p.ParseOptions == (parser.ParseOptions{}) &&
+		!p.AllowTypeSystemDefinitions &&
+		!p.SemanticNullability &&
+		p.Executor == nil
+}
+
+// cachedIntrospection returns the cached result of the request described by
+// the given params, if the request is an introspection request that was
+// already executed against the schema.
+func cachedIntrospection(p *Params) *Result {
+	if !introspectionCacheable(p) {
+		return nil
+	}
+	return p.Schema.introspection.get(introspectionCacheKey(p))
+}
+
+// cacheIntrospection caches the result of the request described by the given
+// params if the executed operation only selects introspection fields, using
+// no variables.
+func cacheIntrospection(p *Params, document *ast.Document, result *Result) {
+	if !introspectionCacheable(p) || result.HasErrors() {
+		return
+	}
+	if !isIntrospectionOperation(document, p.OperationName) {
+		return
+	}
+	p.Schema.introspection.set(introspectionCacheKey(p), result)
+}
+
+// isIntrospectionOperation reports whether the operation of the document
+// that gets executed only selects the __schema and __type meta fields at its
+// root, without depending on variables.
+func isIntrospectionOperation(document *ast.Document, operationName string) bool {
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		def, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" && operation != nil {
+			return false
+		}
+		if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+			operation = def
+		}
+	}
+	if operation == nil || operation.Operation != ast.OperationTypeQuery {
+		return false
+	}
+	if len(operation.VariableDefinitions) != 0 || len(operation.Directives) != 0 {
+		return false
+	}
+	if operation.SelectionSet == nil || len(operation.SelectionSet.Selections) == 0 {
+		return false
+	}
+	for _, selection := range operation.SelectionSet.Selections {
+		field, ok := selection.(*ast.Field)
+		if !ok || len(field.Directives) != 0 {
+			return false
+		}
+		if field.Name == nil {
+			return false
+		}
+		switch field.Name.Value {
+		case SchemaMetaFieldDef.Name, TypeMetaFieldDef.Name:
+		default:
+			return false
+		}
+	}
+	return true
+}
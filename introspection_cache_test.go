@@ -0,0 +1,174 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestIntrospectionJSON(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := schema.IntrospectionJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: graphql.IntrospectionQuery,
+	})
+	expected, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != string(expected) {
+		t.Fatalf("unexpected introspection JSON, expected: %s, got: %s", expected, b)
+	}
+
+	other := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Other",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	if err := schema.AppendType(other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err = schema.IntrospectionJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var introspection struct {
+		Data struct {
+			Schema struct {
+				Types []struct {
+					Name string `json:"name"`
+				} `json:"types"`
+			} `json:"__schema"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b, &introspection); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, ttype := range introspection.Data.Schema.Types {
+		if ttype.Name == "Other" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the cache to be invalidated by AppendType")
+	}
+}
+
+func TestIntrospectionResultsAreCached(t *testing.T) {
+	calls := 0
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						calls++
+						return "world", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query := `{ __type(name: "Query") { name fields { name } } }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"name": "Query",
+				"fields": []interface{}{
+					map[string]interface{}{"name": "hello"},
+				},
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+		})
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("unexpected result, diff: %v", testutil.Diff(expected, result))
+		}
+	}
+
+	// operations that select other fields are never cached
+	for i := 0; i < 2; i++ {
+		graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ __typename hello }`,
+		})
+	}
+	if calls != 2 {
+		t.Fatalf("expected the resolver to be called for every request, got: %v", calls)
+	}
+}
+
+func TestIntrospectionCache_BypassedByOptionsAndNotShared(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := `{ __type(name: "Query") { name } }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{"name": "Query"},
+		},
+	}
+
+	// the callers can't modify the cached result
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("unexpected result, diff: %v", testutil.Diff(expected, result))
+		}
+		result.Data.(map[string]interface{})["__type"].(map[string]interface{})["name"] = "Modified"
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		ResponseLimits: graphql.ResponseLimits{MaxBytes: 10},
+	})
+	if len(result.Errors) != 1 || result.Data != nil {
+		t.Fatalf("expected the response limits to apply to a cached request, got %v", result)
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:             schema,
+		RequestString:      query,
+		ReportResponseSize: true,
+	})
+	if _, ok := result.Extensions["responseSize"]; !ok {
+		t.Fatalf("expected the size of a cached request to be reported, got %v", result.Extensions)
+	}
+}
@@ -130,6 +130,7 @@ type VariableDefinition struct {
 	Variable     *Variable
 	Type         Type
 	DefaultValue Value
+	Directives   []*Directive
 }
 
 func NewVariableDefinition(vd *VariableDefinition) *VariableDefinition {
@@ -186,6 +187,44 @@ func (def *TypeExtensionDefinition) GetOperation() string {
 	return ""
 }
 
+// UnionExtensionDefinition implements Node, Definition
+type UnionExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *UnionDefinition
+}
+
+func NewUnionExtensionDefinition(def *UnionExtensionDefinition) *UnionExtensionDefinition {
+	if def == nil {
+		def = &UnionExtensionDefinition{}
+	}
+	return &UnionExtensionDefinition{
+		Kind:       kinds.UnionExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+	}
+}
+
+func (def *UnionExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *UnionExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *UnionExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *UnionExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *UnionExtensionDefinition) GetOperation() string {
+	return ""
+}
+
 // DirectiveDefinition implements Node, Definition
 type DirectiveDefinition struct {
 	Kind        string
@@ -193,6 +232,7 @@ type DirectiveDefinition struct {
 	Name        *Name
 	Description *StringValue
 	Arguments   []*InputValueDefinition
+	Repeatable  bool
 	Locations   []*Name
 }
 
@@ -206,6 +246,7 @@ func NewDirectiveDefinition(def *DirectiveDefinition) *DirectiveDefinition {
 		Name:        def.Name,
 		Description: def.Description,
 		Arguments:   def.Arguments,
+		Repeatable:  def.Repeatable,
 		Locations:   def.Locations,
 	}
 }
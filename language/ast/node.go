@@ -42,4 +42,5 @@ var _ Node = (*EnumDefinition)(nil)
 var _ Node = (*EnumValueDefinition)(nil)
 var _ Node = (*InputObjectDefinition)(nil)
 var _ Node = (*TypeExtensionDefinition)(nil)
+var _ Node = (*UnionExtensionDefinition)(nil)
 var _ Node = (*DirectiveDefinition)(nil)
@@ -49,6 +49,7 @@ type FragmentSpread struct {
 	Kind       string
 	Loc        *Location
 	Name       *Name
+	Arguments  []*Argument
 	Directives []*Directive
 }
 
@@ -60,6 +61,7 @@ func NewFragmentSpread(fs *FragmentSpread) *FragmentSpread {
 		Kind:       kinds.FragmentSpread,
 		Loc:        fs.Loc,
 		Name:       fs.Name,
+		Arguments:  fs.Arguments,
 		Directives: fs.Directives,
 	}
 }
@@ -0,0 +1,107 @@
+// Package astutil provides helpers to navigate and inspect GraphQL ASTs.
+package astutil
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// Index holds the parent and the ID of each node of an AST, so tools such as
+// linters and transforms can navigate upward from any node, see Parents.
+//
+// IDs are assigned in the order nodes are visited, starting at 0 for the root,
+// so they are stable across parses of the same document. The index must be
+// rebuilt when the AST is modified.
+type Index struct {
+	root    ast.Node
+	nodes   []ast.Node
+	parents map[ast.Node]ast.Node
+	ids     map[ast.Node]int
+}
+
+// Parents returns the index of the nodes of the AST rooted at the given node.
+func Parents(root ast.Node) *Index {
+	idx := &Index{
+		root:    root,
+		parents: map[ast.Node]ast.Node{},
+		ids:     map[ast.Node]int{},
+	}
+	if root == nil {
+		return idx
+	}
+	stack := []ast.Node{}
+	visitor.Visit(root, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			if _, seen := idx.ids[node]; !seen {
+				idx.ids[node] = len(idx.nodes)
+				idx.nodes = append(idx.nodes, node)
+				if len(stack) > 0 {
+					idx.parents[node] = stack[len(stack)-1]
+				}
+			}
+			stack = append(stack, node)
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if _, ok := p.Node.(ast.Node); ok && len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+	return idx
+}
+
+// Root returns the root of the indexed AST.
+func (idx *Index) Root() ast.Node {
+	return idx.root
+}
+
+// Len returns the number of indexed nodes.
+func (idx *Index) Len() int {
+	return len(idx.nodes)
+}
+
+// Parent returns the parent of the given node, or nil for the root and nodes
+// that are not indexed.
+func (idx *Index) Parent(node ast.Node) ast.Node {
+	return idx.parents[node]
+}
+
+// Ancestors returns the ancestors of the given node, closest first.
+func (idx *Index) Ancestors(node ast.Node) []ast.Node {
+	ancestors := []ast.Node{}
+	for parent := idx.parents[node]; parent != nil; parent = idx.parents[parent] {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// Enclosing returns the closest ancestor of the given node of the given kind,
+// e.g. kinds.OperationDefinition, or nil if there is none.
+func (idx *Index) Enclosing(node ast.Node, kind string) ast.Node {
+	for parent := idx.parents[node]; parent != nil; parent = idx.parents[parent] {
+		if parent.GetKind() == kind {
+			return parent
+		}
+	}
+	return nil
+}
+
+// ID returns the ID of the given node, and false if it is not indexed.
+func (idx *Index) ID(node ast.Node) (int, bool) {
+	id, ok := idx.ids[node]
+	return id, ok
+}
+
+// Node returns the node with the given ID, or nil if there is none.
+func (idx *Index) Node(id int) ast.Node {
+	if id < 0 || id >= len(idx.nodes) {
+		return nil
+	}
+	return idx.nodes[id]
+}
@@ -0,0 +1,125 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const query = `
+	query Hero($episode: Episode) {
+		hero(episode: $episode) {
+			name
+			... on Droid {
+				primaryFunction
+			}
+		}
+	}
+`
+
+func parse(t *testing.T, query string) *ast.Document {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return doc
+}
+
+// findField returns the first field with the given name in the selection set.
+func findField(selectionSet *ast.SelectionSet, name string) *ast.Field {
+	if selectionSet == nil {
+		return nil
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name.Value == name {
+				return selection
+			}
+			if field := findField(selection.SelectionSet, name); field != nil {
+				return field
+			}
+		case *ast.InlineFragment:
+			if field := findField(selection.SelectionSet, name); field != nil {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+func TestParents_NavigatesUpward(t *testing.T) {
+	doc := parse(t, query)
+	idx := astutil.Parents(doc)
+
+	operation := doc.Definitions[0].(*ast.OperationDefinition)
+	field := findField(operation.SelectionSet, "primaryFunction")
+	if field == nil {
+		t.Fatalf("primaryFunction not found")
+	}
+
+	fragment, ok := idx.Enclosing(field, kinds.InlineFragment).(*ast.InlineFragment)
+	if !ok || fragment.TypeCondition.Name.Value != "Droid" {
+		t.Fatalf("expected the enclosing inline fragment, got: %v", idx.Enclosing(field, kinds.InlineFragment))
+	}
+	if idx.Enclosing(field, kinds.OperationDefinition) != operation {
+		t.Fatalf("expected the enclosing operation")
+	}
+	if idx.Parent(field.Name) != field {
+		t.Fatalf("expected the name of the field to have the field as parent")
+	}
+	if idx.Parent(doc) != nil {
+		t.Fatalf("expected the document to have no parent")
+	}
+
+	ancestors := idx.Ancestors(field)
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1] != doc {
+		t.Fatalf("expected the last ancestor to be the document, got: %v", ancestors)
+	}
+	expectedKinds := []string{
+		kinds.SelectionSet, kinds.InlineFragment, kinds.SelectionSet, kinds.Field,
+		kinds.SelectionSet, kinds.OperationDefinition, kinds.Document,
+	}
+	if len(ancestors) != len(expectedKinds) {
+		t.Fatalf("expected %v ancestors, got: %v", len(expectedKinds), len(ancestors))
+	}
+	for i, kind := range expectedKinds {
+		if ancestors[i].GetKind() != kind {
+			t.Fatalf("expected ancestor #%v to be a %v, got: %v", i, kind, ancestors[i].GetKind())
+		}
+	}
+}
+
+func TestParents_AssignsStableIDs(t *testing.T) {
+	doc1 := parse(t, query)
+	doc2 := parse(t, query)
+	idx1 := astutil.Parents(doc1)
+	idx2 := astutil.Parents(doc2)
+
+	if idx1.Len() == 0 || idx1.Len() != idx2.Len() {
+		t.Fatalf("expected both documents to have the same number of nodes, got: %v and %v", idx1.Len(), idx2.Len())
+	}
+	if id, ok := idx1.ID(doc1); !ok || id != 0 {
+		t.Fatalf("expected the root to have ID 0, got: %v", id)
+	}
+
+	field1 := findField(doc1.Definitions[0].(*ast.OperationDefinition).SelectionSet, "name")
+	field2 := findField(doc2.Definitions[0].(*ast.OperationDefinition).SelectionSet, "name")
+	id1, ok1 := idx1.ID(field1)
+	id2, ok2 := idx2.ID(field2)
+	if !ok1 || !ok2 || id1 != id2 {
+		t.Fatalf("expected the same field of both documents to have the same ID, got: %v and %v", id1, id2)
+	}
+	if idx1.Node(id1) != field1 {
+		t.Fatalf("expected the node with ID %v to be the field", id1)
+	}
+	if idx1.Node(idx1.Len()) != nil || idx1.Node(-1) != nil {
+		t.Fatalf("expected no node for unknown IDs")
+	}
+	if _, ok := idx1.ID(field2); ok {
+		t.Fatalf("expected nodes of another document not to be indexed")
+	}
+}
@@ -0,0 +1,159 @@
+package astutil
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DocumentStats are the size statistics of the selections of a document, see
+// Stats.
+type DocumentStats struct {
+	// Operations and Fragments are the numbers of operation and fragment
+	// definitions.
+	Operations int
+	Fragments  int
+
+	// Fields, FragmentSpreads and InlineFragments are the numbers of
+	// selections of each kind written in the document.
+	Fields          int
+	FragmentSpreads int
+	InlineFragments int
+
+	// Arguments and Directives are the numbers of arguments and directives
+	// written in the operations and fragments, the arguments of the
+	// directives included.
+	Arguments  int
+	Directives int
+
+	// Aliases is the number of distinct aliases.
+	Aliases int
+
+	// MaxDepth is the maximum depth of the fields of the operations, the
+	// root fields having depth 1, following the fragment spreads.
+	MaxDepth int
+}
+
+// Stats returns the size statistics of the document, in a single pass over
+// it, without validating it: a cheap gate for the documents of untrusted
+// clients before their validation, e.g. rejecting the documents having more
+// than a thousand fields. The fragments are counted once however many times
+// they are spread, and spreads of unknown fragments and cycles add no depth.
+func Stats(doc *ast.Document) DocumentStats {
+	s := &statsCounter{
+		aliases:   map[string]bool{},
+		fragments: map[string]*ast.FragmentDefinition{},
+		depths:    map[string]int{},
+	}
+	if doc == nil {
+		return s.stats
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			s.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			s.stats.Operations++
+			s.countDirectives(definition.Directives)
+			s.countSelectionSet(definition.SelectionSet)
+		case *ast.FragmentDefinition:
+			s.stats.Fragments++
+			s.countDirectives(definition.Directives)
+			s.countSelectionSet(definition.SelectionSet)
+		}
+	}
+	for _, definition := range doc.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			if depth := s.depth(operation.SelectionSet); depth > s.stats.MaxDepth {
+				s.stats.MaxDepth = depth
+			}
+		}
+	}
+	s.stats.Aliases = len(s.aliases)
+	return s.stats
+}
+
+type statsCounter struct {
+	stats     DocumentStats
+	aliases   map[string]bool
+	fragments map[string]*ast.FragmentDefinition
+
+	// depths are the depths of the fragments, -1 while being computed
+	depths map[string]int
+}
+
+func (s *statsCounter) countSelectionSet(selectionSet *ast.SelectionSet) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			s.stats.Fields++
+			if selection.Alias != nil {
+				s.aliases[selection.Alias.Value] = true
+			}
+			s.stats.Arguments += len(selection.Arguments)
+			s.countDirectives(selection.Directives)
+			s.countSelectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			s.stats.FragmentSpreads++
+			s.stats.Arguments += len(selection.Arguments)
+			s.countDirectives(selection.Directives)
+		case *ast.InlineFragment:
+			s.stats.InlineFragments++
+			s.countDirectives(selection.Directives)
+			s.countSelectionSet(selection.SelectionSet)
+		}
+	}
+}
+
+func (s *statsCounter) countDirectives(directives []*ast.Directive) {
+	s.stats.Directives += len(directives)
+	for _, directive := range directives {
+		s.stats.Arguments += len(directive.Arguments)
+	}
+}
+
+// depth returns the depth of the fields of the selection set.
+func (s *statsCounter) depth(selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
+	max := 0
+	for _, selection := range selectionSet.Selections {
+		depth := 0
+		switch selection := selection.(type) {
+		case *ast.Field:
+			depth = 1 + s.depth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = s.depth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Name != nil {
+				depth = s.fragmentDepth(selection.Name.Value)
+			}
+		}
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
+
+func (s *statsCounter) fragmentDepth(name string) int {
+	if depth, ok := s.depths[name]; ok {
+		if depth < 0 {
+			return 0
+		}
+		return depth
+	}
+	fragment, ok := s.fragments[name]
+	if !ok {
+		return 0
+	}
+	s.depths[name] = -1
+	depth := s.depth(fragment.SelectionSet)
+	s.depths[name] = depth
+	return depth
+}
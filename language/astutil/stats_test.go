@@ -0,0 +1,41 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+)
+
+func TestStats(t *testing.T) {
+	doc := parse(t, `
+		query Feed($first: Int) @cached {
+			first: posts(first: $first) {
+				...PostFields
+				author { ... on User { name @include(if: true) } }
+			}
+			second: posts(first: 2, after: "a") { ...PostFields }
+		}
+		fragment PostFields on Post {
+			title
+			comments { author { ...Cycle } }
+		}
+		fragment Cycle on User { friends { ...Cycle } }
+	`)
+	expected := astutil.DocumentStats{
+		Operations:      1,
+		Fragments:       2,
+		Fields:          8,
+		FragmentSpreads: 4,
+		InlineFragments: 1,
+		Arguments:       4,
+		Directives:      2,
+		Aliases:         2,
+		MaxDepth:        4,
+	}
+	if stats := astutil.Stats(doc); stats != expected {
+		t.Fatalf("expected %+v, got: %+v", expected, stats)
+	}
+	if stats := astutil.Stats(nil); stats != (astutil.DocumentStats{}) {
+		t.Fatalf("expected empty stats, got: %+v", stats)
+	}
+}
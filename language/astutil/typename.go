@@ -0,0 +1,75 @@
+package astutil
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// AddTypename returns a copy of the document with a __typename field selected
+// in the selection set of every field having one, i.e. of every object,
+// interface and union field, as gateways need before delegating an operation
+// so the abstract types of the returned data can be resolved. The selection
+// sets already selecting __typename without an alias nor directives are kept
+// as they are, so adding it again changes nothing, and the root selection sets
+// of the operations are left alone, their types being known. The fragments
+// are changed along with the fields. The document is not modified.
+func AddTypename(doc *ast.Document) *ast.Document {
+	if doc == nil {
+		return nil
+	}
+	added := *doc
+	added.Definitions = make([]ast.Node, len(doc.Definitions))
+	for i, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			operation := *definition
+			operation.SelectionSet = addTypename(definition.SelectionSet, false)
+			added.Definitions[i] = &operation
+		case *ast.FragmentDefinition:
+			fragment := *definition
+			fragment.SelectionSet = addTypename(definition.SelectionSet, false)
+			added.Definitions[i] = &fragment
+		default:
+			added.Definitions[i] = definition
+		}
+	}
+	return &added
+}
+
+// addTypename copies the selection set, adding __typename to the selection
+// sets of its fields, and to itself when typename is true.
+func addTypename(selectionSet *ast.SelectionSet, typename bool) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	added := *selectionSet
+	added.Selections = make([]ast.Selection, 0, len(selectionSet.Selections)+1)
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if isTypename(selection) {
+				typename = false
+			}
+			field := *selection
+			field.SelectionSet = addTypename(selection.SelectionSet, true)
+			added.Selections = append(added.Selections, &field)
+		case *ast.InlineFragment:
+			fragment := *selection
+			fragment.SelectionSet = addTypename(selection.SelectionSet, false)
+			added.Selections = append(added.Selections, &fragment)
+		default:
+			added.Selections = append(added.Selections, selection)
+		}
+	}
+	if typename {
+		added.Selections = append(added.Selections, ast.NewField(&ast.Field{
+			Name: ast.NewName(&ast.Name{Value: "__typename"}),
+		}))
+	}
+	return &added
+}
+
+// isTypename reports whether the field is a plain __typename selection.
+func isTypename(field *ast.Field) bool {
+	return field.Name != nil && field.Name.Value == "__typename" &&
+		field.Alias == nil && len(field.Directives) == 0
+}
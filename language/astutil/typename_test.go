@@ -0,0 +1,59 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+func TestAddTypename(t *testing.T) {
+	doc := parse(t, `
+		{
+			hero {
+				name
+				friends { ... on Droid { primaryFunction } }
+			}
+			search { __typename ...Result }
+		}
+		fragment Result on SearchResult {
+			... on Human { starships { name } }
+		}
+	`)
+	expected := `{
+  hero {
+    name
+    friends {
+      ... on Droid {
+        primaryFunction
+      }
+      __typename
+    }
+    __typename
+  }
+  search {
+    __typename
+    ...Result
+  }
+}
+
+fragment Result on SearchResult {
+  ... on Human {
+    starships {
+      name
+      __typename
+    }
+  }
+}
+`
+	added := astutil.AddTypename(doc)
+	if printed := printer.Print(added); printed != expected {
+		t.Fatalf("unexpected document:\n%v", printed)
+	}
+	if printed := printer.Print(astutil.AddTypename(added)); printed != expected {
+		t.Fatalf("expected adding __typename twice to change nothing, got:\n%v", printed)
+	}
+	if printed := printer.Print(doc); printed == expected {
+		t.Fatalf("expected the document not to be modified")
+	}
+}
@@ -149,7 +149,7 @@ func readNumber(s *source.Source, start int, firstCode rune, codeLength int) (To
 		position += codeLength
 		code, codeLength = runeAt(body, position)
 		if code >= '0' && code <= '9' {
-			description := fmt.Sprintf("Invalid number, unexpected digit after 0: %v.", printCharCode(code))
+			description := gqlerrors.Message(gqlerrors.MsgUnexpectedDigitAfterZero, printCharCode(code))
 			return Token{}, gqlerrors.NewSyntaxError(s, position, description)
 		}
 	} else {
@@ -211,7 +211,7 @@ func readDigits(s *source.Source, start int, firstCode rune, codeLength int) (in
 		return position, nil
 	}
 	var description string
-	description = fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
+	description = gqlerrors.Message(gqlerrors.MsgExpectedDigit, printCharCode(code))
 	return position, gqlerrors.NewSyntaxError(s, position, description)
 }
 
@@ -233,7 +233,7 @@ func readString(s *source.Source, start int) (Token, error) {
 
 			// SourceCharacter
 			if code < 0x0020 && code != 0x0009 {
-				return Token{}, gqlerrors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
+				return Token{}, gqlerrors.NewSyntaxError(s, runePosition, gqlerrors.Message(gqlerrors.MsgInvalidCharacterInString, printCharCode(code)))
 			}
 			position += n
 			runePosition++
@@ -269,8 +269,7 @@ func readString(s *source.Source, start int) (Token, error) {
 					// Check if there are at least 4 bytes available
 					if len(body) <= position+4 {
 						return Token{}, gqlerrors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:])))
+							gqlerrors.Message(gqlerrors.MsgInvalidUnicodeEscape, string(body[position+1:])))
 					}
 					charCode := uniCharCode(
 						rune(body[position+1]),
@@ -280,8 +279,7 @@ func readString(s *source.Source, start int) (Token, error) {
 					)
 					if charCode < 0 {
 						return Token{}, gqlerrors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:position+5])))
+							gqlerrors.Message(gqlerrors.MsgInvalidUnicodeEscape, string(body[position+1:position+5])))
 					}
 					valueBuffer.WriteRune(charCode)
 					position += 4
@@ -289,7 +287,7 @@ func readString(s *source.Source, start int) (Token, error) {
 					break
 				default:
 					return Token{}, gqlerrors.NewSyntaxError(s, runePosition,
-						fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
+						gqlerrors.Message(gqlerrors.MsgInvalidEscape, code))
 				}
 				position += n
 				runePosition++
@@ -301,7 +299,7 @@ func readString(s *source.Source, start int) (Token, error) {
 		}
 	}
 	if code != '"' { // quote (")
-		return Token{}, gqlerrors.NewSyntaxError(s, runePosition, "Unterminated string.")
+		return Token{}, gqlerrors.NewSyntaxError(s, runePosition, gqlerrors.Message(gqlerrors.MsgUnterminatedString))
 	}
 	stringContent := body[chunkStart:position]
 	valueBuffer.Write(stringContent)
@@ -344,7 +342,7 @@ func readBlockString(s *source.Source, start int) (Token, error) {
 			code != 0x0009 &&
 			code != 0x000a &&
 			code != 0x000d {
-			return Token{}, gqlerrors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
+			return Token{}, gqlerrors.NewSyntaxError(s, runePosition, gqlerrors.Message(gqlerrors.MsgInvalidCharacterInString, printCharCode(code)))
 		}
 
 		// Escape Triple-Quote (\""")
@@ -366,7 +364,7 @@ func readBlockString(s *source.Source, start int) (Token, error) {
 		runePosition++
 	}
 
-	return Token{}, gqlerrors.NewSyntaxError(s, runePosition, "Unterminated string.")
+	return Token{}, gqlerrors.NewSyntaxError(s, runePosition, gqlerrors.Message(gqlerrors.MsgUnterminatedString))
 }
 
 var splitLinesRegex = regexp.MustCompile("\r\n|[\n\r]")
@@ -490,7 +488,7 @@ func readToken(s *source.Source, fromPosition int) (Token, error) {
 
 	// SourceCharacter
 	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
-		return Token{}, gqlerrors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
+		return Token{}, gqlerrors.NewSyntaxError(s, runePosition, gqlerrors.Message(gqlerrors.MsgInvalidCharacter, printCharCode(code)))
 	}
 
 	switch code {
@@ -571,7 +569,7 @@ func readToken(s *source.Source, fromPosition int) (Token, error) {
 		}
 		return token, err
 	}
-	description := fmt.Sprintf("Unexpected character %v.", printCharCode(code))
+	description := gqlerrors.Message(gqlerrors.MsgUnexpectedCharacter, printCharCode(code))
 	return Token{}, gqlerrors.NewSyntaxError(s, runePosition, description)
 }
 
@@ -648,3 +646,51 @@ func GetTokenDesc(token Token) string {
 	}
 	return fmt.Sprintf("%s \"%s\"", token.Kind.String(), token.Value)
 }
+
+// LeadingComment returns the value of the comments on the lines directly
+// preceding the token starting at position, when the token is the first of
+// its line, as read by the legacy comment descriptions: the comment lines are
+// joined without their `#` and their common indentation is removed. It also
+// returns the start and end positions of the comments, -1 when there are
+// none.
+func LeadingComment(s *source.Source, position int) (value string, start int, end int) {
+	body := s.Body
+	isIgnored := func(line []byte) bool {
+		return len(bytes.Trim(line, " \t,\uFEFF")) == 0
+	}
+	lineStart := func(position int) int {
+		for position > 0 && body[position-1] != '\n' && body[position-1] != '\r' {
+			position--
+		}
+		return position
+	}
+
+	start, end = -1, -1
+	current := lineStart(position)
+	if !isIgnored(body[current:position]) {
+		return "", -1, -1
+	}
+	comments := []string{}
+	for current > 0 {
+		lineEnd := current - 1
+		if body[lineEnd] == '\n' && lineEnd > 0 && body[lineEnd-1] == '\r' {
+			lineEnd--
+		}
+		previous := lineStart(lineEnd)
+		line := body[previous:lineEnd]
+		comment := bytes.TrimLeft(line, " \t,\uFEFF")
+		if len(comment) == 0 || comment[0] != '#' {
+			break
+		}
+		comments = append([]string{string(comment[1:])}, comments...)
+		if end == -1 {
+			end = lineEnd
+		}
+		start = lineEnd - len(comment)
+		current = previous
+	}
+	if len(comments) == 0 {
+		return "", -1, -1
+	}
+	return blockStringValue("\n" + strings.Join(comments, "\n")), start, end
+}
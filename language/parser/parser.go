@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"fmt"
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
@@ -38,6 +37,26 @@ func init() {
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
+
+	// ExperimentalFragmentVariables enables the experimental fragment variables:
+	// variable definitions on fragment definitions and arguments on fragment
+	// spreads, such as `fragment Foo($x: Int = 1) on T` and `...Foo(x: 2)`.
+	ExperimentalFragmentVariables bool
+
+	// CommentDescriptions reads the `#` comments on the lines directly
+	// preceding a type system definition without a string description as
+	// its description, as the legacy graphql-js did before descriptions were
+	// strings, to load older schema files without rewriting them.
+	CommentDescriptions bool
+
+	// Recover, on a syntax error, skips to the next definition and goes on
+	// parsing, for the editors and linters working on documents being
+	// written: Parse returns the document of the definitions parsed along
+	// with a gqlerrors.List of all the syntax errors.
+	// A definition is resumed at its first token when it begins a line,
+	// after the closing of the definition in error or, when it is left
+	// unclosed, at the beginning of the line.
+	Recover bool
 }
 
 type ParseParams struct {
@@ -63,12 +82,17 @@ func Parse(p ParseParams) (*ast.Document, error) {
 		sourceObj = source.NewSource(&source.Source{Body: []byte(body)})
 	}
 	parser, err := makeParser(sourceObj, p.Options)
-	if err != nil {
+	if err != nil && !p.Options.Recover {
 		return nil, err
 	}
-	doc, err := parseDocument(parser)
+	var errs gqlerrors.List
 	if err != nil {
-		return nil, err
+		errs = errs.Append(err)
+		recoverDefinition(parser, 0)
+	}
+	doc, err := parseDocument(parser, errs)
+	if err != nil {
+		return doc, err
 	}
 	return doc, nil
 }
@@ -111,7 +135,7 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 	lexToken := lexer.Lex(s)
 	token, err := lexToken(0)
 	if err != nil {
-		return &Parser{}, err
+		return &Parser{LexToken: lexToken, Source: s, Options: opts}, err
 	}
 	return &Parser{
 		LexToken: lexToken,
@@ -124,11 +148,12 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 
 /* Implements the parsing rules in the Document section. */
 
-func parseDocument(parser *Parser) (*ast.Document, error) {
+// parseDocument parses the document, recovering from the syntax errors with
+// ParseOptions.Recover, appended to errs.
+func parseDocument(parser *Parser, errs gqlerrors.List) (*ast.Document, error) {
 	var (
 		nodes []ast.Node
 		node  ast.Node
-		item  parseDefinitionFn
 		err   error
 	)
 	start := parser.Token.Start
@@ -138,23 +163,96 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 		} else if skp {
 			break
 		}
-		switch kind := parser.Token.Kind; kind {
-		case lexer.BRACE_L:
-			item = parseOperationDefinition
-		case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
-			item = parseTypeSystemDefinition
-		default:
-			return nil, unexpected(parser, lexer.Token{})
-		}
-		if node, err = item(parser); err != nil {
-			return nil, err
+		definitionStart := parser.Token.Start
+		if node, err = parseDefinition(parser); err != nil {
+			if !parser.Options.Recover {
+				return nil, err
+			}
+			errs = errs.Append(err)
+			recoverDefinition(parser, definitionStart)
+			continue
 		}
 		nodes = append(nodes, node)
 	}
 	return ast.NewDocument(&ast.Document{
 		Loc:         loc(parser, start),
 		Definitions: nodes,
-	}), nil
+	}), errs.Err()
+}
+
+func parseDefinition(parser *Parser) (ast.Node, error) {
+	switch parser.Token.Kind {
+	case lexer.BRACE_L:
+		return parseOperationDefinition(parser)
+	case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
+		return parseTypeSystemDefinition(parser)
+	}
+	return nil, unexpected(parser, lexer.Token{})
+}
+
+// recoverDefinition advances the parser to the first token after the given
+// position which begins a definition on its line, either out of the
+// brackets opened since the position or at the beginning of the line, or to
+// the end of the source, skipping the characters the lexer fails to read.
+func recoverDefinition(parser *Parser, position int) {
+	start, depth := position, 0
+	token, err := parser.LexToken(position)
+	for {
+		if err != nil {
+			next := position + 1
+			if err, ok := err.(*gqlerrors.Error); ok && len(err.Positions) > 0 && err.Positions[0] >= next {
+				next = err.Positions[0] + 1
+			}
+			position = next
+			token, err = parser.LexToken(position)
+			continue
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		if token.Start != start && beginsDefinition(token) {
+			if column := lineColumn(parser.Source, token.Start); column == 0 || column > 0 && depth <= 0 {
+				break
+			}
+		}
+		switch token.Kind {
+		case lexer.BRACE_L, lexer.PAREN_L, lexer.BRACKET_L:
+			depth++
+		case lexer.BRACE_R, lexer.PAREN_R, lexer.BRACKET_R:
+			depth--
+		}
+		position = token.End
+		token, err = parser.LexToken(position)
+	}
+	parser.PrevEnd = position
+	parser.Token = token
+}
+
+// lineColumn returns the column of the position if it is preceded only by
+// ignored characters on its line, -1 otherwise.
+func lineColumn(s *source.Source, position int) int {
+	for i := position - 1; i >= 0; i-- {
+		switch s.Body[i] {
+		case '\n', '\r':
+			return position - i - 1
+		case ' ', '\t', ',':
+		default:
+			return -1
+		}
+	}
+	return position
+}
+
+// beginsDefinition reports whether the token can begin a definition.
+func beginsDefinition(token lexer.Token) bool {
+	switch token.Kind {
+	case lexer.BRACE_L, lexer.STRING, lexer.BLOCK_STRING:
+		return true
+	case lexer.NAME:
+		_, ok := tokenDefinitionFn[token.Value]
+		return ok
+	}
+	return false
 }
 
 /* Implements the parsing rules in the Operations section. */
@@ -282,10 +380,15 @@ func parseVariableDefinition(parser *Parser) (interface{}, error) {
 			return nil, err
 		}
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewVariableDefinition(&ast.VariableDefinition{
 		Variable:     variable,
 		Type:         ttype,
 		DefaultValue: defaultValue,
+		Directives:   directives,
 		Loc:          loc(parser, start),
 	}), nil
 }
@@ -462,12 +565,19 @@ func parseFragment(parser *Parser) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+		var arguments []*ast.Argument
+		if parser.Options.ExperimentalFragmentVariables {
+			if arguments, err = parseArguments(parser); err != nil {
+				return nil, err
+			}
+		}
 		directives, err := parseDirectives(parser)
 		if err != nil {
 			return nil, err
 		}
 		return ast.NewFragmentSpread(&ast.FragmentSpread{
 			Name:       name,
+			Arguments:  arguments,
 			Directives: directives,
 			Loc:        loc(parser, start),
 		}), nil
@@ -516,6 +626,12 @@ func parseFragmentDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	var variableDefinitions []*ast.VariableDefinition
+	if parser.Options.ExperimentalFragmentVariables {
+		if variableDefinitions, err = parseVariableDefinitions(parser); err != nil {
+			return nil, err
+		}
+	}
 	_, err = expectKeyWord(parser, "on")
 	if err != nil {
 		return nil, err
@@ -533,11 +649,12 @@ func parseFragmentDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	return ast.NewFragmentDefinition(&ast.FragmentDefinition{
-		Name:          name,
-		TypeCondition: typeCondition,
-		Directives:    directives,
-		SelectionSet:  selectionSet,
-		Loc:           loc(parser, start),
+		Name:                name,
+		VariableDefinitions: variableDefinitions,
+		TypeCondition:       typeCondition,
+		Directives:          directives,
+		SelectionSet:        selectionSet,
+		Loc:                 loc(parser, start),
 	}), nil
 }
 
@@ -1361,7 +1478,9 @@ func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * TypeExtensionDefinition : extend ObjectTypeDefinition
+ * TypeExtensionDefinition :
+ *   - extend ObjectTypeDefinition
+ *   - extend UnionTypeDefinition
  */
 func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1370,6 +1489,17 @@ func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 
+	if peek(parser, lexer.NAME) && parser.Token.Value == lexer.UNION {
+		definition, err := parseUnionTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewUnionExtensionDefinition(&ast.UnionExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition.(*ast.UnionDefinition),
+		}), nil
+	}
+
 	definition, err := parseObjectTypeDefinition(parser)
 	if err != nil {
 		return nil, err
@@ -1390,6 +1520,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		description *ast.StringValue
 		name        *ast.Name
 		args        []*ast.InputValueDefinition
+		repeatable  bool
 		locations   []*ast.Name
 	)
 	start := parser.Token.Start
@@ -1408,6 +1539,12 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if args, err = parseArgumentDefs(parser); err != nil {
 		return nil, err
 	}
+	if peek(parser, lexer.NAME) && parser.Token.Value == "repeatable" {
+		repeatable = true
+		if err = advance(parser); err != nil {
+			return nil, err
+		}
+	}
 	if _, err = expectKeyWord(parser, "on"); err != nil {
 		return nil, err
 	}
@@ -1420,6 +1557,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		Name:        name,
 		Description: description,
 		Arguments:   args,
+		Repeatable:  repeatable,
 		Locations:   locations,
 	}), nil
 }
@@ -1465,9 +1603,29 @@ func parseDescription(parser *Parser) (*ast.StringValue, error) {
 	if peekDescription(parser) {
 		return parseStringLiteral(parser)
 	}
+	if parser.Options.CommentDescriptions {
+		return parseCommentDescription(parser), nil
+	}
 	return nil, nil
 }
 
+// parseCommentDescription returns the comments preceding the current token
+// as a description, nil if there are none, see ParseOptions.CommentDescriptions.
+func parseCommentDescription(parser *Parser) *ast.StringValue {
+	value, start, end := lexer.LeadingComment(parser.Source, parser.Token.Start)
+	if start < 0 {
+		return nil
+	}
+	description := &ast.StringValue{Value: value}
+	if !parser.Options.NoLocation {
+		description.Loc = ast.NewLocation(&ast.Location{Start: start, End: end})
+		if !parser.Options.NoSource {
+			description.Loc.Source = parser.Source
+		}
+	}
+	return ast.NewStringValue(description)
+}
+
 /* Core parsing utility functions */
 
 // Returns a location object, used to identify the place in
@@ -1531,7 +1689,7 @@ func expect(parser *Parser, kind lexer.TokenKind) (lexer.Token, error) {
 	if token.Kind == kind {
 		return token, advance(parser)
 	}
-	descp := fmt.Sprintf("Expected %s, found %s", kind, lexer.GetTokenDesc(token))
+	descp := gqlerrors.Message(gqlerrors.MsgExpectedToken, kind, lexer.GetTokenDesc(token))
 	return token, gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
 }
 
@@ -1542,7 +1700,7 @@ func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 	if token.Kind == lexer.NAME && token.Value == value {
 		return token, advance(parser)
 	}
-	descp := fmt.Sprintf("Expected \"%s\", found %s", value, lexer.GetTokenDesc(token))
+	descp := gqlerrors.Message(gqlerrors.MsgExpectedKeyword, value, lexer.GetTokenDesc(token))
 	return token, gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
 }
 
@@ -1553,12 +1711,12 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 	if (atToken == lexer.Token{}) {
 		token = parser.Token
 	}
-	description := fmt.Sprintf("Unexpected %v", lexer.GetTokenDesc(token))
+	description := gqlerrors.Message(gqlerrors.MsgUnexpectedToken, lexer.GetTokenDesc(token))
 	return gqlerrors.NewSyntaxError(parser.Source, token.Start, description)
 }
 
 func unexpectedEmpty(parser *Parser, beginLoc int, openKind, closeKind lexer.TokenKind) error {
-	description := fmt.Sprintf("Unexpected empty IN %s%s", openKind, closeKind)
+	description := gqlerrors.Message(gqlerrors.MsgUnexpectedEmpty, openKind, closeKind)
 	return gqlerrors.NewSyntaxError(parser.Source, beginLoc, description)
 }
 
@@ -165,6 +165,43 @@ func TestParsesConstantDefaultValues(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
+func TestParsesVariableDefinitionDirectives(t *testing.T) {
+	doc, err := Parse(ParseParams{Source: `query ($id: ID! = "a" @lowerCase @trim(left: true)) { field }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	operation := doc.Definitions[0].(*ast.OperationDefinition)
+	directives := operation.VariableDefinitions[0].Directives
+	if len(directives) != 2 || directives[0].Name.Value != "lowerCase" || directives[1].Name.Value != "trim" {
+		t.Fatalf("unexpected directives: %v", directives)
+	}
+	if len(directives[1].Arguments) != 1 {
+		t.Fatalf("expected the arguments of @trim, got: %v", directives[1].Arguments)
+	}
+}
+
+func TestParsesExperimentalFragmentVariables(t *testing.T) {
+	source := `{ ...Foo(x: 1) } fragment Foo($x: Int = 2) on Query { field(x: $x) }`
+	if _, err := Parse(ParseParams{Source: source}); err == nil {
+		t.Fatalf("expected fragment variables to require the option")
+	}
+	doc, err := Parse(ParseParams{
+		Source:  source,
+		Options: ParseOptions{ExperimentalFragmentVariables: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spread := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.FragmentSpread)
+	if len(spread.Arguments) != 1 || spread.Arguments[0].Name.Value != "x" {
+		t.Fatalf("unexpected spread arguments: %v", spread.Arguments)
+	}
+	fragment := doc.Definitions[1].(*ast.FragmentDefinition)
+	if len(fragment.VariableDefinitions) != 1 || fragment.VariableDefinitions[0].Variable.Name.Value != "x" {
+		t.Fatalf("unexpected fragment variables: %v", fragment.VariableDefinitions)
+	}
+}
+
 func TestDoesNotAcceptFragmentsNameOn(t *testing.T) {
 	test := errorMessageTest{
 		`fragment on on on { on }`,
@@ -809,3 +846,88 @@ func toError(err error) *gqlerrors.Error {
 		return nil
 	}
 }
+
+func TestParsesRepeatableDirectiveDefinitions(t *testing.T) {
+	for source, expected := range map[string]bool{
+		`directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION`: true,
+		`directive @tag(name: String!) on OBJECT | FIELD_DEFINITION`:            false,
+		`directive @tag repeatable on OBJECT`:                                   true,
+	} {
+		astDoc, err := Parse(ParseParams{Source: source})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		def := astDoc.Definitions[0].(*ast.DirectiveDefinition)
+		if def.Repeatable != expected {
+			t.Fatalf("%v: expected repeatable to be %v", source, expected)
+		}
+		if printed := printer.Print(astDoc); printed != source+"\n" {
+			t.Fatalf("expected %q, got: %q", source, printed)
+		}
+	}
+}
+
+func TestParseRecover_ReturnsPartialDocumentAndAllErrors(t *testing.T) {
+	source := `query A {
+  a
+}
+
+query B {
+  b(x: )
+}
+
+type User {
+  name: String
+
+type Post {
+  title: String
+}
+
+query C { c(x: ?) }
+fragment F on User { name }
+query D { d }
+`
+	astDoc, err := Parse(ParseParams{Source: source, Options: ParseOptions{Recover: true}})
+	errs, ok := err.(gqlerrors.List)
+	if !ok {
+		t.Fatalf("expected a list of errors, got: %#v", err)
+	}
+	expectedErrors := []string{
+		`Syntax Error GraphQL (6:8) Unexpected )`,
+		`Syntax Error GraphQL (12:6) Expected :, found Name "Post"`,
+		`Syntax Error GraphQL (16:16) Unexpected character "?".`,
+	}
+	if len(errs) != len(expectedErrors) {
+		t.Fatalf("expected %v errors, got: %v", len(expectedErrors), errs)
+	}
+	for i, expected := range expectedErrors {
+		if !strings.HasPrefix(errs[i].Message, expected) {
+			t.Fatalf("expected error %q, got: %q", expected, errs[i].Message)
+		}
+	}
+
+	names := []string{}
+	for _, definition := range astDoc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			names = append(names, definition.Name.Value)
+		case *ast.ObjectDefinition:
+			names = append(names, definition.Name.Value)
+		case *ast.FragmentDefinition:
+			names = append(names, definition.Name.Value)
+		}
+	}
+	if expected := []string{"A", "Post", "F", "D"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected the definitions %v, got: %v", expected, names)
+	}
+}
+
+func TestParseRecover_WithoutErrors(t *testing.T) {
+	astDoc, err := Parse(ParseParams{Source: `{ a }`, Options: ParseOptions{Recover: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(astDoc.Definitions) != 1 {
+		t.Fatalf("expected a definition, got: %v", astDoc.Definitions)
+	}
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Edit is a text edit of a source: the bytes from Start to End replaced by
+// Text.
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+type ReparseParams struct {
+	// Document is the document of Source, parsed with locations.
+	Document *ast.Document
+	Source   *source.Source
+	Edit     Edit
+	Options  ParseOptions
+}
+
+// Reparse parses the source edited by the edit, reparsing only the
+// definitions of the document the edit touches, for the editors and language
+// servers reparsing large documents as they are written. It returns the
+// document of the edited source along with it.
+//
+// The other definitions of the document are reused, their locations patched
+// to refer to the edited source: the document given must no longer be used.
+// The document is parsed entirely when it has no locations, when the edit
+// changes the definitions around it, e.g. by removing a closing brace, and
+// with ParseOptions.Recover. The locations are always recorded.
+func Reparse(p ReparseParams) (*ast.Document, *source.Source, error) {
+	edit := p.Edit
+	body := p.Source.Body
+	if edit.Start < 0 || edit.End < edit.Start || edit.End > len(body) {
+		edit = Edit{Start: 0, End: len(body), Text: edit.Text}
+	}
+	edited := make([]byte, 0, len(body)-(edit.End-edit.Start)+len(edit.Text))
+	edited = append(edited, body[:edit.Start]...)
+	edited = append(edited, edit.Text...)
+	edited = append(edited, body[edit.End:]...)
+	s := source.NewSource(&source.Source{Body: edited, Name: p.Source.Name})
+
+	opts := p.Options
+	opts.NoLocation, opts.NoSource = false, false
+	if doc, ok := reparseDefinitions(p.Document, s, edit, opts); ok {
+		return doc, s, nil
+	}
+	doc, err := Parse(ParseParams{Source: s, Options: opts})
+	return doc, s, err
+}
+
+// reparseDefinitions reparses the definitions of the document the edit
+// touches, reporting false when the document is to be parsed entirely.
+func reparseDefinitions(doc *ast.Document, s *source.Source, edit Edit, opts ParseOptions) (*ast.Document, bool) {
+	if doc == nil || opts.Recover || len(doc.Definitions) == 0 {
+		return nil, false
+	}
+	// the definitions before and after the edit are kept
+	before, after := 0, len(doc.Definitions)
+	for i, definition := range doc.Definitions {
+		loc := definition.GetLoc()
+		if loc == nil {
+			return nil, false
+		}
+		if loc.End < edit.Start {
+			before = i + 1
+		}
+		if loc.Start > edit.End && after == len(doc.Definitions) {
+			after = i
+		}
+	}
+	// the comments preceding a definition can be its description
+	if opts.CommentDescriptions && after < len(doc.Definitions) {
+		after++
+	}
+
+	delta := len(edit.Text) - (edit.End - edit.Start)
+	start, end := 0, len(s.Body)
+	if before > 0 {
+		start = doc.Definitions[before-1].GetLoc().End
+	}
+	if after < len(doc.Definitions) {
+		end = doc.Definitions[after].GetLoc().Start + delta
+	}
+
+	parser, err := makeParser(s, opts)
+	if err != nil {
+		return nil, false
+	}
+	if start > 0 {
+		if parser.Token, err = parser.LexToken(start); err != nil {
+			return nil, false
+		}
+		parser.PrevEnd = start
+	}
+	definitions := append([]ast.Node{}, doc.Definitions[:before]...)
+	for parser.Token.Kind != lexer.EOF && parser.Token.Start < end {
+		node, err := parseDefinition(parser)
+		if err != nil {
+			return nil, false
+		}
+		definitions = append(definitions, node)
+	}
+	// the edit changed the definitions after the reparsed ones
+	if after < len(doc.Definitions) && parser.Token.Start != end ||
+		after == len(doc.Definitions) && parser.Token.Kind != lexer.EOF {
+		return nil, false
+	}
+	if len(definitions) == 0 && after == len(doc.Definitions) {
+		return nil, false
+	}
+	for i, definition := range doc.Definitions {
+		switch {
+		case i < before:
+			patchLocations(reflect.ValueOf(definition), s, 0)
+		case i >= after:
+			patchLocations(reflect.ValueOf(definition), s, delta)
+			definitions = append(definitions, definition)
+		}
+	}
+	return ast.NewDocument(&ast.Document{
+		Loc: &ast.Location{
+			Start:  definitions[0].GetLoc().Start,
+			End:    len(s.Body),
+			Source: s,
+		},
+		Definitions: definitions,
+	}), true
+}
+
+var locationType = reflect.TypeOf(&ast.Location{})
+
+// patchLocations shifts the locations of the node and its children by delta,
+// in the given source.
+func patchLocations(value reflect.Value, s *source.Source, delta int) {
+	switch value.Kind() {
+	case reflect.Interface:
+		patchLocations(value.Elem(), s, delta)
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		if value.Type() == locationType {
+			loc := value.Interface().(*ast.Location)
+			loc.Start += delta
+			loc.End += delta
+			loc.Source = s
+			return
+		}
+		patchLocations(value.Elem(), s, delta)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			patchLocations(value.Field(i), s, delta)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			patchLocations(value.Index(i), s, delta)
+		}
+	}
+}
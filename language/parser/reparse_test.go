@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/source"
+)
+
+func TestReparse_MatchesParse(t *testing.T) {
+	body := `"""The users."""
+type User {
+  name: String
+}
+
+type Post {
+  title: String
+}
+
+query Posts { posts { title } }
+`
+	tests := []struct {
+		name string
+		edit func(body string) Edit
+	}{
+		{"edit in a definition", func(body string) Edit {
+			at := strings.Index(body, "title: String")
+			return Edit{Start: at, End: at + len("title"), Text: "headline"}
+		}},
+		{"edit of a description", func(body string) Edit {
+			at := strings.Index(body, "users")
+			return Edit{Start: at, End: at + len("users"), Text: "accounts"}
+		}},
+		{"new definition", func(body string) Edit {
+			at := strings.Index(body, "type Post")
+			return Edit{Start: at, End: at, Text: "scalar Date\n\n"}
+		}},
+		{"removed definition", func(body string) Edit {
+			at := strings.Index(body, "type Post")
+			return Edit{Start: at, End: strings.Index(body, "query"), Text: ""}
+		}},
+		{"removed closing brace", func(body string) Edit {
+			at := strings.Index(body, "}\n\ntype Post")
+			return Edit{Start: at, End: at + 1, Text: ""}
+		}},
+		{"appended field", func(body string) Edit {
+			at := strings.Index(body, "posts {") + len("posts {")
+			return Edit{Start: at, End: at, Text: " id"}
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := source.NewSource(&source.Source{Body: []byte(body)})
+			doc, err := Parse(ParseParams{Source: s})
+			if err != nil {
+				t.Fatal(err)
+			}
+			reparsed, edited, reparseErr := Reparse(ReparseParams{Document: doc, Source: s, Edit: test.edit(body)})
+			expected, err := Parse(ParseParams{Source: edited})
+			if (err == nil) != (reparseErr == nil) {
+				t.Fatalf("expected error %v, got: %v", err, reparseErr)
+			}
+			if !reflect.DeepEqual(reparsed, expected) {
+				t.Fatalf("expected the reparsed document to be the parsed one, got: %#v", reparsed)
+			}
+		})
+	}
+}
+
+func TestReparse_ReusesUntouchedDefinitions(t *testing.T) {
+	s := source.NewSource(&source.Source{Body: []byte("type A { a: Int }\ntype B { b: Int }\ntype C { c: Int }")})
+	doc, err := Parse(ParseParams{Source: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, last := doc.Definitions[0], doc.Definitions[2]
+	at := strings.Index(string(s.Body), "b: Int")
+	reparsed, _, err := Reparse(ReparseParams{Document: doc, Source: s, Edit: Edit{Start: at, End: at + 1, Text: "bb"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reparsed.Definitions[0] != first || reparsed.Definitions[2] != last {
+		t.Fatalf("expected the untouched definitions to be reused")
+	}
+	if loc := last.GetLoc(); loc.Start != 37 || loc.End != 54 {
+		t.Fatalf("expected the location of the last definition to be shifted, got: %v-%v", loc.Start, loc.End)
+	}
+}
@@ -680,6 +680,39 @@ func TestSchemaParser_SimpleUnion(t *testing.T) {
 	}
 }
 
+func TestSchemaParser_UnionExtension(t *testing.T) {
+	body := `extend union Hello = World`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 26),
+		Definitions: []ast.Node{
+			ast.NewUnionExtensionDefinition(&ast.UnionExtensionDefinition{
+				Loc: testLoc(0, 26),
+				Definition: ast.NewUnionDefinition(&ast.UnionDefinition{
+					Loc: testLoc(7, 26),
+					Name: ast.NewName(&ast.Name{
+						Value: "Hello",
+						Loc:   testLoc(13, 18),
+					}),
+					Directives: []*ast.Directive{},
+					Types: []*ast.Named{
+						ast.NewNamed(&ast.Named{
+							Loc: testLoc(21, 26),
+							Name: ast.NewName(&ast.Name{
+								Value: "World",
+								Loc:   testLoc(21, 26),
+							}),
+						}),
+					},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
 func TestSchemaParser_UnionWithTwoTypes(t *testing.T) {
 	body := `union Hello = Wo | Rld`
 	astDoc := parse(t, body)
@@ -821,6 +854,7 @@ input Hello {
 		Locations: []location.SourceLocation{
 			{Line: 3, Column: 8},
 		},
+		Extensions: map[string]interface{}{"code": gqlerrors.CodeParseFailed},
 	}
 	if err == nil {
 		t.Fatalf("expected error, expected: %v, got: %v", expectedError, nil)
@@ -829,3 +863,76 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_CommentDescriptions(t *testing.T) {
+	body := `
+# A greeting.
+#
+#   Indented.
+type Hello {
+  # The world.
+  world(
+    # The name.
+    name: String
+  ): String # not a description
+  other: String
+
+  # not a description either
+
+  last: String
+}
+
+# The colors.
+enum Color {
+  # Red.
+  RED
+}
+
+"String description."
+# Ignored.
+scalar Date
+`
+	astDoc, err := Parse(ParseParams{
+		Source:  body,
+		Options: ParseOptions{CommentDescriptions: true},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	description := func(value *ast.StringValue) interface{} {
+		if value == nil {
+			return nil
+		}
+		return value.Value
+	}
+	object := astDoc.Definitions[0].(*ast.ObjectDefinition)
+	enum := astDoc.Definitions[1].(*ast.EnumDefinition)
+	scalar := astDoc.Definitions[2].(*ast.ScalarDefinition)
+	tests := []struct {
+		actual   interface{}
+		expected interface{}
+	}{
+		{description(object.Description), "A greeting.\n\n  Indented."},
+		{description(object.Fields[0].Description), "The world."},
+		{description(object.Fields[0].Arguments[0].Description), "The name."},
+		{description(object.Fields[1].Description), nil},
+		{description(object.Fields[2].Description), nil},
+		{description(enum.Description), "The colors."},
+		{description(enum.Values[0].Description), "Red."},
+		{description(scalar.Description), "String description."},
+	}
+	for i, test := range tests {
+		if !reflect.DeepEqual(test.actual, test.expected) {
+			t.Errorf("%d: expected description %#v, got %#v", i, test.expected, test.actual)
+		}
+	}
+
+	if loc := object.Description.Loc; string(body[loc.Start:loc.End]) != "# A greeting.\n#\n#   Indented." {
+		t.Errorf("unexpected description location %v-%v", loc.Start, loc.End)
+	}
+
+	astDoc = parse(t, body)
+	if description := astDoc.Definitions[0].(*ast.ObjectDefinition).Description; description != nil {
+		t.Fatalf("expected comments not to be descriptions by default, got %v", description.Value)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"reflect"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/visitor"
@@ -92,25 +91,21 @@ func getDescription(raw interface{}) string {
 	return desc
 }
 
+// toSliceString returns the printed strings of a list, whose values are
+// replaced by their printed strings when leaving them.
 func toSliceString(slice interface{}) []string {
-	if slice == nil {
-		return []string{}
-	}
 	res := []string{}
-	switch reflect.TypeOf(slice).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(slice)
-		for i := 0; i < s.Len(); i++ {
-			elem := s.Index(i)
-			elemInterface := elem.Interface()
-			if elem, ok := elemInterface.(string); ok {
+	switch slice := slice.(type) {
+	case []string:
+		return append(res, slice...)
+	case []interface{}:
+		for _, elem := range slice {
+			if elem, ok := elem.(string); ok {
 				res = append(res, elem)
 			}
 		}
-		return res
-	default:
-		return res
 	}
+	return res
 }
 
 func join(str []string, sep string) string {
@@ -236,15 +231,17 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			variable := fmt.Sprintf("%v", node.Variable)
 			ttype := fmt.Sprintf("%v", node.Type)
 			defaultValue := fmt.Sprintf("%v", node.DefaultValue)
+			directives := toSliceString(node.Directives)
 
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
+			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "") + wrap(" ", join(directives, " "), "")
 		case map[string]interface{}:
 
 			variable := getMapValueString(node, "Variable")
 			ttype := getMapValueString(node, "Type")
 			defaultValue := getMapValueString(node, "DefaultValue")
+			directives := toSliceString(getMapValue(node, "Directives"))
 
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
+			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "") + wrap(" ", join(directives, " "), "")
 
 		}
 		return visitor.ActionNoChange, nil
@@ -312,8 +309,9 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			return visitor.ActionUpdate, "... on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
+			args := toSliceString(getMapValue(node, "Arguments"))
 			directives := toSliceString(getMapValue(node, "Directives"))
-			return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
+			return visitor.ActionUpdate, "..." + name + wrap("(", join(args, ", "), ")") + wrap(" ", join(directives, " "), "")
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -337,16 +335,18 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case *ast.FragmentDefinition:
 			name := fmt.Sprintf("%v", node.Name)
+			varDefs := toSliceString(node.VariableDefinitions)
 			typeCondition := fmt.Sprintf("%v", node.TypeCondition)
 			directives := toSliceString(node.Directives)
 			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
+			return visitor.ActionUpdate, "fragment " + name + wrap("(", join(varDefs, ", "), ")") + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
+			varDefs := toSliceString(getMapValue(node, "VariableDefinitions"))
 			typeCondition := getMapValueString(node, "TypeCondition")
 			directives := toSliceString(getMapValue(node, "Directives"))
 			selectionSet := getMapValueString(node, "SelectionSet")
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
+			return visitor.ActionUpdate, "fragment " + name + wrap("(", join(varDefs, ", "), ")") + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -890,6 +890,19 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		}
 		return visitor.ActionNoChange, nil
 	},
+	"UnionExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+		switch node := p.Node.(type) {
+		case *ast.UnionExtensionDefinition:
+			definition := fmt.Sprintf("%v", node.Definition)
+			str := "extend " + definition
+			return visitor.ActionUpdate, str
+		case map[string]interface{}:
+			definition := getMapValueString(node, "Definition")
+			str := "extend " + definition
+			return visitor.ActionUpdate, str
+		}
+		return visitor.ActionNoChange, nil
+	},
 	"DirectiveDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case *ast.DirectiveDefinition:
@@ -907,7 +920,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			} else {
 				argsStr = wrap("(", join(args, ", "), ")")
 			}
-			str := fmt.Sprintf("directive @%v%v on %v", node.Name, argsStr, join(toSliceString(node.Locations), " | "))
+			str := fmt.Sprintf("directive @%v%v%v on %v", node.Name, argsStr, repeatable(node.Repeatable), join(toSliceString(node.Locations), " | "))
 			if desc := getDescription(node); desc != "" {
 				str = fmt.Sprintf("%s\n%s", desc, str)
 			}
@@ -929,7 +942,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			} else {
 				argsStr = wrap("(", join(args, ", "), ")")
 			}
-			str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
+			isRepeatable, _ := getMapValue(node, "Repeatable").(bool)
+			str := fmt.Sprintf("directive @%v%v%v on %v", name, argsStr, repeatable(isRepeatable), join(locations, " | "))
 			if desc := getDescription(node); desc != "" {
 				str = fmt.Sprintf("%s\n%s", desc, str)
 			}
@@ -939,15 +953,65 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 	},
 }
 
+// repeatable returns the repeatable keyword of directive definitions.
+func repeatable(isRepeatable bool) string {
+	if isRepeatable {
+		return " repeatable"
+	}
+	return ""
+}
+
+// RegisterKind registers the function printing the nodes of a custom kind,
+// see visitor.RegisterKind, or replaces the one of a kind of the language.
+// Like the printing functions of the language, it is called when leaving the
+// nodes, with their printed children, and returns visitor.ActionUpdate with
+// the printed node.
+// RegisterKind is not safe to call concurrently with Print, and is meant to
+// be called before the documents are printed, such as in an init function.
+func RegisterKind(kind string, print visitor.VisitFunc) {
+	printDocASTReducer[kind] = print
+}
+
 func Print(astNode ast.Node) (printed interface{}) {
+	return PrintWith(astNode, nil)
+}
+
+// PrintWith prints the node like Print, with the printing functions of the
+// given kinds replaced by the overrides, e.g. to redact the string values:
+//
+//	printer.PrintWith(astDoc, map[string]visitor.VisitFunc{
+//		kinds.StringValue: func(p visitor.VisitFuncParams) (string, interface{}) {
+//			return visitor.ActionUpdate, `"<redacted>"`
+//		},
+//	})
+//
+// An override can decorate the default printing function of its kind,
+// returned by Renderer.
+func PrintWith(astNode ast.Node, overrides map[string]visitor.VisitFunc) (printed interface{}) {
 	defer func() interface{} {
 		if r := recover(); r != nil {
 			return fmt.Sprintf("%v", astNode)
 		}
 		return printed
 	}()
+	reducer := printDocASTReducer
+	if len(overrides) > 0 {
+		reducer = make(map[string]visitor.VisitFunc, len(printDocASTReducer)+len(overrides))
+		for kind, fn := range printDocASTReducer {
+			reducer[kind] = fn
+		}
+		for kind, fn := range overrides {
+			reducer[kind] = fn
+		}
+	}
 	printed = visitor.Visit(astNode, &visitor.VisitorOptions{
-		LeaveKindMap: printDocASTReducer,
+		LeaveKindMap: reducer,
 	}, nil)
 	return printed
 }
+
+// Renderer returns the function printing the nodes of the given kind, nil if
+// the kind is not printed.
+func Renderer(kind string) visitor.VisitFunc {
+	return printDocASTReducer[kind]
+}
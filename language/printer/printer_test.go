@@ -1,13 +1,16 @@
 package printer_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"reflect"
 	"testing"
 
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/visitor"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -59,6 +62,42 @@ func TestPrinter_PrintsMinimalAST(t *testing.T) {
 	}
 }
 
+func TestPrinter_PrintsVariableDefinitionDirectives(t *testing.T) {
+	query := `query ($id: ID! = "A" @lowerCase @trim(left: true), $n: Int) { field(id: $id) }`
+	astDoc := parse(t, query)
+	results := printer.Print(astDoc)
+	expected := `query ($id: ID! = "A" @lowerCase @trim(left: true), $n: Int) {
+  field(id: $id)
+}
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_PrintsFragmentVariables(t *testing.T) {
+	query := `{ ...Foo(x: 1, y: $y) @include(if: true) } fragment Foo($x: Int = 2, $y: String) on Query { field(x: $x) }`
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source:  query,
+		Options: parser.ParseOptions{ExperimentalFragmentVariables: true},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	results := printer.Print(astDoc)
+	expected := `{
+  ...Foo(x: 1, y: $y) @include(if: true)
+}
+
+fragment Foo($x: Int = 2, $y: String) on Query {
+  field(x: $x)
+}
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
 // TestPrinter_ProducesHelpfulErrorMessages
 // Skipped, can't figure out how to pass in an invalid astDoc, which is already strongly-typed
 
@@ -200,3 +239,58 @@ func TestPrinter_CorrectlyPrintsStringArgumentsWithProperQuoting(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+// tagDefinition is a vendor node, `tag @name "value"`.
+type tagDefinition struct {
+	Kind  string
+	Loc   *ast.Location
+	Name  *ast.Name
+	Value *ast.StringValue
+}
+
+func (def *tagDefinition) GetKind() string       { return def.Kind }
+func (def *tagDefinition) GetLoc() *ast.Location { return def.Loc }
+
+func TestPrinter_PrintsCustomKinds(t *testing.T) {
+	visitor.RegisterKind("TagDefinition", "Name", "Value")
+	printer.RegisterKind("TagDefinition", func(p visitor.VisitFuncParams) (string, interface{}) {
+		node := p.Node.(map[string]interface{})
+		return visitor.ActionUpdate, fmt.Sprintf("tag @%v %v", node["Name"], node["Value"])
+	})
+
+	astDoc := parse(t, `scalar Date`)
+	astDoc.Definitions = append(astDoc.Definitions, &tagDefinition{
+		Kind:  "TagDefinition",
+		Name:  ast.NewName(&ast.Name{Value: "owner"}),
+		Value: ast.NewStringValue(&ast.StringValue{Value: "team-a"}),
+	})
+	expected := "scalar Date\n\ntag @owner \"team-a\"\n"
+	if printed := printer.Print(astDoc); printed != expected {
+		t.Fatalf("expected %q, got: %q", expected, printed)
+	}
+}
+
+func TestPrintWith_OverridesKinds(t *testing.T) {
+	astDoc := parse(t, `{ user(token: "secret", id: 4) { name } }`)
+	printed := printer.PrintWith(astDoc, map[string]visitor.VisitFunc{
+		kinds.StringValue: func(p visitor.VisitFuncParams) (string, interface{}) {
+			return visitor.ActionUpdate, `"<redacted>"`
+		},
+		kinds.Field: func(p visitor.VisitFuncParams) (string, interface{}) {
+			action, field := printer.Renderer(kinds.Field)(p)
+			return action, fmt.Sprintf("%v # field", field)
+		},
+	})
+	expected := `{
+  user(token: "<redacted>", id: 4) {
+    name # field
+  } # field
+}
+`
+	if !reflect.DeepEqual(expected, printed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+	if printed := printer.Print(astDoc); printed == expected {
+		t.Fatalf("expected the overrides not to change Print")
+	}
+}
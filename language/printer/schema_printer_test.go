@@ -113,6 +113,8 @@ extend type Foo {
 
 extend type Foo @onType {}
 
+extend union Feed = Photo
+
 type NoFields {}
 
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
@@ -31,6 +31,7 @@ var QueryDocumentKeys = KeyMap{
 		"Variable",
 		"Type",
 		"DefaultValue",
+		"Directives",
 	},
 	"Variable":     []string{"Name"},
 	"SelectionSet": []string{"Selections"},
@@ -48,6 +49,7 @@ var QueryDocumentKeys = KeyMap{
 
 	"FragmentSpread": []string{
 		"Name",
+		"Arguments",
 		"Directives",
 	},
 	"InlineFragment": []string{
@@ -57,6 +59,7 @@ var QueryDocumentKeys = KeyMap{
 	},
 	"FragmentDefinition": []string{
 		"Name",
+		"VariableDefinitions",
 		"TypeCondition",
 		"Directives",
 		"SelectionSet",
@@ -136,11 +139,24 @@ var QueryDocumentKeys = KeyMap{
 		"Fields",
 	},
 
-	"TypeExtensionDefinition": []string{"Definition"},
+	"TypeExtensionDefinition":  []string{"Definition"},
+	"UnionExtensionDefinition": []string{"Definition"},
 
 	"DirectiveDefinition": []string{"Name", "Arguments", "Locations"},
 }
 
+// RegisterKind registers the keys of the children of the nodes of a custom
+// kind, the names of their fields, traversed by Visit with the default key
+// map, or replaces the keys of a kind of the language, e.g. to traverse the
+// arguments of the fragment spreads of a fork.
+// The custom nodes implement ast.Node and, to be edited by the visitors such
+// as the printer, have a Kind field like the nodes of the language.
+// RegisterKind is not safe to call concurrently with Visit, and is meant to
+// be called before the documents are visited, such as in an init function.
+func RegisterKind(kind string, keys ...string) {
+	QueryDocumentKeys[kind] = keys
+}
+
 type stack struct {
 	Index   int
 	Keys    []interface{}
@@ -1746,3 +1746,40 @@ func TestVisitor_VisitWithTypeInfo_MaintainsTypeInfoDuringEdit(t *testing.T) {
 	}
 
 }
+
+// fragmentArgument is a custom node of a fork, an argument of a fragment
+// spread.
+type fragmentArgument struct {
+	Kind  string
+	Loc   *ast.Location
+	Name  *ast.Name
+	Value ast.Value
+}
+
+func (arg *fragmentArgument) GetKind() string       { return arg.Kind }
+func (arg *fragmentArgument) GetLoc() *ast.Location { return arg.Loc }
+
+func TestVisitor_VisitsCustomKinds(t *testing.T) {
+	visitor.RegisterKind("FragmentArgument", "Name", "Value")
+
+	root := &fragmentArgument{
+		Kind:  "FragmentArgument",
+		Name:  ast.NewName(&ast.Name{Value: "size"}),
+		Value: ast.NewIntValue(&ast.IntValue{Value: "10"}),
+	}
+	visited := []interface{}{}
+	v := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(ast.Node); ok {
+				visited = append(visited, node.GetKind())
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+	visitor.Visit(root, v, nil)
+
+	expected := []interface{}{"FragmentArgument", kinds.Name, kinds.IntValue}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, visited))
+	}
+}
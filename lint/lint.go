@@ -0,0 +1,183 @@
+// Package lint checks GraphQL documents against a schema with rules going
+// beyond the validation of the specification, such as requiring operation
+// names or forbidding the usage of deprecated fields. It reports structured
+// diagnostics with severities, suitable for CI and editors. LintSDL similarly
+// checks schema definitions against naming and documentation conventions.
+//
+//     diagnostics := lint.Lint(&schema, document, lint.Config{
+//         Severities: map[string]lint.Severity{
+//             "no-deprecated": lint.SeverityError,
+//         },
+//     })
+//     if lint.MaxSeverity(diagnostics) >= lint.SeverityError {
+//         os.Exit(1)
+//     }
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity int
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem found in a document by a rule.
+type Diagnostic struct {
+	// File is the name of the source of the document, set by LintSDL.
+	File string `json:"file,omitempty"`
+
+	Rule      string                    `json:"rule"`
+	Severity  Severity                  `json:"severity"`
+	Message   string                    `json:"message"`
+	Locations []location.SourceLocation `json:"locations,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	position := d.File
+	if len(d.Locations) > 0 {
+		if position != "" {
+			position += ":"
+		}
+		position += fmt.Sprintf("%d:%d", d.Locations[0].Line, d.Locations[0].Column)
+	}
+	if position == "" {
+		return fmt.Sprintf("%v: %v (%v)", d.Severity, d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%v: %v: %v (%v)", position, d.Severity, d.Message, d.Rule)
+}
+
+// Rule is a lint rule. Check reports the problems found in the document to
+// the validation context, like the validation rules of the specification.
+type Rule struct {
+	Name        string
+	Description string
+
+	// Severity is the default severity of the diagnostics of the rule.
+	Severity Severity
+
+	Check graphql.ValidationRuleFn
+}
+
+// Config configures Lint.
+type Config struct {
+	// Rules are the rules to check, defaults to DefaultRules.
+	Rules []Rule
+
+	// Severities override the severity of rules by name, SeverityOff
+	// disabling the rule.
+	Severities map[string]Severity
+}
+
+// DefaultRules returns the rules checked by default, with their default
+// options.
+func DefaultRules() []Rule {
+	return []Rule{
+		RequireOperationName(),
+		NoDeprecated(),
+		AliasNaming(nil),
+		MaxInlineFragments(DefaultMaxInlineFragments),
+		RequireIDSelection(DefaultIDField),
+	}
+}
+
+// Lint checks the document against the schema with the rules of the config,
+// and returns the diagnostics sorted by location.
+func Lint(schema *graphql.Schema, document *ast.Document, config Config) []Diagnostic {
+	rules := config.Rules
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	var errs gqlerrors.List
+	severities := map[string]Severity{}
+	for _, rule := range rules {
+		severity := rule.Severity
+		if override, ok := config.Severities[rule.Name]; ok {
+			severity = override
+		}
+		if severity == SeverityOff || rule.Check == nil {
+			continue
+		}
+		severities[rule.Name] = severity
+		typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: schema})
+		ruleErrs := graphql.VisitUsingRules(schema, typeInfo, document, []graphql.ValidationRuleFn{rule.Check})
+		for i := range ruleErrs {
+			ruleErrs[i].Extensions = map[string]interface{}{"rule": rule.Name}
+		}
+		errs = errs.Append(ruleErrs)
+	}
+
+	diagnostics := []Diagnostic{}
+	for _, err := range errs.Sort() {
+		name, _ := err.Extensions["rule"].(string)
+		diagnostics = append(diagnostics, Diagnostic{
+			Rule:      name,
+			Severity:  severities[name],
+			Message:   err.Message,
+			Locations: err.Locations,
+		})
+	}
+	return diagnostics
+}
+
+// MaxSeverity returns the highest severity of the diagnostics, SeverityOff if
+// there are none.
+func MaxSeverity(diagnostics []Diagnostic) Severity {
+	max := SeverityOff
+	for _, d := range diagnostics {
+		if d.Severity > max {
+			max = d.Severity
+		}
+	}
+	return max
+}
+
+// sortDiagnostics sorts the diagnostics by file and location, keeping the
+// order of the diagnostics at the same location.
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if len(a.Locations) == 0 || len(b.Locations) == 0 {
+			return len(a.Locations) > len(b.Locations)
+		}
+		if a.Locations[0].Line != b.Locations[0].Line {
+			return a.Locations[0].Line < b.Locations[0].Line
+		}
+		return a.Locations[0].Column < b.Locations[0].Column
+	})
+}
+
+// report reports a problem found by a rule on the given nodes.
+func report(context *graphql.ValidationContext, message string, nodes ...ast.Node) {
+	context.ReportError(gqlerrors.NewError(message, nodes, "", nil, []int{}, nil))
+}
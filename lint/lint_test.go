@@ -0,0 +1,112 @@
+package lint_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/lint"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func lintTestSchema(t *testing.T) *graphql.Schema {
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":     &graphql.EnumValueConfig{Value: 0},
+			"CRIMSON": &graphql.EnumValueConfig{Value: 1, DeprecationReason: "Use RED."},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.ID},
+			"name":     &graphql.Field{Type: graphql.String},
+			"fullName": &graphql.Field{Type: graphql.String, DeprecationReason: "Use name."},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: user,
+					Args: graphql.FieldConfigArgument{
+						"color": &graphql.ArgumentConfig{Type: color},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func parse(t *testing.T, query string) *ast.Document {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func diagnostic(rule string, severity lint.Severity, message string, line, column int) lint.Diagnostic {
+	return lint.Diagnostic{
+		Rule:      rule,
+		Severity:  severity,
+		Message:   message,
+		Locations: []location.SourceLocation{{Line: line, Column: column}},
+	}
+}
+
+func TestLint_DefaultRules(t *testing.T) {
+	doc := parse(t, `{
+  User_1: user(color: CRIMSON) { name fullName }
+  user { ...F }
+}
+fragment F on User { id ... { name } ... { name } }`)
+	diagnostics := lint.Lint(lintTestSchema(t), doc, lint.Config{})
+	expected := []lint.Diagnostic{
+		diagnostic("require-operation-name", lint.SeverityWarning, "Anonymous query operation, operations must be named.", 1, 1),
+		diagnostic("alias-naming", lint.SeverityInfo, `Alias "User_1" does not match the pattern "^[a-z][a-zA-Z0-9]*$".`, 2, 3),
+		diagnostic("require-id-selection", lint.SeverityWarning, `Field "user" of type "User" must select "id".`, 2, 3),
+		diagnostic("no-deprecated", lint.SeverityWarning, `The enum value "Color.CRIMSON" is deprecated: Use RED.`, 2, 23),
+		diagnostic("no-deprecated", lint.SeverityWarning, `The field "User.fullName" is deprecated: Use name.`, 2, 39),
+	}
+	if !reflect.DeepEqual(expected, diagnostics) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diagnostics))
+	}
+	if severity := lint.MaxSeverity(diagnostics); severity != lint.SeverityWarning {
+		t.Fatalf("expected the max severity to be warning, got: %v", severity)
+	}
+}
+
+func TestLint_ConfiguresRules(t *testing.T) {
+	doc := parse(t, `query Q { user { id ... { name } ... { name } user_name: name } }`)
+	diagnostics := lint.Lint(lintTestSchema(t), doc, lint.Config{
+		Rules: []lint.Rule{
+			lint.MaxInlineFragments(1),
+			lint.AliasNaming(regexp.MustCompile(`^[a-z_]+$`)),
+			lint.RequireOperationName(),
+		},
+		Severities: map[string]lint.Severity{
+			"max-inline-fragments":   lint.SeverityError,
+			"require-operation-name": lint.SeverityOff,
+		},
+	})
+	expected := []lint.Diagnostic{
+		diagnostic("max-inline-fragments", lint.SeverityError, "Selection set has more than 1 inline fragments.", 1, 34),
+	}
+	if !reflect.DeepEqual(expected, diagnostics) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diagnostics))
+	}
+	if diagnostics[0].String() != "1:34: error: Selection set has more than 1 inline fragments. (max-inline-fragments)" {
+		t.Fatalf("unexpected string: %v", diagnostics[0])
+	}
+}
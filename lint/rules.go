@@ -0,0 +1,239 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+const (
+	// DefaultMaxInlineFragments is the default limit of MaxInlineFragments.
+	DefaultMaxInlineFragments = 5
+
+	// DefaultIDField is the default field of RequireIDSelection.
+	DefaultIDField = "id"
+)
+
+// DefaultAliasPattern is the default pattern of AliasNaming, lower camel case.
+var DefaultAliasPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// RequireOperationName reports anonymous operations, whose names help
+// monitoring and debugging.
+func RequireOperationName() Rule {
+	return Rule{
+		Name:        "require-operation-name",
+		Description: "Operations must be named.",
+		Severity:    SeverityWarning,
+		Check: func(context *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+			return &graphql.ValidationRuleInstance{
+				VisitorOpts: &visitor.VisitorOptions{
+					KindFuncMap: map[string]visitor.NamedVisitFuncs{
+						kinds.OperationDefinition: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								if node, ok := p.Node.(*ast.OperationDefinition); ok && node.Name == nil {
+									report(context, fmt.Sprintf("Anonymous %v operation, operations must be named.", node.Operation), node)
+								}
+								return visitor.ActionNoChange, nil
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+// NoDeprecated reports the usages of deprecated fields and enum values.
+func NoDeprecated() Rule {
+	return Rule{
+		Name:        "no-deprecated",
+		Description: "Deprecated fields and enum values must not be used.",
+		Severity:    SeverityWarning,
+		Check: func(context *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+			return &graphql.ValidationRuleInstance{
+				VisitorOpts: &visitor.VisitorOptions{
+					KindFuncMap: map[string]visitor.NamedVisitFuncs{
+						kinds.Field: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								node, ok := p.Node.(*ast.Field)
+								fieldDef := context.FieldDef()
+								if !ok || fieldDef == nil || fieldDef.DeprecationReason == "" {
+									return visitor.ActionNoChange, nil
+								}
+								parentName := ""
+								if parentType := context.ParentType(); parentType != nil {
+									parentName = parentType.Name()
+								}
+								report(context, fmt.Sprintf(`The field "%v.%v" is deprecated: %v`,
+									parentName, fieldDef.Name, fieldDef.DeprecationReason), node)
+								return visitor.ActionNoChange, nil
+							},
+						},
+						kinds.EnumValue: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								node, ok := p.Node.(*ast.EnumValue)
+								enum, isEnum := graphql.GetNamed(context.InputType()).(*graphql.Enum)
+								if !ok || !isEnum {
+									return visitor.ActionNoChange, nil
+								}
+								for _, value := range enum.Values() {
+									if value.Name == node.Value && value.DeprecationReason != "" {
+										report(context, fmt.Sprintf(`The enum value "%v.%v" is deprecated: %v`,
+											enum.Name(), value.Name, value.DeprecationReason), node)
+									}
+								}
+								return visitor.ActionNoChange, nil
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+// AliasNaming reports the aliases not matching the pattern, DefaultAliasPattern
+// if nil.
+func AliasNaming(pattern *regexp.Regexp) Rule {
+	if pattern == nil {
+		pattern = DefaultAliasPattern
+	}
+	return Rule{
+		Name:        "alias-naming",
+		Description: "Aliases must match a naming pattern.",
+		Severity:    SeverityInfo,
+		Check: func(context *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+			return &graphql.ValidationRuleInstance{
+				VisitorOpts: &visitor.VisitorOptions{
+					KindFuncMap: map[string]visitor.NamedVisitFuncs{
+						kinds.Field: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								node, ok := p.Node.(*ast.Field)
+								if ok && node.Alias != nil && !pattern.MatchString(node.Alias.Value) {
+									report(context, fmt.Sprintf(`Alias "%v" does not match the pattern "%v".`,
+										node.Alias.Value, pattern), node.Alias)
+								}
+								return visitor.ActionNoChange, nil
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+// MaxInlineFragments reports the selection sets with more than max inline
+// fragments, which are better expressed with named fragments.
+func MaxInlineFragments(max int) Rule {
+	return Rule{
+		Name:        "max-inline-fragments",
+		Description: fmt.Sprintf("Selection sets must have at most %d inline fragments.", max),
+		Severity:    SeverityWarning,
+		Check: func(context *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+			return &graphql.ValidationRuleInstance{
+				VisitorOpts: &visitor.VisitorOptions{
+					KindFuncMap: map[string]visitor.NamedVisitFuncs{
+						kinds.SelectionSet: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								node, ok := p.Node.(*ast.SelectionSet)
+								if !ok || node == nil {
+									return visitor.ActionNoChange, nil
+								}
+								count := 0
+								for _, selection := range node.Selections {
+									if fragment, ok := selection.(*ast.InlineFragment); ok {
+										if count++; count == max+1 {
+											report(context, fmt.Sprintf("Selection set has more than %d inline fragments.", max), fragment)
+										}
+									}
+								}
+								return visitor.ActionNoChange, nil
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+// RequireIDSelection reports the selection sets of object and interface types
+// having the given identifier field which do not select it, the identifier
+// being needed by normalized client caches.
+func RequireIDSelection(idField string) Rule {
+	return Rule{
+		Name:        "require-id-selection",
+		Description: fmt.Sprintf(`Selection sets of types with an "%v" field must select it.`, idField),
+		Severity:    SeverityWarning,
+		Check: func(context *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+			return &graphql.ValidationRuleInstance{
+				VisitorOpts: &visitor.VisitorOptions{
+					KindFuncMap: map[string]visitor.NamedVisitFuncs{
+						kinds.Field: {
+							Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+								node, ok := p.Node.(*ast.Field)
+								if !ok || node.SelectionSet == nil {
+									return visitor.ActionNoChange, nil
+								}
+								ttype := graphql.GetNamed(context.Type())
+								if !hasField(ttype, idField) || selectsField(context, node.SelectionSet, idField, map[string]bool{}) {
+									return visitor.ActionNoChange, nil
+								}
+								report(context, fmt.Sprintf(`Field "%v" of type "%v" must select "%v".`,
+									node.Name.Value, ttype, idField), node)
+								return visitor.ActionNoChange, nil
+							},
+						},
+					},
+				},
+			}
+		},
+	}
+}
+
+func hasField(ttype graphql.Named, name string) bool {
+	switch ttype := ttype.(type) {
+	case *graphql.Object:
+		_, ok := ttype.Fields()[name]
+		return ok
+	case *graphql.Interface:
+		_, ok := ttype.Fields()[name]
+		return ok
+	}
+	return false
+}
+
+// selectsField reports whether the selection set selects the field without
+// alias, directly or through fragments.
+func selectsField(context *graphql.ValidationContext, selectionSet *ast.SelectionSet, name string, visited map[string]bool) bool {
+	if selectionSet == nil {
+		return false
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name != nil && selection.Name.Value == name && selection.Alias == nil {
+				return true
+			}
+		case *ast.InlineFragment:
+			if selectsField(context, selection.SelectionSet, name, visited) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			if fragment := context.Fragment(selection.Name.Value); fragment != nil &&
+				selectsField(context, fragment.SelectionSet, name, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
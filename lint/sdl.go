@@ -0,0 +1,393 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// SDLRule is a lint rule for the schema definition language. Check reports
+// the problems found in the type definitions of the document.
+type SDLRule struct {
+	Name        string
+	Description string
+
+	// Severity is the default severity of the diagnostics of the rule.
+	Severity Severity
+
+	Check func(context *SDLContext)
+}
+
+// SDLConfig configures LintSDL.
+type SDLConfig struct {
+	// Rules are the rules to check, defaults to DefaultSDLRules.
+	Rules []SDLRule
+
+	// Severities override the severity of rules by name, SeverityOff
+	// disabling the rule.
+	Severities map[string]Severity
+}
+
+// SDLContext is the context of an SDL rule checking a document.
+type SDLContext struct {
+	document    *ast.Document
+	source      *source.Source
+	rule        string
+	severity    Severity
+	diagnostics []Diagnostic
+}
+
+// Document returns the checked document.
+func (c *SDLContext) Document() *ast.Document {
+	return c.document
+}
+
+// Report reports a problem found on the given node.
+func (c *SDLContext) Report(message string, node ast.Node) {
+	d := Diagnostic{
+		File:     c.source.Name,
+		Rule:     c.rule,
+		Severity: c.severity,
+		Message:  message,
+	}
+	if loc := node.GetLoc(); loc != nil {
+		d.Locations = []location.SourceLocation{location.GetLocation(c.source, loc.Start)}
+	}
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+// DefaultSDLRules returns the SDL rules checked by default, with their default
+// options.
+func DefaultSDLRules() []SDLRule {
+	return []SDLRule{
+		TypeNaming(nil),
+		FieldNaming(nil),
+		EnumValueNaming(nil),
+		RequireDescriptions(),
+		BooleanPrefix(DefaultBooleanPrefixes...),
+		InputSuffix(DefaultInputSuffix),
+		NoExecutableDefinitions(),
+	}
+}
+
+// LintSDL parses and checks the given schema definition sources, typically
+// one per file, the name of a source being reported as the file of its
+// diagnostics. The diagnostics are sorted by file and location.
+func LintSDL(config SDLConfig, sources ...*source.Source) ([]Diagnostic, error) {
+	rules := config.Rules
+	if rules == nil {
+		rules = DefaultSDLRules()
+	}
+	diagnostics := []Diagnostic{}
+	for _, src := range sources {
+		document, err := parser.Parse(parser.ParseParams{Source: src})
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			severity := rule.Severity
+			if override, ok := config.Severities[rule.Name]; ok {
+				severity = override
+			}
+			if severity == SeverityOff || rule.Check == nil {
+				continue
+			}
+			context := &SDLContext{
+				document: document,
+				source:   src,
+				rule:     rule.Name,
+				severity: severity,
+			}
+			rule.Check(context)
+			diagnostics = append(diagnostics, context.diagnostics...)
+		}
+	}
+	sortDiagnostics(diagnostics)
+	return diagnostics, nil
+}
+
+const (
+	// DefaultInputSuffix is the default suffix of InputSuffix.
+	DefaultInputSuffix = "Input"
+)
+
+var (
+	// DefaultTypeNamePattern is the default pattern of TypeNaming, upper camel case.
+	DefaultTypeNamePattern = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+	// DefaultFieldNamePattern is the default pattern of FieldNaming, lower camel case.
+	DefaultFieldNamePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+	// DefaultEnumValuePattern is the default pattern of EnumValueNaming,
+	// upper snake case.
+	DefaultEnumValuePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+	// DefaultBooleanPrefixes are the default prefixes of BooleanPrefix.
+	DefaultBooleanPrefixes = []string{"is", "has", "can", "should", "was", "will"}
+)
+
+// TypeNaming reports the types whose name does not match the pattern,
+// DefaultTypeNamePattern if nil.
+func TypeNaming(pattern *regexp.Regexp) SDLRule {
+	if pattern == nil {
+		pattern = DefaultTypeNamePattern
+	}
+	return SDLRule{
+		Name:        "type-naming",
+		Description: "Type names must match a naming pattern.",
+		Severity:    SeverityWarning,
+		Check: func(context *SDLContext) {
+			for _, def := range context.Document().Definitions {
+				if name := typeDefinitionName(def); name != nil && !pattern.MatchString(name.Value) {
+					context.Report(fmt.Sprintf(`Type "%v" does not match the pattern "%v".`, name.Value, pattern), name)
+				}
+			}
+		},
+	}
+}
+
+// FieldNaming reports the fields, input fields and arguments whose name does
+// not match the pattern, DefaultFieldNamePattern if nil.
+func FieldNaming(pattern *regexp.Regexp) SDLRule {
+	if pattern == nil {
+		pattern = DefaultFieldNamePattern
+	}
+	return SDLRule{
+		Name:        "field-naming",
+		Description: "Field and argument names must match a naming pattern.",
+		Severity:    SeverityWarning,
+		Check: func(context *SDLContext) {
+			check := func(kind, typeName string, name *ast.Name) {
+				if name != nil && !pattern.MatchString(name.Value) {
+					context.Report(fmt.Sprintf(`%v "%v.%v" does not match the pattern "%v".`,
+						kind, typeName, name.Value, pattern), name)
+				}
+			}
+			for _, def := range context.Document().Definitions {
+				typeName := nameValue(typeDefinitionName(def))
+				for _, field := range typeDefinitionFields(def) {
+					check("Field", typeName, field.Name)
+					for _, arg := range field.Arguments {
+						check("Argument", typeName+"."+nameValue(field.Name), arg.Name)
+					}
+				}
+				if def, ok := def.(*ast.InputObjectDefinition); ok {
+					for _, field := range def.Fields {
+						check("Input field", typeName, field.Name)
+					}
+				}
+			}
+		},
+	}
+}
+
+// EnumValueNaming reports the enum values whose name does not match the
+// pattern, DefaultEnumValuePattern if nil.
+func EnumValueNaming(pattern *regexp.Regexp) SDLRule {
+	if pattern == nil {
+		pattern = DefaultEnumValuePattern
+	}
+	return SDLRule{
+		Name:        "enum-value-naming",
+		Description: "Enum values must match a naming pattern.",
+		Severity:    SeverityWarning,
+		Check: func(context *SDLContext) {
+			for _, def := range context.Document().Definitions {
+				enum, ok := def.(*ast.EnumDefinition)
+				if !ok {
+					continue
+				}
+				for _, value := range enum.Values {
+					if value.Name != nil && !pattern.MatchString(value.Name.Value) {
+						context.Report(fmt.Sprintf(`Enum value "%v.%v" does not match the pattern "%v".`,
+							nameValue(enum.Name), value.Name.Value, pattern), value.Name)
+					}
+				}
+			}
+		},
+	}
+}
+
+// RequireDescriptions reports the types, fields, input fields and enum values
+// without description.
+func RequireDescriptions() SDLRule {
+	return SDLRule{
+		Name:        "require-descriptions",
+		Description: "Types, fields and enum values must have a description.",
+		Severity:    SeverityInfo,
+		Check: func(context *SDLContext) {
+			check := func(what string, node ast.Node, description *ast.StringValue) {
+				if description == nil || strings.TrimSpace(description.Value) == "" {
+					context.Report(fmt.Sprintf(`%v has no description.`, what), node)
+				}
+			}
+			for _, def := range context.Document().Definitions {
+				if _, ok := def.(*ast.TypeExtensionDefinition); ok {
+					continue
+				}
+				typeName := nameValue(typeDefinitionName(def))
+				if describable, ok := def.(ast.DescribableNode); ok && typeName != "" {
+					check(fmt.Sprintf(`Type "%v"`, typeName), def, describable.GetDescription())
+				}
+				for _, field := range typeDefinitionFields(def) {
+					check(fmt.Sprintf(`Field "%v.%v"`, typeName, nameValue(field.Name)), field, field.Description)
+				}
+				switch def := def.(type) {
+				case *ast.InputObjectDefinition:
+					for _, field := range def.Fields {
+						check(fmt.Sprintf(`Input field "%v.%v"`, typeName, nameValue(field.Name)), field, field.Description)
+					}
+				case *ast.EnumDefinition:
+					for _, value := range def.Values {
+						check(fmt.Sprintf(`Enum value "%v.%v"`, typeName, nameValue(value.Name)), value, value.Description)
+					}
+				}
+			}
+		},
+	}
+}
+
+// BooleanPrefix reports the Boolean fields whose name does not start with one
+// of the prefixes, DefaultBooleanPrefixes if none, such as "isActive".
+func BooleanPrefix(prefixes ...string) SDLRule {
+	if len(prefixes) == 0 {
+		prefixes = DefaultBooleanPrefixes
+	}
+	return SDLRule{
+		Name:        "boolean-prefix",
+		Description: "Boolean fields must start with a prefix such as " + strings.Join(prefixes, ", ") + ".",
+		Severity:    SeverityInfo,
+		Check: func(context *SDLContext) {
+			for _, def := range context.Document().Definitions {
+				typeName := nameValue(typeDefinitionName(def))
+				for _, field := range typeDefinitionFields(def) {
+					name := nameValue(field.Name)
+					if namedType(field.Type) != "Boolean" || hasWordPrefix(name, prefixes) {
+						continue
+					}
+					context.Report(fmt.Sprintf(`Boolean field "%v.%v" should start with one of %v.`,
+						typeName, name, strings.Join(prefixes, ", ")), field.Name)
+				}
+			}
+		},
+	}
+}
+
+// InputSuffix reports the input object types whose name does not end with the
+// suffix, DefaultInputSuffix if empty.
+func InputSuffix(suffix string) SDLRule {
+	if suffix == "" {
+		suffix = DefaultInputSuffix
+	}
+	return SDLRule{
+		Name:        "input-suffix",
+		Description: fmt.Sprintf(`Input object type names must end with "%v".`, suffix),
+		Severity:    SeverityWarning,
+		Check: func(context *SDLContext) {
+			for _, def := range context.Document().Definitions {
+				if def, ok := def.(*ast.InputObjectDefinition); ok && def.Name != nil &&
+					!strings.HasSuffix(def.Name.Value, suffix) {
+					context.Report(fmt.Sprintf(`Input type "%v" should end with "%v".`, def.Name.Value, suffix), def.Name)
+				}
+			}
+		},
+	}
+}
+
+// NoExecutableDefinitions reports the operations and fragments of schema
+// definition documents, which are ignored when the schema is built.
+func NoExecutableDefinitions() SDLRule {
+	return SDLRule{
+		Name:        "no-executable-definitions",
+		Description: "Schema definition documents must not contain operations nor fragments.",
+		Severity:    SeverityWarning,
+		Check: func(context *SDLContext) {
+			for _, def := range context.Document().Definitions {
+				switch def := def.(type) {
+				case *ast.OperationDefinition:
+					if name := nameValue(def.Name); name != "" {
+						context.Report(fmt.Sprintf(`Operation "%v" is not a type system definition.`, name), def)
+					} else {
+						context.Report(`Anonymous operation is not a type system definition.`, def)
+					}
+				case *ast.FragmentDefinition:
+					context.Report(fmt.Sprintf(`Fragment "%v" is not a type system definition.`, nameValue(def.Name)), def)
+				}
+			}
+		},
+	}
+}
+
+// typeDefinitionName returns the name of a type definition, nil for other
+// definitions and type extensions.
+func typeDefinitionName(def ast.Node) *ast.Name {
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		return def.Name
+	case *ast.ObjectDefinition:
+		return def.Name
+	case *ast.InterfaceDefinition:
+		return def.Name
+	case *ast.UnionDefinition:
+		return def.Name
+	case *ast.EnumDefinition:
+		return def.Name
+	case *ast.InputObjectDefinition:
+		return def.Name
+	}
+	return nil
+}
+
+// typeDefinitionFields returns the fields of an object or interface type
+// definition, or of an object type extension.
+func typeDefinitionFields(def ast.Node) []*ast.FieldDefinition {
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		return def.Fields
+	case *ast.InterfaceDefinition:
+		return def.Fields
+	case *ast.TypeExtensionDefinition:
+		if def.Definition != nil {
+			return def.Definition.Fields
+		}
+	}
+	return nil
+}
+
+func nameValue(name *ast.Name) string {
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+func namedType(ttype ast.Type) string {
+	switch ttype := ttype.(type) {
+	case *ast.NonNull:
+		return namedType(ttype.Type)
+	case *ast.List:
+		return namedType(ttype.Type)
+	case *ast.Named:
+		return nameValue(ttype.Name)
+	}
+	return ""
+}
+
+// hasWordPrefix reports whether the name starts with one of the prefixes
+// followed by an upper case letter, or is the prefix itself.
+func hasWordPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if rest := name[len(prefix):]; rest == "" || (rest[0] >= 'A' && rest[0] <= 'Z') || rest[0] == '_' {
+			return true
+		}
+	}
+	return false
+}
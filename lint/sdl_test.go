@@ -0,0 +1,125 @@
+package lint_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/lint"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func sdlDiagnostic(file, rule string, severity lint.Severity, message string, line, column int) lint.Diagnostic {
+	d := diagnostic(rule, severity, message, line, column)
+	d.File = file
+	return d
+}
+
+func TestLintSDL_DefaultRules(t *testing.T) {
+	src := source.NewSource(&source.Source{
+		Name: "schema.graphql",
+		Body: []byte(`"The root."
+type Query {
+  "A user."
+  user_by_id(UserID: ID): user
+}
+
+type user {
+  "Whether the user is active."
+  active: Boolean
+  "Whether the user is an admin."
+  isAdmin: Boolean!
+}
+
+"Colors."
+enum Color {
+  "Red."
+  red
+}
+
+"Filters."
+input Filter {
+  "The name."
+  name: String
+}
+`),
+	})
+	diagnostics, err := lint.LintSDL(lint.SDLConfig{}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []lint.Diagnostic{
+		sdlDiagnostic("schema.graphql", "field-naming", lint.SeverityWarning, `Field "Query.user_by_id" does not match the pattern "^[a-z][a-zA-Z0-9]*$".`, 4, 3),
+		sdlDiagnostic("schema.graphql", "field-naming", lint.SeverityWarning, `Argument "Query.user_by_id.UserID" does not match the pattern "^[a-z][a-zA-Z0-9]*$".`, 4, 14),
+		sdlDiagnostic("schema.graphql", "require-descriptions", lint.SeverityInfo, `Type "user" has no description.`, 7, 1),
+		sdlDiagnostic("schema.graphql", "type-naming", lint.SeverityWarning, `Type "user" does not match the pattern "^[A-Z][a-zA-Z0-9]*$".`, 7, 6),
+		sdlDiagnostic("schema.graphql", "boolean-prefix", lint.SeverityInfo, `Boolean field "user.active" should start with one of is, has, can, should, was, will.`, 9, 3),
+		sdlDiagnostic("schema.graphql", "enum-value-naming", lint.SeverityWarning, `Enum value "Color.red" does not match the pattern "^[A-Z][A-Z0-9_]*$".`, 17, 3),
+		sdlDiagnostic("schema.graphql", "input-suffix", lint.SeverityWarning, `Input type "Filter" should end with "Input".`, 21, 7),
+	}
+	if !reflect.DeepEqual(expected, diagnostics) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diagnostics))
+	}
+	if s := diagnostics[0].String(); s != `schema.graphql:4:3: warning: Field "Query.user_by_id" does not match the pattern "^[a-z][a-zA-Z0-9]*$". (field-naming)` {
+		t.Fatalf("unexpected string: %v", s)
+	}
+}
+
+func TestLintSDL_ConfiguresRulesAcrossFiles(t *testing.T) {
+	sources := []*source.Source{
+		source.NewSource(&source.Source{Name: "b.graphql", Body: []byte("type B { enabled: Boolean }")}),
+		source.NewSource(&source.Source{Name: "a.graphql", Body: []byte("input AFilter { x: Int }\ntype A { x: Int }")}),
+	}
+	diagnostics, err := lint.LintSDL(lint.SDLConfig{
+		Rules: []lint.SDLRule{
+			lint.InputSuffix("Filter"),
+			lint.BooleanPrefix("is"),
+			lint.TypeNaming(regexp.MustCompile(`^[A-Z]{2,}`)),
+		},
+		Severities: map[string]lint.Severity{
+			"boolean-prefix": lint.SeverityError,
+		},
+	}, sources...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []lint.Diagnostic{
+		sdlDiagnostic("a.graphql", "type-naming", lint.SeverityWarning, `Type "A" does not match the pattern "^[A-Z]{2,}".`, 2, 6),
+		sdlDiagnostic("b.graphql", "type-naming", lint.SeverityWarning, `Type "B" does not match the pattern "^[A-Z]{2,}".`, 1, 6),
+		sdlDiagnostic("b.graphql", "boolean-prefix", lint.SeverityError, `Boolean field "B.enabled" should start with one of is.`, 1, 10),
+	}
+	if !reflect.DeepEqual(expected, diagnostics) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diagnostics))
+	}
+
+	_, err = lint.LintSDL(lint.SDLConfig{}, source.NewSource(&source.Source{Name: "bad.graphql", Body: []byte("type {")}))
+	if err == nil {
+		t.Fatalf("expected a syntax error")
+	}
+}
+
+func TestLintSDL_NoExecutableDefinitions(t *testing.T) {
+	src := source.NewSource(&source.Source{
+		Name: "schema.graphql",
+		Body: []byte(`type Query { name: String }
+query Names { name }
+{ name }
+fragment NameFields on Query { name }
+`),
+	})
+	diagnostics, err := lint.LintSDL(lint.SDLConfig{
+		Rules: []lint.SDLRule{lint.NoExecutableDefinitions()},
+	}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []lint.Diagnostic{
+		sdlDiagnostic("schema.graphql", "no-executable-definitions", lint.SeverityWarning, `Operation "Names" is not a type system definition.`, 2, 1),
+		sdlDiagnostic("schema.graphql", "no-executable-definitions", lint.SeverityWarning, `Anonymous operation is not a type system definition.`, 3, 1),
+		sdlDiagnostic("schema.graphql", "no-executable-definitions", lint.SeverityWarning, `Fragment "NameFields" is not a type system definition.`, 4, 1),
+	}
+	if !reflect.DeepEqual(expected, diagnostics) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, diagnostics))
+	}
+}
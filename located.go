@@ -17,7 +17,7 @@ func NewLocatedErrorWithPath(err interface{}, nodes []ast.Node, path []interface
 
 func newLocatedError(err interface{}, nodes []ast.Node, path []interface{}) *gqlerrors.Error {
 	if err, ok := err.(*gqlerrors.Error); ok {
-		return err
+		return locateError(err, nodes, path)
 	}
 
 	var origError error
@@ -42,6 +42,52 @@ func newLocatedError(err interface{}, nodes []ast.Node, path []interface{}) *gql
 	)
 }
 
+// locateError returns the error located at the given nodes and path, unless it
+// already has locations and a path, e.g. when returned by a resolver built with
+// gqlerrors.NewError and no nodes.
+func locateError(err *gqlerrors.Error, nodes []ast.Node, path []interface{}) *gqlerrors.Error {
+	if (len(err.Locations) != 0 || len(nodes) == 0) && (err.Path != nil || path == nil) {
+		return err
+	}
+	located := *err
+	if len(located.Locations) == 0 && len(nodes) != 0 {
+		relocated := gqlerrors.NewError(err.Message, nodes, err.Stack, nil, []int{}, nil)
+		located.Nodes = relocated.Nodes
+		located.Source = relocated.Source
+		located.Positions = relocated.Positions
+		located.Locations = relocated.Locations
+	}
+	if located.Path == nil {
+		located.Path = path
+	}
+	return &located
+}
+
+// ArgumentError returns an error located at the given argument of the field,
+// or at the field when the argument is not provided, for resolvers rejecting
+// the value of an argument, e.g.
+//
+//	if p.Args["first"].(int) > 100 {
+//		return nil, p.Info.ArgumentError("first", errors.New("first must not exceed 100"))
+//	}
+func (info ResolveInfo) ArgumentError(name string, err interface{}) *gqlerrors.Error {
+	nodes := []ast.Node{}
+	for _, fieldAST := range info.FieldASTs {
+		if fieldAST == nil {
+			continue
+		}
+		for _, argAST := range fieldAST.Arguments {
+			if argAST.Name != nil && argAST.Name.Value == name {
+				nodes = append(nodes, argAST)
+			}
+		}
+	}
+	if len(nodes) == 0 {
+		nodes = FieldASTsToNodeASTs(info.FieldASTs)
+	}
+	return NewLocatedErrorWithPath(err, nodes, info.Path.AsArray())
+}
+
 func FieldASTsToNodeASTs(fieldASTs []*ast.Field) []ast.Node {
 	nodes := []ast.Node{}
 	for _, fieldAST := range fieldASTs {
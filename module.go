@@ -0,0 +1,222 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Module is a part of a schema composed by Compose, such as the types and
+// resolvers of a domain of a service.
+type Module struct {
+	// Name identifies the module in the dependencies and errors.
+	Name string
+
+	// DependsOn are the names of the modules this module depends on, for
+	// example because it extends their types. They are composed and
+	// initialized before it.
+	DependsOn []string
+
+	// Query, Mutation and Subscription are the fields the module adds to the
+	// root types of the schema.
+	Query        Fields
+	Mutation     Fields
+	Subscription Fields
+
+	// Types are the types of the module not reachable from its root fields.
+	Types []Type
+
+	// Extensions are the fields the module adds to the object types of the
+	// modules it depends on, by type name.
+	Extensions map[string]Fields
+
+	// Directives are the directives of the module, in addition to the
+	// specified directives.
+	Directives []*Directive
+
+	// Resolvers are bound to the composed schema, see Schema.BindResolvers.
+	Resolvers ResolverMap
+
+	// Init is called with the composed schema, in the order of the
+	// dependencies of the modules.
+	Init func(schema *Schema) error
+}
+
+// Compose builds a schema from the modules, in the order of their
+// dependencies, failing on unknown or cyclic dependencies and on conflicts:
+// types, root fields, extension fields, directives and resolvers defined by
+// more than one module.
+//
+// The root types of the schema are named Query, Mutation and Subscription.
+// Extensions add fields to the object types in place, so a set of modules is
+// composed once. Fields without resolvers are not reported, call
+// BindResolvers with an empty map on the composed schema to check them.
+func Compose(modules ...*Module) (Schema, error) {
+	ordered, err := sortModules(modules)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	roots := map[string]Fields{
+		"Query":        {},
+		"Mutation":     {},
+		"Subscription": {},
+	}
+	owners := map[string]string{}
+	claim := func(module *Module, kind, name string) error {
+		key := kind + " " + name
+		if owner, ok := owners[key]; ok && owner != module.Name {
+			return fmt.Errorf(`Modules "%v" and "%v" both define the %v.`, owner, module.Name, key)
+		}
+		owners[key] = module.Name
+		return nil
+	}
+
+	types := []Type{}
+	directives := []*Directive{}
+	resolvers := ResolverMap{}
+	for _, module := range ordered {
+		for rootName, fields := range map[string]Fields{
+			"Query":        module.Query,
+			"Mutation":     module.Mutation,
+			"Subscription": module.Subscription,
+		} {
+			for fieldName, field := range fields {
+				if err := claim(module, "field", rootName+"."+fieldName); err != nil {
+					return Schema{}, err
+				}
+				roots[rootName][fieldName] = field
+			}
+		}
+		for _, ttype := range module.Types {
+			if err := claim(module, "type", ttype.Name()); err != nil {
+				return Schema{}, err
+			}
+			types = append(types, ttype)
+		}
+		for _, directive := range module.Directives {
+			if err := claim(module, "directive", "@"+directive.Name); err != nil {
+				return Schema{}, err
+			}
+			directives = append(directives, directive)
+		}
+		for typeName, fields := range module.Resolvers {
+			if resolvers[typeName] == nil {
+				resolvers[typeName] = map[string]FieldResolveFn{}
+			}
+			for fieldName, resolve := range fields {
+				if err := claim(module, "resolver of", typeName+"."+fieldName); err != nil {
+					return Schema{}, err
+				}
+				resolvers[typeName][fieldName] = resolve
+			}
+		}
+	}
+
+	config := SchemaConfig{Types: types}
+	for _, directive := range SpecifiedDirectives {
+		if _, ok := owners["directive @"+directive.Name]; !ok {
+			directives = append(directives, directive)
+		}
+	}
+	config.Directives = directives
+	if len(roots["Query"]) > 0 {
+		config.Query = NewObject(ObjectConfig{Name: "Query", Fields: roots["Query"]})
+	}
+	if len(roots["Mutation"]) > 0 {
+		config.Mutation = NewObject(ObjectConfig{Name: "Mutation", Fields: roots["Mutation"]})
+	}
+	if len(roots["Subscription"]) > 0 {
+		config.Subscription = NewObject(ObjectConfig{Name: "Subscription", Fields: roots["Subscription"]})
+	}
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+
+	for _, module := range ordered {
+		typeNames := []string{}
+		for typeName := range module.Extensions {
+			typeNames = append(typeNames, typeName)
+		}
+		sort.Strings(typeNames)
+		for _, typeName := range typeNames {
+			object, ok := schema.Type(typeName).(*Object)
+			if !ok {
+				return schema, fmt.Errorf(`Module "%v" extends "%v", which is not an object type of the schema.`, module.Name, typeName)
+			}
+			for fieldName, field := range module.Extensions[typeName] {
+				if _, exists := object.Fields()[fieldName]; exists {
+					return schema, fmt.Errorf(`Module "%v" extends "%v" with the field "%v", which already exists.`, module.Name, typeName, fieldName)
+				}
+				object.AddFieldConfig(fieldName, field)
+				if err := object.Error(); err != nil {
+					return schema, err
+				}
+			}
+		}
+		// types only reachable from the extension fields
+		for _, fields := range module.Extensions {
+			for _, field := range fields {
+				if err := schema.AppendType(GetNamed(field.Type).(Type)); err != nil {
+					return schema, err
+				}
+			}
+		}
+	}
+
+	if err := schema.BindResolversWithConfig(BindResolversConfig{Resolvers: resolvers, AllowMissing: true}); err != nil {
+		return schema, err
+	}
+	for _, module := range ordered {
+		if module.Init == nil {
+			continue
+		}
+		if err := module.Init(&schema); err != nil {
+			return schema, fmt.Errorf(`Module "%v" failed to initialize: %v`, module.Name, err)
+		}
+	}
+	return schema, nil
+}
+
+// sortModules sorts the modules in the order of their dependencies, keeping
+// the given order otherwise.
+func sortModules(modules []*Module) ([]*Module, error) {
+	byName := map[string]*Module{}
+	for _, module := range modules {
+		if _, ok := byName[module.Name]; ok {
+			return nil, fmt.Errorf(`Module "%v" is composed more than once.`, module.Name)
+		}
+		byName[module.Name] = module
+	}
+	ordered := []*Module{}
+	state := map[string]int{} // 1 while visiting, 2 once visited
+	var visit func(module *Module, path []string) error
+	visit = func(module *Module, path []string) error {
+		switch state[module.Name] {
+		case 1:
+			return fmt.Errorf(`Modules have a dependency cycle: %v.`, strings.Join(append(path, module.Name), " -> "))
+		case 2:
+			return nil
+		}
+		state[module.Name] = 1
+		for _, name := range module.DependsOn {
+			dependency, ok := byName[name]
+			if !ok {
+				return fmt.Errorf(`Module "%v" depends on the unknown module "%v".`, module.Name, name)
+			}
+			if err := visit(dependency, append(append([]string{}, path...), module.Name)); err != nil {
+				return err
+			}
+		}
+		state[module.Name] = 2
+		ordered = append(ordered, module)
+		return nil
+	}
+	for _, module := range modules {
+		if err := visit(module, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
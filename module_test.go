@@ -0,0 +1,158 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func newUsersModule() *graphql.Module {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	return &graphql.Module{
+		Name: "users",
+		Query: graphql.Fields{
+			"me": &graphql.Field{Type: user},
+		},
+		Resolvers: graphql.ResolverMap{
+			"Query": {
+				"me": func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"id": "1"}, nil
+				},
+			},
+		},
+	}
+}
+
+func TestCompose_ComposesModulesInDependencyOrder(t *testing.T) {
+	order := []string{}
+	initModule := func(name string) func(*graphql.Schema) error {
+		return func(*graphql.Schema) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	orderType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.Fields{
+			"total": &graphql.Field{Type: graphql.Float},
+		},
+	})
+	orders := &graphql.Module{
+		Name:      "orders",
+		DependsOn: []string{"users"},
+		Extensions: map[string]graphql.Fields{
+			"User": {
+				"orders": &graphql.Field{
+					Type: graphql.NewList(orderType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{map[string]interface{}{"total": 9.5}}, nil
+					},
+				},
+			},
+		},
+		Mutation: graphql.Fields{
+			"cancelOrder": &graphql.Field{Type: graphql.Boolean},
+		},
+		Resolvers: graphql.ResolverMap{
+			"Mutation": {
+				"cancelOrder": func(p graphql.ResolveParams) (interface{}, error) {
+					return true, nil
+				},
+			},
+		},
+		Init: initModule("orders"),
+	}
+	users := newUsersModule()
+	users.Init = initModule("users")
+
+	schema, err := graphql.Compose(orders, users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"users", "orders"}, order) {
+		t.Fatalf("unexpected init order: %v", order)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ me { id orders { total } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"me": map[string]interface{}{
+				"id":     "1",
+				"orders": []interface{}{map[string]interface{}{"total": 9.5}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { cancelOrder }`,
+	})
+	if !reflect.DeepEqual(&graphql.Result{Data: map[string]interface{}{"cancelOrder": true}}, result) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestCompose_DetectsConflicts(t *testing.T) {
+	tests := []struct {
+		modules  []*graphql.Module
+		expected string
+	}{
+		{
+			modules: []*graphql.Module{newUsersModule(), {
+				Name:  "accounts",
+				Query: graphql.Fields{"me": &graphql.Field{Type: graphql.String}},
+			}},
+			expected: `Modules "users" and "accounts" both define the field Query.me.`,
+		},
+		{
+			modules: []*graphql.Module{newUsersModule(), {
+				Name:       "cache",
+				Directives: []*graphql.Directive{graphql.IncludeDirective},
+			}, {
+				Name:       "cache2",
+				Directives: []*graphql.Directive{graphql.IncludeDirective},
+			}},
+			expected: `Modules "cache" and "cache2" both define the directive @include.`,
+		},
+		{
+			modules: []*graphql.Module{newUsersModule(), {
+				Name:       "profiles",
+				DependsOn:  []string{"users"},
+				Extensions: map[string]graphql.Fields{"User": {"id": &graphql.Field{Type: graphql.String}}},
+			}},
+			expected: `Module "profiles" extends "User" with the field "id", which already exists.`,
+		},
+		{
+			modules:  []*graphql.Module{newUsersModule(), {Name: "billing", DependsOn: []string{"payments"}}},
+			expected: `Module "billing" depends on the unknown module "payments".`,
+		},
+		{
+			modules: []*graphql.Module{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+			expected: `Modules have a dependency cycle: a -> b -> a.`,
+		},
+		{
+			modules:  []*graphql.Module{newUsersModule(), newUsersModule()},
+			expected: `Module "users" is composed more than once.`,
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.Compose(test.modules...)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("expected error %q, got: %v", test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// IDCodec encodes the global IDs of the nodes of a schema from the names of
+// their types and their IDs, and decodes them, see NodeSchema.
+type IDCodec interface {
+	EncodeID(typeName, id string) string
+	DecodeID(globalID string) (typeName, id string, err error)
+}
+
+// Base64IDCodec is the default IDCodec, encoding the global IDs as the base64
+// encoding of the type name and the ID separated by a colon, e.g.
+// "VXNlcjox" for "User:1", like the Relay reference implementation.
+type Base64IDCodec struct{}
+
+// EncodeID implements IDCodec.
+func (Base64IDCodec) EncodeID(typeName, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + id))
+}
+
+// DecodeID implements IDCodec.
+func (Base64IDCodec) DecodeID(globalID string) (string, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", "", fmt.Errorf(`Invalid global ID "%v".`, globalID)
+	}
+	typeName, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || typeName == "" {
+		return "", "", fmt.Errorf(`Invalid global ID "%v".`, globalID)
+	}
+	return typeName, id, nil
+}
+
+// NodeFetcher returns the node of its type with the given ID, decoded from
+// its global ID, or nil when there is none. It is called with the parameters
+// of the node or nodes field.
+type NodeFetcher func(p ResolveParams, id string) (interface{}, error)
+
+// NodeConfig configures the nodes of a schema, see NodeSchema.
+type NodeConfig struct {
+	// Codec encodes and decodes the global IDs, defaults to Base64IDCodec.
+	Codec IDCodec
+
+	// Fetchers fetch the nodes by ID, by name of their type. The node and
+	// nodes fields fail for the global IDs of other types.
+	Fetchers map[string]NodeFetcher
+}
+
+// NodeSchema returns a schema implementing the object identification of the
+// Relay specification, see RenameTypes: the object types of the schema having
+// an "id" field of a scalar type, the root types excepted, implement the
+// Node interface,
+//
+//	interface Node {
+//		id: ID!
+//	}
+//
+// their id fields resolving to the global IDs encoding the names of their
+// types along with the IDs resolved by the schema. The query type gets the
+// node(id: ID!): Node and nodes(ids: [ID!]!): [Node]! fields, fetching the
+// nodes of their global IDs with the fetchers of their types. The id fields of
+// a scalar type of the interfaces are of type ID! as well.
+//
+// The schema must not have a type named Node, nor node and nodes fields on its
+// query type.
+func NodeSchema(schema *Schema, config NodeConfig) (Schema, error) {
+	if config.Codec == nil {
+		config.Codec = Base64IDCodec{}
+	}
+	if err := invariantf(schema.Type("Node") == nil,
+		`Cannot add the Node interface to a schema with a type named "Node".`); err != nil {
+		return Schema{}, err
+	}
+	for _, fieldName := range []string{"node", "nodes"} {
+		if err := invariantf(schema.QueryType().Fields()[fieldName] == nil,
+			`Cannot add the field %v.%v, the schema already has it.`, schema.QueryType(), fieldName); err != nil {
+			return Schema{}, err
+		}
+	}
+	return transformSchema(schema, &schemaTransform{node: newNodeTransform(schema, config)})
+}
+
+// nodeTransform adds the Node interface to the object types with an id field
+// and the node and nodes fields to the query type, see NodeSchema.
+type nodeTransform struct {
+	config NodeConfig
+	query  *Object
+	roots  map[*Object]bool
+	iface  *Interface
+}
+
+func newNodeTransform(schema *Schema, config NodeConfig) *nodeTransform {
+	return &nodeTransform{
+		config: config,
+		query:  schema.QueryType(),
+		roots: map[*Object]bool{
+			schema.QueryType():        true,
+			schema.MutationType():     true,
+			schema.SubscriptionType(): true,
+		},
+		iface: NewInterface(InterfaceConfig{
+			Name:        "Node",
+			Description: "An object with a global ID.",
+			Fields: Fields{
+				"id": &Field{
+					Type:        NewNonNull(ID),
+					Description: "The global ID of the object.",
+				},
+			},
+		}),
+	}
+}
+
+// isNode reports whether the object of the source schema implements Node.
+func (n *nodeTransform) isNode(object *Object) bool {
+	if n == nil || n.roots[object] {
+		return false
+	}
+	return isNodeID(object.Fields()["id"])
+}
+
+// isNodeID reports whether the field is an id field of a scalar type.
+func isNodeID(id *FieldDefinition) bool {
+	if id == nil {
+		return false
+	}
+	_, ok := GetNamed(id.Type).(*Scalar)
+	return ok
+}
+
+// interfaces returns the transformed interfaces of the object of the source
+// schema, along with the Node interface when it implements it.
+func (n *nodeTransform) interfaces(object *Object, interfaces []*Interface) []*Interface {
+	if n.isNode(object) {
+		interfaces = append(interfaces, n.iface)
+	}
+	return interfaces
+}
+
+// fields returns the transformed fields of the object of the source schema,
+// with its id field resolving to the global ID when it implements Node, and
+// the node and nodes fields when it is the query type.
+func (n *nodeTransform) fields(object *Object, transformed *Object, fields Fields) Fields {
+	if n == nil {
+		return fields
+	}
+	if n.isNode(object) {
+		id := *fields["id"]
+		resolve := id.Resolve
+		if resolve == nil {
+			resolve = DefaultResolveFn
+		}
+		id.Type = NewNonNull(ID)
+		id.Resolve = func(p ResolveParams) (interface{}, error) {
+			value, err := resolve(p)
+			if err != nil || isNullish(value) {
+				return nil, err
+			}
+			return n.config.Codec.EncodeID(transformed.Name(), fmt.Sprint(value)), nil
+		}
+		fields["id"] = &id
+	}
+	if object == n.query {
+		fields["node"] = &Field{
+			Type:        n.iface,
+			Description: "Fetches the object with the given global ID.",
+			Args: FieldConfigArgument{
+				"id": &ArgumentConfig{Type: NewNonNull(ID), Description: "The global ID of the object."},
+			},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return n.fetch(p, p.Args["id"].(string))
+			},
+		}
+		fields["nodes"] = &Field{
+			Type:        NewNonNull(NewList(n.iface)),
+			Description: "Fetches the objects with the given global IDs.",
+			Args: FieldConfigArgument{
+				"ids": &ArgumentConfig{Type: NewNonNull(NewList(NewNonNull(ID))), Description: "The global IDs of the objects."},
+			},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				nodes := []interface{}{}
+				for _, id := range p.Args["ids"].([]interface{}) {
+					node, err := n.fetch(p, id.(string))
+					if err != nil {
+						return nil, err
+					}
+					nodes = append(nodes, node)
+				}
+				return nodes, nil
+			},
+		}
+	}
+	return fields
+}
+
+// interfaceFields returns the transformed fields of the interface of the
+// source schema, with its id field of type ID! like those of the nodes
+// implementing it.
+func (n *nodeTransform) interfaceFields(iface *Interface, fields Fields) Fields {
+	if n == nil || !isNodeID(iface.Fields()["id"]) {
+		return fields
+	}
+	id := *fields["id"]
+	id.Type = NewNonNull(ID)
+	fields["id"] = &id
+	return fields
+}
+
+// fetch returns the node with the given global ID, along with its type.
+func (n *nodeTransform) fetch(p ResolveParams, globalID string) (interface{}, error) {
+	typeName, id, err := n.config.Codec.DecodeID(globalID)
+	if err != nil {
+		return nil, err
+	}
+	fetch := n.config.Fetchers[typeName]
+	object, _ := p.Info.Schema.Type(typeName).(*Object)
+	if fetch == nil || object == nil || !p.Info.Schema.IsPossibleType(n.iface, object) {
+		return nil, fmt.Errorf(`Cannot fetch the node of type "%v".`, typeName)
+	}
+	node, err := fetch(p, id)
+	if err != nil || isNullish(node) {
+		return nil, err
+	}
+	return Typed{TypeName: typeName, Value: node}, nil
+}
@@ -0,0 +1,104 @@
+package graphql_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func nodeTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NodeSchema(&testutil.StarWarsSchema, graphql.NodeConfig{
+		Fetchers: map[string]graphql.NodeFetcher{
+			"Human": func(p graphql.ResolveParams, id string) (interface{}, error) {
+				n, err := strconv.Atoi(id)
+				if err != nil {
+					return nil, err
+				}
+				if human := testutil.GetHuman(n); human.ID != "" {
+					return human, nil
+				}
+				return nil, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestNodeSchema(t *testing.T) {
+	schema := nodeTestSchema(t)
+	codec := graphql.Base64IDCodec{}
+	luke := codec.EncodeID("Human", "1000")
+	query := `query ($luke: ID!, $missing: ID!) {
+		hero { __typename id ... on Node { id } }
+		luke: node(id: $luke) { __typename id ... on Human { name } }
+		missing: node(id: $missing) { id }
+		nodes(ids: [$luke]) { ... on Character { name } }
+	}`
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"luke": luke, "missing": codec.EncodeID("Human", "1")},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"__typename": "Droid",
+				"id":         codec.EncodeID("Droid", "2001"),
+			},
+			"luke": map[string]interface{}{
+				"__typename": "Human",
+				"id":         luke,
+				"name":       "Luke Skywalker",
+			},
+			"missing": nil,
+			"nodes": []interface{}{
+				map[string]interface{}{"name": "Luke Skywalker"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if luke != "SHVtYW46MTAwMA==" {
+		t.Fatalf("unexpected global ID: %v", luke)
+	}
+}
+
+func TestNodeSchema_Errors(t *testing.T) {
+	schema := nodeTestSchema(t)
+	codec := graphql.Base64IDCodec{}
+	for globalID, message := range map[string]string{
+		"Human:1000":                    `Invalid global ID "Human:1000".`,
+		codec.EncodeID("Droid", "2001"): `Cannot fetch the node of type "Droid".`,
+		codec.EncodeID("Query", "1"):    `Cannot fetch the node of type "Query".`,
+	} {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($id: ID!) { node(id: $id) { id } }`,
+			VariableValues: map[string]interface{}{"id": globalID},
+		})
+		expected := &graphql.Result{
+			Data: map[string]interface{}{"node": nil},
+			Errors: []gqlerrors.FormattedError{{
+				Message:   message,
+				Locations: []location.SourceLocation{{Line: 1, Column: 20}},
+				Path:      []interface{}{"node"},
+			}},
+		}
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+
+	if _, err := graphql.NodeSchema(&schema, graphql.NodeConfig{}); err == nil ||
+		err.Error() != `Cannot add the Node interface to a schema with a type named "Node".` {
+		t.Fatalf("expected the schema with a Node type to be rejected, got %v", err)
+	}
+}
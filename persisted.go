@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// VariablePreset is a server-defined rule applied to the value provided by the
+// client for a variable, before the value is coerced to the type of the
+// variable. provided is false when the client did not provide the variable.
+// The returned value replaces the value provided by the client, and is
+// coerced and validated like any client value.
+type VariablePreset func(input interface{}, provided bool) (interface{}, error)
+
+// DefaultVariable returns a preset using the given value when the client
+// provides no value, or null, for the variable.
+func DefaultVariable(value interface{}) VariablePreset {
+	return func(input interface{}, provided bool) (interface{}, error) {
+		if !provided || input == nil {
+			return value, nil
+		}
+		return input, nil
+	}
+}
+
+// FixedVariable returns a preset always using the given value, ignoring the
+// value provided by the client.
+func FixedVariable(value interface{}) VariablePreset {
+	return func(interface{}, bool) (interface{}, error) {
+		return value, nil
+	}
+}
+
+// MaxIntVariable returns a preset capping the Int provided by the client to
+// the given maximum, e.g. to bound the page size of a public API.
+func MaxIntVariable(max int) VariablePreset {
+	return func(input interface{}, provided bool) (interface{}, error) {
+		if !provided || input == nil {
+			return input, nil
+		}
+		if value, ok := Int.ParseValue(input).(int); ok && value > max {
+			return max, nil
+		}
+		return input, nil
+	}
+}
+
+// PersistedOperation is an operation registered ahead of time and executed
+// by id, along with server-defined presets for its variables.
+type PersistedOperation struct {
+	ID            string
+	Query         string
+	OperationName string
+
+	// VariablePresets are applied to the variables provided by the client, by variable name.
+	VariablePresets map[string]VariablePreset
+}
+
+// OperationRegistry holds persisted operations by id. It is safe for concurrent use.
+type OperationRegistry struct {
+	mu         sync.RWMutex
+	operations map[string]*PersistedOperation
+	// parsed are the operations parsed by Schema.Warmup, by id
+	parsed map[string]*parsedRequest
+}
+
+// NewOperationRegistry returns an empty operation registry.
+func NewOperationRegistry() *OperationRegistry {
+	return &OperationRegistry{
+		operations: map[string]*PersistedOperation{},
+	}
+}
+
+// Register adds the given operation to the registry.
+func (r *OperationRegistry) Register(operation *PersistedOperation) error {
+	if operation == nil || operation.ID == "" {
+		return fmt.Errorf("persisted operation must have an id")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.operations[operation.ID]; ok {
+		return fmt.Errorf("persisted operation %q is already registered", operation.ID)
+	}
+	r.operations[operation.ID] = operation
+	return nil
+}
+
+// Operation returns the operation registered with the given id.
+func (r *OperationRegistry) Operation(id string) (*PersistedOperation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	operation, ok := r.operations[id]
+	return operation, ok
+}
+
+// Params returns the given params set up to execute the operation registered
+// with the given id, along with the presets of its variables.
+func (r *OperationRegistry) Params(id string, p Params) (Params, error) {
+	operation, ok := r.Operation(id)
+	if !ok {
+		return p, fmt.Errorf("PersistedQueryNotFound: unknown persisted operation %q", id)
+	}
+	p.RequestString = operation.Query
+	p.OperationName = operation.OperationName
+	p.VariablePresets = operation.VariablePresets
+	r.mu.RLock()
+	p.parsed = r.parsed[id]
+	r.mu.RUnlock()
+	return p, nil
+}
+
+// operationList returns the registered operations, sorted by id.
+func (r *OperationRegistry) operationList() []*PersistedOperation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	operations := make([]*PersistedOperation, 0, len(r.operations))
+	for _, operation := range r.operations {
+		operations = append(operations, operation)
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID < operations[j].ID
+	})
+	return operations
+}
+
+// setParsed keeps the parsed document of the operation with the given id.
+func (r *OperationRegistry) setParsed(id string, parsed *parsedRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.parsed == nil {
+		r.parsed = map[string]*parsedRequest{}
+	}
+	r.parsed[id] = parsed
+}
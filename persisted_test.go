@@ -0,0 +1,130 @@
+package graphql_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func newPersistedRegistry(t *testing.T) (graphql.Schema, *graphql.OperationRegistry) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+						"status": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return fmt.Sprintf("%v %v", p.Args["limit"], p.Args["status"]), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registry := graphql.NewOperationRegistry()
+	err = registry.Register(&graphql.PersistedOperation{
+		ID:    "items",
+		Query: `query Items($limit: Int!, $status: String = "open") { items(limit: $limit, status: $status) }`,
+		VariablePresets: map[string]graphql.VariablePreset{
+			"limit": func(input interface{}, provided bool) (interface{}, error) {
+				input, err := graphql.DefaultVariable(10)(input, provided)
+				if err != nil {
+					return nil, err
+				}
+				return graphql.MaxIntVariable(100)(input, true)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = registry.Register(&graphql.PersistedOperation{
+		ID:    "archived",
+		Query: `query Archived($status: String) { items(limit: 1, status: $status) }`,
+		VariablePresets: map[string]graphql.VariablePreset{
+			"status": graphql.FixedVariable("archived"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema, registry
+}
+
+func TestPersistedOperationVariablePresets(t *testing.T) {
+	schema, registry := newPersistedRegistry(t)
+	tests := []struct {
+		id        string
+		variables map[string]interface{}
+		expected  string
+	}{
+		{id: "items", variables: nil, expected: "10 open"},
+		{id: "items", variables: map[string]interface{}{"limit": 5}, expected: "5 open"},
+		{id: "items", variables: map[string]interface{}{"limit": 1000, "status": nil}, expected: "100 <nil>"},
+		{id: "archived", variables: map[string]interface{}{"status": "open"}, expected: "1 archived"},
+	}
+	for _, test := range tests {
+		params, err := registry.Params(test.id, graphql.Params{
+			Schema:         schema,
+			VariableValues: test.variables,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := graphql.Do(params)
+		if len(result.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+		expected := map[string]interface{}{"items": test.expected}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("%v with %v: expected %v, got: %v", test.id, test.variables, expected, result.Data)
+		}
+	}
+}
+
+func TestPersistedOperationPresetErrors(t *testing.T) {
+	schema, registry := newPersistedRegistry(t)
+
+	if _, err := registry.Params("unknown", graphql.Params{Schema: schema}); err == nil {
+		t.Fatalf("expected an error for an unknown operation")
+	}
+	if err := registry.Register(&graphql.PersistedOperation{ID: "items"}); err == nil {
+		t.Fatalf("expected an error registering a duplicate operation")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($limit: Int) { items(limit: $limit) }`,
+		VariableValues: map[string]interface{}{"limit": 1},
+		VariablePresets: map[string]graphql.VariablePreset{
+			"limit": func(interface{}, bool) (interface{}, error) {
+				return nil, errors.New("not allowed")
+			},
+		},
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, `Variable "$limit" got invalid value: not allowed`) {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	// values set by presets are coerced like client values
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($limit: Int) { items(limit: $limit) }`,
+		VariablePresets: map[string]graphql.VariablePreset{
+			"limit": graphql.FixedVariable("many"),
+		},
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, `Variable "$limit" got invalid value "many"`) {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
@@ -0,0 +1,254 @@
+// Package projection converts the selection set of a resolved field into a
+// MongoDB projection document, so resolvers backed by a Mongo collection only
+// fetch the document paths a query actually asked for.
+//
+// The produced documents are plain maps and can be passed to any Mongo driver
+// (e.g. as a bson.M) without this package depending on one.
+package projection
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Config holds the per-type mapping between GraphQL fields and stored documents.
+// Types without a configuration project every selected field by its GraphQL name.
+type Config struct {
+	Types map[string]*TypeConfig
+}
+
+// TypeConfig describes how the fields of a single GraphQL object type map onto a document.
+type TypeConfig struct {
+	// Fields maps GraphQL field names to document paths. Unmapped fields use
+	// the GraphQL field name as the path.
+	Fields map[string]string
+
+	// Ignore lists GraphQL fields that are computed by resolvers and have no
+	// stored counterpart.
+	Ignore []string
+
+	// Always lists document paths projected whenever the type is selected,
+	// typically keys that resolvers of computed fields depend on.
+	Always []string
+
+	// Lookups maps GraphQL fields that reference documents stored in another collection.
+	Lookups map[string]*LookupConfig
+}
+
+// LookupConfig describes a reference to documents stored in another collection,
+// mirroring the fields of a Mongo `$lookup` stage.
+type LookupConfig struct {
+	From         string
+	LocalField   string
+	ForeignField string
+}
+
+// Lookup is a `$lookup` stage required to resolve a selected reference field,
+// along with the projection to apply to the joined documents.
+type Lookup struct {
+	From         string
+	LocalField   string
+	ForeignField string
+	As           string
+
+	Projection map[string]interface{}
+	Lookups    []*Lookup
+}
+
+// Stage returns the lookup as a `$lookup` aggregation pipeline stage using
+// a sub-pipeline so the joined documents are projected as well.
+func (l *Lookup) Stage() map[string]interface{} {
+	pipeline := []interface{}{
+		map[string]interface{}{
+			"$match": map[string]interface{}{
+				"$expr": map[string]interface{}{
+					"$eq": []interface{}{"$" + l.ForeignField, "$$local"},
+				},
+			},
+		},
+	}
+	for _, nested := range l.Lookups {
+		pipeline = append(pipeline, nested.Stage())
+	}
+	if len(l.Projection) > 0 {
+		pipeline = append(pipeline, map[string]interface{}{"$project": l.Projection})
+	}
+	return map[string]interface{}{
+		"$lookup": map[string]interface{}{
+			"from":     l.From,
+			"let":      map[string]interface{}{"local": "$" + l.LocalField},
+			"pipeline": pipeline,
+			"as":       l.As,
+		},
+	}
+}
+
+// Result is the projection computed for a field.
+type Result struct {
+	// Projection is the projection document for the field's own collection.
+	Projection map[string]interface{}
+
+	// Lookups are the `$lookup` stages needed for selected reference fields.
+	Lookups []*Lookup
+}
+
+// FromResolveInfo computes the projection for the documents returned by the
+// field currently being resolved.
+func FromResolveInfo(info graphql.ResolveInfo, config Config) *Result {
+	b := &builder{
+		config:    config,
+		schema:    info.Schema,
+		fragments: info.Fragments,
+		variables: info.VariableValues,
+	}
+	return b.build(info.ReturnType, info.FieldASTs)
+}
+
+type builder struct {
+	config    Config
+	schema    graphql.Schema
+	fragments map[string]ast.Definition
+	variables map[string]interface{}
+}
+
+func (b *builder) build(ttype graphql.Type, fieldASTs []*ast.Field) *Result {
+	result := &Result{
+		Projection: map[string]interface{}{},
+		Lookups:    []*Lookup{},
+	}
+	b.project(result, "", ttype, fieldASTs)
+	return result
+}
+
+func (b *builder) project(result *Result, prefix string, ttype graphql.Type, fieldASTs []*ast.Field) {
+	named := graphql.GetNamed(ttype)
+	typeName := ""
+	if named, ok := named.(graphql.Type); ok {
+		typeName = named.Name()
+	}
+	typeConfig := b.config.Types[typeName]
+	if typeConfig == nil {
+		typeConfig = &TypeConfig{}
+	}
+	for _, path := range typeConfig.Always {
+		result.Projection[prefix+path] = 1
+	}
+
+	for _, subField := range b.collectFields(fieldASTs) {
+		name := subField.name
+		if name == graphql.TypeNameMetaFieldDef.Name || contains(typeConfig.Ignore, name) {
+			continue
+		}
+		fieldDef := fieldDefinition(named, name)
+		if lookup, ok := typeConfig.Lookups[name]; ok {
+			result.Projection[prefix+lookup.LocalField] = 1
+			var subType graphql.Type
+			if fieldDef != nil {
+				subType = fieldDef.Type
+			}
+			nested := b.build(subType, subField.asts)
+			result.Lookups = append(result.Lookups, &Lookup{
+				From:         lookup.From,
+				LocalField:   prefix + lookup.LocalField,
+				ForeignField: lookup.ForeignField,
+				As:           prefix + name,
+				Projection:   nested.Projection,
+				Lookups:      nested.Lookups,
+			})
+			continue
+		}
+
+		path := name
+		if mapped, ok := typeConfig.Fields[name]; ok {
+			path = mapped
+		}
+		if fieldDef != nil && hasSelections(subField.asts) && graphql.IsCompositeType(graphql.GetNamed(fieldDef.Type)) {
+			// embedded document: project only the selected sub-paths.
+			b.project(result, prefix+path+".", fieldDef.Type, subField.asts)
+			continue
+		}
+		result.Projection[prefix+path] = 1
+	}
+}
+
+type selectedField struct {
+	name string
+	asts []*ast.Field
+}
+
+// collectFields merges the sub-selections of the given field ASTs by field name,
+// following fragments regardless of their type condition so abstract types
+// project the union of the fields requested for each possible type.
+func (b *builder) collectFields(fieldASTs []*ast.Field) []*selectedField {
+	fields := []*selectedField{}
+	index := map[string]*selectedField{}
+	visited := map[string]bool{}
+	var collect func(selectionSet *ast.SelectionSet)
+	collect = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name == nil || !graphql.ShouldIncludeNode(selection, b.variables) {
+					continue
+				}
+				name := selection.Name.Value
+				field, ok := index[name]
+				if !ok {
+					field = &selectedField{name: name}
+					index[name] = field
+					fields = append(fields, field)
+				}
+				field.asts = append(field.asts, selection)
+			case *ast.InlineFragment:
+				if graphql.ShouldIncludeNode(selection, b.variables) {
+					collect(selection.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				if selection.Name == nil || visited[selection.Name.Value] || !graphql.ShouldIncludeNode(selection, b.variables) {
+					continue
+				}
+				visited[selection.Name.Value] = true
+				if fragment, ok := b.fragments[selection.Name.Value]; ok {
+					collect(fragment.GetSelectionSet())
+				}
+			}
+		}
+	}
+	for _, fieldAST := range fieldASTs {
+		if fieldAST != nil {
+			collect(fieldAST.SelectionSet)
+		}
+	}
+	return fields
+}
+
+func fieldDefinition(ttype graphql.Named, name string) *graphql.FieldDefinition {
+	switch ttype := ttype.(type) {
+	case *graphql.Object:
+		return ttype.Fields()[name]
+	case *graphql.Interface:
+		return ttype.Fields()[name]
+	}
+	return nil
+}
+
+func hasSelections(fieldASTs []*ast.Field) bool {
+	for _, fieldAST := range fieldASTs {
+		if fieldAST != nil && fieldAST.SelectionSet != nil && len(fieldAST.SelectionSet.Selections) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package projection_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/projection"
+)
+
+func projectionSchema(t *testing.T, config projection.Config, captured **projection.Result) graphql.Schema {
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.ID},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	addressType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Address",
+		Fields: graphql.Fields{
+			"city":   &graphql.Field{Type: graphql.String},
+			"street": &graphql.Field{Type: graphql.String},
+		},
+	})
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.ID},
+			"title":   &graphql.Field{Type: graphql.String},
+			"body":    &graphql.Field{Type: graphql.String},
+			"excerpt": &graphql.Field{Type: graphql.String},
+			"address": &graphql.Field{Type: addressType},
+			"author":  &graphql.Field{Type: authorType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"posts": &graphql.Field{
+					Type: graphql.NewList(postType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						*captured = projection.FromResolveInfo(p.Info, config)
+						return nil, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error building schema: %v", err)
+	}
+	return schema
+}
+
+func TestFromResolveInfo_ProjectsSelectedFields(t *testing.T) {
+	config := projection.Config{
+		Types: map[string]*projection.TypeConfig{
+			"Post": {
+				Fields:  map[string]string{"id": "_id"},
+				Ignore:  []string{"excerpt"},
+				Always:  []string{"status"},
+				Lookups: map[string]*projection.LookupConfig{"author": {From: "authors", LocalField: "authorId", ForeignField: "_id"}},
+			},
+			"Author": {
+				Fields: map[string]string{"id": "_id"},
+			},
+		},
+	}
+	var result *projection.Result
+	schema := projectionSchema(t, config, &result)
+	r := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($withBody: Boolean!) {
+			posts {
+				id
+				...PostFields
+				body @include(if: $withBody)
+				excerpt
+				address { city }
+				author { name }
+			}
+		}
+		fragment PostFields on Post { title __typename }`,
+		VariableValues: map[string]interface{}{"withBody": false},
+	})
+	if r.HasErrors() {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := map[string]interface{}{
+		"_id":          1,
+		"status":       1,
+		"title":        1,
+		"address.city": 1,
+		"authorId":     1,
+	}
+	if !reflect.DeepEqual(result.Projection, expected) {
+		t.Fatalf("unexpected projection, expected: %v, got: %v", expected, result.Projection)
+	}
+	if len(result.Lookups) != 1 {
+		t.Fatalf("expected one lookup, got: %v", len(result.Lookups))
+	}
+	lookup := result.Lookups[0]
+	if lookup.From != "authors" || lookup.LocalField != "authorId" || lookup.As != "author" {
+		t.Fatalf("unexpected lookup: %+v", lookup)
+	}
+	if !reflect.DeepEqual(lookup.Projection, map[string]interface{}{"name": 1}) {
+		t.Fatalf("unexpected lookup projection: %v", lookup.Projection)
+	}
+	stage := lookup.Stage()["$lookup"].(map[string]interface{})
+	if stage["from"] != "authors" || stage["as"] != "author" {
+		t.Fatalf("unexpected lookup stage: %v", stage)
+	}
+}
+
+func TestFromResolveInfo_UnconfiguredTypeUsesFieldNames(t *testing.T) {
+	var result *projection.Result
+	schema := projectionSchema(t, projection.Config{}, &result)
+	r := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ posts { title author { name } } }`,
+	})
+	if r.HasErrors() {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := map[string]interface{}{"title": 1, "author.name": 1}
+	if !reflect.DeepEqual(result.Projection, expected) {
+		t.Fatalf("unexpected projection, expected: %v, got: %v", expected, result.Projection)
+	}
+}
+
+func TestFromResolveInfo_SkipsExcludedSelections(t *testing.T) {
+	var result *projection.Result
+	schema := projectionSchema(t, projection.Config{}, &result)
+	r := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($brief: Boolean = true) {
+			posts {
+				title
+				body @skip(if: $brief)
+				...AuthorFields @skip(if: $brief)
+				... on Post @include(if: $brief) { excerpt }
+			}
+		}
+		fragment AuthorFields on Post { author { name } }`,
+	})
+	if r.HasErrors() {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := map[string]interface{}{"title": 1, "excerpt": 1}
+	if !reflect.DeepEqual(result.Projection, expected) {
+		t.Fatalf("unexpected projection, expected: %v, got: %v", expected, result.Projection)
+	}
+}
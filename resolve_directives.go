@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// AppliedDirective is a directive applied in the document of an operation,
+// such as a custom client directive `@currency(code: "EUR")`, or to an
+// element of the schema, see ScalarConfig.AppliedDirectives.
+type AppliedDirective struct {
+	Name string
+
+	// Args are the values of the arguments coerced by the definition of the
+	// directive in the schema, with the variables of the operation, nil when
+	// the schema does not define the directive. The arguments of the
+	// directives applied to the schema are their internal values.
+	Args map[string]interface{}
+
+	// Location is the location of the node of the directive, one of the
+	// DirectiveLocation constants of the operations, empty for the directives
+	// applied to the schema.
+	Location string
+
+	AST *ast.Directive
+}
+
+// FieldDirectives returns the directives applied to the field, in the order
+// of the document when the field is selected more than once.
+func (info ResolveInfo) FieldDirectives() []*AppliedDirective {
+	directives := []*AppliedDirective{}
+	for _, fieldAST := range info.FieldASTs {
+		if fieldAST != nil {
+			directives = info.appendDirectives(directives, fieldAST.Directives, DirectiveLocationField)
+		}
+	}
+	return directives
+}
+
+// FragmentDirectives returns the directives applied to the fragment spreads,
+// fragment definitions and inline fragments through which the field is
+// selected in the selection set of its parent, outermost first.
+func (info ResolveInfo) FragmentDirectives() []*AppliedDirective {
+	directives := []*AppliedDirective{}
+	for _, fragment := range info.fragments {
+		switch fragment := fragment.(type) {
+		case *ast.FragmentSpread:
+			directives = info.appendDirectives(directives, fragment.Directives, DirectiveLocationFragmentSpread)
+		case *ast.FragmentDefinition:
+			directives = info.appendDirectives(directives, fragment.Directives, DirectiveLocationFragmentDefinition)
+		case *ast.InlineFragment:
+			directives = info.appendDirectives(directives, fragment.Directives, DirectiveLocationInlineFragment)
+		}
+	}
+	return directives
+}
+
+// OperationDirectives returns the directives applied to the operation.
+func (info ResolveInfo) OperationDirectives() []*AppliedDirective {
+	directives := []*AppliedDirective{}
+	operation, ok := info.Operation.(*ast.OperationDefinition)
+	if !ok {
+		return directives
+	}
+	location := DirectiveLocationQuery
+	switch operation.Operation {
+	case ast.OperationTypeMutation:
+		location = DirectiveLocationMutation
+	case ast.OperationTypeSubscription:
+		location = DirectiveLocationSubscription
+	}
+	return info.appendDirectives(directives, operation.Directives, location)
+}
+
+// Directive returns the directive of the given name applied the closest to
+// the field: to the field itself, then to its innermost fragment, then to the
+// operation, nil if the directive is not applied.
+func (info ResolveInfo) Directive(name string) *AppliedDirective {
+	for _, directive := range info.FieldDirectives() {
+		if directive.Name == name {
+			return directive
+		}
+	}
+	fragmentDirectives := info.FragmentDirectives()
+	for i := len(fragmentDirectives) - 1; i >= 0; i-- {
+		if fragmentDirectives[i].Name == name {
+			return fragmentDirectives[i]
+		}
+	}
+	for _, directive := range info.OperationDirectives() {
+		if directive.Name == name {
+			return directive
+		}
+	}
+	return nil
+}
+
+func (info ResolveInfo) appendDirectives(directives []*AppliedDirective, directiveASTs []*ast.Directive, location string) []*AppliedDirective {
+	for _, directiveAST := range directiveASTs {
+		if directiveAST == nil || directiveAST.Name == nil {
+			continue
+		}
+		applied := &AppliedDirective{
+			Name:     directiveAST.Name.Value,
+			Location: location,
+			AST:      directiveAST,
+		}
+		if directive := info.Schema.Directive(applied.Name); directive != nil {
+			applied.Args = getArgumentValues(directive.Args, directiveAST.Arguments, info.VariableValues)
+		}
+		directives = append(directives, applied)
+	}
+	return directives
+}
+
+// fieldFragments returns the fragments through which the field ASTs were
+// collected, without duplicates, ahead of time or on execution.
+func fieldFragments(eCtx *executionContext, fieldASTs []*ast.Field) []ast.Node {
+	if fragments, ok := eCtx.plan.collectedFragments(fieldASTs); ok {
+		return fragments
+	}
+	if eCtx.fieldFragments == nil {
+		return nil
+	}
+	var fragments []ast.Node
+	for _, fieldAST := range fieldASTs {
+		for _, fragment := range eCtx.fieldFragments[fieldAST] {
+			if !containsNode(fragments, fragment) {
+				fragments = append(fragments, fragment)
+			}
+		}
+	}
+	return fragments
+}
+
+func containsNode(nodes []ast.Node, node ast.Node) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
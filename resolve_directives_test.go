@@ -0,0 +1,171 @@
+package graphql_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func resolveDirectivesSchema(t *testing.T) graphql.Schema {
+	uppercaseDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name: "uppercase",
+		Locations: []string{
+			graphql.DirectiveLocationField,
+			graphql.DirectiveLocationFragmentSpread,
+			graphql.DirectiveLocationInlineFragment,
+			graphql.DirectiveLocationQuery,
+		},
+	})
+	currencyDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name: "currency",
+		Locations: []string{
+			graphql.DirectiveLocationField,
+			graphql.DirectiveLocationFragmentDefinition,
+			graphql.DirectiveLocationQuery,
+		},
+		Args: graphql.FieldConfigArgument{
+			"code": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "USD"},
+		},
+	})
+
+	// the resolvers describe the directives they see
+	describe := func(directives []*graphql.AppliedDirective) string {
+		names := []string{}
+		for _, directive := range directives {
+			names = append(names, fmt.Sprintf("%v@%v %v", directive.Location, directive.Name, directive.Args))
+		}
+		return strings.Join(names, " ")
+	}
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := "widget"
+					if p.Info.Directive("uppercase") != nil {
+						name = strings.ToUpper(name)
+					}
+					return name, nil
+				},
+			},
+			"price": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					code := "USD"
+					if currency := p.Info.Directive("currency"); currency != nil {
+						code = currency.Args["code"].(string)
+					}
+					return "42 " + code, nil
+				},
+			},
+			"directives": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return strings.Join([]string{
+						describe(p.Info.FieldDirectives()),
+						describe(p.Info.FragmentDirectives()),
+						describe(p.Info.OperationDirectives()),
+					}, " | "), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"product": &graphql.Field{
+					Type: productType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return struct{}{}, nil
+					},
+				},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, uppercaseDirective, currencyDirective),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestResolveInfo_Directives(t *testing.T) {
+	schema := resolveDirectivesSchema(t)
+	tests := []struct {
+		query     string
+		variables map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			`{ product { name @uppercase price } }`,
+			nil,
+			map[string]interface{}{"name": "WIDGET", "price": "42 USD"},
+		},
+		{
+			`query ($code: String) @currency(code: "GBP") { product { price @currency(code: $code) } }`,
+			map[string]interface{}{"code": "EUR"},
+			map[string]interface{}{"price": "42 EUR"},
+		},
+		{
+			`query @currency(code: "GBP") { product { ...Prices } } fragment Prices on Product { price }`,
+			nil,
+			map[string]interface{}{"price": "42 GBP"},
+		},
+		{
+			`{ product { ...Prices ... @uppercase { name } } } fragment Prices on Product @currency(code: "JPY") { price }`,
+			nil,
+			map[string]interface{}{"name": "WIDGET", "price": "42 JPY"},
+		},
+		{
+			`{ product { name ... @uppercase { price } } }`,
+			nil,
+			map[string]interface{}{"name": "widget", "price": "42 USD"},
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  test.query,
+			VariableValues: test.variables,
+		})
+		expected := &graphql.Result{Data: map[string]interface{}{"product": test.expected}}
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("%v: unexpected result, Diff: %v", test.query, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestResolveInfo_DirectivesByLocation(t *testing.T) {
+	schema := resolveDirectivesSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `
+			query @uppercase {
+				product {
+					... on Product @uppercase {
+						...Directives @uppercase
+					}
+				}
+			}
+			fragment Directives on Product @currency {
+				directives @currency(code: "EUR") @include(if: true)
+			}
+		`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := "FIELD@currency map[code:EUR] FIELD@include map[if:true] | " +
+		"INLINE_FRAGMENT@uppercase map[] FRAGMENT_SPREAD@uppercase map[] FRAGMENT_DEFINITION@currency map[code:USD] | " +
+		"QUERY@uppercase map[]"
+	actual := result.Data.(map[string]interface{})["product"].(map[string]interface{})["directives"]
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("unexpected directives:\n%v\nexpected:\n%v", actual, expected)
+	}
+}
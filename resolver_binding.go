@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolverMap maps the names of object types to the resolvers of their
+// fields, by field name.
+type ResolverMap map[string]map[string]FieldResolveFn
+
+// BindResolversConfig options for binding resolvers to a schema.
+type BindResolversConfig struct {
+	Resolvers ResolverMap
+
+	// RequireResolver reports whether a field must have a resolver, bound or
+	// defined with the field, defaults to IsNonTrivialField.
+	RequireResolver func(schema *Schema, parentType *Object, field *FieldDefinition) bool
+
+	// AllowMissing disables the check of the fields requiring a resolver.
+	AllowMissing bool
+}
+
+// IsNonTrivialField reports whether the field cannot be resolved by
+// DefaultResolveFn from the value of its parent: the fields of the root
+// types and the fields with arguments.
+func IsNonTrivialField(schema *Schema, parentType *Object, field *FieldDefinition) bool {
+	if len(field.Args) > 0 {
+		return true
+	}
+	return parentType == schema.QueryType() || parentType == schema.MutationType() || parentType == schema.SubscriptionType()
+}
+
+// BindResolvers binds the resolvers to the fields of the schema, checking
+// that every non-trivial field has a resolver, see BindResolversWithConfig.
+func (gq *Schema) BindResolvers(resolvers ResolverMap) error {
+	return gq.BindResolversWithConfig(BindResolversConfig{Resolvers: resolvers})
+}
+
+// BindResolversWithConfig binds the resolvers to the fields of the schema.
+//
+// Bound resolvers take precedence over the Resolve functions of the fields and
+// are kept by the schema, not by its types, so the same type definitions can
+// be bound to different resolvers by different schemas, e.g. one with the
+// implementations of a service and one with the stubs of its tests. Binding
+// again adds or replaces resolvers.
+//
+// It fails when a resolver is bound to an unknown type or field, or when a
+// field required by config.RequireResolver has no resolver.
+func (gq *Schema) BindResolversWithConfig(config BindResolversConfig) error {
+	if err := gq.guard.lock("bind resolvers"); err != nil {
+		return err
+	}
+	defer gq.guard.unlock()
+
+	typeNames := []string{}
+	for typeName := range config.Resolvers {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	resolvers := make(ResolverMap, len(gq.resolvers)+len(config.Resolvers))
+	for typeName, fields := range gq.resolvers {
+		resolvers[typeName] = fields
+	}
+	for _, typeName := range typeNames {
+		object, ok := gq.Type(typeName).(*Object)
+		if err := invariantf(ok, `Cannot bind resolvers to "%v", it is not an object type of the schema.`, typeName); err != nil {
+			return err
+		}
+		fields := map[string]FieldResolveFn{}
+		for fieldName, resolve := range resolvers[typeName] {
+			fields[fieldName] = resolve
+		}
+		for fieldName, resolve := range config.Resolvers[typeName] {
+			_, ok := object.Fields()[fieldName]
+			if err := invariantf(ok, `Cannot bind a resolver to "%v.%v", the field does not exist.`, typeName, fieldName); err != nil {
+				return err
+			}
+			fields[fieldName] = resolve
+		}
+		resolvers[typeName] = fields
+	}
+
+	if !config.AllowMissing {
+		requireResolver := config.RequireResolver
+		if requireResolver == nil {
+			requireResolver = IsNonTrivialField
+		}
+		missing := []string{}
+		for _, ttype := range gq.TypeMap() {
+			object, ok := ttype.(*Object)
+			if !ok || strings.HasPrefix(object.Name(), "__") {
+				continue
+			}
+			for fieldName, field := range object.Fields() {
+				if gq.fieldResolver(object, field) == nil && resolvers[object.Name()][fieldName] == nil && requireResolver(gq, object, field) {
+					missing = append(missing, fmt.Sprintf("%v.%v", object.Name(), fieldName))
+				}
+			}
+		}
+		sort.Strings(missing)
+		if err := invariantf(len(missing) == 0, `Missing resolvers for %v.`, strings.Join(missing, ", ")); err != nil {
+			return err
+		}
+	}
+
+	gq.resolvers = resolvers
+	return nil
+}
+
+// fieldResolver returns the resolver of the field, the one bound to the schema,
+// the Resolve function of the field or the one it inherits from an interface,
+// nil when the field has none.
+func (gq *Schema) fieldResolver(parentType *Object, fieldDef *FieldDefinition) FieldResolveFn {
+	if resolve := gq.resolvers[parentType.Name()][fieldDef.Name]; resolve != nil {
+		return resolve
+	}
+	if fieldDef.Resolve != nil {
+		return fieldDef.Resolve
+	}
+	if inherited := gq.inherited[parentType.Name()][fieldDef.Name]; inherited != nil {
+		return inherited.resolve
+	}
+	return nil
+}
+
+// fieldArgs returns the arguments of the field, with the default values it
+// inherits from an interface.
+func (gq *Schema) fieldArgs(parentType *Object, fieldDef *FieldDefinition) []*Argument {
+	if inherited := gq.inherited[parentType.Name()][fieldDef.Name]; inherited != nil && inherited.args != nil {
+		return inherited.args
+	}
+	return fieldDef.Args
+}
@@ -0,0 +1,106 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// newBindingTestSchema builds the pure type definitions, without resolvers.
+func newBindingTestSchema(t *testing.T) graphql.Schema {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"greeting": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"prefix": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"me": &graphql.Field{Type: user},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestBindResolvers_BindsResolversPerSchema(t *testing.T) {
+	bind := func(name string) graphql.Schema {
+		schema := newBindingTestSchema(t)
+		err := schema.BindResolvers(graphql.ResolverMap{
+			"Query": {
+				"me": func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"name": name}, nil
+				},
+			},
+			"User": {
+				"greeting": func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Args["prefix"].(string) + " " + name, nil
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	service, stub := bind("Luke"), bind("Stub")
+	for schema, name := range map[*graphql.Schema]string{&service: "Luke", &stub: "Stub"} {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ me { name greeting(prefix: "Hello") } }`,
+		})
+		expected := &graphql.Result{
+			Data: map[string]interface{}{
+				"me": map[string]interface{}{"name": name, "greeting": "Hello " + name},
+			},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestBindResolvers_ChecksResolvers(t *testing.T) {
+	schema := newBindingTestSchema(t)
+	err := schema.BindResolvers(graphql.ResolverMap{})
+	if err == nil || err.Error() != "Missing resolvers for Query.me, User.greeting." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = schema.BindResolvers(graphql.ResolverMap{"User": {"age": nil}})
+	if err == nil || err.Error() != `Cannot bind a resolver to "User.age", the field does not exist.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = schema.BindResolvers(graphql.ResolverMap{"String": {}})
+	if err == nil || err.Error() != `Cannot bind resolvers to "String", it is not an object type of the schema.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = schema.BindResolversWithConfig(graphql.BindResolversConfig{
+		Resolvers: graphql.ResolverMap{},
+		RequireResolver: func(schema *graphql.Schema, parentType *graphql.Object, field *graphql.FieldDefinition) bool {
+			return parentType.Name() == "User"
+		},
+	})
+	if err == nil || err.Error() != "Missing resolvers for User.greeting, User.name." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := schema.BindResolversWithConfig(graphql.BindResolversConfig{AllowMissing: true}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CodeResponseTooLarge is the code of the error aborting an execution whose
+// response exceeds ResponseLimits.MaxBytes.
+const CodeResponseTooLarge = "RESPONSE_TOO_LARGE"
+
+// ResponseLimits bound the size of the response of an execution, preventing a
+// single query from producing huge responses. Zero values mean no limit.
+type ResponseLimits struct {
+	// MaxBytes is the maximum size of the data of the response, estimated
+	// from its JSON encoding while it is completed. The execution is aborted
+	// once it is exceeded, with a RESPONSE_TOO_LARGE error at the path of the
+	// value exceeding it and no data.
+	MaxBytes int
+
+	// MaxStringLength is the maximum length of the strings of the response, in
+	// characters. Longer strings are a field error at their path.
+	MaxStringLength int
+}
+
+// responseBudgetError aborts an execution whose response exceeds
+// ResponseLimits.MaxBytes, it is not recovered as a field error.
+type responseBudgetError struct {
+	*gqlerrors.Error
+}
+
+// delimiters stands for the delimiters of the objects and lists of the
+// response in estimateSize.
+type delimiters struct{}
+
+// responseBudget tracks the estimated size of the response of an execution.
+type responseBudget struct {
+	limits ResponseLimits
+	size   int
+}
+
+// complete accounts for the completed value at the given path, panicking with
+// a responseBudgetError once the response exceeds its maximum size, and
+// returns an error if the value is a string exceeding its maximum length.
+func (b *responseBudget) complete(value interface{}, fieldASTs []*ast.Field, path *ResponsePath) error {
+	if b == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok && b.limits.MaxStringLength > 0 && utf8.RuneCountInString(s) > b.limits.MaxStringLength {
+		return fmt.Errorf("String value exceeds the maximum length of %d characters.", b.limits.MaxStringLength)
+	}
+	if b.limits.MaxBytes <= 0 {
+		return nil
+	}
+	// the key and separators of the value in its parent object or list
+	b.size++
+	if key, ok := path.Key.(string); ok {
+		b.size += len(key) + 3
+	}
+	b.size += estimateSize(value)
+	if b.size > b.limits.MaxBytes {
+		err := NewLocatedErrorWithPath(
+			fmt.Sprintf("Response exceeds the maximum size of %d bytes.", b.limits.MaxBytes),
+			FieldASTsToNodeASTs(fieldASTs),
+			path.AsArray(),
+		)
+		err.Extensions = map[string]interface{}{
+			"code":     CodeResponseTooLarge,
+			"maxBytes": b.limits.MaxBytes,
+		}
+		panic(responseBudgetError{err})
+	}
+	return nil
+}
+
+// estimateSize returns the estimated size of the JSON encoding of the given
+// completed leaf value, or of the delimiters of objects and lists.
+func estimateSize(value interface{}) int {
+	switch value := value.(type) {
+	case nil:
+		return len("null")
+	case delimiters:
+		return len("{}")
+	case string:
+		return len(value) + 2
+	case bool:
+		if value {
+			return len("true")
+		}
+		return len("false")
+	case int:
+		return len(strconv.Itoa(value))
+	case float64:
+		return len(strconv.FormatFloat(value, 'g', -1, 64))
+	default:
+		return len(fmt.Sprintf("%v", value))
+	}
+}
@@ -0,0 +1,102 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+func responseLimitsSchema(t *testing.T) graphql.Schema {
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(item),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						items := []map[string]interface{}{}
+						for i := 0; i < 100; i++ {
+							items = append(items, map[string]interface{}{"name": "item"})
+						}
+						return items, nil
+					},
+				},
+				"text": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return strings.Repeat("a", 20), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestResponseLimits_AbortsWhenExceedingMaxBytes(t *testing.T) {
+	schema := responseLimitsSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ items { name } }`,
+		ResponseLimits: graphql.ResponseLimits{MaxBytes: 200},
+	})
+	if result.Data != nil {
+		t.Fatalf("expected no data, got: %v", result.Data)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected an error, got: %v", result.Errors)
+	}
+	err := result.Errors[0]
+	if err.Message != "Response exceeds the maximum size of 200 bytes." {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	if code := gqlerrors.Code(err); code != graphql.CodeResponseTooLarge {
+		t.Fatalf("expected code %q, got: %q", graphql.CodeResponseTooLarge, code)
+	}
+	if expected := []interface{}{"items", 11, "name"}; !reflect.DeepEqual(err.Path, expected) {
+		t.Fatalf("expected path %v, got: %v", expected, err.Path)
+	}
+}
+
+func TestResponseLimits_WithinMaxBytes(t *testing.T) {
+	schema := responseLimitsSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ items { name } }`,
+		ResponseLimits: graphql.ResponseLimits{MaxBytes: 2000},
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestResponseLimits_MaxStringLength(t *testing.T) {
+	schema := responseLimitsSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ text items { name } }`,
+		ResponseLimits: graphql.ResponseLimits{MaxStringLength: 10},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected an error, got: %v", result.Errors)
+	}
+	if expected := []interface{}{"text"}; !reflect.DeepEqual(result.Errors[0].Path, expected) {
+		t.Fatalf("expected path %v, got: %v", expected, result.Errors[0].Path)
+	}
+	data := result.Data.(map[string]interface{})
+	if data["text"] != nil || len(data["items"].([]interface{})) != 100 {
+		t.Fatalf("expected only the string to be null, got: %v", data)
+	}
+}
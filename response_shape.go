@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// responseShape records the fields of a response by path, their list indices
+// left out, see ExecuteParams.ReportResponseShape.
+type responseShape map[string]shapeField
+
+type shapeField struct {
+	name  string
+	ttype Type
+}
+
+// record records the field resolved at the given path.
+func (s responseShape) record(path *ResponsePath, fieldName string, ttype Type) {
+	if s != nil {
+		s[shapePath(path)] = shapeField{name: fieldName, ttype: ttype}
+	}
+}
+
+// shapePath returns the response keys of the path, joined by dots.
+func shapePath(path *ResponsePath) string {
+	keys := []string{}
+	for ; path != nil; path = path.Prev {
+		if key, ok := path.Key.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	return strings.Join(keys, ".")
+}
+
+// signature returns the signature of the shape of the data, e.g.
+//
+//	{hero:Character{friends:[Character]#3{name:String}|null,name:String!}}
+//
+// The fields are sorted by response key, an aliased field is written with its
+// name, as in "droid=hero:Character", a field resolved to null is followed by
+// "=null", and a list by its length and the distinct signatures of its items.
+func (s responseShape) signature(data interface{}) string {
+	b := &strings.Builder{}
+	s.writeValue(b, "", data)
+	return b.String()
+}
+
+func (s responseShape) writeValue(b *strings.Builder, path string, value interface{}) {
+	switch value := value.(type) {
+	case nil:
+		b.WriteString("=null")
+	case map[string]interface{}:
+		s.writeObject(b, path, value)
+	case []interface{}:
+		b.WriteString("#")
+		b.WriteString(strconv.Itoa(len(value)))
+		items := []string{}
+		seen := map[string]bool{}
+		for _, item := range value {
+			signature := "null"
+			if item != nil {
+				signature = s.itemSignature(path, item)
+			}
+			if signature != "" && !seen[signature] {
+				seen[signature] = true
+				items = append(items, signature)
+			}
+		}
+		b.WriteString(strings.Join(items, "|"))
+	}
+}
+
+// itemSignature returns the signature of a list item, empty for a scalar.
+func (s responseShape) itemSignature(path string, item interface{}) string {
+	b := &strings.Builder{}
+	s.writeValue(b, path, item)
+	return b.String()
+}
+
+func (s responseShape) writeObject(b *strings.Builder, path string, object map[string]interface{}) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	b.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		b.WriteString(key)
+		if field, ok := s[fieldPath]; ok {
+			if field.name != key {
+				b.WriteString("=")
+				b.WriteString(field.name)
+			}
+			b.WriteString(":")
+			b.WriteString(field.ttype.String())
+		}
+		s.writeValue(b, fieldPath, object[key])
+	}
+	b.WriteString("}")
+}
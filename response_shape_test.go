@@ -0,0 +1,40 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestReportResponseShape(t *testing.T) {
+	query := `{
+		hero(episode: EMPIRE) {
+			name
+			friends { name ... on Droid { primaryFunction } }
+		}
+		droid: hero { __typename appearsIn }
+	}`
+	result := graphql.Do(graphql.Params{
+		Schema:              testutil.StarWarsSchema,
+		RequestString:       query,
+		ReportResponseShape: true,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := `{` +
+		`droid=hero:Character{__typename:String!,appearsIn:[Episode]#3},` +
+		`hero:Character{friends:[Character]#4{name:String}|{name:String,primaryFunction:String},name:String}}`
+	if shape := result.Extensions["responseShape"]; shape != expected {
+		t.Fatalf("Unexpected shape, Diff: %v", testutil.Diff(expected, shape))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+	})
+	if _, ok := result.Extensions["responseShape"]; ok {
+		t.Fatalf("expected the shape to be reported only when requested")
+	}
+}
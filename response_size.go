@@ -0,0 +1,65 @@
+package graphql
+
+// responseSize is the estimated size of the JSON encoding of the data of a
+// response, before compression, and the number of its list items by top level
+// field, see ExecuteParams.ReportResponseSize.
+type responseSize struct {
+	bytes     int
+	listItems map[string]int
+}
+
+// measureResponse measures the completed data of a response.
+func measureResponse(data interface{}) responseSize {
+	size := responseSize{listItems: map[string]int{}}
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		size.bytes = size.measure(data, "")
+		return size
+	}
+	size.bytes = len("{}") + separators(len(object))
+	for key, value := range object {
+		size.bytes += len(key) + 3 + size.measure(value, key)
+	}
+	return size
+}
+
+// measure returns the estimated size of the value, counting the items of its
+// lists for the given top level field.
+func (s *responseSize) measure(value interface{}, field string) int {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		n := len("{}") + separators(len(value))
+		for key, v := range value {
+			n += len(key) + 3 + s.measure(v, field)
+		}
+		return n
+	case []interface{}:
+		if field != "" {
+			s.listItems[field] += len(value)
+		}
+		n := len("[]") + separators(len(value))
+		for _, item := range value {
+			n += s.measure(item, field)
+		}
+		return n
+	default:
+		return estimateSize(value)
+	}
+}
+
+// separators returns the number of commas separating the given number of
+// entries of an object or items of a list.
+func separators(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return n - 1
+}
+
+// extension returns the "responseSize" extension of the result.
+func (s responseSize) extension() map[string]interface{} {
+	return map[string]interface{}{
+		"bytes":     s.bytes,
+		"listItems": s.listItems,
+	}
+}
@@ -0,0 +1,52 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestReportResponseSize(t *testing.T) {
+	query := `{
+		hero(episode: EMPIRE) {
+			name
+			friends { name appearsIn }
+		}
+		droid: hero { name }
+	}`
+	result := graphql.Do(graphql.Params{
+		Schema:             testutil.StarWarsSchema,
+		RequestString:      query,
+		ReportResponseSize: true,
+		CollectStats:       true,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	encoded, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the 4 friends of Luke and their appearances in the 3 episodes
+	expected := map[string]interface{}{
+		"bytes":     len(encoded),
+		"listItems": map[string]int{"hero": 4 + 4*3},
+	}
+	if size := result.Extensions["responseSize"]; !reflect.DeepEqual(expected, size) {
+		t.Fatalf("Unexpected size, Diff: %v", testutil.Diff(expected, size))
+	}
+	if result.Stats.ResponseBytes != len(encoded) || !reflect.DeepEqual(result.Stats.ListItems, expected["listItems"]) {
+		t.Fatalf("expected the size to be recorded in the stats, got %+v", result.Stats)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+	})
+	if _, ok := result.Extensions["responseSize"]; ok {
+		t.Fatalf("expected the size to be reported only when requested")
+	}
+}
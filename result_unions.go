@@ -0,0 +1,95 @@
+package graphql
+
+import (
+	"errors"
+)
+
+// ErrorTypes maps the errors of the resolvers of fields of union types to
+// object types of the unions, for the "result union" pattern where the
+// expected errors are part of the schema, e.g. a union of a SuccessPayload, a
+// ValidationError and a NotFoundError, see SchemaConfig.ErrorTypes:
+//
+//	errorTypes := &graphql.ErrorTypes{}
+//	graphql.MapErrorType[*NotFoundError](errorTypes, notFoundErrorType)
+//
+// When the resolver of a field of a union type fails with, or returns, an
+// error mapped to an object type of the union, the field resolves to the
+// error, completed as a value of the object type, instead of failing. The
+// errors returned as items of lists of unions are mapped alike. The other
+// errors are field errors, as usual.
+type ErrorTypes struct {
+	mappings []errorTypeMapping
+}
+
+// errorTypeMapping maps the errors of a Go type to an object type.
+type errorTypeMapping struct {
+	typeName string
+
+	// as returns the error of the Go type in the chain of the error, see
+	// errors.As.
+	as func(err error) (interface{}, bool)
+}
+
+// MapErrorType maps the errors of type E, found in the chain of the errors
+// as by errors.As, to the given object type, whose fields are resolved with
+// the error of type E as source. The mappings are tried in the order they
+// are added, for the object types of the union of the field.
+func MapErrorType[E error](m *ErrorTypes, ttype *Object) {
+	m.mappings = append(m.mappings, errorTypeMapping{
+		typeName: ttype.Name(),
+		as: func(err error) (interface{}, bool) {
+			var target E
+			if errors.As(err, &target) {
+				return target, true
+			}
+			return nil, false
+		},
+	})
+}
+
+// typed returns the error as the Typed value of the first object type of the
+// given type, when it is a union, mapped to the type of the error.
+func (m *ErrorTypes) typed(ttype Type, err error) (Typed, bool) {
+	union, ok := GetNullable(ttype).(*Union)
+	if m == nil || !ok || err == nil {
+		return Typed{}, false
+	}
+	for _, mapping := range m.mappings {
+		member := false
+		for _, object := range union.Types() {
+			member = member || object.Name() == mapping.typeName
+		}
+		if !member {
+			continue
+		}
+		if value, ok := mapping.as(err); ok {
+			return Typed{TypeName: mapping.typeName, Value: value}, true
+		}
+	}
+	return Typed{}, false
+}
+
+// ResultUnionConfig is the config of a result union, see NewResultUnion.
+type ResultUnionConfig struct {
+	Name        string
+	Description string
+
+	// Success is the type of the values of the union which are not errors,
+	// and Errors the types of the errors, see ErrorTypes.
+	Success *Object
+	Errors  []*Object
+}
+
+// NewResultUnion returns the union of the success type and the error types
+// of the config, resolving the type of the values which are not mapped
+// errors, see ErrorTypes, to the success type.
+func NewResultUnion(config ResultUnionConfig) *Union {
+	return NewUnion(UnionConfig{
+		Name:        config.Name,
+		Description: config.Description,
+		Types:       append([]*Object{config.Success}, config.Errors...),
+		ResolveType: func(p ResolveTypeParams) *Object {
+			return config.Success
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package graphql_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type notFoundError struct {
+	ID string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%v not found", e.ID)
+}
+
+type validationError struct {
+	Field string
+}
+
+func (e validationError) Error() string {
+	return fmt.Sprintf("invalid %v", e.Field)
+}
+
+func resultUnionsTestSchema(t *testing.T) graphql.Schema {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	notFound := graphql.NewObject(graphql.ObjectConfig{
+		Name: "NotFoundError",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.ID,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*notFoundError).ID, nil
+				},
+			},
+		},
+	})
+	invalid := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ValidationError",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(validationError).Field, nil
+				},
+			},
+		},
+	})
+	result := graphql.NewResultUnion(graphql.ResultUnionConfig{
+		Name:    "UserResult",
+		Success: user,
+		Errors:  []*graphql.Object{notFound, invalid},
+	})
+	errorTypes := &graphql.ErrorTypes{}
+	graphql.MapErrorType[*notFoundError](errorTypes, notFound)
+	graphql.MapErrorType[validationError](errorTypes, invalid)
+
+	users := map[string]interface{}{"1": map[string]interface{}{"name": "Ada"}}
+	resolveUser := func(id string) (interface{}, error) {
+		switch {
+		case id == "":
+			return nil, fmt.Errorf("cannot find the user: %w", validationError{Field: "id"})
+		case id == "fail":
+			return nil, errors.New("the database is down")
+		case users[id] == nil:
+			return nil, &notFoundError{ID: id}
+		}
+		return users[id], nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.NewNonNull(result),
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveUser(p.Args["id"].(string))
+					},
+				},
+				"users": &graphql.Field{
+					Type: graphql.NewList(result),
+					Args: graphql.FieldConfigArgument{
+						"ids": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.ID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						items := []interface{}{}
+						for _, id := range p.Args["ids"].([]interface{}) {
+							user, err := resolveUser(id.(string))
+							if err != nil {
+								items = append(items, err)
+								continue
+							}
+							items = append(items, user)
+						}
+						return items, nil
+					},
+				},
+			},
+		}),
+		ErrorTypes: errorTypes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestErrorTypes(t *testing.T) {
+	schema := resultUnionsTestSchema(t)
+	query := `{
+		ada: user(id: "1") { __typename ...Result }
+		bob: user(id: "2") { __typename ...Result }
+		empty: user(id: "") { __typename ...Result }
+		users(ids: ["1", "2", "fail"]) { __typename ...Result }
+	}
+	fragment Result on UserResult {
+		... on User { name }
+		... on NotFoundError { id }
+		... on ValidationError { field }
+	}`
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"ada":   map[string]interface{}{"__typename": "User", "name": "Ada"},
+			"bob":   map[string]interface{}{"__typename": "NotFoundError", "id": "2"},
+			"empty": map[string]interface{}{"__typename": "ValidationError", "field": "id"},
+			"users": []interface{}{
+				map[string]interface{}{"__typename": "User", "name": "Ada"},
+				map[string]interface{}{"__typename": "NotFoundError", "id": "2"},
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{{
+			Message:   "the database is down",
+			Locations: []location.SourceLocation{{Line: 5, Column: 3}},
+			Path:      []interface{}{"users", 2},
+		}},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestErrorTypes_UnmappedErrors(t *testing.T) {
+	schema := resultUnionsTestSchema(t)
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user(id: "fail") { __typename } }`})
+	expected := &graphql.Result{
+		Data: nil,
+		Errors: []gqlerrors.FormattedError{{
+			Message:   "the database is down",
+			Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+			Path:      []interface{}{"user"},
+		}},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -16,8 +16,6 @@ import (
 
 // SpecifiedRules set includes all validation rules defined by the GraphQL spec.
 var SpecifiedRules = []ValidationRuleFn{
-	ArgumentsOfCorrectTypeRule,
-	DefaultValuesOfCorrectTypeRule,
 	FieldsOnCorrectTypeRule,
 	FragmentsOnCompositeTypesRule,
 	KnownArgumentNamesRule,
@@ -33,11 +31,13 @@ var SpecifiedRules = []ValidationRuleFn{
 	PossibleFragmentSpreadsRule,
 	ProvidedNonNullArgumentsRule,
 	ScalarLeafsRule,
+	SingleFieldSubscriptionsRule,
 	UniqueArgumentNamesRule,
 	UniqueFragmentNamesRule,
 	UniqueInputFieldNamesRule,
 	UniqueOperationNamesRule,
 	UniqueVariableNamesRule,
+	ValuesOfCorrectTypeRule,
 	VariablesAreInputTypesRule,
 	VariablesInAllowedPositionRule,
 }
@@ -48,7 +48,7 @@ type ValidationRuleInstance struct {
 type ValidationRuleFn func(context *ValidationContext) *ValidationRuleInstance
 
 func newValidationError(message string, nodes []ast.Node) *gqlerrors.Error {
-	return gqlerrors.NewError(
+	err := gqlerrors.NewError(
 		message,
 		nodes,
 		"",
@@ -56,6 +56,8 @@ func newValidationError(message string, nodes []ast.Node) *gqlerrors.Error {
 		[]int{},
 		nil, // TODO: this is interim, until we port "better-error-messages-for-inputs"
 	)
+	err.Extensions = map[string]interface{}{"code": gqlerrors.CodeValidationFailed}
+	return err
 }
 
 func reportError(context *ValidationContext, message string, nodes []ast.Node) (string, interface{}) {
@@ -85,7 +87,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 								}
 								reportError(
 									context,
-									fmt.Sprintf(`Argument "%v" has invalid value %v.%v`,
+									gqlerrors.Message(gqlerrors.MsgBadArgValue,
 										argNameValue, printer.Print(argAST.Value), messagesStr),
 									[]ast.Node{argAST.Value},
 								)
@@ -127,7 +129,7 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 						if ttype, ok := ttype.(*NonNull); ok && defaultValue != nil {
 							reportError(
 								context,
-								fmt.Sprintf(`Variable "$%v" of type "%v" is required and will not use the default value. Perhaps you meant to use type "%v".`,
+								gqlerrors.Message(gqlerrors.MsgDefaultForNonNullVariable,
 									name, ttype, ttype.OfType),
 								[]ast.Node{defaultValue},
 							)
@@ -138,7 +140,7 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 							}
 							reportError(
 								context,
-								fmt.Sprintf(`Variable "$%v" has invalid default value: %v.%v`,
+								gqlerrors.Message(gqlerrors.MsgBadVariableDefault,
 									name, printer.Print(defaultValue), messagesStr),
 								[]ast.Node{defaultValue},
 							)
@@ -183,19 +185,19 @@ func quotedOrList(slice []string) string {
 		maxLength = len(quoted)
 	}
 	if maxLength > 2 {
-		return fmt.Sprintf("%v, or %v", strings.Join(quoted[0:maxLength-1], ", "), quoted[maxLength-1])
+		return gqlerrors.Message(gqlerrors.MsgOrListSerial, strings.Join(quoted[0:maxLength-1], ", "), quoted[maxLength-1])
 	}
 	if maxLength > 1 {
-		return fmt.Sprintf("%v or %v", strings.Join(quoted[0:maxLength-1], ", "), quoted[maxLength-1])
+		return gqlerrors.Message(gqlerrors.MsgOrList, strings.Join(quoted[0:maxLength-1], ", "), quoted[maxLength-1])
 	}
 	return quoted[0]
 }
 func UndefinedFieldMessage(fieldName string, ttypeName string, suggestedTypeNames []string, suggestedFieldNames []string) string {
-	message := fmt.Sprintf(`Cannot query field "%v" on type "%v".`, fieldName, ttypeName)
+	message := gqlerrors.Message(gqlerrors.MsgUndefinedField, fieldName, ttypeName)
 	if len(suggestedTypeNames) > 0 {
-		message = fmt.Sprintf(`%v Did you mean to use an inline fragment on %v?`, message, quotedOrList(suggestedTypeNames))
+		message = gqlerrors.Message(gqlerrors.MsgDidYouMeanInlineFragment, message, quotedOrList(suggestedTypeNames))
 	} else if len(suggestedFieldNames) > 0 {
-		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestedFieldNames))
+		message = gqlerrors.Message(gqlerrors.MsgDidYouMean, message, quotedOrList(suggestedFieldNames))
 	}
 	return message
 }
@@ -377,7 +379,7 @@ func FragmentsOnCompositeTypesRule(context *ValidationContext) *ValidationRuleIn
 						if node.TypeCondition != nil && ttype != nil && !IsCompositeType(ttype) {
 							reportError(
 								context,
-								fmt.Sprintf(`Fragment cannot condition on non composite type "%v".`, ttype),
+								gqlerrors.Message(gqlerrors.MsgInlineFragmentOnNonComposite, ttype),
 								[]ast.Node{node.TypeCondition},
 							)
 						}
@@ -396,7 +398,7 @@ func FragmentsOnCompositeTypesRule(context *ValidationContext) *ValidationRuleIn
 							}
 							reportError(
 								context,
-								fmt.Sprintf(`Fragment "%v" cannot condition on non composite type "%v".`, nodeName, printer.Print(node.TypeCondition)),
+								gqlerrors.Message(gqlerrors.MsgFragmentOnNonComposite, nodeName, printer.Print(node.TypeCondition)),
 								[]ast.Node{node.TypeCondition},
 							)
 						}
@@ -412,20 +414,20 @@ func FragmentsOnCompositeTypesRule(context *ValidationContext) *ValidationRuleIn
 }
 
 func unknownArgMessage(argName string, fieldName string, parentTypeName string, suggestedArgs []string) string {
-	message := fmt.Sprintf(`Unknown argument "%v" on field "%v" of type "%v".`, argName, fieldName, parentTypeName)
+	message := gqlerrors.Message(gqlerrors.MsgUnknownArg, argName, fieldName, parentTypeName)
 
 	if len(suggestedArgs) > 0 {
-		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestedArgs))
+		message = gqlerrors.Message(gqlerrors.MsgDidYouMean, message, quotedOrList(suggestedArgs))
 	}
 
 	return message
 }
 
 func unknownDirectiveArgMessage(argName string, directiveName string, suggestedArgs []string) string {
-	message := fmt.Sprintf(`Unknown argument "%v" on directive "@%v".`, argName, directiveName)
+	message := gqlerrors.Message(gqlerrors.MsgUnknownDirectiveArg, argName, directiveName)
 
 	if len(suggestedArgs) > 0 {
-		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestedArgs))
+		message = gqlerrors.Message(gqlerrors.MsgDidYouMean, message, quotedOrList(suggestedArgs))
 	}
 
 	return message
@@ -507,6 +509,25 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 									[]ast.Node{node},
 								)
 							}
+						case kinds.FragmentSpread:
+							spread, _ := argumentOf.(*ast.FragmentSpread)
+							if spread == nil || spread.Name == nil || context.Argument() != nil {
+								return action, nil
+							}
+							fragment := context.Fragment(spread.Name.Value)
+							if fragment == nil {
+								return action, nil
+							}
+							for _, varDef := range fragment.VariableDefinitions {
+								if varDef.Variable != nil && varDef.Variable.Name != nil {
+									argNames = append(argNames, varDef.Variable.Name.Value)
+								}
+							}
+							message := gqlerrors.Message(gqlerrors.MsgUnknownFragmentArg, node.Name.Value, spread.Name.Value)
+							if suggestions := suggestionList(node.Name.Value, argNames); len(suggestions) > 0 {
+								message = gqlerrors.Message(gqlerrors.MsgDidYouMean, message, quotedOrList(suggestions))
+							}
+							reportError(context, message, []ast.Node{node})
 						}
 					}
 					return action, nil
@@ -520,7 +541,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 }
 
 func MisplaceDirectiveMessage(directiveName string, location string) string {
-	return fmt.Sprintf(`Directive "%v" may not be used on %v.`, directiveName, location)
+	return gqlerrors.Message(gqlerrors.MsgMisplacedDirective, directiveName, location)
 }
 
 // KnownDirectivesRule Known directives
@@ -530,6 +551,23 @@ func MisplaceDirectiveMessage(directiveName string, location string) string {
 func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.DirectiveDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.DirectiveDefinition); ok {
+						for _, location := range node.Locations {
+							if location == nil || LookupDirectiveLocation(location.Value) != nil {
+								continue
+							}
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgUnknownDirectiveLocation, location.Value),
+								[]ast.Node{location},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
 			kinds.Directive: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					var action = visitor.ActionNoChange
@@ -550,25 +588,38 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 						if directiveDef == nil {
 							return reportError(
 								context,
-								fmt.Sprintf(`Unknown directive "%v".`, nodeName),
+								gqlerrors.Message(gqlerrors.MsgUnknownDirective, nodeName),
 								[]ast.Node{node},
 							)
 						}
 
-						candidateLocation := getDirectiveLocationForASTPath(p.Ancestors)
+						candidateLocations := getDirectiveLocationsForASTPath(p.Ancestors)
+						candidateLocation := ""
+						if len(candidateLocations) > 0 {
+							candidateLocation = candidateLocations[0]
+						}
 
 						directiveHasLocation := false
 						for _, loc := range directiveDef.Locations {
-							if loc == candidateLocation {
-								directiveHasLocation = true
-								break
+							for _, candidate := range candidateLocations {
+								if loc == candidate {
+									directiveHasLocation = true
+									break
+								}
 							}
 						}
 
 						if candidateLocation == "" {
+							// the node the directive is applied to has no
+							// location, such as a registered location which
+							// does not apply to it: report the kind of the node
+							appliedTo := node.GetKind()
+							if len(p.Ancestors) > 0 && p.Ancestors[len(p.Ancestors)-1] != nil {
+								appliedTo = p.Ancestors[len(p.Ancestors)-1].GetKind()
+							}
 							reportError(
 								context,
-								MisplaceDirectiveMessage(nodeName, node.GetKind()),
+								MisplaceDirectiveMessage(nodeName, appliedTo),
 								[]ast.Node{node},
 							)
 						} else if !directiveHasLocation {
@@ -590,80 +641,6 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
-func getDirectiveLocationForASTPath(ancestors []ast.Node) string {
-	var appliedTo ast.Node
-	if len(ancestors) > 0 {
-		appliedTo = ancestors[len(ancestors)-1]
-	}
-	if appliedTo == nil {
-		return ""
-	}
-	kind := appliedTo.GetKind()
-	if kind == kinds.OperationDefinition {
-		appliedTo, _ := appliedTo.(*ast.OperationDefinition)
-		if appliedTo.Operation == ast.OperationTypeQuery {
-			return DirectiveLocationQuery
-		}
-		if appliedTo.Operation == ast.OperationTypeMutation {
-			return DirectiveLocationMutation
-		}
-		if appliedTo.Operation == ast.OperationTypeSubscription {
-			return DirectiveLocationSubscription
-		}
-	}
-	if kind == kinds.Field {
-		return DirectiveLocationField
-	}
-	if kind == kinds.FragmentSpread {
-		return DirectiveLocationFragmentSpread
-	}
-	if kind == kinds.InlineFragment {
-		return DirectiveLocationInlineFragment
-	}
-	if kind == kinds.FragmentDefinition {
-		return DirectiveLocationFragmentDefinition
-	}
-	if kind == kinds.SchemaDefinition {
-		return DirectiveLocationSchema
-	}
-	if kind == kinds.ScalarDefinition {
-		return DirectiveLocationScalar
-	}
-	if kind == kinds.ObjectDefinition {
-		return DirectiveLocationObject
-	}
-	if kind == kinds.FieldDefinition {
-		return DirectiveLocationFieldDefinition
-	}
-	if kind == kinds.InterfaceDefinition {
-		return DirectiveLocationInterface
-	}
-	if kind == kinds.UnionDefinition {
-		return DirectiveLocationUnion
-	}
-	if kind == kinds.EnumDefinition {
-		return DirectiveLocationEnum
-	}
-	if kind == kinds.EnumValueDefinition {
-		return DirectiveLocationEnumValue
-	}
-	if kind == kinds.InputObjectDefinition {
-		return DirectiveLocationInputObject
-	}
-	if kind == kinds.InputValueDefinition {
-		var parentNode ast.Node
-		if len(ancestors) >= 3 {
-			parentNode = ancestors[len(ancestors)-3]
-		}
-		if parentNode.GetKind() == kinds.InputObjectDefinition {
-			return DirectiveLocationInputFieldDefinition
-		} else {
-			return DirectiveLocationArgumentDefinition
-		}
-	}
-	return ""
-}
-
 // KnownFragmentNamesRule Known fragment names
 //
 // A GraphQL document is only valid if all `...Fragment` fragment spreads refer
@@ -686,7 +663,7 @@ func KnownFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance
 						if fragment == nil {
 							reportError(
 								context,
-								fmt.Sprintf(`Unknown fragment "%v".`, fragmentName),
+								gqlerrors.Message(gqlerrors.MsgUnknownFragment, fragmentName),
 								[]ast.Node{node.Name},
 							)
 						}
@@ -702,9 +679,9 @@ func KnownFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance
 }
 
 func unknownTypeMessage(typeName string, suggestedTypes []string) string {
-	message := fmt.Sprintf(`Unknown type "%v".`, typeName)
+	message := gqlerrors.Message(gqlerrors.MsgUnknownType, typeName)
 	if len(suggestedTypes) > 0 {
-		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestedTypes))
+		message = gqlerrors.Message(gqlerrors.MsgDidYouMean, message, quotedOrList(suggestedTypes))
 	}
 
 	return message
@@ -795,7 +772,7 @@ func LoneAnonymousOperationRule(context *ValidationContext) *ValidationRuleInsta
 						if node.Name == nil && operationCount > 1 {
 							reportError(
 								context,
-								`This anonymous operation must be the only defined operation.`,
+								gqlerrors.Message(gqlerrors.MsgAnonymousOperationNotAlone),
 								[]ast.Node{node},
 							)
 						}
@@ -813,9 +790,9 @@ func LoneAnonymousOperationRule(context *ValidationContext) *ValidationRuleInsta
 func CycleErrorMessage(fragName string, spreadNames []string) string {
 	via := ""
 	if len(spreadNames) > 0 {
-		via = " via " + strings.Join(spreadNames, ", ")
+		via = gqlerrors.Message(gqlerrors.MsgFragmentCyclePath, strings.Join(spreadNames, ", "))
 	}
-	return fmt.Sprintf(`Cannot spread fragment "%v" within itself%v.`, fragName, via)
+	return gqlerrors.Message(gqlerrors.MsgFragmentCycle, fragName, via)
 }
 
 // NoFragmentCyclesRule No fragment cycles
@@ -831,69 +808,88 @@ func NoFragmentCyclesRule(context *ValidationContext) *ValidationRuleInstance {
 	// Position in the spread path
 	spreadPathIndexByName := map[string]int{}
 
-	// This does a straight-forward DFS to find cycles.
-	// It does not terminate when a cycle was found but continues to explore
-	// the graph to find all possible cycles.
-	var detectCycleRecursive func(fragment *ast.FragmentDefinition)
-	detectCycleRecursive = func(fragment *ast.FragmentDefinition) {
+	// A fragment being explored, and the next of its spreads to follow.
+	type frame struct {
+		name    string
+		spreads []*ast.FragmentSpread
+		next    int
+	}
 
-		fragmentName := ""
-		if fragment.Name != nil {
-			fragmentName = fragment.Name.Value
-		}
-		visitedFrags[fragmentName] = true
+	reportCycle := func(spreadNode *ast.FragmentSpread, spreadName string, cycleIndex int) {
+		cyclePath := spreadPath[cycleIndex:]
 
-		spreadNodes := context.FragmentSpreads(fragment.SelectionSet)
-		if len(spreadNodes) == 0 {
-			return
+		spreadNames := make([]string, 0, len(cyclePath))
+		nodes := make([]ast.Node, 0, len(cyclePath)+1)
+		for _, s := range cyclePath {
+			name := ""
+			if s.Name != nil {
+				name = s.Name.Value
+			}
+			spreadNames = append(spreadNames, name)
+			nodes = append(nodes, s)
 		}
+		nodes = append(nodes, spreadNode)
 
-		spreadPathIndexByName[fragmentName] = len(spreadPath)
+		reportError(
+			context,
+			CycleErrorMessage(spreadName, spreadNames),
+			nodes,
+		)
+	}
 
-		for _, spreadNode := range spreadNodes {
+	// This does a straight-forward DFS to find cycles, with an explicit stack
+	// so that long chains of fragments do not grow the goroutine stack.
+	// It does not terminate when a cycle was found but continues to explore
+	// the graph to find all possible cycles.
+	detectCycles := func(fragment *ast.FragmentDefinition) {
+		stack := []frame{}
+		enter := func(fragment *ast.FragmentDefinition) bool {
+			fragmentName := ""
+			if fragment.Name != nil {
+				fragmentName = fragment.Name.Value
+			}
+			visitedFrags[fragmentName] = true
+
+			spreadNodes := context.FragmentSpreads(fragment.SelectionSet)
+			if len(spreadNodes) == 0 {
+				return false
+			}
+			spreadPathIndexByName[fragmentName] = len(spreadPath)
+			stack = append(stack, frame{name: fragmentName, spreads: spreadNodes})
+			return true
+		}
+
+		enter(fragment)
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(top.spreads) {
+				delete(spreadPathIndexByName, top.name)
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 {
+					// leave the spread through which the fragment was entered
+					spreadPath = spreadPath[:len(spreadPath)-1]
+				}
+				continue
+			}
+			spreadNode := top.spreads[top.next]
+			top.next++
 
 			spreadName := ""
 			if spreadNode.Name != nil {
 				spreadName = spreadNode.Name.Value
 			}
-			cycleIndex, ok := spreadPathIndexByName[spreadName]
-			if !ok {
-				spreadPath = append(spreadPath, spreadNode)
-				if visited, ok := visitedFrags[spreadName]; !ok || !visited {
-					spreadFragment := context.Fragment(spreadName)
-					if spreadFragment != nil {
-						detectCycleRecursive(spreadFragment)
-					}
-				}
-				spreadPath = spreadPath[:len(spreadPath)-1]
-			} else {
-				cyclePath := spreadPath[cycleIndex:]
-
-				spreadNames := []string{}
-				for _, s := range cyclePath {
-					name := ""
-					if s.Name != nil {
-						name = s.Name.Value
-					}
-					spreadNames = append(spreadNames, name)
-				}
-
-				nodes := []ast.Node{}
-				for _, c := range cyclePath {
-					nodes = append(nodes, c)
+			if cycleIndex, ok := spreadPathIndexByName[spreadName]; ok {
+				reportCycle(spreadNode, spreadName, cycleIndex)
+				continue
+			}
+			spreadPath = append(spreadPath, spreadNode)
+			if !visitedFrags[spreadName] {
+				if spreadFragment := context.Fragment(spreadName); spreadFragment != nil && enter(spreadFragment) {
+					continue
 				}
-				nodes = append(nodes, spreadNode)
-
-				reportError(
-					context,
-					CycleErrorMessage(spreadName, spreadNames),
-					nodes,
-				)
 			}
-
+			spreadPath = spreadPath[:len(spreadPath)-1]
 		}
-		delete(spreadPathIndexByName, fragmentName)
-
 	}
 
 	visitorOpts := &visitor.VisitorOptions{
@@ -911,7 +907,7 @@ func NoFragmentCyclesRule(context *ValidationContext) *ValidationRuleInstance {
 							nodeName = node.Name.Value
 						}
 						if _, ok := visitedFrags[nodeName]; !ok {
-							detectCycleRecursive(node)
+							detectCycles(node)
 						}
 					}
 					return visitor.ActionSkip, nil
@@ -926,9 +922,9 @@ func NoFragmentCyclesRule(context *ValidationContext) *ValidationRuleInstance {
 
 func UndefinedVarMessage(varName string, opName string) string {
 	if opName != "" {
-		return fmt.Sprintf(`Variable "$%v" is not defined by operation "%v".`, varName, opName)
+		return gqlerrors.Message(gqlerrors.MsgUndefinedVariableInOperation, varName, opName)
 	}
-	return fmt.Sprintf(`Variable "$%v" is not defined.`, varName)
+	return gqlerrors.Message(gqlerrors.MsgUndefinedVariable, varName)
 }
 
 // NoUndefinedVariablesRule No undefined variables
@@ -1046,7 +1042,7 @@ func NoUnusedFragmentsRule(context *ValidationContext) *ValidationRuleInstance {
 						if !ok || isFragNameUsed != true {
 							reportError(
 								context,
-								fmt.Sprintf(`Fragment "%v" is never used.`, defName),
+								gqlerrors.Message(gqlerrors.MsgUnusedFragment, defName),
 								[]ast.Node{def},
 							)
 						}
@@ -1063,9 +1059,9 @@ func NoUnusedFragmentsRule(context *ValidationContext) *ValidationRuleInstance {
 
 func UnusedVariableMessage(varName string, opName string) string {
 	if opName != "" {
-		return fmt.Sprintf(`Variable "$%v" is never used in operation "%v".`, varName, opName)
+		return gqlerrors.Message(gqlerrors.MsgUnusedVariableInOperation, varName, opName)
 	}
-	return fmt.Sprintf(`Variable "$%v" is never used.`, varName)
+	return gqlerrors.Message(gqlerrors.MsgUnusedVariable, varName)
 }
 
 // NoUnusedVariablesRule No unused variables
@@ -1120,6 +1116,33 @@ func NoUnusedVariablesRule(context *ValidationContext) *ValidationRuleInstance {
 					return visitor.ActionNoChange, nil
 				},
 			},
+			kinds.FragmentDefinition: {
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					fragment, ok := p.Node.(*ast.FragmentDefinition)
+					if !ok || fragment == nil || fragment.Name == nil || len(fragment.VariableDefinitions) == 0 {
+						return visitor.ActionNoChange, nil
+					}
+					variableNameUsed := map[string]bool{}
+					for _, usage := range context.VariableUsages(fragment) {
+						if usage.Node.Name != nil {
+							variableNameUsed[usage.Node.Name.Value] = true
+						}
+					}
+					for _, variableDef := range fragment.VariableDefinitions {
+						if variableDef.Variable == nil || variableDef.Variable.Name == nil {
+							continue
+						}
+						if variableName := variableDef.Variable.Name.Value; !variableNameUsed[variableName] {
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgUnusedFragmentVariable, variableName, fragment.Name.Value),
+								[]ast.Node{variableDef},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
 			kinds.VariableDefinition: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if def, ok := p.Node.(*ast.VariableDefinition); ok && def != nil {
@@ -1144,7 +1167,10 @@ func getFragmentType(context *ValidationContext, name string) Type {
 	return ttype
 }
 
-func doTypesOverlap(schema *Schema, t1 Type, t2 Type) bool {
+// DoTypesOverlap Provided two composite types, determine if they "overlap", that
+// is if some object type of the given schema is a possible type of both. The
+// order of the types does not matter.
+func DoTypesOverlap(schema *Schema, t1 Type, t2 Type) bool {
 	if t1 == t2 {
 		return true
 	}
@@ -1201,11 +1227,10 @@ func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInst
 						fragType := context.Type()
 						parentType, _ := context.ParentType().(Type)
 
-						if fragType != nil && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
+						if fragType != nil && parentType != nil && !DoTypesOverlap(context.Schema(), fragType, parentType) {
 							reportError(
 								context,
-								fmt.Sprintf(`Fragment cannot be spread here as objects of `+
-									`type "%v" can never be of type "%v".`, parentType, fragType),
+								gqlerrors.Message(gqlerrors.MsgImpossibleInlineFragment, parentType, fragType),
 								[]ast.Node{node},
 							)
 						}
@@ -1222,11 +1247,10 @@ func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInst
 						}
 						fragType := getFragmentType(context, fragName)
 						parentType, _ := context.ParentType().(Type)
-						if fragType != nil && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
+						if fragType != nil && parentType != nil && !DoTypesOverlap(context.Schema(), fragType, parentType) {
 							reportError(
 								context,
-								fmt.Sprintf(`Fragment "%v" cannot be spread here as objects of `+
-									`type "%v" can never be of type "%v".`, fragName, parentType, fragType),
+								gqlerrors.Message(gqlerrors.MsgImpossibleFragment, fragName, parentType, fragType),
 								[]ast.Node{node},
 							)
 						}
@@ -1278,8 +1302,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 									}
 									reportError(
 										context,
-										fmt.Sprintf(`Field "%v" argument "%v" of type "%v" `+
-											`is required but not provided.`, fieldName, argDef.Name(), argDefType),
+										gqlerrors.Message(gqlerrors.MsgMissingFieldArg, fieldName, argDef.Name(), argDefType),
 										[]ast.Node{fieldAST},
 									)
 								}
@@ -1319,8 +1342,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 									}
 									reportError(
 										context,
-										fmt.Sprintf(`Directive "@%v" argument "%v" of type `+
-											`"%v" is required but not provided.`, directiveName, argDef.Name(), argDefType),
+										gqlerrors.Message(gqlerrors.MsgMissingDirectiveArg, directiveName, argDef.Name(), argDefType),
 										[]ast.Node{directiveAST},
 									)
 								}
@@ -1330,6 +1352,38 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 					return visitor.ActionNoChange, nil
 				},
 			},
+			kinds.FragmentSpread: {
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					spreadAST, ok := p.Node.(*ast.FragmentSpread)
+					if !ok || spreadAST == nil || spreadAST.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					fragment := context.Fragment(spreadAST.Name.Value)
+					if fragment == nil {
+						return visitor.ActionNoChange, nil
+					}
+					argASTMap := map[string]*ast.Argument{}
+					for _, arg := range spreadAST.Arguments {
+						if arg.Name != nil {
+							argASTMap[arg.Name.Value] = arg
+						}
+					}
+					for _, varDef := range fragment.VariableDefinitions {
+						if varDef.Variable == nil || varDef.Variable.Name == nil || varDef.DefaultValue != nil {
+							continue
+						}
+						varName := varDef.Variable.Name.Value
+						if _, ok := varDef.Type.(*ast.NonNull); ok && argASTMap[varName] == nil {
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgMissingFragmentArg, spreadAST.Name.Value, varName, printer.Print(varDef.Type)),
+								[]ast.Node{spreadAST},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
 		},
 	}
 	return &ValidationRuleInstance{
@@ -1358,14 +1412,14 @@ func ScalarLeafsRule(context *ValidationContext) *ValidationRuleInstance {
 								if node.SelectionSet != nil {
 									reportError(
 										context,
-										fmt.Sprintf(`Field "%v" of type "%v" must not have a sub selection.`, nodeName, ttype),
+										gqlerrors.Message(gqlerrors.MsgNoSubselectionAllowed, nodeName, ttype),
 										[]ast.Node{node.SelectionSet},
 									)
 								}
 							} else if node.SelectionSet == nil {
 								reportError(
 									context,
-									fmt.Sprintf(`Field "%v" of type "%v" must have a sub selection.`, nodeName, ttype),
+									gqlerrors.Message(gqlerrors.MsgRequiredSubselection, nodeName, ttype),
 									[]ast.Node{node},
 								)
 							}
@@ -1381,6 +1435,88 @@ func ScalarLeafsRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
+// SingleFieldSubscriptionsRule Subscriptions with single field
+//
+// A GraphQL subscription is valid only if it contains a single root field,
+// which is not an introspection field, counting the fields selected through
+// fragments.
+func SingleFieldSubscriptionsRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.OperationDefinition)
+					if !ok || node.Operation != ast.OperationTypeSubscription {
+						return visitor.ActionSkip, nil
+					}
+					subscription := gqlerrors.Message(gqlerrors.MsgAnonymousSubscription)
+					if node.Name != nil {
+						subscription = gqlerrors.Message(gqlerrors.MsgNamedSubscription, node.Name.Value)
+					}
+					responseNames := []string{}
+					fields := map[string][]*ast.Field{}
+					collectRootFields(context, node.SelectionSet, fields, &responseNames, map[string]bool{})
+					if len(responseNames) > 1 {
+						extraFields := []ast.Node{}
+						for _, responseName := range responseNames[1:] {
+							for _, field := range fields[responseName] {
+								extraFields = append(extraFields, field)
+							}
+						}
+						reportError(
+							context,
+							gqlerrors.Message(gqlerrors.MsgSubscriptionMultipleRootFields, subscription),
+							extraFields,
+						)
+					}
+					for _, responseName := range responseNames {
+						field := fields[responseName][0]
+						if field.Name != nil && strings.HasPrefix(field.Name.Value, "__") {
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgSubscriptionIntrospectionRootField, subscription),
+								[]ast.Node{field},
+							)
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// collectRootFields collects the fields of the selection set by response
+// name, in the order of the document, through the fragments.
+func collectRootFields(context *ValidationContext, selectionSet *ast.SelectionSet, fields map[string][]*ast.Field, responseNames *[]string, visited map[string]bool) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			responseName := getFieldEntryKey(selection)
+			if _, ok := fields[responseName]; !ok {
+				*responseNames = append(*responseNames, responseName)
+			}
+			fields[responseName] = append(fields[responseName], selection)
+		case *ast.InlineFragment:
+			collectRootFields(context, selection.SelectionSet, fields, responseNames, visited)
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			if fragment := context.Fragment(selection.Name.Value); fragment != nil {
+				collectRootFields(context, fragment.SelectionSet, fields, responseNames, visited)
+			}
+		}
+	}
+}
+
 // UniqueArgumentNamesRule Unique argument names
 //
 // A GraphQL field or directive is only valid if all supplied arguments are
@@ -1402,6 +1538,12 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 					return visitor.ActionNoChange, nil
 				},
 			},
+			kinds.FragmentSpread: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownArgNames = map[string]*ast.Name{}
+					return visitor.ActionNoChange, nil
+				},
+			},
 			kinds.Argument: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if node, ok := p.Node.(*ast.Argument); ok {
@@ -1412,7 +1554,7 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 						if nameAST, ok := knownArgNames[argName]; ok {
 							reportError(
 								context,
-								fmt.Sprintf(`There can be only one argument named "%v".`, argName),
+								gqlerrors.Message(gqlerrors.MsgDuplicateArg, argName),
 								[]ast.Node{nameAST, node.Name},
 							)
 						} else {
@@ -1452,7 +1594,7 @@ func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance
 						if nameAST, ok := knownFragmentNames[fragmentName]; ok {
 							reportError(
 								context,
-								fmt.Sprintf(`There can only be one fragment named "%v".`, fragmentName),
+								gqlerrors.Message(gqlerrors.MsgDuplicateFragment, fragmentName),
 								[]ast.Node{nameAST, node.Name},
 							)
 						} else {
@@ -1501,7 +1643,7 @@ func UniqueInputFieldNamesRule(context *ValidationContext) *ValidationRuleInstan
 						if knownNameAST, ok := knownNames[fieldName]; ok {
 							reportError(
 								context,
-								fmt.Sprintf(`There can be only one input field named "%v".`, fieldName),
+								gqlerrors.Message(gqlerrors.MsgDuplicateInputField, fieldName),
 								[]ast.Node{knownNameAST, node.Name},
 							)
 						} else {
@@ -1541,7 +1683,7 @@ func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstanc
 						if nameAST, ok := knownOperationNames[operationName]; ok {
 							reportError(
 								context,
-								fmt.Sprintf(`There can only be one operation named "%v".`, operationName),
+								gqlerrors.Message(gqlerrors.MsgDuplicateOperation, operationName),
 								[]ast.Node{nameAST, errNode},
 							)
 						} else {
@@ -1579,6 +1721,12 @@ func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance
 					return visitor.ActionNoChange, nil
 				},
 			},
+			kinds.FragmentDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownVariableNames = map[string]*ast.Name{}
+					return visitor.ActionNoChange, nil
+				},
+			},
 			kinds.VariableDefinition: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if node, ok := p.Node.(*ast.VariableDefinition); ok && node != nil {
@@ -1591,7 +1739,7 @@ func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance
 						if nameAST, ok := knownVariableNames[variableName]; ok {
 							reportError(
 								context,
-								fmt.Sprintf(`There can only be one variable named "%v".`, variableName),
+								gqlerrors.Message(gqlerrors.MsgDuplicateVariable, variableName),
 								[]ast.Node{nameAST, variableNameAST},
 							)
 						} else {
@@ -1629,7 +1777,7 @@ func VariablesAreInputTypesRule(context *ValidationContext) *ValidationRuleInsta
 							}
 							reportError(
 								context,
-								fmt.Sprintf(`Variable "$%v" cannot be non-input type "%v".`,
+								gqlerrors.Message(gqlerrors.MsgNonInputTypeOnVariable,
 									variableName, printer.Print(node.Type)),
 								[]ast.Node{node.Type},
 							)
@@ -1683,11 +1831,10 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 								if err != nil {
 									varType = nil
 								}
-								if varType != nil && !isTypeSubTypeOf(context.Schema(), effectiveType(varType, varDef), usage.Type) {
+								if varType != nil && !IsTypeSubTypeOf(context.Schema(), effectiveType(varType, varDef), usage.Type) {
 									reportError(
 										context,
-										fmt.Sprintf(`Variable "$%v" of type "%v" used in position `+
-											`expecting type "%v".`, varName, varType, usage.Type),
+										gqlerrors.Message(gqlerrors.MsgBadVariablePosition, varName, varType, usage.Type),
 										[]ast.Node{varDef, usage.Node},
 									)
 								}
@@ -1698,6 +1845,36 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 					return visitor.ActionNoChange, nil
 				},
 			},
+			kinds.FragmentDefinition: {
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					fragment, ok := p.Node.(*ast.FragmentDefinition)
+					if !ok || fragment == nil || len(fragment.VariableDefinitions) == 0 {
+						return visitor.ActionNoChange, nil
+					}
+					// the usages of the variables defined by the fragment are
+					// checked against their definition in the fragment
+					scoped := fragmentVariableDefinitions(fragment)
+					for _, usage := range context.VariableUsages(fragment) {
+						if usage.Node.Name == nil || usage.Type == nil {
+							continue
+						}
+						varName := usage.Node.Name.Value
+						varDef := scoped[varName]
+						if varDef == nil {
+							continue
+						}
+						varType, err := typeFromAST(*context.Schema(), varDef.Type)
+						if err == nil && varType != nil && !IsTypeSubTypeOf(context.Schema(), effectiveType(varType, varDef), usage.Type) {
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgBadVariablePosition, varName, varType, usage.Type),
+								[]ast.Node{varDef, usage.Node},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
 			kinds.VariableDefinition: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if varDefAST, ok := p.Node.(*ast.VariableDefinition); ok {
@@ -1719,59 +1896,134 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 	}
 }
 
+// ValuesOfCorrectTypeRule Values of correct type
+//
+// A GraphQL document is only valid if all argument values and variable default
+// values are of the type expected by their definition. Unlike
+// ArgumentsOfCorrectTypeRule and DefaultValuesOfCorrectTypeRule, which it
+// replaces in SpecifiedRules, an error is reported for each invalid nested
+// literal, at its location, e.g. `In field "b": Expected type "Int", found "3".`
+// for the field "b" of an input object.
+func ValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
+	reportLiteralErrors := func(ttype Input, valueAST ast.Value) {
+		for _, err := range literalErrors(ttype, valueAST, valueAST) {
+			reportError(context, err.message, []ast.Node{err.node})
+		}
+	}
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Argument: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if argAST, ok := p.Node.(*ast.Argument); ok {
+						if argDef := context.Argument(); argDef != nil {
+							reportLiteralErrors(argDef.Type, argAST.Value)
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.VariableDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if varDefAST, ok := p.Node.(*ast.VariableDefinition); ok && varDefAST.DefaultValue != nil {
+						name := ""
+						if varDefAST.Variable != nil && varDefAST.Variable.Name != nil {
+							name = varDefAST.Variable.Name.Value
+						}
+						ttype := context.InputType()
+						if ttype, ok := ttype.(*NonNull); ok {
+							reportError(
+								context,
+								gqlerrors.Message(gqlerrors.MsgDefaultForNonNullVariable,
+									name, ttype, ttype.OfType),
+								[]ast.Node{varDefAST.DefaultValue},
+							)
+						}
+						reportLiteralErrors(ttype, varDefAST.DefaultValue)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
 // Utility for validators which determines if a value literal AST is valid given
 // an input type.
 //
 // Note that this only validates literal values, variables are assumed to
 // provide values of the correct type.
 func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+	errs := literalErrors(ttype, valueAST, valueAST)
+	if len(errs) == 0 {
+		return true, nil
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.message)
+	}
+	return false, messages
+}
+
+// literalError is an error of a literal value, with the nested literal it is
+// located at.
+type literalError struct {
+	message string
+	node    ast.Node
+}
+
+// literalErrors returns the errors of a value literal AST given an input type,
+// the errors of a missing value being located at the given enclosing node.
+func literalErrors(ttype Input, valueAST ast.Value, node ast.Node) []literalError {
 	if _, ok := ttype.(*NonNull); !ok {
 		if valueAST == nil {
-			return true, nil
+			return nil
 		}
 
 		// This function only tests literals, and assumes variables will provide
 		// values of the correct type.
 		if valueAST.GetKind() == kinds.Variable {
-			return true, nil
+			return nil
 		}
 	}
 	switch ttype := ttype.(type) {
 	case *NonNull:
 		// A value must be provided if the type is non-null.
 		if e := ttype.Error(); e != nil {
-			return false, []string{e.Error()}
+			return []literalError{{e.Error(), node}}
 		}
 		if valueAST == nil {
 			if ttype.OfType.Name() != "" {
-				return false, []string{fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())}
+				return []literalError{{gqlerrors.Message(gqlerrors.MsgExpectedNonNullType, ttype.OfType.Name()), node}}
 			}
-			return false, []string{"Expected non-null value, found null."}
+			return []literalError{{gqlerrors.Message(gqlerrors.MsgExpectedNonNull), node}}
 		}
 		ofType, _ := ttype.OfType.(Input)
-		return isValidLiteralValue(ofType, valueAST)
+		return literalErrors(ofType, valueAST, valueAST)
 	case *List:
 		// Lists accept a non-list value as a list of one.
 		itemType, _ := ttype.OfType.(Input)
 		if valueAST, ok := valueAST.(*ast.ListValue); ok {
-			messagesReduce := []string{}
-			for _, value := range valueAST.Values {
-				_, messages := isValidLiteralValue(itemType, value)
-				for idx, message := range messages {
-					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
+			errs := []literalError{}
+			for idx, value := range valueAST.Values {
+				for _, err := range literalErrors(itemType, value, value) {
+					err.message = gqlerrors.Message(gqlerrors.MsgInElement, idx, err.message)
+					errs = append(errs, err)
 				}
 			}
-			return (len(messagesReduce) == 0), messagesReduce
+			return errs
 		}
-		return isValidLiteralValue(itemType, valueAST)
+		return literalErrors(itemType, valueAST, valueAST)
 	case *InputObject:
 		// Input objects check each defined field and look for undefined fields.
 		valueAST, ok := valueAST.(*ast.ObjectValue)
 		if !ok {
-			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
+			return []literalError{{gqlerrors.Message(gqlerrors.MsgExpectedObject, ttype.Name()), node}}
 		}
 		fields := ttype.Fields()
-		messagesReduce := []string{}
+		errs := []literalError{}
 
 		// Ensure every provided field is defined.
 		fieldASTs := valueAST.Fields
@@ -1780,33 +2032,37 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			fieldASTMap[fieldAST.Name.Value] = fieldAST
 			field, ok := fields[fieldAST.Name.Value]
 			if !ok || field == nil {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldAST.Name.Value))
+				errs = append(errs, literalError{gqlerrors.Message(gqlerrors.MsgUnknownInputField, fieldAST.Name.Value), fieldAST})
 			}
 		}
 		// Ensure every defined field is valid.
-		for fieldName, field := range fields {
+		fieldNames := make([]string, 0, len(fields))
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
 			var fieldASTValue ast.Value
 			if fieldAST := fieldASTMap[fieldName]; fieldAST != nil {
 				fieldASTValue = fieldAST.Value
 			}
-			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue); !isValid {
-				for _, message := range messages {
-					messagesReduce = append(messagesReduce, fmt.Sprintf("In field \"%v\": %v", fieldName, message))
-				}
+			for _, err := range literalErrors(fields[fieldName].Type, fieldASTValue, valueAST) {
+				err.message = gqlerrors.Message(gqlerrors.MsgInField, fieldName, err.message)
+				errs = append(errs, err)
 			}
 		}
-		return (len(messagesReduce) == 0), messagesReduce
+		return errs
 	case *Scalar:
 		if isNullish(ttype.ParseLiteral(valueAST)) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
+			return []literalError{{gqlerrors.Message(gqlerrors.MsgExpectedType, ttype.Name(), printer.Print(valueAST)), valueAST}}
 		}
 	case *Enum:
 		if isNullish(ttype.ParseLiteral(valueAST)) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
+			return []literalError{{gqlerrors.Message(gqlerrors.MsgExpectedType, ttype.Name(), printer.Print(valueAST)), valueAST}}
 		}
 	}
 
-	return true, nil
+	return nil
 }
 
 // Internal struct to sort results from suggestionList()
@@ -5,6 +5,7 @@ import (
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -94,6 +95,69 @@ func TestValidate_KnownDirectives_WithMisplacedDirectives(t *testing.T) {
 	})
 }
 
+func TestValidate_KnownDirectives_WithWellPlacedDirectivesOnOperationsAndFragments(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.KnownDirectivesRule, `
+      query Foo($var: Boolean @onVariableDefinition) @onQuery {
+        name @onField
+        ...Frag @onFragmentSpread
+        ... @onInlineFragment { name }
+      }
+
+      subscription Baz @onSubscription {
+        someField
+      }
+
+      fragment Frag on Human @onFragmentDefinition {
+        name
+      }
+    `)
+}
+func TestValidate_KnownDirectives_WithMisplacedDirectivesOnOperationsAndFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.KnownDirectivesRule, `
+      query Foo($var: Boolean @onQuery) @onVariableDefinition {
+        ... @onFragmentSpread { name }
+      }
+
+      subscription Baz @onMutation {
+        someField
+      }
+
+      fragment Frag on Human @skip(if: true) @onInlineFragment {
+        name @include(if: true) @onFragmentDefinition
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "onQuery" may not be used on VARIABLE_DEFINITION.`, 2, 31),
+		testutil.RuleError(`Directive "onVariableDefinition" may not be used on QUERY.`, 2, 41),
+		testutil.RuleError(`Directive "onFragmentSpread" may not be used on INLINE_FRAGMENT.`, 3, 13),
+		testutil.RuleError(`Directive "onMutation" may not be used on SUBSCRIPTION.`, 6, 24),
+		testutil.RuleError(`Directive "skip" may not be used on FRAGMENT_DEFINITION.`, 10, 30),
+		testutil.RuleError(`Directive "onInlineFragment" may not be used on FRAGMENT_DEFINITION.`, 10, 46),
+		testutil.RuleError(`Directive "onFragmentDefinition" may not be used on FIELD.`, 11, 33),
+	})
+}
+func TestValidate_KnownDirectives_WithDirectiveOnNodeWithoutLocation(t *testing.T) {
+	// an operation of an unknown type, which only a hand-built AST can hold
+	doc := ast.NewDocument(&ast.Document{
+		Definitions: []ast.Node{
+			ast.NewOperationDefinition(&ast.OperationDefinition{
+				Operation: "unknown",
+				Directives: []*ast.Directive{
+					ast.NewDirective(&ast.Directive{Name: ast.NewName(&ast.Name{Value: "onQuery"})}),
+				},
+				SelectionSet: ast.NewSelectionSet(&ast.SelectionSet{
+					Selections: []ast.Selection{
+						ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "name"})}),
+					},
+				}),
+			}),
+		},
+	})
+	result := graphql.ValidateDocument(testutil.TestSchema, doc, []graphql.ValidationRuleFn{graphql.KnownDirectivesRule})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Directive "onQuery" may not be used on OperationDefinition.` {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
 func TestValidate_KnownDirectives_WithinSchemaLanguage_WithWellPlacedDirectives(t *testing.T) {
 	testutil.ExpectPassesRule(t, graphql.KnownDirectivesRule, `
         type MyObj implements MyInterface @onObject {
@@ -163,3 +227,11 @@ func TestValidate_KnownDirectives_WithinSchemaLanguage_WithMisplacedDirectives(t
 		testutil.RuleError(`Directive "onObject" may not be used on SCHEMA.`, 22, 16),
 	})
 }
+
+func TestValidate_KnownDirectives_WithinSchemaLanguage_WithUnknownLocations(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.KnownDirectivesRule, `
+        directive @onNowhere on FIELD | NOWHERE
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown directive location "NOWHERE".`, 2, 41),
+	})
+}
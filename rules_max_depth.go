@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// MaxDepthRule Max depth
+//
+// A GraphQL operation is only valid if its fields, including the fields
+// selected through fragments, are nested at most maxDepth deep, its top level
+// fields having a depth of 1. It is not part of SpecifiedRules, it protects
+// the server from deeply nested queries once appended to them:
+//
+//	rules := append(graphql.SpecifiedRules, graphql.MaxDepthRule(10))
+//
+// The first field of an operation nested deeper than maxDepth is reported.
+// The usual introspection query is nested 13 deep. Spreading a fragment
+// within itself does not nest deeper, it is reported by NoFragmentCyclesRule.
+// A maxDepth of 0 or less means no limit.
+func MaxDepthRule(maxDepth int) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		return maxDepthRule(context, maxDepth)
+	}
+}
+
+// depthChain is the deepest chain of fields of a selection set, shared by the
+// chains of its parents.
+type depthChain struct {
+	field *ast.Field
+	depth int
+	next  *depthChain
+}
+
+func maxDepthRule(context *ValidationContext, maxDepth int) *ValidationRuleInstance {
+
+	// Deepest chains of the fragments, nil while a fragment is explored.
+	fragmentChains := map[string]*depthChain{}
+
+	// Fields already reported, when the operations spread the same fragment.
+	reported := map[*ast.Field]bool{}
+
+	var selectionSetChain func(selectionSet *ast.SelectionSet) *depthChain
+	fragmentChain := func(name string) *depthChain {
+		if chain, ok := fragmentChains[name]; ok {
+			return chain
+		}
+		fragment := context.Fragment(name)
+		if fragment == nil {
+			return nil
+		}
+		fragmentChains[name] = nil
+		chain := selectionSetChain(fragment.SelectionSet)
+		fragmentChains[name] = chain
+		return chain
+	}
+	selectionSetChain = func(selectionSet *ast.SelectionSet) *depthChain {
+		if selectionSet == nil {
+			return nil
+		}
+		var deepest *depthChain
+		for _, selection := range selectionSet.Selections {
+			var chain *depthChain
+			switch selection := selection.(type) {
+			case *ast.Field:
+				next := selectionSetChain(selection.SelectionSet)
+				chain = &depthChain{field: selection, depth: 1, next: next}
+				if next != nil {
+					chain.depth += next.depth
+				}
+			case *ast.InlineFragment:
+				chain = selectionSetChain(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				if selection.Name != nil {
+					chain = fragmentChain(selection.Name.Value)
+				}
+			}
+			if chain != nil && (deepest == nil || chain.depth > deepest.depth) {
+				deepest = chain
+			}
+		}
+		return deepest
+	}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.OperationDefinition)
+					if !ok || node == nil || maxDepth <= 0 {
+						return visitor.ActionSkip, nil
+					}
+					chain := selectionSetChain(node.SelectionSet)
+					if chain == nil || chain.depth <= maxDepth {
+						return visitor.ActionSkip, nil
+					}
+					for i := 0; i < maxDepth; i++ {
+						chain = chain.next
+					}
+					if !reported[chain.field] {
+						reported[chain.field] = true
+						fieldName := ""
+						if chain.field.Name != nil {
+							fieldName = chain.field.Name.Value
+						}
+						reportError(
+							context,
+							gqlerrors.Message(gqlerrors.MsgMaxDepthExceeded, fieldName, maxDepth),
+							[]ast.Node{chain.field},
+						)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
@@ -0,0 +1,73 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_MaxDepth_FieldsWithinTheMaximumDepth(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.MaxDepthRule(3), `
+      {
+        human {
+          relatives { name }
+          ...relativesFragment
+        }
+      }
+      fragment relativesFragment on Human {
+        ... on Human { relatives { name } }
+      }
+    `)
+}
+func TestValidate_MaxDepth_NoLimit(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.MaxDepthRule(0), `
+      {
+        human { relatives { relatives { relatives { name } } } }
+      }
+    `)
+}
+func TestValidate_MaxDepth_FieldsDeeperThanTheMaximumDepth(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.MaxDepthRule(3), `
+      {
+        dog { name }
+        human {
+          relatives {
+            relatives { name }
+          }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "name" exceeds the maximum depth of 3.`, 6, 25),
+	})
+}
+func TestValidate_MaxDepth_FieldsDeeperThroughFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.MaxDepthRule(2), `
+      query first { human { ...relativesFragment } }
+      query second { human { ...relativesFragment } }
+      fragment relativesFragment on Human {
+        ... on Human {
+          relatives { name }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "name" exceeds the maximum depth of 2.`, 6, 23),
+	})
+}
+func TestValidate_MaxDepth_RecursiveFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.MaxDepthRule(1), `
+      { human { ...relativesFragment } }
+      fragment relativesFragment on Human {
+        relatives { ...relativesFragment }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "relatives" exceeds the maximum depth of 1.`, 4, 9),
+	})
+}
+func TestValidate_MaxDepth_IntrospectionQuery(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.MaxDepthRule(13), testutil.IntrospectionQuery)
+	testutil.ExpectFailsRule(t, graphql.MaxDepthRule(12), testutil.IntrospectionQuery, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "kind" exceeds the maximum depth of 12.`, 87, 19),
+	})
+}
@@ -1,10 +1,13 @@
 package graphql_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -176,3 +179,70 @@ func TestValidate_NoCircularFragmentSpreads_NoSpreadingItselfDeeplyAndImmediatel
 			4, 41),
 	})
 }
+
+// fragmentChain generates n fragments each spreading the next ones, up to
+// fanOut of them, the last fragment spreading the first one when cyclic.
+func fragmentChain(n, fanOut int, cyclic bool) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "fragment frag%d on Dog { name", i)
+		for j := i + 1; j < n && j <= i+fanOut; j++ {
+			fmt.Fprintf(&b, " ...frag%d", j)
+		}
+		if cyclic && i == n-1 {
+			b.WriteString(" ...frag0")
+		}
+		b.WriteString(" }\n")
+	}
+	return b.String()
+}
+
+func TestValidate_NoCircularFragmentSpreads_LongChains(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.NoFragmentCyclesRule, fragmentChain(10000, 1, false))
+
+	AST, err := parser.Parse(parser.ParseParams{Source: fragmentChain(100, 1, true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.ValidateDocument(testutil.TestSchema, AST, []graphql.ValidationRuleFn{graphql.NoFragmentCyclesRule})
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0].Message, `Cannot spread fragment "frag0" within itself via frag1, frag2,`) {
+		t.Fatalf("expected the cycle to be reported once, got %v errors", len(result.Errors))
+	}
+	if len(result.Errors[0].Locations) != 100 {
+		t.Fatalf("expected the locations of the 100 spreads of the cycle, got %v", len(result.Errors[0].Locations))
+	}
+}
+
+func benchmarkNoFragmentCycles(n, fanOut int) func(b *testing.B) {
+	return func(b *testing.B) {
+		AST, err := parser.Parse(parser.ParseParams{Source: fragmentChain(n, fanOut, false)})
+		if err != nil {
+			b.Fatal(err)
+		}
+		rules := []graphql.ValidationRuleFn{graphql.NoFragmentCyclesRule}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if result := graphql.ValidateDocument(testutil.TestSchema, AST, rules); !result.IsValid {
+				b.Fatalf("unexpected errors: %v", result.Errors)
+			}
+		}
+	}
+}
+
+// Chains of fragments, and dense graphs of fragments each spreading all the
+// next ones, the number of spreads growing quadratically.
+func BenchmarkNoFragmentCycles_Chain_100(b *testing.B) {
+	benchmarkNoFragmentCycles(100, 1)(b)
+}
+
+func BenchmarkNoFragmentCycles_Chain_1K(b *testing.B) {
+	benchmarkNoFragmentCycles(1000, 1)(b)
+}
+
+func BenchmarkNoFragmentCycles_Dense_100(b *testing.B) {
+	benchmarkNoFragmentCycles(100, 100)(b)
+}
+
+func BenchmarkNoFragmentCycles_Dense_500(b *testing.B) {
+	benchmarkNoFragmentCycles(500, 500)(b)
+}
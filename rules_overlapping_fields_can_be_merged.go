@@ -1,9 +1,9 @@
 package graphql
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/kinds"
 	"github.com/graphql-go/graphql/language/printer"
@@ -11,8 +11,7 @@ import (
 )
 
 func fieldsConflictMessage(responseName string, reason conflictReason) string {
-	return fmt.Sprintf(`Fields "%v" conflict because %v. `+
-		`Use different aliases on the fields to fetch both if this was intentional.`,
+	return gqlerrors.Message(gqlerrors.MsgFieldsConflict,
 		responseName,
 		fieldsConflictReasonMessage(reason),
 	)
@@ -27,13 +26,12 @@ func fieldsConflictReasonMessage(message interface{}) string {
 	case []conflictReason:
 		messages := []string{}
 		for _, r := range reason {
-			messages = append(messages, fmt.Sprintf(
-				`subfields "%v" conflict because %v`,
+			messages = append(messages, gqlerrors.Message(gqlerrors.MsgSubfieldsConflict,
 				r.Name,
 				fieldsConflictReasonMessage(r.Message),
 			))
 		}
-		return strings.Join(messages, " and ")
+		return strings.Join(messages, gqlerrors.Message(gqlerrors.MsgConflictReasonsSeparator))
 	}
 	return ""
 }
@@ -401,7 +399,7 @@ func (rule *overlappingFieldsCanBeMergedRule) findConflict(parentFieldsAreMutual
 			return &conflict{
 				Reason: conflictReason{
 					Name:    responseName,
-					Message: fmt.Sprintf(`%v and %v are different fields`, name1, name2),
+					Message: gqlerrors.Message(gqlerrors.MsgDifferentFields, name1, name2),
 				},
 				FieldsLeft:  []ast.Node{ast1},
 				FieldsRight: []ast.Node{ast2},
@@ -413,7 +411,7 @@ func (rule *overlappingFieldsCanBeMergedRule) findConflict(parentFieldsAreMutual
 			return &conflict{
 				Reason: conflictReason{
 					Name:    responseName,
-					Message: `they have differing arguments`,
+					Message: gqlerrors.Message(gqlerrors.MsgDifferingArguments),
 				},
 				FieldsLeft:  []ast.Node{ast1},
 				FieldsRight: []ast.Node{ast2},
@@ -425,7 +423,7 @@ func (rule *overlappingFieldsCanBeMergedRule) findConflict(parentFieldsAreMutual
 		return &conflict{
 			Reason: conflictReason{
 				Name:    responseName,
-				Message: fmt.Sprintf(`they return conflicting types %v and %v`, type1, type2),
+				Message: gqlerrors.Message(gqlerrors.MsgConflictingTypes, type1, type2),
 			},
 			FieldsLeft:  []ast.Node{ast1},
 			FieldsRight: []ast.Node{ast2},
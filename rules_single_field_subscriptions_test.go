@@ -0,0 +1,69 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_SingleFieldSubscriptions_ValidSubscription(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+      }
+    `)
+}
+func TestValidate_SingleFieldSubscriptions_ValidSubscriptionWithFragments(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription sub {
+        ...newMessageFields
+        ... on SubscriptionRoot { newMessage { body } }
+      }
+      fragment newMessageFields on SubscriptionRoot {
+        newMessage {
+          body
+          sender
+        }
+      }
+    `)
+}
+func TestValidate_SingleFieldSubscriptions_IgnoresQueriesAndMutations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      query { dog { name } human { name } __typename }
+    `)
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        notImportantEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 4, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootFieldInFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription {
+        importantEmails
+        ...notImportantEmails
+      }
+      fragment notImportantEmails on SubscriptionRoot {
+        notImportantEmails
+        ...notImportantEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Anonymous Subscription must select only one top level field.`, 7, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithIntrospectionRootField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        __typename
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must not select an introspection top level field.`, 3, 9),
+	})
+}
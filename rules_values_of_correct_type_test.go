@@ -0,0 +1,64 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_ValuesOfCorrectType_GoodValues(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ValuesOfCorrectTypeRule, `
+      query Good($a: [String] = ["one", "two"], $b: ComplexInput = {requiredField: true}, $s: String) {
+        complicatedArgs {
+          complexArgField(complexArg: {requiredField: true, stringListField: ["one", $s]})
+          intArgField(intArg: 2) @include(if: true)
+        }
+      }
+    `)
+}
+func TestValidate_ValuesOfCorrectType_ReportsTheNestedLiteral(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            complexArgField(complexArg: {
+              stringListField: ["one", 2],
+              intField: "3",
+              unknownField: "value"
+            })
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(`In field "unknownField": Unknown field.`, 7, 15),
+			testutil.RuleError(`In field "intField": Expected type "Int", found "3".`, 6, 25),
+			testutil.RuleError(`In field "requiredField": Expected "Boolean!", found null.`, 4, 41),
+			testutil.RuleError(`In field "stringListField": In element #1: Expected type "String", found 2.`, 5, 40),
+		})
+}
+func TestValidate_ValuesOfCorrectType_ReportsTheArgumentValue(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: "3")
+            complexArgField(complexArg: 1)
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(`Expected type "Int", found "3".`, 4, 33),
+			testutil.RuleError(`Expected "ComplexInput", found not an object.`, 5, 41),
+		})
+}
+func TestValidate_ValuesOfCorrectType_ReportsVariableDefaultValues(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+      query Bad($a: [String] = ["one", 2], $b: Int! = 1) {
+        dog { name }
+      }
+    `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(`In element #1: Expected type "String", found 2.`, 2, 40),
+			testutil.RuleError(`Variable "$b" of type "Int!" is required and will not use the default value. Perhaps you meant to use type "Int".`, 2, 55),
+		})
+}
@@ -0,0 +1,179 @@
+// Package sanitize rewrites operations so they can be logged without leaking
+// the personal data clients embed in them as literals, such as
+// `user(email: "jane@example.com")`.
+package sanitize
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// Operation returns the printed document with its string, integer and float
+// literals replaced by placeholder variables named $_1, $_2... in the order of
+// the document, along with the values of the literals by placeholder name.
+// The literals of lists and input objects are replaced one by one, the
+// booleans and enums are kept. The sanitized text is meant for logs, it does
+// not define the placeholder variables. The document is not modified.
+func Operation(doc *ast.Document) (string, map[string]interface{}) {
+	s := &sanitizer{args: map[string]interface{}{}}
+	sanitized := *doc
+	sanitized.Definitions = make([]ast.Node, len(doc.Definitions))
+	for i, definition := range doc.Definitions {
+		sanitized.Definitions[i] = s.definition(definition)
+	}
+	return fmt.Sprint(printer.Print(&sanitized)), s.args
+}
+
+// sanitizer copies the nodes containing literals, replacing the literals.
+type sanitizer struct {
+	args map[string]interface{}
+}
+
+func (s *sanitizer) definition(definition ast.Node) ast.Node {
+	switch definition := definition.(type) {
+	case *ast.OperationDefinition:
+		operation := *definition
+		operation.VariableDefinitions = s.variableDefinitions(definition.VariableDefinitions)
+		operation.Directives = s.directives(definition.Directives)
+		operation.SelectionSet = s.selectionSet(definition.SelectionSet)
+		return &operation
+	case *ast.FragmentDefinition:
+		fragment := *definition
+		fragment.VariableDefinitions = s.variableDefinitions(definition.VariableDefinitions)
+		fragment.Directives = s.directives(definition.Directives)
+		fragment.SelectionSet = s.selectionSet(definition.SelectionSet)
+		return &fragment
+	}
+	return definition
+}
+
+func (s *sanitizer) variableDefinitions(definitions []*ast.VariableDefinition) []*ast.VariableDefinition {
+	if definitions == nil {
+		return nil
+	}
+	sanitized := make([]*ast.VariableDefinition, len(definitions))
+	for i, definition := range definitions {
+		if definition == nil {
+			continue
+		}
+		variable := *definition
+		variable.DefaultValue = s.value(definition.DefaultValue)
+		variable.Directives = s.directives(definition.Directives)
+		sanitized[i] = &variable
+	}
+	return sanitized
+}
+
+func (s *sanitizer) selectionSet(selectionSet *ast.SelectionSet) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	sanitized := *selectionSet
+	sanitized.Selections = make([]ast.Selection, len(selectionSet.Selections))
+	for i, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			field := *selection
+			field.Arguments = s.arguments(selection.Arguments)
+			field.Directives = s.directives(selection.Directives)
+			field.SelectionSet = s.selectionSet(selection.SelectionSet)
+			sanitized.Selections[i] = &field
+		case *ast.FragmentSpread:
+			spread := *selection
+			spread.Arguments = s.arguments(selection.Arguments)
+			spread.Directives = s.directives(selection.Directives)
+			sanitized.Selections[i] = &spread
+		case *ast.InlineFragment:
+			fragment := *selection
+			fragment.Directives = s.directives(selection.Directives)
+			fragment.SelectionSet = s.selectionSet(selection.SelectionSet)
+			sanitized.Selections[i] = &fragment
+		default:
+			sanitized.Selections[i] = selection
+		}
+	}
+	return &sanitized
+}
+
+func (s *sanitizer) directives(directives []*ast.Directive) []*ast.Directive {
+	if directives == nil {
+		return nil
+	}
+	sanitized := make([]*ast.Directive, len(directives))
+	for i, directive := range directives {
+		if directive == nil {
+			continue
+		}
+		d := *directive
+		d.Arguments = s.arguments(directive.Arguments)
+		sanitized[i] = &d
+	}
+	return sanitized
+}
+
+func (s *sanitizer) arguments(args []*ast.Argument) []*ast.Argument {
+	if args == nil {
+		return nil
+	}
+	sanitized := make([]*ast.Argument, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			continue
+		}
+		a := *arg
+		a.Value = s.value(arg.Value)
+		sanitized[i] = &a
+	}
+	return sanitized
+}
+
+func (s *sanitizer) value(value ast.Value) ast.Value {
+	switch value := value.(type) {
+	case *ast.StringValue:
+		return s.placeholder(value.Loc, value.Value)
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(value.Value, 10, 64); err == nil {
+			return s.placeholder(value.Loc, i)
+		}
+		return s.placeholder(value.Loc, value.Value)
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return s.placeholder(value.Loc, f)
+		}
+		return s.placeholder(value.Loc, value.Value)
+	case *ast.ListValue:
+		list := *value
+		list.Values = make([]ast.Value, len(value.Values))
+		for i, item := range value.Values {
+			list.Values[i] = s.value(item)
+		}
+		return &list
+	case *ast.ObjectValue:
+		object := *value
+		object.Fields = make([]*ast.ObjectField, len(value.Fields))
+		for i, field := range value.Fields {
+			if field == nil {
+				continue
+			}
+			f := *field
+			f.Value = s.value(field.Value)
+			object.Fields[i] = &f
+		}
+		return &object
+	}
+	return value
+}
+
+// placeholder records the value of a literal and returns the variable
+// replacing it.
+func (s *sanitizer) placeholder(loc *ast.Location, value interface{}) ast.Value {
+	name := fmt.Sprintf("_%d", len(s.args)+1)
+	s.args[name] = value
+	return ast.NewVariable(&ast.Variable{
+		Loc:  loc,
+		Name: ast.NewName(&ast.Name{Loc: loc, Value: name}),
+	})
+}
@@ -0,0 +1,52 @@
+package sanitize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/sanitize"
+)
+
+func TestOperation(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		query Users($first: Int = 10) {
+			users(email: "jane@example.com", first: $first, minScore: 4.5, roles: [ADMIN], active: true) {
+				name @include(if: true)
+				posts(filter: {tags: ["private", "draft"], draft: false}) { title }
+			}
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := printer.Print(doc)
+
+	text, args := sanitize.Operation(doc)
+	expectedText := `query Users($first: Int = $_1) {
+  users(email: $_2, first: $first, minScore: $_3, roles: [ADMIN], active: true) {
+    name @include(if: true)
+    posts(filter: {tags: [$_4, $_5], draft: false}) {
+      title
+    }
+  }
+}
+`
+	if text != expectedText {
+		t.Fatalf("unexpected sanitized text:\n%v\nexpected:\n%v", text, expectedText)
+	}
+	expectedArgs := map[string]interface{}{
+		"_1": int64(10),
+		"_2": "jane@example.com",
+		"_3": 4.5,
+		"_4": "private",
+		"_5": "draft",
+	}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Fatalf("unexpected args %v", args)
+	}
+	if printed := printer.Print(doc); printed != original {
+		t.Fatalf("expected the document not to be modified, got:\n%v", printed)
+	}
+}
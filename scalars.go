@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
@@ -142,6 +143,8 @@ func coerceInt(value interface{}) interface{} {
 			return nil
 		}
 		return coerceInt(*value)
+	case json.Number:
+		return coerceInt(string(value))
 	}
 
 	// If the value cannot be transformed into an int, return nil instead of '0'
@@ -149,18 +152,87 @@ func coerceInt(value interface{}) interface{} {
 	return nil
 }
 
+// maxSafeInteger is the largest integer exactly representable by a float64, 2^53 - 1.
+const maxSafeInteger = 1<<53 - 1
+
+// parseInt coerces an input value to a 32-bit signed integer. Unlike coerceInt
+// it never truncates: non-integral and out of range numbers are rejected with
+// an error describing why.
+func parseInt(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return parseInt(i)
+		}
+		f, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Int cannot represent non-integer value: %v", value)
+		}
+		return parseInt(f)
+	case int:
+		return parseInt(int64(value))
+	case int64:
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %v", value)
+		}
+		return int(value), nil
+	case uint:
+		return parseInt(uint64(value))
+	case uint32:
+		return parseInt(uint64(value))
+	case uint64:
+		if value > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %v", value)
+		}
+		return int(value), nil
+	case float32:
+		return parseInt(float64(value))
+	case float64:
+		if value != math.Trunc(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("Int cannot represent non-integer value: %v", value)
+		}
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %v", value)
+		}
+		return int(value), nil
+	}
+	return coerceInt(value), nil
+}
+
+// serializeInt coerces an output value like coerceInt, rejecting with an
+// error the numbers that are out of the 32-bit signed integer range instead of
+// resolving them to null.
+func serializeInt(value interface{}) (interface{}, error) {
+	if coerced := coerceInt(value); coerced != nil {
+		return coerced, nil
+	}
+	var number float64
+	switch coerced := coerceFloat(value).(type) {
+	case float64:
+		number = coerced
+	case float32:
+		number = float64(coerced)
+	default:
+		return nil, nil
+	}
+	if number < math.MinInt32 || number > math.MaxInt32 {
+		return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %v", value)
+	}
+	return nil, nil
+}
+
 // Int is the GraphQL Integer type definition.
 var Int = NewScalar(ScalarConfig{
 	Name: "Int",
 	Description: "The `Int` scalar type represents non-fractional signed whole numeric " +
 		"values. Int can represent values between -(2^31) and 2^31 - 1. ",
-	Serialize:  coerceInt,
-	ParseValue: coerceInt,
+	SerializeE:  serializeInt,
+	ParseValueE: parseInt,
 	ParseLiteral: func(valueAST ast.Value) interface{} {
 		switch valueAST := valueAST.(type) {
 		case *ast.IntValue:
-			if intValue, err := strconv.Atoi(valueAST.Value); err == nil {
-				return intValue
+			if intValue, err := strconv.ParseInt(valueAST.Value, 10, 32); err == nil {
+				return int(intValue)
 			}
 		}
 		return nil
@@ -274,6 +346,8 @@ func coerceFloat(value interface{}) interface{} {
 			return nil
 		}
 		return coerceFloat(*value)
+	case json.Number:
+		return coerceFloat(string(value))
 	}
 
 	// If the value cannot be transformed into an float, return nil instead of '0.0'
@@ -281,29 +355,106 @@ func coerceFloat(value interface{}) interface{} {
 	return nil
 }
 
+// parseFloat coerces an input value to a float, rejecting numbers that
+// cannot be represented as a finite float64.
+func parseFloat(value interface{}) (interface{}, error) {
+	if value, ok := value.(json.Number); ok {
+		f, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Float cannot represent non numeric value: %v", value)
+		}
+		return f, nil
+	}
+	return coerceFloat(value), nil
+}
+
+// parseSafeFloat is like parseFloat, but also rejects integers which a float64
+// cannot represent exactly.
+func parseSafeFloat(value interface{}) (interface{}, error) {
+	var unsafe bool
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			unsafe = i > maxSafeInteger || i < -maxSafeInteger
+		} else if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			unsafe = true
+		}
+	case int:
+		unsafe = int64(value) > maxSafeInteger || int64(value) < -maxSafeInteger
+	case int64:
+		unsafe = value > maxSafeInteger || value < -maxSafeInteger
+	case uint:
+		unsafe = uint64(value) > maxSafeInteger
+	case uint64:
+		unsafe = value > maxSafeInteger
+	case float64:
+		unsafe = value == math.Trunc(value) && math.Abs(value) > maxSafeInteger
+	}
+	if unsafe {
+		return nil, fmt.Errorf("Float cannot represent integer value outside of the safe range ±(2^53 - 1): %v", value)
+	}
+	return parseFloat(value)
+}
+
+func parseFloatLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.FloatValue:
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue
+		}
+	case *ast.IntValue:
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return nil
+}
+
+const floatDescription = "The `Float` scalar type represents signed double-precision fractional " +
+	"values as specified by " +
+	"[IEEE 754](http://en.wikipedia.org/wiki/IEEE_floating_point). "
+
 // Float is the GraphQL float type definition.
 var Float = NewScalar(ScalarConfig{
-	Name: "Float",
-	Description: "The `Float` scalar type represents signed double-precision fractional " +
-		"values as specified by " +
-		"[IEEE 754](http://en.wikipedia.org/wiki/IEEE_floating_point). ",
-	Serialize:  coerceFloat,
-	ParseValue: coerceFloat,
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		switch valueAST := valueAST.(type) {
-		case *ast.FloatValue:
-			if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
-				return floatValue
-			}
-		case *ast.IntValue:
-			if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
-				return floatValue
-			}
-		}
-		return nil
-	},
+	Name:         "Float",
+	Description:  floatDescription,
+	Serialize:    coerceFloat,
+	ParseValueE:  parseFloat,
+	ParseLiteral: parseFloatLiteral,
 })
 
+// FloatOptions configures a Float scalar created with NewFloatScalar.
+type FloatOptions struct {
+	// Name is the name of the scalar, "Float" by default, replacing the
+	// default Float type definition in the schema.
+	Name string
+
+	// SafeIntegers rejects integer input values outside of -(2^53 - 1) and 2^53 - 1,
+	// which cannot be represented exactly by a float64, instead of rounding them.
+	SafeIntegers bool
+}
+
+// NewFloatScalar creates a Float scalar with the given options, to be used in
+// place of the default Float type definition, or along with it under another
+// name.
+func NewFloatScalar(opts FloatOptions) *Scalar {
+	name := opts.Name
+	if name == "" {
+		name = "Float"
+	}
+	parseValue := parseFloat
+	if opts.SafeIntegers {
+		parseValue = parseSafeFloat
+	}
+	return NewScalar(ScalarConfig{
+		Name:         name,
+		Description:  floatDescription,
+		Serialize:    coerceFloat,
+		ParseValueE:  parseValue,
+		ParseLiteral: parseFloatLiteral,
+	})
+}
+
 func coerceString(value interface{}) interface{} {
 	if v, ok := value.(*string); ok {
 		if v == nil {
@@ -333,6 +484,12 @@ var String = NewScalar(ScalarConfig{
 
 func coerceBool(value interface{}) interface{} {
 	switch value := value.(type) {
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return nil
+		}
+		return coerceBool(f)
 	case bool:
 		return value
 	case *bool:
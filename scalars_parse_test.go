@@ -1,7 +1,9 @@
 package graphql_test
 
 import (
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -52,3 +54,142 @@ func TestTypeSystem_Scalar_ParseLiteralOutputDateTime(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeSystem_Scalar_ParseValueOutputInt(t *testing.T) {
+	tests := []struct {
+		Value    interface{}
+		Expected interface{}
+	}{
+		{1, 1},
+		{int64(-2147483648), -2147483648},
+		{float64(3), 3},
+		{json.Number("42"), 42},
+		{json.Number("4e1"), 40},
+		{"7", 7},
+	}
+	for _, test := range tests {
+		if val := graphql.Int.ParseValue(test.Value); val != test.Expected {
+			t.Fatalf("failed Int.ParseValue(%T(%v)), expected: %v, got %v", test.Value, test.Value, test.Expected, val)
+		}
+	}
+
+	errors := []struct {
+		Value    interface{}
+		Expected string
+	}{
+		{1.5, "Int cannot represent non-integer value: 1.5"},
+		{json.Number("1.5"), "Int cannot represent non-integer value: 1.5"},
+		{int64(2147483648), "Int cannot represent non 32-bit signed integer value: 2147483648"},
+		{json.Number("9007199254740993"), "Int cannot represent non 32-bit signed integer value: 9007199254740993"},
+		{uint64(1 << 40), "Int cannot represent non 32-bit signed integer value: 1099511627776"},
+	}
+	for _, test := range errors {
+		_, err := graphql.Int.ParseValueE(test.Value)
+		if err == nil || err.Error() != test.Expected {
+			t.Fatalf("failed Int.ParseValueE(%T(%v)), expected error: %v, got %v", test.Value, test.Value, test.Expected, err)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_ParseValueOutputFloat(t *testing.T) {
+	if val := graphql.Float.ParseValue(json.Number("1.5")); val != 1.5 {
+		t.Fatalf("expected 1.5, got %v", val)
+	}
+	if val := graphql.Float.ParseValue(json.Number("9007199254740993")); val != float64(9007199254740993) {
+		t.Fatalf("expected unsafe integers to be accepted by default, got %v", val)
+	}
+
+	safeFloat := graphql.NewFloatScalar(graphql.FloatOptions{SafeIntegers: true})
+	if val := safeFloat.ParseValue(json.Number("9007199254740991")); val != float64(9007199254740991) {
+		t.Fatalf("expected the max safe integer to be accepted, got %v", val)
+	}
+	for _, value := range []interface{}{json.Number("9007199254740993"), json.Number("-9007199254740993"), int64(1 << 60), float64(1 << 60)} {
+		if _, err := safeFloat.ParseValueE(value); err == nil {
+			t.Fatalf("expected an error parsing unsafe integer %T(%v)", value, value)
+		}
+	}
+	if val := safeFloat.ParseValue(json.Number("1e300")); val != 1e300 {
+		t.Fatalf("expected non-integral floats to be accepted, got %v", val)
+	}
+	if val := safeFloat.ParseValue(json.Number("9007199254740993")); val != nil {
+		t.Fatalf("expected ParseValue to reject unsafe integers with nil, got %v", val)
+	}
+
+	safeDouble := graphql.NewFloatScalar(graphql.FloatOptions{Name: "SafeDouble", SafeIntegers: true})
+	if safeDouble.Name() != "SafeDouble" || safeFloat.Name() != "Float" {
+		t.Fatalf("unexpected names: %v, %v", safeDouble.Name(), safeFloat.Name())
+	}
+}
+
+func TestTypeSystem_Scalar_ParseValueOutputBoolean(t *testing.T) {
+	if val := graphql.Boolean.ParseValue(json.Number("1")); val != true {
+		t.Fatalf("expected true, got %v", val)
+	}
+	if val := graphql.Boolean.ParseValue(json.Number("0")); val != false {
+		t.Fatalf("expected false, got %v", val)
+	}
+}
+
+func TestTypeSystem_Scalar_IntVariableOverflowReportsError(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($int: Int) { int(input: $int) }`,
+		RawVariables:  []byte(`{"int": 2147483648}`),
+	})
+	expected := "Variable \"$int\" got invalid value 2147483648.\n" +
+		"Expected type \"Int\", found \"2147483648\"; Int cannot represent non 32-bit signed integer value: 2147483648"
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestTypeSystem_Scalar_IntLiteralOverflowReportsError(t *testing.T) {
+	if val := graphql.Int.ParseLiteral(&ast.IntValue{Value: "3000000000"}); val != nil {
+		t.Fatalf("expected an out of range literal to be rejected, got %v", val)
+	}
+	if val := graphql.Int.ParseLiteral(&ast.IntValue{Value: "-2147483648"}); val != -2147483648 {
+		t.Fatalf("expected the min 32-bit signed integer, got %v", val)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"x": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ int(x: 3000000000) }`,
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "3000000000") {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if result.Data != nil {
+		t.Fatalf("expected no data, got: %v", result.Data)
+	}
+}
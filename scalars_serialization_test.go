@@ -99,6 +99,44 @@ func TestTypeSystem_Scalar_SerializesOutputInt(t *testing.T) {
 	}
 }
 
+func TestTypeSystem_Scalar_SerializesOutOfRangeIntReportsError(t *testing.T) {
+	for _, value := range []interface{}{int64(3000000000), uint64(math.MaxInt32) + 1, float64(-1e100), "3000000000"} {
+		if _, err := graphql.Int.SerializeE(value); err == nil {
+			t.Fatalf("expected an error serializing %T(%v)", value, value)
+		}
+	}
+	if val, err := graphql.Int.SerializeE("one"); val != nil || err != nil {
+		t.Fatalf("expected a non-numeric value to serialize to null, got %v, %v", val, err)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return int64(3000000000), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ int }`,
+	})
+	expected := "Int cannot represent non 32-bit signed integer value: 3000000000"
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(result.Data, map[string]interface{}{"int": nil}) {
+		t.Fatalf("expected the field to be null, got: %v", result.Data)
+	}
+}
+
 func TestTypeSystem_Scalar_SerializesOutputFloat(t *testing.T) {
 	tests := []float64SerializationTest{
 		{int(1), 1.0},
@@ -1,5 +1,13 @@
 package graphql
 
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
 type SchemaConfig struct {
 	Query        *Object
 	Mutation     *Object
@@ -7,6 +15,20 @@ type SchemaConfig struct {
 	Types        []Type
 	Directives   []*Directive
 	Extensions   []Extension
+
+	// IntrospectAppliedDirectives exposes the directives applied to the
+	// elements of the schema, see ScalarConfig.AppliedDirectives, through the
+	// appliedDirectives extension fields of introspection, null otherwise.
+	IntrospectAppliedDirectives bool
+
+	// PruneUnreachableTypes hides the types of Types, which must be named
+	// types, not reachable from the root types from introspection, see
+	// Schema.UnreachableTypes. They remain in the type map of the schema.
+	PruneUnreachableTypes bool
+
+	// ErrorTypes maps the errors of the resolvers of fields of union types to
+	// object types of the unions, see ErrorTypes.
+	ErrorTypes *ErrorTypes
 }
 
 type TypeMap map[string]Type
@@ -41,12 +63,50 @@ type Schema struct {
 	implementations  map[string][]*Object
 	possibleTypeMap  map[string]map[string]bool
 	extensions       []Extension
+
+	// resolvers are the resolvers bound by BindResolvers, never modified in
+	// place as they may be shared with copies and variants of the schema.
+	resolvers ResolverMap
+
+	// inherited are the Resolve functions and argument default values the
+	// fields of the objects inherit from their interfaces, by object and field
+	// name, see inheritInterfaceDefaults. Like resolvers, never modified in
+	// place.
+	inherited map[string]map[string]*inheritedField
+
+	introspection *introspectionCache
+
+	// introspectAppliedDirectives see SchemaConfig.IntrospectAppliedDirectives.
+	introspectAppliedDirectives bool
+
+	// pruneUnreachableTypes see SchemaConfig.PruneUnreachableTypes.
+	pruneUnreachableTypes bool
+
+	// prunedTypes are the introspected types when pruneUnreachableTypes is
+	// set, computed along with the implementations, see introspectedTypes.
+	prunedTypes TypeMap
+
+	// errorTypes see SchemaConfig.ErrorTypes.
+	errorTypes *ErrorTypes
+
+	// plans are the plans of the persisted operations compiled by Warmup.
+	plans *planCache
+
+	// guard serializes the mutations of the schema, see Freeze.
+	guard *schemaGuard
 }
 
 func NewSchema(config SchemaConfig) (Schema, error) {
+	return newSchema(config, nil)
+}
+
+// newSchema builds the schema of the config, timing the phases of the build
+// in the report when it is not nil.
+func newSchema(config SchemaConfig, report *SchemaBuildReport) (Schema, error) {
 	var err error
+	start, phase := time.Now(), time.Now()
 
-	schema := Schema{}
+	schema := Schema{introspection: newIntrospectionCache(), plans: newPlanCache(), guard: &schemaGuard{}}
 
 	if err = invariant(config.Query != nil, "Schema query must be Object Type but got: nil."); err != nil {
 		return schema, err
@@ -63,6 +123,9 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	schema.queryType = config.Query
 	schema.mutationType = config.Mutation
 	schema.subscriptionType = config.Subscription
+	schema.introspectAppliedDirectives = config.IntrospectAppliedDirectives
+	schema.pruneUnreachableTypes = config.PruneUnreachableTypes
+	schema.errorTypes = config.ErrorTypes
 
 	// Provide specified directives (e.g. @include and @skip) by default.
 	schema.directives = config.Directives
@@ -92,7 +155,14 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		initialTypes = append(initialTypes, SchemaType)
 	}
 
-	// assume that user will never add a nil object to config
+	for _, ttype := range config.Types {
+		if err = invariantf(
+			IsNamedType(ttype),
+			`Schema types must be named types but got: %v.`, ttype,
+		); err != nil {
+			return schema, err
+		}
+	}
 	initialTypes = append(initialTypes, config.Types...)
 
 	for _, ttype := range initialTypes {
@@ -105,6 +175,9 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	}
 
 	schema.typeMap = typeMap
+	if report != nil {
+		report.TypeCollection, phase = time.Since(phase), time.Now()
+	}
 
 	// Keep track of all implementations by interface name.
 	if schema.implementations == nil {
@@ -123,7 +196,12 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	if report != nil {
+		report.Implementations, phase = time.Since(phase), time.Now()
+	}
+
 	// Enforce correct interface implementations
+	inherited := map[string]map[string]*inheritedField{}
 	for _, ttype := range schema.typeMap {
 		if ttype, ok := ttype.(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
@@ -131,26 +209,47 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 				if err != nil {
 					return schema, err
 				}
+				inheritInterfaceDefaults(inherited, ttype, iface)
 			}
 		}
 	}
+	schema.inherited = inherited
+	schema.prunedTypes = schema.pruneTypes()
+	if report != nil {
+		report.Assertions = time.Since(phase)
+	}
 
 	// Add extensions from config
 	if len(config.Extensions) != 0 {
 		schema.extensions = config.Extensions
 	}
 
+	if report != nil {
+		report.collect(&schema, config)
+		report.Total = time.Since(start)
+	}
 	return schema, nil
 }
 
 //Added Check implementation of interfaces at runtime..
 //Add Implementations at Runtime..
 func (gq *Schema) AddImplementation() error {
-
-	// Keep track of all implementations by interface name.
-	if gq.implementations == nil {
-		gq.implementations = map[string][]*Object{}
+	if err := gq.guard.lock("add the implementations"); err != nil {
+		return err
 	}
+	defer gq.guard.unlock()
+	return gq.addImplementation()
+}
+
+func (gq *Schema) addImplementation() error {
+	gq.ensureOwnTypeMap()
+	gq.introspection.invalidate()
+	gq.plans.invalidate()
+
+	// Keep track of all implementations by interface name, from scratch as
+	// types may have been added since, and reset the possible types cache.
+	gq.implementations = map[string][]*Object{}
+	gq.possibleTypeMap = nil
 	for _, ttype := range gq.typeMap {
 		if ttype, ok := ttype.(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
@@ -165,6 +264,7 @@ func (gq *Schema) AddImplementation() error {
 	}
 
 	// Enforce correct interface implementations
+	inherited := map[string]map[string]*inheritedField{}
 	for _, ttype := range gq.typeMap {
 		if ttype, ok := ttype.(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
@@ -172,9 +272,12 @@ func (gq *Schema) AddImplementation() error {
 				if err != nil {
 					return err
 				}
+				inheritInterfaceDefaults(inherited, ttype, iface)
 			}
 		}
 	}
+	gq.inherited = inherited
+	gq.prunedTypes = gq.pruneTypes()
 
 	return nil
 }
@@ -185,13 +288,44 @@ func (gq *Schema) AppendType(objectType Type) error {
 	if objectType.Error() != nil {
 		return objectType.Error()
 	}
+	if err := gq.guard.lock(fmt.Sprintf(`append the type "%v"`, objectType.Name())); err != nil {
+		return err
+	}
+	defer gq.guard.unlock()
+	gq.ensureOwnTypeMap()
 	var err error
 	gq.typeMap, err = typeMapReducer(gq, gq.typeMap, objectType)
 	if err != nil {
 		return err
 	}
 	//Now Add interface implementation..
-	return gq.AddImplementation()
+	return gq.addImplementation()
+}
+
+// ExtendUnion applies an `extend union X = Y` definition parsed from SDL,
+// adding the object types of the schema it names to the union X.
+func (gq *Schema) ExtendUnion(def *ast.UnionExtensionDefinition) error {
+	if def == nil || def.Definition == nil || def.Definition.Name == nil {
+		return nil
+	}
+	if err := gq.guard.lock(fmt.Sprintf(`extend "%v"`, def.Definition.Name.Value)); err != nil {
+		return err
+	}
+	defer gq.guard.unlock()
+	union, ok := gq.Type(def.Definition.Name.Value).(*Union)
+	if err := invariantf(ok, `Cannot extend "%v", it is not a union type of the schema.`, def.Definition.Name.Value); err != nil {
+		return err
+	}
+	for _, member := range def.Definition.Types {
+		object, ok := gq.Type(member.Name.Value).(*Object)
+		if err := invariantf(ok, `Cannot add "%v" to %v, it is not an object type of the schema.`, member.Name.Value, union); err != nil {
+			return err
+		}
+		if err := union.AppendType(object); err != nil {
+			return err
+		}
+	}
+	return gq.addImplementation()
 }
 
 func (gq *Schema) QueryType() *Object {
@@ -227,6 +361,58 @@ func (gq *Schema) Type(name string) Type {
 	return gq.TypeMap()[name]
 }
 
+// UnreachableTypes returns the types of the schema which are not reachable
+// from its root types, through the fields, their arguments, the interfaces
+// and the implementations of the interfaces, sorted by name: the types given
+// in SchemaConfig.Types which are not used by the schema. The types added
+// by introspection are not returned.
+func (gq *Schema) UnreachableTypes() []Type {
+	roots := []Type{}
+	for _, root := range []*Object{gq.QueryType(), gq.MutationType(), gq.SubscriptionType(), SchemaType} {
+		if root != nil {
+			roots = append(roots, root)
+		}
+	}
+	reachable := reachableTypes(gq, roots)
+	names := []string{}
+	for name := range gq.TypeMap() {
+		if !reachable[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	types := make([]Type, 0, len(names))
+	for _, name := range names {
+		types = append(types, gq.Type(name))
+	}
+	return types
+}
+
+// introspectedTypes returns the types of the schema exposed by
+// introspection, by name.
+func (gq *Schema) introspectedTypes() TypeMap {
+	if !gq.pruneUnreachableTypes {
+		return gq.TypeMap()
+	}
+	return gq.prunedTypes
+}
+
+// pruneTypes returns the types of the schema without its unreachable types
+// when pruneUnreachableTypes is set, nil otherwise.
+func (gq *Schema) pruneTypes() TypeMap {
+	if !gq.pruneUnreachableTypes {
+		return nil
+	}
+	typeMap := TypeMap{}
+	for name, ttype := range gq.TypeMap() {
+		typeMap[name] = ttype
+	}
+	for _, ttype := range gq.UnreachableTypes() {
+		delete(typeMap, ttype.Name())
+	}
+	return typeMap
+}
+
 func (gq *Schema) PossibleTypes(abstractType Abstract) []*Object {
 	switch abstractType := abstractType.(type) {
 	case *Union:
@@ -262,6 +448,12 @@ func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bo
 
 // AddExtensions can be used to add additional extensions to the schema
 func (gq *Schema) AddExtensions(e ...Extension) {
+	if err := gq.guard.lock("add extensions"); err != nil {
+		panic(err)
+	}
+	defer gq.guard.unlock()
+	gq.introspection.invalidate()
+	gq.plans.invalidate()
 	gq.extensions = append(gq.extensions, e...)
 }
 
@@ -376,12 +568,74 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 	return typeMap, nil
 }
 
+// inheritedField is the Resolve function and the arguments a field of an
+// object inherits from the field of an interface.
+type inheritedField struct {
+	resolve FieldResolveFn
+
+	// args are the arguments of the field, copies of them with the default
+	// values of the interface, nil when the field inherits none.
+	args []*Argument
+}
+
+// inheritInterfaceDefaults records the Resolve function and the argument
+// default values of the fields of the interface which the fields of the
+// object do not define, leaving the fields themselves untouched as they may
+// belong to other schemas.
+func inheritInterfaceDefaults(inherited map[string]map[string]*inheritedField, object *Object, iface *Interface) {
+	objectFieldMap := object.Fields()
+	for fieldName, ifaceField := range iface.Fields() {
+		objectField := objectFieldMap[fieldName]
+		if objectField == nil {
+			continue
+		}
+		field := inherited[object.Name()][fieldName]
+		if field == nil {
+			field = &inheritedField{}
+		}
+		if objectField.Resolve == nil && field.resolve == nil {
+			field.resolve = ifaceField.Resolve
+		}
+		for _, ifaceArg := range ifaceField.Args {
+			if ifaceArg.DefaultValue == nil {
+				continue
+			}
+			for i, arg := range objectField.Args {
+				if arg.PrivateName != ifaceArg.PrivateName || arg.DefaultValue != nil {
+					continue
+				}
+				if field.args == nil {
+					field.args = append([]*Argument{}, objectField.Args...)
+				}
+				if field.args[i].DefaultValue == nil {
+					inheritedArg := *arg
+					inheritedArg.DefaultValue = ifaceArg.DefaultValue
+					field.args[i] = &inheritedArg
+				}
+			}
+		}
+		if field.resolve == nil && field.args == nil {
+			continue
+		}
+		if inherited[object.Name()] == nil {
+			inherited[object.Name()] = map[string]*inheritedField{}
+		}
+		inherited[object.Name()][fieldName] = field
+	}
+}
+
 func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Interface) error {
 	objectFieldMap := object.Fields()
 	ifaceFieldMap := iface.Fields()
 
-	// Assert each interface field is implemented.
+	// Assert each interface field is implemented, in order for the first
+	// error to be the same across builds.
+	fieldNames := make([]string, 0, len(ifaceFieldMap))
 	for fieldName := range ifaceFieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
 		objectField := objectFieldMap[fieldName]
 		ifaceField := ifaceFieldMap[fieldName]
 
@@ -398,7 +652,7 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 		// Assert interface field type is satisfied by object field type, by being
 		// a valid subtype. (covariant)
 		err = invariantf(
-			isTypeSubTypeOf(schema, objectField.Type, ifaceField.Type),
+			IsTypeSubTypeOf(schema, objectField.Type, ifaceField.Type),
 			`%v.%v expects type "%v" but `+
 				`%v.%v provides type "%v".`,
 			iface, fieldName, ifaceField.Type,
@@ -409,7 +663,7 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 		}
 
 		// Assert each interface field arg is implemented.
-		for _, ifaceArg := range ifaceField.Args {
+		for _, ifaceArg := range sortedArguments(ifaceField.Args) {
 			argName := ifaceArg.PrivateName
 			var objectArg *Argument
 			for _, arg := range objectField.Args {
@@ -433,7 +687,7 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 			// Assert interface field arg type matches object field arg type.
 			// (invariant)
 			err = invariantf(
-				isEqualType(ifaceArg.Type, objectArg.Type),
+				IsEqualType(ifaceArg.Type, objectArg.Type),
 				`%v.%v(%v:) expects type "%v" `+
 					`but %v.%v(%v:) provides `+
 					`type "%v".`,
@@ -444,8 +698,10 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 				return err
 			}
 		}
-		// Assert additional arguments must not be required.
-		for _, objectArg := range objectField.Args {
+		// Assert additional arguments must not be required: they are nullable
+		// or have a default value, as the interface field is selected without
+		// them.
+		for _, objectArg := range sortedArguments(objectField.Args) {
 			argName := objectArg.PrivateName
 			var ifaceArg *Argument
 			for _, arg := range ifaceField.Args {
@@ -458,7 +714,7 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 			if ifaceArg == nil {
 				_, ok := objectArg.Type.(*NonNull)
 				err = invariantf(
-					!ok,
+					!ok || objectArg.DefaultValue != nil,
 					`%v.%v(%v:) is of required type `+
 						`"%v" but is not also provided by the interface %v.%v.`,
 					object, fieldName, argName,
@@ -473,7 +729,16 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 	return nil
 }
 
-func isEqualType(typeA Type, typeB Type) bool {
+// sortedArguments returns a copy of the arguments sorted by name.
+func sortedArguments(args []*Argument) []*Argument {
+	sorted := append([]*Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PrivateName < sorted[j].PrivateName })
+	return sorted
+}
+
+// IsEqualType returns true if both types are the same named type, or are
+// lists or non-null wrappers of equal types.
+func IsEqualType(typeA Type, typeB Type) bool {
 	// Equivalent type is a valid subtype
 	if typeA == typeB {
 		return true
@@ -481,22 +746,23 @@ func isEqualType(typeA Type, typeB Type) bool {
 	// If either type is non-null, the other must also be non-null.
 	if typeA, ok := typeA.(*NonNull); ok {
 		if typeB, ok := typeB.(*NonNull); ok {
-			return isEqualType(typeA.OfType, typeB.OfType)
+			return IsEqualType(typeA.OfType, typeB.OfType)
 		}
 	}
 	// If either type is a list, the other must also be a list.
 	if typeA, ok := typeA.(*List); ok {
 		if typeB, ok := typeB.(*List); ok {
-			return isEqualType(typeA.OfType, typeB.OfType)
+			return IsEqualType(typeA.OfType, typeB.OfType)
 		}
 	}
 	// Otherwise the types are not equal.
 	return false
 }
 
-// isTypeSubTypeOf Provided a type and a super type, return true if the first type is either
-// equal or a subset of the second super type (covariant).
-func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
+// IsTypeSubTypeOf Provided a type and a super type, return true if the first type is either
+// equal or a subset of the second super type (covariant). The possible types of
+// the abstract types are those of the given schema.
+func IsTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 	// Equivalent type is a valid subtype
 	if maybeSubType == superType {
 		return true
@@ -505,19 +771,19 @@ func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 	// If superType is non-null, maybeSubType must also be nullable.
 	if superType, ok := superType.(*NonNull); ok {
 		if maybeSubType, ok := maybeSubType.(*NonNull); ok {
-			return isTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
+			return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
 		}
 		return false
 	}
 	if maybeSubType, ok := maybeSubType.(*NonNull); ok {
 		// If superType is nullable, maybeSubType may be non-null.
-		return isTypeSubTypeOf(schema, maybeSubType.OfType, superType)
+		return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType)
 	}
 
 	// If superType type is a list, maybeSubType type must also be a list.
 	if superType, ok := superType.(*List); ok {
 		if maybeSubType, ok := maybeSubType.(*List); ok {
-			return isTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
+			return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
 		}
 		return false
 	} else if _, ok := maybeSubType.(*List); ok {
@@ -541,3 +807,52 @@ func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 	// Otherwise, the child type is not a valid subtype of the parent type.
 	return false
 }
+
+// reachableTypes returns the names of the named types reachable from the
+// given types through the fields, their arguments, the interfaces and the
+// possible types of the schema.
+func reachableTypes(schema *Schema, types []Type) map[string]bool {
+	reachable := map[string]bool{}
+	var visit func(ttype Type)
+	visitField := func(field *FieldDefinition) {
+		for _, arg := range field.Args {
+			visit(arg.Type)
+		}
+		visit(field.Type)
+	}
+	visit = func(ttype Type) {
+		named, ok := GetNamed(ttype).(Type)
+		if !ok || reachable[named.Name()] {
+			return
+		}
+		reachable[named.Name()] = true
+		switch named := named.(type) {
+		case *Object:
+			for _, iface := range named.Interfaces() {
+				visit(iface)
+			}
+			for _, field := range named.Fields() {
+				visitField(field)
+			}
+		case *Interface:
+			for _, object := range schema.PossibleTypes(named) {
+				visit(object)
+			}
+			for _, field := range named.Fields() {
+				visitField(field)
+			}
+		case *Union:
+			for _, object := range schema.PossibleTypes(named) {
+				visit(object)
+			}
+		case *InputObject:
+			for _, field := range named.Fields() {
+				visit(field.Type)
+			}
+		}
+	}
+	for _, ttype := range types {
+		visit(ttype)
+	}
+	return reachable
+}
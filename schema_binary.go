@@ -0,0 +1,512 @@
+//go:build !tinygo
+
+package graphql
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// schemaBinaryHeader starts the binary snapshots of schemas, followed by the
+// version of their format.
+const schemaBinaryHeader = "GQLS\x01"
+
+// MarshalBinary returns a compact binary snapshot of the structure of the
+// schema, to be cached, e.g. on disk, and loaded with UnmarshalBinary much
+// faster than the schema is built from SDL or introspection.
+//
+// The snapshot holds the types, fields, arguments, default values, enum
+// values, descriptions, deprecations and directives of the schema, not its
+// behavior: resolvers, custom scalar functions, internal enum values,
+// extensions and applied directives are lost, see UnmarshalBinary.
+func (gq *Schema) MarshalBinary() ([]byte, error) {
+	snapshot := schemaSnapshot{}
+	if gq.QueryType() != nil {
+		snapshot.Query = gq.QueryType().Name()
+	}
+	if gq.MutationType() != nil {
+		snapshot.Mutation = gq.MutationType().Name()
+	}
+	if gq.SubscriptionType() != nil {
+		snapshot.Subscription = gq.SubscriptionType().Name()
+	}
+
+	names := []string{}
+	for name := range gq.TypeMap() {
+		if !strings.HasPrefix(name, "__") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typeSnapshot, err := snapshotType(gq.Type(name))
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Types = append(snapshot.Types, typeSnapshot)
+	}
+	for _, directive := range gq.Directives() {
+		directiveSnapshot := directiveSnapshot{Name: directive.Name}
+		if specifiedDirective(directive.Name) != directive {
+			directiveSnapshot.Description = directive.Description
+			directiveSnapshot.Locations = directive.Locations
+			directiveSnapshot.Repeatable = directive.Repeatable
+			directiveSnapshot.Args = snapshotArgs(directive.Args)
+		} else {
+			directiveSnapshot.Specified = true
+		}
+		snapshot.Directives = append(snapshot.Directives, directiveSnapshot)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(schemaBinaryHeader)
+	if err := gob.NewEncoder(&buf).Encode(&snapshot); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the schema with the schema of a snapshot returned by
+// MarshalBinary.
+//
+// The loaded schema has no resolvers, bind them with Schema.BindResolvers. Its
+// custom scalars serialize and parse their values as is, its enum values have
+// their name as internal value, and its abstract types resolve the type of
+// Typed values, like the schemas built by BuildSchema. The specified scalars
+// and directives are those of this package.
+func (gq *Schema) UnmarshalBinary(data []byte) error {
+	if gq.IsFrozen() {
+		return newFrozenError("Cannot unmarshal a snapshot into a frozen schema.")
+	}
+	if !bytes.HasPrefix(data, []byte(schemaBinaryHeader)) {
+		return errors.New("Invalid schema snapshot: unknown format or version.")
+	}
+	snapshot := schemaSnapshot{}
+	if err := gob.NewDecoder(bytes.NewReader(data[len(schemaBinaryHeader):])).Decode(&snapshot); err != nil {
+		return fmt.Errorf("Invalid schema snapshot: %v", err)
+	}
+
+	l := &snapshotLoader{types: map[string]Type{}, snapshots: map[string]*typeSnapshot{}}
+	for i := range snapshot.Types {
+		l.snapshots[snapshot.Types[i].Name] = &snapshot.Types[i]
+	}
+	config := SchemaConfig{}
+	for _, root := range []struct {
+		name string
+		root **Object
+	}{
+		{snapshot.Query, &config.Query},
+		{snapshot.Mutation, &config.Mutation},
+		{snapshot.Subscription, &config.Subscription},
+	} {
+		if root.name != "" {
+			*root.root, _ = l.namedType(root.name).(*Object)
+		}
+	}
+	for _, typeSnapshot := range snapshot.Types {
+		config.Types = append(config.Types, l.namedType(typeSnapshot.Name))
+	}
+	for _, directiveSnapshot := range snapshot.Directives {
+		if directiveSnapshot.Specified {
+			config.Directives = append(config.Directives, specifiedDirective(directiveSnapshot.Name))
+			continue
+		}
+		config.Directives = append(config.Directives, NewDirective(DirectiveConfig{
+			Name:        directiveSnapshot.Name,
+			Description: directiveSnapshot.Description,
+			Locations:   directiveSnapshot.Locations,
+			Repeatable:  directiveSnapshot.Repeatable,
+			Args:        l.args(directiveSnapshot.Args),
+		}))
+	}
+	if l.err != nil {
+		return l.err
+	}
+
+	schema, err := NewSchema(config)
+	if l.err != nil {
+		return l.err
+	}
+	if err != nil {
+		return err
+	}
+	*gq = schema
+	return nil
+}
+
+// The kinds of the types of a snapshot.
+const (
+	snapshotScalar = iota
+	snapshotObject
+	snapshotInterface
+	snapshotUnion
+	snapshotEnum
+	snapshotInputObject
+)
+
+type schemaSnapshot struct {
+	Query        string
+	Mutation     string
+	Subscription string
+	Types        []typeSnapshot
+	Directives   []directiveSnapshot
+}
+
+// typeSnapshot is a named type. Specified scalars are snapshotted by name, and
+// the types of fields and arguments by reference, e.g. `[String!]`.
+type typeSnapshot struct {
+	Kind        int
+	Name        string
+	Description string
+	Specified   bool
+	Interfaces  []string
+	Fields      []fieldSnapshot
+	InputFields []argSnapshot
+	Types       []string
+	Values      []enumValueSnapshot
+}
+
+type fieldSnapshot struct {
+	Name              string
+	Description       string
+	Type              string
+	Args              []argSnapshot
+	DeprecationReason string
+}
+
+// argSnapshot is an argument or an input field, with its default value as a
+// printed literal.
+type argSnapshot struct {
+	Name              string
+	Description       string
+	Type              string
+	DefaultValue      string
+	DeprecationReason string
+}
+
+type enumValueSnapshot struct {
+	Name              string
+	Description       string
+	DeprecationReason string
+}
+
+type directiveSnapshot struct {
+	Name        string
+	Specified   bool
+	Description string
+	Locations   []string
+	Repeatable  bool
+	Args        []argSnapshot
+}
+
+// specifiedScalars are the scalars of this package, snapshotted by name.
+var specifiedScalars = map[string]*Scalar{
+	Int.Name():      Int,
+	Float.Name():    Float,
+	String.Name():   String,
+	Boolean.Name():  Boolean,
+	ID.Name():       ID,
+	DateTime.Name(): DateTime,
+}
+
+func specifiedDirective(name string) *Directive {
+	for _, directive := range SpecifiedDirectives {
+		if directive.Name == name {
+			return directive
+		}
+	}
+	return nil
+}
+
+func snapshotType(ttype Type) (typeSnapshot, error) {
+	snapshot := typeSnapshot{Name: ttype.Name(), Description: ttype.Description()}
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		snapshot.Kind = snapshotScalar
+		snapshot.Specified = specifiedScalars[ttype.Name()] == ttype
+	case *Object:
+		snapshot.Kind = snapshotObject
+		for _, iface := range ttype.Interfaces() {
+			snapshot.Interfaces = append(snapshot.Interfaces, iface.Name())
+		}
+		snapshot.Fields = snapshotFields(ttype.Fields())
+	case *Interface:
+		snapshot.Kind = snapshotInterface
+		snapshot.Fields = snapshotFields(ttype.Fields())
+	case *Union:
+		snapshot.Kind = snapshotUnion
+		for _, object := range ttype.Types() {
+			snapshot.Types = append(snapshot.Types, object.Name())
+		}
+	case *Enum:
+		snapshot.Kind = snapshotEnum
+		for _, value := range ttype.Values() {
+			snapshot.Values = append(snapshot.Values, enumValueSnapshot{
+				Name:              value.Name,
+				Description:       value.Description,
+				DeprecationReason: value.DeprecationReason,
+			})
+		}
+	case *InputObject:
+		snapshot.Kind = snapshotInputObject
+		fields := ttype.Fields()
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := fields[name]
+			snapshot.InputFields = append(snapshot.InputFields, argSnapshot{
+				Name:         name,
+				Description:  field.Description(),
+				Type:         field.Type.String(),
+				DefaultValue: printDefaultLiteral(field.DefaultLiteral()),
+			})
+		}
+	default:
+		return snapshot, fmt.Errorf(`Cannot snapshot the type "%v".`, ttype)
+	}
+	return snapshot, nil
+}
+
+func snapshotFields(fields FieldDefinitionMap) []fieldSnapshot {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	snapshots := make([]fieldSnapshot, 0, len(names))
+	for _, name := range names {
+		field := fields[name]
+		snapshots = append(snapshots, fieldSnapshot{
+			Name:              name,
+			Description:       field.Description,
+			Type:              field.Type.String(),
+			Args:              snapshotArgs(field.Args),
+			DeprecationReason: field.DeprecationReason,
+		})
+	}
+	return snapshots
+}
+
+func snapshotArgs(args []*Argument) []argSnapshot {
+	snapshots := make([]argSnapshot, 0, len(args))
+	for _, arg := range args {
+		snapshots = append(snapshots, argSnapshot{
+			Name:              arg.Name(),
+			Description:       arg.Description(),
+			Type:              arg.Type.String(),
+			DefaultValue:      printDefaultLiteral(arg.DefaultLiteral()),
+			DeprecationReason: arg.DeprecationReason,
+		})
+	}
+	return snapshots
+}
+
+func printDefaultLiteral(literal ast.Value) string {
+	if literal == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", printer.Print(literal))
+}
+
+// snapshotLoader builds the types of a snapshot on demand, the fields of the
+// types being thunks so the types may reference each other.
+type snapshotLoader struct {
+	types     map[string]Type
+	snapshots map[string]*typeSnapshot
+
+	// err is the first error of the load, the thunks of the types cannot
+	// return theirs.
+	err error
+}
+
+func (l *snapshotLoader) fail(format string, a ...interface{}) {
+	if l.err == nil {
+		l.err = fmt.Errorf("Invalid schema snapshot: "+format, a...)
+	}
+}
+
+func (l *snapshotLoader) namedType(name string) Type {
+	if ttype, ok := l.types[name]; ok {
+		return ttype
+	}
+	snapshot, ok := l.snapshots[name]
+	if !ok {
+		l.fail(`unknown type "%v".`, name)
+		return nil
+	}
+	var ttype Type
+	switch snapshot.Kind {
+	case snapshotScalar:
+		if scalar := specifiedScalars[name]; snapshot.Specified && scalar != nil {
+			ttype = scalar
+			break
+		}
+		ttype = NewScalar(ScalarConfig{
+			Name:         name,
+			Description:  snapshot.Description,
+			Serialize:    func(value interface{}) interface{} { return value },
+			ParseValue:   func(value interface{}) interface{} { return value },
+			ParseLiteral: untypedLiteralValue,
+		})
+	case snapshotObject:
+		ttype = NewObject(ObjectConfig{
+			Name:        name,
+			Description: snapshot.Description,
+			Interfaces: (InterfacesThunk)(func() []*Interface {
+				interfaces := []*Interface{}
+				for _, ifaceName := range snapshot.Interfaces {
+					if iface, ok := l.namedType(ifaceName).(*Interface); ok {
+						interfaces = append(interfaces, iface)
+					} else {
+						l.fail(`"%v" is not an interface.`, ifaceName)
+					}
+				}
+				return interfaces
+			}),
+			Fields: (FieldsThunk)(func() Fields {
+				return l.fields(snapshot.Fields)
+			}),
+		})
+	case snapshotInterface:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: snapshot.Description,
+			Fields: (FieldsThunk)(func() Fields {
+				return l.fields(snapshot.Fields)
+			}),
+		})
+	case snapshotUnion:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: snapshot.Description,
+			TypedValues: true,
+			Types: (UnionTypesThunk)(func() []*Object {
+				objects := []*Object{}
+				for _, objectName := range snapshot.Types {
+					if object, ok := l.namedType(objectName).(*Object); ok {
+						objects = append(objects, object)
+					} else {
+						l.fail(`"%v" is not an object.`, objectName)
+					}
+				}
+				return objects
+			}),
+		})
+	case snapshotEnum:
+		values := EnumValueConfigMap{}
+		for _, value := range snapshot.Values {
+			values[value.Name] = &EnumValueConfig{
+				Value:             value.Name,
+				Description:       value.Description,
+				DeprecationReason: value.DeprecationReason,
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: snapshot.Description,
+			Values:      values,
+		})
+	case snapshotInputObject:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: snapshot.Description,
+			Fields: (InputObjectConfigFieldMapThunk)(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for _, field := range snapshot.InputFields {
+					fields[field.Name] = &InputObjectFieldConfig{
+						Type:         l.inputType(field.Type),
+						DefaultValue: l.defaultValue(field.DefaultValue),
+						Description:  field.Description,
+					}
+				}
+				return fields
+			}),
+		})
+	default:
+		l.fail(`unknown kind %v of type "%v".`, snapshot.Kind, name)
+		return nil
+	}
+	l.types[name] = ttype
+	return ttype
+}
+
+func (l *snapshotLoader) fields(snapshots []fieldSnapshot) Fields {
+	fields := Fields{}
+	for _, field := range snapshots {
+		ttype, _ := l.typeRef(field.Type).(Output)
+		if ttype == nil || !IsOutputType(ttype) {
+			l.fail(`"%v" is not an output type.`, field.Type)
+		}
+		fields[field.Name] = &Field{
+			Name:              field.Name,
+			Type:              ttype,
+			Args:              l.args(field.Args),
+			Description:       field.Description,
+			DeprecationReason: field.DeprecationReason,
+		}
+	}
+	return fields
+}
+
+func (l *snapshotLoader) args(snapshots []argSnapshot) FieldConfigArgument {
+	args := FieldConfigArgument{}
+	for _, arg := range snapshots {
+		args[arg.Name] = &ArgumentConfig{
+			Type:              l.inputType(arg.Type),
+			DefaultValue:      l.defaultValue(arg.DefaultValue),
+			Description:       arg.Description,
+			DeprecationReason: arg.DeprecationReason,
+		}
+	}
+	return args
+}
+
+func (l *snapshotLoader) inputType(ref string) Input {
+	ttype, _ := l.typeRef(ref).(Input)
+	if ttype == nil || !IsInputType(ttype) {
+		l.fail(`"%v" is not an input type.`, ref)
+	}
+	return ttype
+}
+
+// typeRef returns the type of a reference such as `[String!]`.
+func (l *snapshotLoader) typeRef(ref string) Type {
+	if strings.HasSuffix(ref, "!") {
+		if ofType := l.typeRef(ref[:len(ref)-1]); ofType != nil {
+			return NewNonNull(ofType)
+		}
+		return nil
+	}
+	if strings.HasPrefix(ref, "[") && strings.HasSuffix(ref, "]") {
+		if ofType := l.typeRef(ref[1 : len(ref)-1]); ofType != nil {
+			return NewList(ofType)
+		}
+		return nil
+	}
+	return l.namedType(ref)
+}
+
+// defaultValue returns the default value literal of a snapshot, default values
+// being coerced from literals.
+func (l *snapshotLoader) defaultValue(literal string) interface{} {
+	if literal == "" {
+		return nil
+	}
+	value, err := parser.ParseValue(parser.ParseParams{Source: literal})
+	if err != nil {
+		l.fail(`invalid default value %v.`, literal)
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+//go:build !tinygo
+
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestSchema_MarshalBinary(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+		"A node."
+		interface Node { id: ID! }
+		type User implements Node {
+		  id: ID!
+		  name(format: Format = SHORT): String @deprecated(reason: "Use fullName.")
+		  fullName: String
+		  tags: [String!]!
+		}
+		type Bot implements Node { id: ID! }
+		union Actor = User | Bot
+		enum Format { SHORT LONG @deprecated }
+		input Filter { ids: [ID!] = ["1"], format: Format = LONG }
+		scalar JSON
+		directive @cost(weight: Int! = 1) repeatable on FIELD_DEFINITION | OBJECT
+		type Query {
+		  actors(filter: Filter): [Actor]
+		  node(id: ID!): Node
+		  config: JSON
+		}
+		type Mutation { reset: Boolean }
+	`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := schema.AppendType(graphql.DateTime); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := schema.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded := graphql.Schema{}
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected, printed := graphql.PrintSchema(&schema), graphql.PrintSchema(&loaded); expected != printed {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, printed))
+	}
+	if loaded.Type("DateTime") != graphql.DateTime || loaded.Directive("deprecated") != graphql.DeprecatedDirective {
+		t.Fatalf("expected the specified scalars and directives of the package")
+	}
+
+	err = loaded.BindResolversWithConfig(graphql.BindResolversConfig{AllowMissing: true, Resolvers: graphql.ResolverMap{
+		"Query": {
+			"actors": func(p graphql.ResolveParams) (interface{}, error) {
+				filter := p.Args["filter"].(map[string]interface{})
+				return []interface{}{
+					graphql.Typed{TypeName: "User", Value: map[string]interface{}{"fullName": filter["format"], "tags": filter["ids"]}},
+				}, nil
+			},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        loaded,
+		RequestString: `{ actors(filter: {}) { ... on User { fullName tags } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"actors": []interface{}{
+				map[string]interface{}{"fullName": "LONG", "tags": []interface{}{"1"}},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchema_UnmarshalBinaryRejectsUnknownData(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("type Query { a: String }"), []byte("GQLS\x01garbage")} {
+		schema := graphql.Schema{}
+		if err := schema.UnmarshalBinary(data); err == nil || !strings.HasPrefix(err.Error(), "Invalid schema snapshot") {
+			t.Fatalf("expected an invalid snapshot error for %q, got %v", data, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaBuildReport describes the build of a schema by NewSchemaWithReport:
+// the size of the schema, the time spent in each phase of the build, and the
+// types which were not declared the way they are used.
+type SchemaBuildReport struct {
+	// Types, Fields, InputFields, Arguments and Directives count the named
+	// types of the schema, the fields of its objects and interfaces, the
+	// fields of its input objects, the arguments of its fields and its
+	// directives, the introspection types excluded.
+	Types       int
+	Fields      int
+	InputFields int
+	Arguments   int
+	Directives  int
+
+	// TypeCollection is the time spent collecting the types reachable from
+	// the root types and the types of the config, Implementations the time
+	// spent indexing the implementations of the interfaces, Assertions the
+	// time spent checking that the objects implement their interfaces, and
+	// Total the time spent building the schema.
+	TypeCollection  time.Duration
+	Implementations time.Duration
+	Assertions      time.Duration
+	Total           time.Duration
+
+	// OrphanTypes are the names of the types of SchemaConfig.Types which are
+	// not reachable from the root types, neither through the fields nor as
+	// the implementations of the interfaces, sorted.
+	OrphanTypes []string
+
+	// ImplicitTypes are the names of the types added to the schema which are
+	// not reachable from the root types nor declared in SchemaConfig.Types,
+	// such as the scalars of the introspection types, sorted.
+	ImplicitTypes []string
+}
+
+// NewSchemaWithReport creates a schema like NewSchema, along with the report
+// of its build. The report is returned with the phases completed when the
+// build fails.
+func NewSchemaWithReport(config SchemaConfig) (Schema, *SchemaBuildReport, error) {
+	report := &SchemaBuildReport{}
+	schema, err := newSchema(config, report)
+	return schema, report, err
+}
+
+// String summarizes the report on one line, e.g. to be logged.
+func (report *SchemaBuildReport) String() string {
+	summary := fmt.Sprintf(
+		"%d types, %d fields, %d input fields, %d arguments, %d directives built in %v "+
+			"(type collection %v, implementations %v, assertions %v)",
+		report.Types, report.Fields, report.InputFields, report.Arguments, report.Directives, report.Total,
+		report.TypeCollection, report.Implementations, report.Assertions,
+	)
+	if len(report.OrphanTypes) > 0 {
+		summary += fmt.Sprintf(", orphan types: %v", strings.Join(report.OrphanTypes, ", "))
+	}
+	if len(report.ImplicitTypes) > 0 {
+		summary += fmt.Sprintf(", implicit types: %v", strings.Join(report.ImplicitTypes, ", "))
+	}
+	return summary
+}
+
+// collect counts the elements of the built schema and detects its orphan and
+// implicit types.
+func (report *SchemaBuildReport) collect(schema *Schema, config SchemaConfig) {
+	report.Directives = len(schema.Directives())
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		report.Types++
+		switch ttype := ttype.(type) {
+		case *Object:
+			report.countFields(ttype.Fields())
+		case *Interface:
+			report.countFields(ttype.Fields())
+		case *InputObject:
+			report.InputFields += len(ttype.Fields())
+		}
+	}
+
+	roots := []Type{}
+	for _, root := range []*Object{schema.QueryType(), schema.MutationType(), schema.SubscriptionType()} {
+		if root != nil {
+			roots = append(roots, root)
+		}
+	}
+	reachable := reachableTypes(schema, roots)
+	for _, ttype := range config.Types {
+		if named, ok := GetNamed(ttype).(Type); ok && !reachable[named.Name()] {
+			report.OrphanTypes = append(report.OrphanTypes, named.Name())
+		}
+	}
+	declared := reachableTypes(schema, append(roots, config.Types...))
+	for name := range schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") && !declared[name] {
+			report.ImplicitTypes = append(report.ImplicitTypes, name)
+		}
+	}
+	sort.Strings(report.OrphanTypes)
+	sort.Strings(report.ImplicitTypes)
+}
+
+func (report *SchemaBuildReport) countFields(fields FieldDefinitionMap) {
+	report.Fields += len(fields)
+	for _, field := range fields {
+		report.Arguments += len(field.Args)
+	}
+}
@@ -0,0 +1,103 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestNewSchemaWithReport(t *testing.T) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.ID},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	legacy := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Legacy",
+		Fields: graphql.Fields{
+			"code": &graphql.Field{Type: graphql.Int},
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"ids": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.ID)},
+		},
+	})
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"nodes": &graphql.Field{
+				Type: graphql.NewList(node),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filter},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+			},
+		},
+	})
+	schema, report, err := graphql.NewSchemaWithReport(graphql.SchemaConfig{
+		Query: query,
+		Types: []graphql.Type{user, legacy},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Type("Legacy") == nil {
+		t.Fatalf("expected the orphan type in the schema")
+	}
+
+	// Query, Node, User, Legacy, Filter, ID, Int, String and Boolean
+	counts := []int{report.Types, report.Fields, report.InputFields, report.Arguments, report.Directives}
+	if expected := []int{9, 5, 1, 2, len(graphql.SpecifiedDirectives)}; !reflect.DeepEqual(expected, counts) {
+		t.Fatalf("expected counts %v, got %v", expected, counts)
+	}
+	if expected := []string{"Legacy"}; !reflect.DeepEqual(expected, report.OrphanTypes) {
+		t.Fatalf("expected orphan types %v, got %v", expected, report.OrphanTypes)
+	}
+	if expected := []string{"Boolean"}; !reflect.DeepEqual(expected, report.ImplicitTypes) {
+		t.Fatalf("expected implicit types %v, got %v", expected, report.ImplicitTypes)
+	}
+	if report.Total <= 0 || report.TypeCollection > report.Total {
+		t.Fatalf("unexpected timings: %v", report)
+	}
+	if summary := report.String(); !strings.HasPrefix(summary, "9 types, 5 fields, 1 input fields, 2 arguments") ||
+		!strings.HasSuffix(summary, "orphan types: Legacy, implicit types: Boolean") {
+		t.Fatalf("unexpected summary: %v", summary)
+	}
+}
+
+func TestNewSchemaWithReport_ReportsTheCompletedPhasesOnError(t *testing.T) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	_, report, err := graphql.NewSchemaWithReport(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Query",
+			Interfaces: []*graphql.Interface{node},
+			Fields: graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if report == nil || report.Total != 0 {
+		t.Fatalf("unexpected report: %v", report)
+	}
+}
@@ -0,0 +1,138 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSchemaFrozen is matched, see errors.Is, by the errors of the mutations of
+// a frozen schema and of its types, see Schema.Freeze.
+var ErrSchemaFrozen = errors.New("The schema is frozen.")
+
+// frozenError is the error of a mutation of a frozen schema or type.
+type frozenError struct {
+	message string
+}
+
+func newFrozenError(format string, args ...interface{}) *frozenError {
+	return &frozenError{message: fmt.Sprintf(format, args...)}
+}
+
+func (e *frozenError) Error() string {
+	return e.message
+}
+
+func (e *frozenError) Is(target error) bool {
+	return target == ErrSchemaFrozen
+}
+
+// schemaGuard serializes the mutations of a schema and of its copies, which
+// share its maps, and forbids them once the schema is frozen.
+type schemaGuard struct {
+	mu     sync.Mutex
+	frozen bool
+}
+
+// lock locks the guard for the mutation, failing when the schema is frozen.
+func (g *schemaGuard) lock(mutation string) error {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	if g.frozen {
+		g.mu.Unlock()
+		return newFrozenError("Cannot %v, the schema is frozen.", mutation)
+	}
+	return nil
+}
+
+func (g *schemaGuard) unlock() {
+	if g != nil {
+		g.mu.Unlock()
+	}
+}
+
+func (g *schemaGuard) isFrozen() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.frozen
+}
+
+// Freeze ends the build phase of the schema, once its types are added and its
+// resolvers bound, before it serves requests.
+//
+// Until then, the mutations of the schema and of its copies are serialized,
+// but not with the executions, which read the types while resolving their
+// thunks and filling their caches. Freeze resolves those thunks and fills
+// those caches ahead of time, so that the executions only read them, and
+// forbids the mutations from then on: AppendType, AddImplementation,
+// ExtendUnion, BindResolvers, Rebuild and UnmarshalBinary fail, and
+// AddExtensions panics, with an error matching ErrSchemaFrozen, as do the
+// mutations of the types of the schema, such as Object.AppendField or
+// Object.AddFieldConfig, even when they are shared with other schemas.
+//
+// Freeze fails when a thunk of the types fails, and does nothing when the
+// schema is already frozen. Variants of a frozen schema can still be
+// derived, see Variant, and are not frozen.
+func (gq *Schema) Freeze() error {
+	if gq.guard == nil {
+		gq.guard = &schemaGuard{}
+	}
+	if err := gq.guard.lock("freeze it"); err != nil {
+		// already frozen
+		return nil
+	}
+	defer gq.guard.unlock()
+
+	possibleTypeMap := map[string]map[string]bool{}
+	for _, ttype := range gq.typeMap {
+		switch ttype := ttype.(type) {
+		case *Object:
+			ttype.Fields()
+			ttype.Interfaces()
+		case *Interface:
+			ttype.Fields()
+		case *Union:
+			ttype.Types()
+		case *InputObject:
+			ttype.Fields()
+		case *Enum:
+			ttype.getNameLookup()
+			ttype.getValueLookup()
+		}
+		if err := ttype.Error(); err != nil {
+			return err
+		}
+		if abstractType, ok := ttype.(Abstract); ok {
+			possibleTypes := map[string]bool{}
+			for _, possibleType := range gq.PossibleTypes(abstractType) {
+				possibleTypes[possibleType.Name()] = true
+			}
+			possibleTypeMap[ttype.Name()] = possibleTypes
+		}
+	}
+	for _, ttype := range gq.typeMap {
+		switch ttype := ttype.(type) {
+		case *Object:
+			ttype.frozen = true
+		case *Interface:
+			ttype.frozen = true
+		case *Union:
+			ttype.frozen = true
+		case *InputObject:
+			ttype.frozen = true
+		}
+	}
+	gq.possibleTypeMap = possibleTypeMap
+	gq.guard.frozen = true
+	return nil
+}
+
+// IsFrozen reports whether the schema is frozen, see Freeze.
+func (gq *Schema) IsFrozen() bool {
+	return gq.guard.isFrozen()
+}
@@ -0,0 +1,174 @@
+package graphql_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func freezeTestSchema(t *testing.T) (graphql.Schema, *graphql.Object, *graphql.Union) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	var user *graphql.Object
+	user = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface { return []*graphql.Interface{node} }),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      &graphql.Field{Type: graphql.ID},
+				"friends": &graphql.Field{Type: graphql.NewList(user)},
+			}
+		}),
+	})
+	actor := graphql.NewUnion(graphql.UnionConfig{
+		Name:        "Actor",
+		Types:       graphql.UnionTypesThunk(func() []*graphql.Object { return []*graphql.Object{user} }),
+		TypedValues: true,
+	})
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type: node,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphql.Typed{TypeName: "User", Value: map[string]interface{}{"id": "1"}}, nil
+				},
+			},
+			"actor": &graphql.Field{
+				Type: actor,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphql.Typed{TypeName: "User", Value: map[string]interface{}{"id": "2"}}, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema, user, actor
+}
+
+func TestSchema_Freeze(t *testing.T) {
+	schema, user, actor := freezeTestSchema(t)
+	if err := schema.Freeze(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !schema.IsFrozen() {
+		t.Fatalf("expected the schema to be frozen")
+	}
+	if err := schema.Freeze(); err != nil {
+		t.Fatalf("expected freezing again to do nothing, got %v", err)
+	}
+
+	other := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Other",
+		Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+	})
+	// copies of the schema are frozen as well
+	copied := schema
+	for expected, mutate := range map[string]func() error{
+		`Cannot append the type "Other", the schema is frozen.`: func() error { return copied.AppendType(other) },
+		`Cannot add the implementations, the schema is frozen.`: schema.AddImplementation,
+		`Cannot bind resolvers, the schema is frozen.`:          func() error { return schema.BindResolvers(graphql.ResolverMap{}) },
+		`Cannot append the type "Other" to Actor, the schema is frozen.`: func() error {
+			return actor.AppendType(other)
+		},
+	} {
+		err := mutate()
+		if err == nil || err.Error() != expected || !errors.Is(err, graphql.ErrSchemaFrozen) {
+			t.Fatalf("expected error %q matching ErrSchemaFrozen, got %v", expected, err)
+		}
+	}
+
+	for expected, mutate := range map[string]func(){
+		`Cannot add the field "User.name", the schema is frozen.`: func() {
+			user.AddFieldConfig("name", &graphql.Field{Type: graphql.String})
+		},
+		`Cannot add extensions, the schema is frozen.`: func() { schema.AddExtensions() },
+	} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if err == nil || err.Error() != expected || !errors.Is(err, graphql.ErrSchemaFrozen) {
+					t.Fatalf("expected a panic with %q matching ErrSchemaFrozen, got %v", expected, err)
+				}
+			}()
+			mutate()
+		}()
+	}
+
+	// variants of a frozen schema are not frozen
+	variant, err := schema.Variant(graphql.SchemaVariantConfig{Types: []graphql.Type{other}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.IsFrozen() || variant.Type("Other") != other || schema.Type("Other") != nil {
+		t.Fatalf("expected the variant only to have the type Other")
+	}
+}
+
+func TestSchema_FreezeServesConcurrentRequests(t *testing.T) {
+	schema, _, _ := freezeTestSchema(t)
+	if err := schema.Freeze(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"node":  map[string]interface{}{"id": "1"},
+			"actor": map[string]interface{}{"id": "2"},
+		},
+	}
+	var wg sync.WaitGroup
+	results := make([]*graphql.Result, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: `{ node { ... on User { id } } actor { ... on Node { id } } }`,
+			})
+		}(i)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestSchema_MutationsAreSerializedUntilFrozen(t *testing.T) {
+	schema, _, _ := freezeTestSchema(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := schema.AppendType(graphql.NewObject(graphql.ObjectConfig{
+				Name:   fmt.Sprintf("Type%v", i),
+				Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+			}))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < 8; i++ {
+		if schema.Type(fmt.Sprintf("Type%v", i)) == nil {
+			t.Fatalf("expected the type Type%v", i)
+		}
+	}
+}
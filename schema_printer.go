@@ -0,0 +1,239 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// PrintSchema prints the schema in the schema definition language, in a
+// canonical form suitable for comparisons: the types, fields, arguments and
+// enum values sorted by name, without the introspection types and the
+// scalars and directives of the specification.
+func PrintSchema(schema *Schema) string {
+	return fmt.Sprintf("%v", printer.Print(SchemaAST(schema)))
+}
+
+// SchemaAST returns the canonical schema definition language document of the
+// schema printed by PrintSchema.
+func SchemaAST(schema *Schema) *ast.Document {
+	definitions := []ast.Node{}
+	if def := schemaDefinitionAST(schema); def != nil {
+		definitions = append(definitions, def)
+	}
+
+	specified := map[string]bool{}
+	for _, directive := range SpecifiedDirectives {
+		specified[directive.Name] = true
+	}
+	directives := []*Directive{}
+	for _, directive := range schema.Directives() {
+		if !specified[directive.Name] {
+			directives = append(directives, directive)
+		}
+	}
+	sort.Slice(directives, func(i, j int) bool { return directives[i].Name < directives[j].Name })
+	for _, directive := range directives {
+		locations := []*ast.Name{}
+		for _, location := range directive.Locations {
+			locations = append(locations, ast.NewName(&ast.Name{Value: location}))
+		}
+		definitions = append(definitions, ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
+			Name:        ast.NewName(&ast.Name{Value: directive.Name}),
+			Description: descriptionAST(directive.Description),
+			Arguments:   argumentsAST(directive.Args),
+			Repeatable:  directive.Repeatable,
+			Locations:   locations,
+		}))
+	}
+
+	names := []string{}
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || isSpecifiedScalar(ttype) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if def := typeDefinitionAST(schema.Type(name)); def != nil {
+			definitions = append(definitions, def)
+		}
+	}
+	return ast.NewDocument(&ast.Document{Definitions: definitions})
+}
+
+func isSpecifiedScalar(ttype Type) bool {
+	switch ttype {
+	case String, Int, Float, Boolean, ID:
+		return true
+	}
+	return false
+}
+
+// schemaDefinitionAST returns the schema definition of the root operation
+// types, nil when they have the default names.
+func schemaDefinitionAST(schema *Schema) *ast.SchemaDefinition {
+	operationTypes := []*ast.OperationTypeDefinition{}
+	isDefault := true
+	for _, root := range []struct {
+		operation   string
+		defaultName string
+		ttype       *Object
+	}{
+		{ast.OperationTypeQuery, "Query", schema.QueryType()},
+		{ast.OperationTypeMutation, "Mutation", schema.MutationType()},
+		{ast.OperationTypeSubscription, "Subscription", schema.SubscriptionType()},
+	} {
+		if root.ttype == nil {
+			continue
+		}
+		isDefault = isDefault && root.ttype.Name() == root.defaultName
+		operationTypes = append(operationTypes, ast.NewOperationTypeDefinition(&ast.OperationTypeDefinition{
+			Operation: root.operation,
+			Type:      namedAST(root.ttype.Name()),
+		}))
+	}
+	if isDefault {
+		return nil
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{OperationTypes: operationTypes})
+}
+
+func typeDefinitionAST(ttype Type) ast.Node {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		return ast.NewScalarDefinition(&ast.ScalarDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+		})
+	case *Object:
+		interfaces := []*ast.Named{}
+		for _, iface := range ttype.Interfaces() {
+			interfaces = append(interfaces, namedAST(iface.Name()))
+		}
+		sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Name.Value < interfaces[j].Name.Value })
+		return ast.NewObjectDefinition(&ast.ObjectDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+			Interfaces:  interfaces,
+			Fields:      fieldsAST(ttype.Fields()),
+		})
+	case *Interface:
+		return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+			Fields:      fieldsAST(ttype.Fields()),
+		})
+	case *Union:
+		types := []*ast.Named{}
+		for _, object := range ttype.Types() {
+			types = append(types, namedAST(object.Name()))
+		}
+		sort.Slice(types, func(i, j int) bool { return types[i].Name.Value < types[j].Name.Value })
+		return ast.NewUnionDefinition(&ast.UnionDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+			Types:       types,
+		})
+	case *Enum:
+		values := []*ast.EnumValueDefinition{}
+		for _, value := range ttype.Values() {
+			values = append(values, ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+				Name:        ast.NewName(&ast.Name{Value: value.Name}),
+				Description: descriptionAST(value.Description),
+				Directives:  deprecatedAST(value.DeprecationReason),
+			}))
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].Name.Value < values[j].Name.Value })
+		return ast.NewEnumDefinition(&ast.EnumDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+			Values:      values,
+		})
+	case *InputObject:
+		fields := []*ast.InputValueDefinition{}
+		for _, field := range ttype.Fields() {
+			fields = append(fields, inputValueAST(field.Name(), field.Description(), field.Type, field.DefaultLiteral(), ""))
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Name.Value < fields[j].Name.Value })
+		return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name()}),
+			Description: descriptionAST(ttype.Description()),
+			Fields:      fields,
+		})
+	}
+	return nil
+}
+
+func fieldsAST(fieldMap FieldDefinitionMap) []*ast.FieldDefinition {
+	fields := []*ast.FieldDefinition{}
+	for _, field := range fieldMap {
+		fields = append(fields, ast.NewFieldDefinition(&ast.FieldDefinition{
+			Name:        ast.NewName(&ast.Name{Value: field.Name}),
+			Description: descriptionAST(field.Description),
+			Arguments:   argumentsAST(field.Args),
+			Type:        typeAST(field.Type),
+			Directives:  deprecatedAST(field.DeprecationReason),
+		}))
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name.Value < fields[j].Name.Value })
+	return fields
+}
+
+func argumentsAST(args []*Argument) []*ast.InputValueDefinition {
+	definitions := []*ast.InputValueDefinition{}
+	for _, arg := range args {
+		definitions = append(definitions, inputValueAST(arg.Name(), arg.Description(), arg.Type, arg.DefaultLiteral(), arg.DeprecationReason))
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name.Value < definitions[j].Name.Value })
+	return definitions
+}
+
+func inputValueAST(name, description string, ttype Input, defaultValue ast.Value, deprecationReason string) *ast.InputValueDefinition {
+	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
+		Name:         ast.NewName(&ast.Name{Value: name}),
+		Description:  descriptionAST(description),
+		Type:         typeAST(ttype),
+		DefaultValue: defaultValue,
+		Directives:   deprecatedAST(deprecationReason),
+	})
+}
+
+func typeAST(ttype Type) ast.Type {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return ast.NewNonNull(&ast.NonNull{Type: typeAST(ttype.OfType)})
+	case *List:
+		return ast.NewList(&ast.List{Type: typeAST(ttype.OfType)})
+	}
+	return namedAST(ttype.Name())
+}
+
+func namedAST(name string) *ast.Named {
+	return ast.NewNamed(&ast.Named{Name: ast.NewName(&ast.Name{Value: name})})
+}
+
+func descriptionAST(description string) *ast.StringValue {
+	if description == "" {
+		return nil
+	}
+	return ast.NewStringValue(&ast.StringValue{Value: description})
+}
+
+func deprecatedAST(reason string) []*ast.Directive {
+	if reason == "" {
+		return nil
+	}
+	directive := ast.NewDirective(&ast.Directive{Name: ast.NewName(&ast.Name{Value: DeprecatedDirective.Name})})
+	if reason != DefaultDeprecationReason {
+		directive.Arguments = []*ast.Argument{ast.NewArgument(&ast.Argument{
+			Name:  ast.NewName(&ast.Name{Value: "reason"}),
+			Value: ast.NewStringValue(&ast.StringValue{Value: reason}),
+		})}
+	}
+	return []*ast.Directive{directive}
+}
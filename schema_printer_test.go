@@ -0,0 +1,166 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestPrintSchema(t *testing.T) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			return true
+		},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String, DeprecationReason: "Use fullName."},
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"old":  &graphql.Field{Type: graphql.String, DeprecationReason: graphql.DefaultDeprecationReason},
+		},
+	})
+	role := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Role",
+		Values: graphql.EnumValueConfigMap{
+			"USER":  &graphql.EnumValueConfig{Value: 1},
+			"ADMIN": &graphql.EnumValueConfig{Value: 0},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(graphql.NewUnion(graphql.UnionConfig{
+						Name:  "Result",
+						Types: []*graphql.Object{user},
+						ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+							return user
+						},
+					})),
+					Description: "The users.",
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+						"role":  &graphql.ArgumentConfig{Type: role},
+					},
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "cached",
+				Locations: []string{graphql.DirectiveLocationField},
+			}),
+		}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `schema {
+  query: Root
+}
+
+directive @cached on FIELD
+
+interface Node {
+  id: ID!
+}
+
+union Result = User
+
+enum Role {
+  ADMIN
+  USER
+}
+
+type Root {
+  
+  """The users."""
+  users(first: Int = 10, role: Role): [Result]
+}
+
+type User implements Node {
+  id: ID!
+  name: String @deprecated(reason: "Use fullName.")
+  old: String @deprecated
+}
+`
+	if printed := graphql.PrintSchema(&schema); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestPrintSchema_DirectiveDefinitionsMatchSDL(t *testing.T) {
+	sdl := `"""Caches the field."""
+directive @cached(
+  """The cache duration, in seconds."""
+  maxAge: Int = 60
+  scope: String @deprecated(reason: "Use maxAge.")
+) repeatable on FIELD_DEFINITION | OBJECT`
+
+	cached := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:        "cached",
+		Description: "Caches the field.",
+		Locations:   []string{graphql.DirectiveLocationFieldDefinition, graphql.DirectiveLocationObject},
+		Args: graphql.FieldConfigArgument{
+			"scope":  &graphql.ArgumentConfig{Type: graphql.String, DeprecationReason: "Use maxAge."},
+			"maxAge": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 60, Description: "The cache duration, in seconds."},
+		},
+		Repeatable: true,
+		Extensions: map[string]interface{}{"owner": "cache"},
+	})
+	if cached.Extensions["owner"] != "cache" {
+		t.Fatalf("expected the extensions to be kept, got: %v", cached.Extensions)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+		}),
+		Directives: append([]*graphql.Directive{cached}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	astDoc, err := parser.Parse(parser.ParseParams{Source: sdl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := printer.Print(astDoc); !strings.Contains(graphql.PrintSchema(&schema), expected.(string)) {
+		t.Fatalf("expected the printed schema to contain the SDL definition, Diff: %v",
+			testutil.Diff(expected, graphql.PrintSchema(&schema)))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{ __schema { directives {
+			name isRepeatable args { name isDeprecated deprecationReason }
+		} } }`,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"name":         "cached",
+		"isRepeatable": true,
+		"args": []interface{}{
+			map[string]interface{}{"name": "maxAge", "isDeprecated": false, "deprecationReason": nil},
+			map[string]interface{}{"name": "scope", "isDeprecated": true, "deprecationReason": "Use maxAge."},
+		},
+	}
+	directives := result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["directives"].([]interface{})
+	if !reflect.DeepEqual(directives[0], expected) {
+		t.Fatalf("expected %v, got: %v", expected, directives[0])
+	}
+}
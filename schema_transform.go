@@ -0,0 +1,530 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// RenameTypes returns a schema with the types of the schema renamed by rename,
+// e.g. prefixed with the name of a service to stitch several schemas into a
+// gateway without conflicts. The introspection types and the specified scalars
+// keep their names.
+//
+// Like the other schema transforms, the returned schema does not share its
+// types with the schema: they are rebuilt, and their fields delegate to the
+// resolvers of the schema, bound ones included. The resolvers are called with
+// the field name, parent type, return type and schema of the transformed
+// schema in their ResolveInfo, so they behave as if the schema was not
+// transformed.
+func RenameTypes(schema *Schema, rename func(name string) string) (Schema, error) {
+	return transformSchema(schema, &schemaTransform{renameType: rename})
+}
+
+// RenameRootFields returns a schema with the fields of the root types of the
+// schema renamed by rename, called with the operation ("query", "mutation" or
+// "subscription") and the name of each field, see RenameTypes.
+func RenameRootFields(schema *Schema, rename func(operation, name string) string) (Schema, error) {
+	return transformSchema(schema, &schemaTransform{renameRootField: rename})
+}
+
+// FilterSchema returns a schema with the types and fields of the schema kept
+// by keep, see RenameTypes. keep is called with the name of each named type
+// and an empty field name, then with the name of each field of the kept
+// object, interface and input object types.
+//
+// The schema is kept valid: the fields and arguments of the removed types are
+// removed, as well as the types left without fields or members. The fields of
+// the interfaces must be kept in the object types implementing them, and the
+// query type must be kept.
+func FilterSchema(schema *Schema, keep func(typeName, fieldName string) bool) (Schema, error) {
+	return transformSchema(schema, &schemaTransform{keep: keep})
+}
+
+// DataSchema returns a schema resolving the queries and mutations of the
+// schema against plain data given as the root object, such as a decoded JSON
+// document, see RenameTypes: every field is resolved by DefaultResolveFn from
+// the value of its parent, its arguments ignored, and the abstract types by
+// the "__typename" key of the maps, e.g. for tests, mocks and static data:
+//
+//	schema, _ := graphql.DataSchema(&starWarsSchema)
+//	graphql.Do(graphql.Params{
+//		Schema:        schema,
+//		RequestString: `{ hero { name ... on Droid { primaryFunction } } }`,
+//		RootObject: map[string]interface{}{
+//			"hero": map[string]interface{}{
+//				"__typename":      "Droid",
+//				"name":            "R2-D2",
+//				"primaryFunction": "Astromech",
+//			},
+//		},
+//	})
+//
+// The resolvers of the schema are not called, and the subscriptions have no
+// source stream.
+func DataSchema(schema *Schema) (Schema, error) {
+	return transformSchema(schema, &schemaTransform{resolveData: true})
+}
+
+// schemaTransform rebuilds the types of a schema, renaming or removing them.
+type schemaTransform struct {
+	renameType      func(name string) string
+	renameRootField func(operation, name string) string
+	keep            func(typeName, fieldName string) bool
+	// resolveData resolves the fields from the data of their parents
+	resolveData bool
+	// node adds the Node interface, see NodeSchema
+	node *nodeTransform
+
+	source *Schema
+	// schema is a copy of the source schema for the ResolveInfo of the resolvers
+	schema     Schema
+	keptTypes  map[string]bool
+	keptFields map[string]map[string]bool
+	// types are the rebuilt types by source name
+	types map[string]Type
+}
+
+func transformSchema(source *Schema, t *schemaTransform) (Schema, error) {
+	if t.renameType == nil {
+		t.renameType = func(name string) string { return name }
+	}
+	if t.renameRootField == nil {
+		t.renameRootField = func(operation, name string) string { return name }
+	}
+	if t.keep == nil {
+		t.keep = func(typeName, fieldName string) bool { return true }
+	}
+	t.source = source
+	t.schema = *source
+	t.types = map[string]Type{}
+	t.prune()
+
+	for operation, root := range map[string]*Object{
+		ast.OperationTypeQuery:        source.QueryType(),
+		ast.OperationTypeMutation:     source.MutationType(),
+		ast.OperationTypeSubscription: source.SubscriptionType(),
+	} {
+		if root == nil {
+			continue
+		}
+		names := map[string]string{}
+		for fieldName := range t.keptFields[root.Name()] {
+			newName := t.renameRootField(operation, fieldName)
+			other, exists := names[newName]
+			first, second := other, fieldName
+			if second < first {
+				first, second = second, first
+			}
+			if err := invariantf(!exists,
+				`Cannot rename the fields %v.%v and %v.%v to "%v".`, root, first, root, second, newName); err != nil {
+				return Schema{}, err
+			}
+			names[newName] = fieldName
+		}
+	}
+
+	config := SchemaConfig{
+		Extensions:                  source.extensions,
+		IntrospectAppliedDirectives: source.introspectAppliedDirectives,
+		PruneUnreachableTypes:       source.pruneUnreachableTypes,
+		ErrorTypes:                  source.errorTypes,
+	}
+	if root := source.QueryType(); root != nil && t.keptTypes[root.Name()] {
+		config.Query = t.named(root).(*Object)
+	}
+	if root := source.MutationType(); root != nil && t.keptTypes[root.Name()] {
+		config.Mutation = t.named(root).(*Object)
+	}
+	if root := source.SubscriptionType(); root != nil && t.keptTypes[root.Name()] {
+		config.Subscription = t.named(root).(*Object)
+	}
+	names := []string{}
+	for name := range t.keptTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config.Types = append(config.Types, t.named(source.Type(name)))
+	}
+	for _, directive := range source.Directives() {
+		if isSpecifiedDirective(directive) {
+			config.Directives = append(config.Directives, directive)
+			continue
+		}
+		if !t.hasRequiredArguments(directive.Args) {
+			continue
+		}
+		config.Directives = append(config.Directives, NewDirective(DirectiveConfig{
+			Name:           directive.Name,
+			Description:    directive.Description,
+			Locations:      directive.Locations,
+			Args:           t.arguments(directive.Args),
+			Repeatable:     directive.Repeatable,
+			CoerceVariable: directive.CoerceVariable,
+			Extensions:     directive.Extensions,
+		}))
+	}
+	return NewSchema(config)
+}
+
+// isTransformed reports whether the type is rebuilt by the transforms, all
+// named types but the introspection types and the specified scalars.
+func isTransformed(ttype Type) bool {
+	return !strings.HasPrefix(ttype.Name(), "__") && !isSpecifiedScalar(ttype)
+}
+
+func isSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if directive == specified {
+			return true
+		}
+	}
+	return false
+}
+
+// prune computes the types and fields kept in the transformed schema, until
+// no type or field refers to a removed type.
+func (t *schemaTransform) prune() {
+	t.keptTypes = map[string]bool{}
+	t.keptFields = map[string]map[string]bool{}
+	for name, ttype := range t.source.TypeMap() {
+		if !isTransformed(ttype) || !t.keep(name, "") {
+			continue
+		}
+		t.keptTypes[name] = true
+		fieldNames := []string{}
+		switch ttype := ttype.(type) {
+		case *Object:
+			for fieldName := range ttype.Fields() {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		case *Interface:
+			for fieldName := range ttype.Fields() {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		case *InputObject:
+			for fieldName := range ttype.Fields() {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+		t.keptFields[name] = map[string]bool{}
+		for _, fieldName := range fieldNames {
+			if t.keep(name, fieldName) {
+				t.keptFields[name][fieldName] = true
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for name := range t.keptTypes {
+			removed := false
+			switch ttype := t.source.Type(name).(type) {
+			case *Object:
+				removed = t.pruneFields(ttype.Fields(), t.keptFields[name])
+			case *Interface:
+				removed = t.pruneFields(ttype.Fields(), t.keptFields[name])
+			case *InputObject:
+				for fieldName, field := range ttype.Fields() {
+					if !t.keptFields[name][fieldName] || t.isKept(field.Type) {
+						continue
+					}
+					if _, ok := field.Type.(*NonNull); ok && field.DefaultValue == nil {
+						removed = true
+						break
+					}
+					delete(t.keptFields[name], fieldName)
+				}
+				removed = removed || len(t.keptFields[name]) == 0
+			case *Union:
+				removed = true
+				for _, object := range ttype.Types() {
+					if t.keptTypes[object.Name()] {
+						removed = false
+					}
+				}
+			}
+			if removed {
+				delete(t.keptTypes, name)
+				changed = true
+			}
+		}
+	}
+}
+
+// pruneFields removes the kept fields referring to removed types, reporting
+// whether none is left.
+func (t *schemaTransform) pruneFields(fields FieldDefinitionMap, kept map[string]bool) bool {
+	for fieldName, field := range fields {
+		if !kept[fieldName] {
+			continue
+		}
+		if !t.hasRequiredArguments(field.Args) || !t.isKept(field.Type) {
+			delete(kept, fieldName)
+		}
+	}
+	return len(kept) == 0
+}
+
+// isKept reports whether the named type of ttype is kept.
+func (t *schemaTransform) isKept(ttype Type) bool {
+	named := GetNamed(ttype).(Type)
+	return !isTransformed(named) || t.keptTypes[named.Name()]
+}
+
+// wrap returns the transformed type of ttype, wrapped in the same lists and
+// non-nulls.
+func (t *schemaTransform) wrap(ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return NewList(t.wrap(ttype.OfType))
+	case *NonNull:
+		return NewNonNull(t.wrap(ttype.OfType))
+	}
+	return t.named(ttype)
+}
+
+// named returns the transformed named type, rebuilding it the first time.
+// The fields are rebuilt when the schema is built, so types can refer to each
+// other.
+func (t *schemaTransform) named(ttype Type) Type {
+	if !isTransformed(ttype) {
+		return ttype
+	}
+	if transformed, ok := t.types[ttype.Name()]; ok {
+		return transformed
+	}
+	name := t.renameType(ttype.Name())
+	var transformed Type
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		config := ttype.scalarConfig
+		config.Name = name
+		transformed = NewScalar(config)
+	case *Enum:
+		config := ttype.enumConfig
+		config.Name = name
+		transformed = NewEnum(config)
+	case *Object:
+		var object *Object
+		object = NewObject(ObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Interfaces: InterfacesThunk(func() []*Interface {
+				interfaces := []*Interface{}
+				for _, iface := range ttype.Interfaces() {
+					if t.keptTypes[iface.Name()] {
+						interfaces = append(interfaces, t.named(iface).(*Interface))
+					}
+				}
+				return t.node.interfaces(ttype, interfaces)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return t.node.fields(ttype, object, t.fields(ttype, ttype.Fields()))
+			}),
+			IsTypeOf:          t.isTypeOf(ttype.IsTypeOf),
+			Extensions:        ttype.Extensions,
+			AppliedDirectives: ttype.AppliedDirectives,
+		})
+		transformed = object
+	case *Interface:
+		transformed = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Fields: FieldsThunk(func() Fields {
+				return t.node.interfaceFields(ttype, t.fields(ttype, ttype.Fields()))
+			}),
+			ResolveType:       t.resolveType(ttype.ResolveType),
+			Extensions:        ttype.Extensions,
+			AppliedDirectives: ttype.AppliedDirectives,
+		})
+	case *Union:
+		transformed = NewUnion(UnionConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Types: UnionTypesThunk(func() []*Object {
+				return t.possibleTypes(ttype.Types())
+			}),
+			ResolveType:       t.resolveType(ttype.ResolveType),
+			TypedValues:       ttype.typeConfig.TypedValues,
+			Extensions:        ttype.Extensions,
+			AppliedDirectives: ttype.AppliedDirectives,
+		})
+	case *InputObject:
+		transformed = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, field := range ttype.Fields() {
+					if t.keptFields[ttype.Name()][fieldName] {
+						fields[fieldName] = &InputObjectFieldConfig{
+							Type:              t.wrap(field.Type).(Input),
+							DefaultValue:      field.DefaultValue,
+							Description:       field.Description(),
+							Transform:         field.Transform,
+							Extensions:        field.Extensions,
+							AppliedDirectives: field.AppliedDirectives,
+						}
+					}
+				}
+				return fields
+			}),
+			Extensions:        ttype.Extensions,
+			AppliedDirectives: ttype.AppliedDirectives,
+		})
+	default:
+		return ttype
+	}
+	t.types[ttype.Name()] = transformed
+	return transformed
+}
+
+// possibleTypes returns the transformed types of the kept objects.
+func (t *schemaTransform) possibleTypes(objects []*Object) []*Object {
+	possibleTypes := []*Object{}
+	for _, object := range objects {
+		if t.keptTypes[object.Name()] {
+			possibleTypes = append(possibleTypes, t.named(object).(*Object))
+		}
+	}
+	return possibleTypes
+}
+
+// fields returns the kept fields of the object or interface parentType,
+// delegating to its resolvers.
+func (t *schemaTransform) fields(parentType Composite, fieldMap FieldDefinitionMap) Fields {
+	operation := ""
+	switch parentType {
+	case t.source.QueryType():
+		operation = ast.OperationTypeQuery
+	case t.source.MutationType():
+		operation = ast.OperationTypeMutation
+	case t.source.SubscriptionType():
+		operation = ast.OperationTypeSubscription
+	}
+	fields := Fields{}
+	for fieldName, field := range fieldMap {
+		if !t.keptFields[parentType.Name()][fieldName] {
+			continue
+		}
+		resolve, args := field.Resolve, field.Args
+		if object, ok := parentType.(*Object); ok {
+			resolve, args = t.source.fieldResolver(object, field), t.source.fieldArgs(object, field)
+		}
+		name := fieldName
+		if operation != "" {
+			name = t.renameRootField(operation, fieldName)
+		}
+		if resolve == nil && name != fieldName {
+			resolve = DefaultResolveFn
+		}
+		subscribe := field.Subscribe
+		if t.resolveData {
+			resolve, subscribe = DefaultResolveFn, nil
+		}
+		fields[name] = &Field{
+			Name:              name,
+			Type:              t.wrap(field.Type).(Output),
+			Args:              t.arguments(args),
+			Resolve:           t.delegate(parentType, field, resolve),
+			Subscribe:         t.delegate(parentType, field, subscribe),
+			OnEvent:           field.OnEvent,
+			DeprecationReason: field.DeprecationReason,
+			Description:       field.Description,
+			SemanticNonNull:   field.SemanticNonNull,
+			Extensions:        field.Extensions,
+			AppliedDirectives: field.AppliedDirectives,
+		}
+	}
+	return fields
+}
+
+// hasRequiredArguments reports whether the non-null arguments all have kept
+// types.
+func (t *schemaTransform) hasRequiredArguments(args []*Argument) bool {
+	for _, arg := range args {
+		if _, ok := arg.Type.(*NonNull); ok && !t.isKept(arg.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// arguments returns the arguments of the kept types.
+func (t *schemaTransform) arguments(args []*Argument) FieldConfigArgument {
+	config := FieldConfigArgument{}
+	for _, arg := range args {
+		if !t.isKept(arg.Type) {
+			continue
+		}
+		config[arg.Name()] = &ArgumentConfig{
+			Type:              t.wrap(arg.Type).(Input),
+			DefaultValue:      arg.DefaultValue,
+			Description:       arg.Description(),
+			DeprecationReason: arg.DeprecationReason,
+			Extensions:        arg.Extensions,
+			AppliedDirectives: arg.AppliedDirectives,
+		}
+	}
+	return config
+}
+
+// delegate returns a resolver calling resolve with the ResolveInfo of the
+// field in the source schema, nil when resolve is nil.
+func (t *schemaTransform) delegate(parentType Composite, field *FieldDefinition, resolve FieldResolveFn) FieldResolveFn {
+	if resolve == nil {
+		return nil
+	}
+	return func(p ResolveParams) (interface{}, error) {
+		p.Info.FieldName = field.Name
+		p.Info.ParentType = parentType
+		p.Info.ReturnType = field.Type
+		p.Info.Schema = t.schema
+		return resolve(p)
+	}
+}
+
+// resolveType returns a ResolveTypeFn returning the transformed type of the
+// object returned by resolveType, nil when resolveType is nil.
+func (t *schemaTransform) resolveType(resolveType ResolveTypeFn) ResolveTypeFn {
+	if t.resolveData {
+		return t.resolveTypename
+	}
+	if resolveType == nil {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		p.Info.Schema = t.schema
+		object := resolveType(p)
+		if object == nil {
+			return nil
+		}
+		// the kept types are all rebuilt with the schema
+		transformed, _ := t.types[object.Name()].(*Object)
+		return transformed
+	}
+}
+
+// isTypeOf returns isTypeOf, nil when resolving data whose types are resolved
+// by name.
+func (t *schemaTransform) isTypeOf(isTypeOf IsTypeOfFn) IsTypeOfFn {
+	if t.resolveData {
+		return nil
+	}
+	return isTypeOf
+}
+
+// resolveTypename returns the transformed type named by the "__typename" key
+// of the data.
+func (t *schemaTransform) resolveTypename(p ResolveTypeParams) *Object {
+	data, ok := p.Value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	name, _ := data["__typename"].(string)
+	object, _ := t.types[name].(*Object)
+	return object
+}
@@ -0,0 +1,350 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type transformUser struct {
+	ID     string
+	Name   string
+	Secret string
+}
+
+type transformPost struct {
+	Title string
+}
+
+func transformTestSchema(t *testing.T) graphql.Schema {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(*transformUser); ok {
+				return p.Info.Schema.Type("User").(*graphql.Object)
+			}
+			return nil
+		},
+	})
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*transformPost)
+			return ok
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":   &graphql.Field{Type: graphql.String},
+			"secret": &graphql.Field{Type: graphql.String},
+			"posts":  &graphql.Field{Type: graphql.NewList(postType)},
+			"parent": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*transformUser)
+			return ok
+		},
+	})
+	searchResult := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "SearchResult",
+		Types: []*graphql.Object{userType, postType},
+	})
+	user := &transformUser{ID: "1", Name: "Ada", Secret: "s3cr3t"}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"me": &graphql.Field{Type: userType},
+				"node": &graphql.Field{
+					Type: nodeInterface,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if p.Args["id"] == user.ID {
+							return user, nil
+						}
+						return nil, nil
+					},
+				},
+				"search": &graphql.Field{
+					Type: graphql.NewList(searchResult),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{user, &transformPost{Title: "Notes"}}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.BindResolvers(graphql.ResolverMap{
+		"Query": {
+			"me": func(p graphql.ResolveParams) (interface{}, error) {
+				return user, nil
+			},
+		},
+		"User": {
+			"parent": func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Info.ParentType.Name() + "." + p.Info.FieldName, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestRenameTypes(t *testing.T) {
+	source := transformTestSchema(t)
+	schema, err := graphql.RenameTypes(&source, func(name string) string {
+		return "Acme" + name
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSDL := `schema {
+  query: AcmeQuery
+}
+
+interface AcmeNode {
+  id: ID!
+}
+
+type AcmePost {
+  title: String
+}
+
+type AcmeQuery {
+  me: AcmeUser
+  node(id: ID!): AcmeNode
+  search: [AcmeSearchResult]
+}
+
+union AcmeSearchResult = AcmePost | AcmeUser
+
+type AcmeUser implements AcmeNode {
+  id: ID!
+  name: String
+  parent: String
+  posts: [AcmePost]
+  secret: String
+}
+`
+	if sdl := graphql.PrintSchema(&schema); sdl != expectedSDL {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expectedSDL, sdl))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			me { __typename name parent }
+			node(id: "1") { __typename ... on AcmeUser { name } }
+			search {
+				... on AcmeUser { name }
+				... on AcmePost { title }
+			}
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"me": map[string]interface{}{
+				"__typename": "AcmeUser",
+				"name":       "Ada",
+				"parent":     "User.parent",
+			},
+			"node": map[string]interface{}{
+				"__typename": "AcmeUser",
+				"name":       "Ada",
+			},
+			"search": []interface{}{
+				map[string]interface{}{"name": "Ada"},
+				map[string]interface{}{"title": "Notes"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRenameRootFields(t *testing.T) {
+	source, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"version": &graphql.Field{Type: graphql.String},
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "hello from " + p.Info.FieldName, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := graphql.RenameRootFields(&source, func(operation, name string) string {
+		return "acme_" + name
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RootObject:    map[string]interface{}{"version": "1.0"},
+		RequestString: `{ acme_hello acme_version }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"acme_hello":   "hello from hello",
+			"acme_version": "1.0",
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRenameRootFields_RejectsConflicts(t *testing.T) {
+	source := transformTestSchema(t)
+	_, err := graphql.RenameRootFields(&source, func(operation, name string) string {
+		if name == "search" {
+			return name
+		}
+		return "acme"
+	})
+	expected := `Cannot rename the fields Query.me and Query.node to "acme".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestFilterSchema(t *testing.T) {
+	source := transformTestSchema(t)
+	schema, err := graphql.FilterSchema(&source, func(typeName, fieldName string) bool {
+		return typeName != "Post" && fieldName != "secret"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSDL := `interface Node {
+  id: ID!
+}
+
+type Query {
+  me: User
+  node(id: ID!): Node
+  search: [SearchResult]
+}
+
+union SearchResult = User
+
+type User implements Node {
+  id: ID!
+  name: String
+  parent: String
+}
+`
+	if sdl := graphql.PrintSchema(&schema); sdl != expectedSDL {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expectedSDL, sdl))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ me { secret } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "secret" on type "User".` {
+		t.Fatalf("expected the removed field to be rejected, got %v", result.Errors)
+	}
+}
+
+func TestFilterSchema_RemovesTypesLeftWithoutFields(t *testing.T) {
+	source := transformTestSchema(t)
+	schema, err := graphql.FilterSchema(&source, func(typeName, fieldName string) bool {
+		return typeName != "Query"
+	})
+	if err == nil {
+		t.Fatalf("expected the query type to be removed, got %v", graphql.PrintSchema(&schema))
+	}
+	schema, err = graphql.FilterSchema(&source, func(typeName, fieldName string) bool {
+		return !(typeName == "Post" && fieldName == "title")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Type("Post") != nil || schema.Type("User").(*graphql.Object).Fields()["posts"] != nil {
+		t.Fatalf("expected Post to be removed with the fields of its type")
+	}
+}
+
+func TestDataSchema_ResolvesFromData(t *testing.T) {
+	schema, err := graphql.DataSchema(&testutil.StarWarsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			hero(episode: EMPIRE) {
+				name
+				friends {
+					name
+					... on Droid { primaryFunction }
+					... on Human { homePlanet }
+				}
+			}
+		}`,
+		RootObject: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"__typename": "Human",
+				"name":       "Leia",
+				"friends": []interface{}{
+					map[string]interface{}{"__typename": "Droid", "name": "R2", "primaryFunction": "Astromech"},
+					map[string]interface{}{"__typename": "Human", "name": "Han", "homePlanet": "Corellia"},
+				},
+			},
+		},
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"hero": map[string]interface{}{
+			"name": "Leia",
+			"friends": []interface{}{
+				map[string]interface{}{"name": "R2", "primaryFunction": "Astromech"},
+				map[string]interface{}{"name": "Han", "homePlanet": "Corellia"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero { name } }`,
+		RootObject:    map[string]interface{}{"hero": map[string]interface{}{"name": "Unknown"}},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected the data without __typename to be rejected, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,128 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func unreachableTypesTestSchema(t *testing.T, prune bool) graphql.Schema {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	legacyStatus := graphql.NewEnum(graphql.EnumConfig{
+		Name: "LegacyStatus",
+		Values: graphql.EnumValueConfigMap{
+			"ACTIVE": &graphql.EnumValueConfig{},
+		},
+	})
+	legacy := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Legacy",
+		Fields: graphql.Fields{
+			"status": &graphql.Field{Type: legacyStatus},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": &graphql.Field{Type: node},
+			},
+		}),
+		Types:                 []graphql.Type{user, legacy},
+		PruneUnreachableTypes: prune,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestSchema_UnreachableTypes(t *testing.T) {
+	schema := unreachableTypesTestSchema(t, false)
+	names := []string{}
+	for _, ttype := range schema.UnreachableTypes() {
+		names = append(names, ttype.Name())
+	}
+	if expected := []string{"Legacy", "LegacyStatus"}; !reflect.DeepEqual(expected, names) {
+		t.Fatalf("expected unreachable types %v, got %v", expected, names)
+	}
+}
+
+func TestSchema_PruneUnreachableTypesFromIntrospection(t *testing.T) {
+	query := `{
+		__schema { types { name } }
+		legacy: __type(name: "Legacy") { name }
+		user: __type(name: "User") { name }
+	}`
+	for _, prune := range []bool{false, true} {
+		schema := unreachableTypesTestSchema(t, prune)
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+		if len(result.Errors) != 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+		data := result.Data.(map[string]interface{})
+		introspected := false
+		for _, ttype := range data["__schema"].(map[string]interface{})["types"].([]interface{}) {
+			if ttype.(map[string]interface{})["name"] == "Legacy" {
+				introspected = true
+			}
+		}
+		if introspected == prune || (data["legacy"] == nil) != prune {
+			t.Fatalf("expected Legacy to be introspected: %v, got: %v", !prune, data)
+		}
+		if data["user"] == nil {
+			t.Fatalf("expected the implementation of a reachable interface to be introspected: %v", data)
+		}
+		if schema.Type("Legacy") == nil {
+			t.Fatalf("expected the pruned type to remain in the schema")
+		}
+	}
+}
+
+func TestSchema_PrunedTypesAreUpdatedOnRebuild(t *testing.T) {
+	schema := unreachableTypesTestSchema(t, true)
+	query := `{ __type(name: "Legacy") { name } }`
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if data := result.Data.(map[string]interface{}); data["__type"] != nil {
+		t.Fatalf("expected Legacy to be pruned, got: %v", data)
+	}
+
+	if err := schema.QueryType().AppendField("legacy", &graphql.Field{Type: schema.Type("Legacy").(graphql.Output)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := schema.Rebuild(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if data := result.Data.(map[string]interface{}); data["__type"] == nil {
+		t.Fatalf("expected Legacy to be introspected once reachable, got: %v", data)
+	}
+}
+
+func TestSchema_RejectsTypesWhichAreNotNamed(t *testing.T) {
+	for ttype, expected := range map[graphql.Type]string{
+		nil:                             "Schema types must be named types but got: <nil>.",
+		graphql.NewList(graphql.String): "Schema types must be named types but got: [String].",
+	} {
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: testutil.StarWarsSchema.QueryType(),
+			Types: []graphql.Type{ttype},
+		})
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q, got %v", expected, err)
+		}
+	}
+}
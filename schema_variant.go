@@ -0,0 +1,111 @@
+package graphql
+
+// SchemaVariantConfig options for deriving a variant of an existing schema.
+type SchemaVariantConfig struct {
+	// Query, Mutation and Subscription replace the root types of the base schema when provided.
+	Query        *Object
+	Mutation     *Object
+	Subscription *Object
+
+	// Types are added to the variant in addition to the types of the base schema.
+	Types []Type
+
+	// Extensions replace the extensions of the base schema when not nil.
+	Extensions []Extension
+}
+
+// Variant derives a new schema from gq, e.g. one per tenant of a multi-tenant service.
+//
+// The variant shares the type definitions and internal maps of the base schema
+// instead of rebuilding them, and only copies those maps when the variant adds
+// types or is modified later on, the base schema copying its own before it is
+// modified (copy-on-write), so thousands of near-identical schemas can be
+// derived cheaply from a single base schema, which is left untouched.
+//
+// Types added to a variant must not conflict with the named types of the base schema.
+func (gq *Schema) Variant(config SchemaVariantConfig) (Schema, error) {
+	variant := Schema{
+		typeMap:          gq.typeMap,
+		directives:       gq.directives,
+		queryType:        gq.queryType,
+		mutationType:     gq.mutationType,
+		subscriptionType: gq.subscriptionType,
+		implementations:  gq.implementations,
+		extensions:       gq.extensions,
+		resolvers:        gq.resolvers,
+		inherited:        gq.inherited,
+		introspection:    newIntrospectionCache(),
+		plans:            newPlanCache(),
+		guard:            &schemaGuard{},
+
+		introspectAppliedDirectives: gq.introspectAppliedDirectives,
+		pruneUnreachableTypes:       gq.pruneUnreachableTypes,
+		prunedTypes:                 gq.prunedTypes,
+		errorTypes:                  gq.errorTypes,
+	}
+	if config.Extensions != nil {
+		variant.extensions = config.Extensions
+	}
+
+	// the replaced root types are removed from the type map of the variant,
+	// their replacements may have the same names.
+	replaced := []*Object{}
+	additions := []Type{}
+	if config.Query != nil {
+		replaced = append(replaced, gq.queryType)
+		variant.queryType = config.Query
+		additions = append(additions, config.Query)
+	}
+	if config.Mutation != nil {
+		replaced = append(replaced, gq.mutationType)
+		variant.mutationType = config.Mutation
+		additions = append(additions, config.Mutation)
+	}
+	if config.Subscription != nil {
+		replaced = append(replaced, gq.subscriptionType)
+		variant.subscriptionType = config.Subscription
+		additions = append(additions, config.Subscription)
+	}
+	additions = append(additions, config.Types...)
+	if len(additions) == 0 {
+		return variant, nil
+	}
+
+	variant.ensureOwnTypeMap()
+	for _, root := range replaced {
+		if root != nil && variant.typeMap[root.Name()] == root {
+			delete(variant.typeMap, root.Name())
+		}
+	}
+	var err error
+	for _, ttype := range additions {
+		if ttype.Error() != nil {
+			return variant, ttype.Error()
+		}
+		if variant.typeMap, err = typeMapReducer(&variant, variant.typeMap, ttype); err != nil {
+			return variant, err
+		}
+	}
+	variant.implementations = nil
+	if err := variant.AddImplementation(); err != nil {
+		return variant, err
+	}
+	return variant, nil
+}
+
+// ensureOwnTypeMap copies the maps of gq before it is modified, as they may
+// be shared with copies and variants of the schema, which must not be
+// affected by the modifications (copy-on-write).
+func (gq *Schema) ensureOwnTypeMap() {
+	typeMap := make(TypeMap, len(gq.typeMap))
+	for name, ttype := range gq.typeMap {
+		typeMap[name] = ttype
+	}
+	implementations := make(map[string][]*Object, len(gq.implementations))
+	for name, impls := range gq.implementations {
+		implementations[name] = append([]*Object{}, impls...)
+	}
+	gq.typeMap = typeMap
+	gq.implementations = implementations
+	gq.possibleTypeMap = nil
+}
@@ -0,0 +1,245 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func newVariantBaseSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestSchemaVariant_SharesTypes(t *testing.T) {
+	base := newVariantBaseSchema(t)
+	variant, err := base.Variant(graphql.SchemaVariantConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.QueryType() != base.QueryType() {
+		t.Fatalf("expected the variant to share the query type of the base schema")
+	}
+	if reflect.ValueOf(variant.TypeMap()).Pointer() != reflect.ValueOf(base.TypeMap()).Pointer() {
+		t.Fatalf("expected the variant to share the type map of the base schema")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        variant,
+		RequestString: `{ hello }`,
+	})
+	expected := &graphql.Result{Data: map[string]interface{}{"hello": "world"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("unexpected result, diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaVariant_AddedTypesDoNotLeakIntoBase(t *testing.T) {
+	base := newVariantBaseSchema(t)
+	tenant := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tenant",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	variant, err := base.Variant(graphql.SchemaVariantConfig{
+		Types: []graphql.Type{tenant},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.Type("Tenant") != tenant {
+		t.Fatalf("expected the variant to contain the added type")
+	}
+	if base.Type("Tenant") != nil {
+		t.Fatalf("expected the base schema not to contain the type added to the variant")
+	}
+	if variant.Type("Query") != base.Type("Query") {
+		t.Fatalf("expected the variant to keep the types of the base schema")
+	}
+}
+
+func TestSchemaVariant_AppendTypeCopiesOnWrite(t *testing.T) {
+	base := newVariantBaseSchema(t)
+	variant, err := base.Variant(graphql.SchemaVariantConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Other",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	if err := variant.AppendType(other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.Type("Other") != nil {
+		t.Fatalf("expected AppendType on the variant not to modify the base schema")
+	}
+
+	another := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Another",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	if err := base.AppendType(another); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.Type("Another") != nil {
+		t.Fatalf("expected AppendType on the base schema not to modify the variant")
+	}
+}
+
+func TestSchemaVariant_ReplacesRootTypes(t *testing.T) {
+	base := newVariantBaseSchema(t)
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TenantQuery",
+		Fields: graphql.Fields{
+			"tenant": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "acme", nil
+				},
+			},
+		},
+	})
+	variant, err := base.Variant(graphql.SchemaVariantConfig{Query: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        variant,
+		RequestString: `{ tenant }`,
+	})
+	expected := &graphql.Result{Data: map[string]interface{}{"tenant": "acme"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("unexpected result, diff: %v", testutil.Diff(expected, result))
+	}
+	if base.QueryType().Name() != "Query" {
+		t.Fatalf("expected the base schema to keep its query type")
+	}
+	if variant.Type("Query") != nil {
+		t.Fatalf("expected the replaced query type to be removed from the variant")
+	}
+}
+
+func TestSchemaVariant_ReplacesRootTypesWithTheSameName(t *testing.T) {
+	base := newVariantBaseSchema(t)
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "tenant", nil
+				},
+			},
+		},
+	})
+	variant, err := base.Variant(graphql.SchemaVariantConfig{Query: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.Type("Query") != query || base.Type("Query") == query {
+		t.Fatalf("expected the query type to be replaced in the variant only")
+	}
+	for schema, hello := range map[*graphql.Schema]string{&base: "world", &variant: "tenant"} {
+		result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ hello }`})
+		expected := &graphql.Result{Data: map[string]interface{}{"hello": hello}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("unexpected result, diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestSchemaVariant_KeepsIntrospectAppliedDirectives(t *testing.T) {
+	base := appliedDirectivesTestSchema(t, true)
+	variant, err := base.Variant(graphql.SchemaVariantConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        variant,
+		RequestString: `{ __type(name: "Query") { appliedDirectives { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"appliedDirectives": []interface{}{map[string]interface{}{"name": "cost"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaVariant_KeepsErrorTypes(t *testing.T) {
+	base := resultUnionsTestSchema(t)
+	variant, err := base.Variant(graphql.SchemaVariantConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        variant,
+		RequestString: `{ user(id: "2") { __typename } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"__typename": "NotFoundError"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaVariant_KeepsPruningUnreachableTypes(t *testing.T) {
+	base := unreachableTypesTestSchema(t, true)
+	query := `{ __type(name: "Legacy") { name } }`
+
+	variant, err := base.Variant(graphql.SchemaVariantConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: variant, RequestString: query})
+	if data := result.Data.(map[string]interface{}); len(result.Errors) != 0 || data["__type"] != nil {
+		t.Fatalf("expected Legacy to be pruned, got: %v", result)
+	}
+
+	// the pruned types are computed again once the variant reaches Legacy
+	variant, err = base.Variant(graphql.SchemaVariantConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"legacy": &graphql.Field{Type: base.Type("Legacy").(graphql.Output)},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result = graphql.Do(graphql.Params{Schema: variant, RequestString: query})
+	if data := result.Data.(map[string]interface{}); len(result.Errors) != 0 || data["__type"] == nil {
+		t.Fatalf("expected Legacy to be introspected, got: %v", result)
+	}
+}
@@ -0,0 +1,132 @@
+package graphql
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// SemanticNonNullDirective is the experimental @semanticNonNull directive of
+// the semantic nullability proposal. It marks the positions of the type of a
+// field which are null only on error, see Field.SemanticNonNull.
+//
+// It is not part of SpecifiedDirectives, schemas exposing it in SDL add it to
+// SchemaConfig.Directives.
+var SemanticNonNullDirective = NewDirective(DirectiveConfig{
+	Name: "semanticNonNull",
+	Description: "Indicates that the positions of the type of the field at the given " +
+		"`levels` are null only when an error occurred, 0 being the field itself " +
+		"and 1 the items of a list.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+	Args: FieldConfigArgument{
+		"levels": &ArgumentConfig{
+			Type:         NewList(NewNonNull(Int)),
+			Description:  "The levels of the type which are semantically non-null.",
+			DefaultValue: []interface{}{0},
+		},
+	},
+})
+
+// SemanticNonNullLevels returns the levels of the @semanticNonNull directive
+// of a field definition parsed from SDL, and false when the field does not
+// have the directive.
+func SemanticNonNullLevels(fieldAST *ast.FieldDefinition) ([]int, bool) {
+	if fieldAST == nil {
+		return nil, false
+	}
+	for _, directive := range fieldAST.Directives {
+		if directive == nil || directive.Name == nil || directive.Name.Value != SemanticNonNullDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name == nil || arg.Name.Value != "levels" {
+				continue
+			}
+			var values []ast.Value
+			switch value := arg.Value.(type) {
+			case *ast.ListValue:
+				values = value.Values
+			default:
+				values = []ast.Value{value}
+			}
+			levels := []int{}
+			for _, value := range values {
+				if value, ok := value.(*ast.IntValue); ok {
+					if level, err := strconv.Atoi(value.Value); err == nil {
+						levels = append(levels, level)
+					}
+				}
+			}
+			return levels, true
+		}
+		return []int{0}, true
+	}
+	return nil, false
+}
+
+// assertSemanticNonNullLevels checks that the semantically non-null levels of
+// a field refer to nullable positions of its type.
+func assertSemanticNonNullLevels(ttype Named, fieldName string, fieldType Output, levels []int) error {
+	for _, level := range levels {
+		position, ok := semanticNonNullPosition(fieldType, level)
+		if err := invariantf(
+			ok && level >= 0,
+			`%v.%v semantic non-null level %v does not exist in type %v.`, ttype, fieldName, level, fieldType,
+		); err != nil {
+			return err
+		}
+		_, isNonNull := position.(*NonNull)
+		if err := invariantf(
+			!isNonNull,
+			`%v.%v semantic non-null level %v is already non-null in type %v.`, ttype, fieldName, level, fieldType,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// semanticNonNullPosition returns the type at the given level of a field type,
+// 0 being the type itself and 1 the type of the items of a list.
+func semanticNonNullPosition(ttype Type, level int) (Type, bool) {
+	for ; level > 0; level-- {
+		if nonNull, ok := ttype.(*NonNull); ok {
+			ttype = nonNull.OfType
+		}
+		list, ok := ttype.(*List)
+		if !ok {
+			return nil, false
+		}
+		ttype = list.OfType
+	}
+	return ttype, ttype != nil
+}
+
+// isSemanticNonNull reports whether the value completed at the given path is
+// semantically non-null, the level being the number of list indices trailing
+// the path.
+func isSemanticNonNull(info ResolveInfo, path *ResponsePath) bool {
+	parentType, ok := info.ParentType.(*Object)
+	if !ok || parentType == nil {
+		return false
+	}
+	fieldDef := parentType.Fields()[info.FieldName]
+	if fieldDef == nil || len(fieldDef.SemanticNonNull) == 0 {
+		return false
+	}
+	level := 0
+	for ; path != nil; path = path.Prev {
+		if _, ok := path.Key.(int); !ok {
+			break
+		}
+		level++
+	}
+	for _, semanticLevel := range fieldDef.SemanticNonNull {
+		if semanticLevel == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func semanticNullabilityTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"name": &graphql.Field{
+					Type:            graphql.String,
+					SemanticNonNull: []int{0},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, nil
+					},
+				},
+				"tags": &graphql.Field{
+					Type:            graphql.NewList(graphql.String),
+					SemanticNonNull: []int{1},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{"a", nil}, nil
+					},
+				},
+				"failing": &graphql.Field{
+					Type:            graphql.String,
+					SemanticNonNull: []int{0},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("boom")
+					},
+				},
+				"nullable": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{graphql.IncludeDirective, graphql.SkipDirective, graphql.SemanticNonNullDirective},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSemanticNullability_NullsAreAllowedByDefault(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        semanticNullabilityTestSchema(t),
+		RequestString: `{ name tags nullable }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"name":     nil,
+			"tags":     []interface{}{"a", nil},
+			"nullable": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSemanticNullability_ReportsNullsWithoutErrors(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:              semanticNullabilityTestSchema(t),
+		RequestString:       `{ name tags failing nullable }`,
+		SemanticNullability: true,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"name":     nil,
+			"tags":     []interface{}{"a", nil},
+			"failing":  nil,
+			"nullable": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Cannot return null for semantically non-nullable field Query.name.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []interface{}{"name"},
+			},
+			{
+				Message:   "Cannot return null for semantically non-nullable field Query.tags.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 8}},
+				Path:      []interface{}{"tags", 1},
+			},
+			{
+				Message:   "boom",
+				Locations: []location.SourceLocation{{Line: 1, Column: 13}},
+				Path:      []interface{}{"failing"},
+			},
+		},
+	}
+	// the fields of a query are resolved in no particular order
+	order := map[string]int{"name": 0, "tags": 1, "failing": 2}
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return order[result.Errors[i].Path[0].(string)] < order[result.Errors[j].Path[0].(string)]
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSemanticNullability_RejectsInvalidLevels(t *testing.T) {
+	tests := map[string]graphql.Output{
+		`Query.field semantic non-null level 1 does not exist in type String.`:         graphql.String,
+		`Query.field semantic non-null level 1 is already non-null in type [String!].`: graphql.NewList(graphql.NewNonNull(graphql.String)),
+	}
+	for expected, ttype := range tests {
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"field": &graphql.Field{Type: ttype, SemanticNonNull: []int{1}},
+				},
+			}),
+		})
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q, got: %v", expected, err)
+		}
+	}
+}
+
+func TestSemanticNullability_LevelsFromSDL(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type Query {
+			a: String @semanticNonNull
+			b: [String] @semanticNonNull(levels: [0, 1])
+			c: String
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := doc.Definitions[0].(*ast.ObjectDefinition).Fields
+	expected := []struct {
+		levels []int
+		ok     bool
+	}{
+		{[]int{0}, true},
+		{[]int{0, 1}, true},
+		{nil, false},
+	}
+	for i, field := range fields {
+		levels, ok := graphql.SemanticNonNullLevels(field)
+		if ok != expected[i].ok || !reflect.DeepEqual(levels, expected[i].levels) {
+			t.Fatalf("unexpected levels of %v: %v, %v", field.Name.Value, levels, ok)
+		}
+	}
+}
+
+func TestSemanticNullability_Introspection(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: semanticNullabilityTestSchema(t),
+		RequestString: `{
+			__type(name: "Query") { fields { name semanticNonNullLevels } }
+			__schema { directives { name } }
+		}`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	levels := map[string]interface{}{}
+	data := result.Data.(map[string]interface{})
+	for _, field := range data["__type"].(map[string]interface{})["fields"].([]interface{}) {
+		field := field.(map[string]interface{})
+		levels[field["name"].(string)] = field["semanticNonNullLevels"]
+	}
+	expected := map[string]interface{}{
+		"name":     []interface{}{0},
+		"tags":     []interface{}{1},
+		"failing":  []interface{}{0},
+		"nullable": nil,
+	}
+	if !reflect.DeepEqual(expected, levels) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, levels))
+	}
+	if !testutil.ContainSubset(data, map[string]interface{}{
+		"__schema": map[string]interface{}{
+			"directives": []interface{}{
+				map[string]interface{}{"name": "semanticNonNull"},
+			},
+		},
+	}) {
+		t.Fatalf("expected the @semanticNonNull directive, got: %v", data["__schema"])
+	}
+}
@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// ShardKeyDirective marks the argument of a field definition holding the
+// routing key of the data it resolves, e.g. the tenant of a multi-tenant
+// database, see ShardKeys. The argument may name a field of an input object
+// argument with a dotted path, e.g. "input.tenantId":
+//
+//	"orders": &graphql.Field{
+//		Args: graphql.FieldConfigArgument{"tenantId": ...},
+//		AppliedDirectives: []*graphql.AppliedDirective{
+//			{Name: "shardKey", Args: map[string]interface{}{"arg": "tenantId"}},
+//		},
+//	}
+//
+// Only the applied directives are read, adding the directive to the schema
+// merely makes it part of its printed definition.
+var ShardKeyDirective = NewDirective(DirectiveConfig{
+	Name:        "shardKey",
+	Description: "Marks the argument holding the routing key of the data the field resolves.",
+	Args: FieldConfigArgument{
+		"arg": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The name of the argument, or the dotted path of a field of an input object argument.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// ShardKey is a routing key of a request, the value of the argument marked
+// with @shardKey of one of its fields.
+type ShardKey struct {
+	// Path is the path of the field in the response, using "[]" for list
+	// items, see PlanStep.Path.
+	Path       []string
+	ParentType string
+	FieldName  string
+
+	// Arg is the argument of the directive, and Value the value it names,
+	// coerced with the variables of the request, nil when it is not provided.
+	Arg   string
+	Value interface{}
+}
+
+// ShardKeys returns the routing keys of the request described by the given
+// params against the given schema, which takes precedence over p.Schema, so
+// that the caller can select the database shard or the region serving the
+// request before executing it. Like Explain, the request is parsed and
+// validated, but no resolver is called.
+func ShardKeys(schema Schema, p Params) ([]*ShardKey, []gqlerrors.FormattedError) {
+	plan := Explain(schema, p)
+	if len(plan.Errors) != 0 {
+		return nil, plan.Errors
+	}
+	keys := []*ShardKey{}
+	seen := map[string]bool{}
+	var visit func(steps []*PlanStep)
+	visit = func(steps []*PlanStep) {
+		for _, step := range steps {
+			if arg, ok := shardKeyArg(schema, step); ok {
+				// the fields of abstract types are planned for each possible type
+				key := strings.Join(step.Path, ".") + "@" + arg
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, &ShardKey{
+						Path:       step.Path,
+						ParentType: step.ParentType,
+						FieldName:  step.FieldName,
+						Arg:        arg,
+						Value:      shardKeyValue(step.Args, arg),
+					})
+				}
+			}
+			visit(step.Children)
+		}
+	}
+	visit(plan.Steps)
+	return keys, nil
+}
+
+// shardKeyArg returns the argument of the @shardKey directive applied to the
+// field of the step, or to the field of one of the interfaces of its parent
+// type.
+func shardKeyArg(schema Schema, step *PlanStep) (string, bool) {
+	parentType, ok := schema.Type(step.ParentType).(*Object)
+	if !ok {
+		return "", false
+	}
+	definitions := []*FieldDefinition{parentType.Fields()[step.FieldName]}
+	for _, iface := range parentType.Interfaces() {
+		definitions = append(definitions, iface.Fields()[step.FieldName])
+	}
+	for _, fieldDef := range definitions {
+		if fieldDef == nil {
+			continue
+		}
+		for _, directive := range fieldDef.AppliedDirectives {
+			if directive.Name != ShardKeyDirective.Name {
+				continue
+			}
+			if arg, ok := directive.Args["arg"].(string); ok {
+				return arg, true
+			}
+		}
+	}
+	return "", false
+}
+
+// shardKeyValue returns the value of the argument at the given dotted path.
+func shardKeyValue(args map[string]interface{}, arg string) interface{} {
+	var value interface{} = args
+	for _, name := range strings.Split(arg, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func shardKeysTestSchema(t *testing.T) graphql.Schema {
+	shardKey := func(arg string) []*graphql.AppliedDirective {
+		return []*graphql.AppliedDirective{{Name: "shardKey", Args: map[string]interface{}{"arg": arg}}}
+	}
+	order := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	tenant := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tenant",
+		Fields: graphql.Fields{
+			"orders": &graphql.Field{
+				Type: graphql.NewList(order),
+				Args: graphql.FieldConfigArgument{
+					"region": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "eu"},
+				},
+				AppliedDirectives: shardKey("region"),
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"tenant": &graphql.Field{
+					Type: tenant,
+					Args: graphql.FieldConfigArgument{
+						"tenantId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					},
+					AppliedDirectives: shardKey("tenantId"),
+				},
+				"search": &graphql.Field{
+					Type: graphql.NewList(order),
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: graphql.NewInputObject(graphql.InputObjectConfig{
+							Name: "OrderFilter",
+							Fields: graphql.InputObjectConfigFieldMap{
+								"tenantId": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+								"text":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+							},
+						})},
+					},
+					AppliedDirectives: shardKey("filter.tenantId"),
+				},
+				"version": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, graphql.ShardKeyDirective),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestShardKeys(t *testing.T) {
+	schema := shardKeysTestSchema(t)
+	keys, errs := graphql.ShardKeys(schema, graphql.Params{
+		RequestString: `query ($tenant: ID!) {
+			version
+			acme: tenant(tenantId: $tenant) { orders { id } }
+			search(filter: {tenantId: "globex", text: "shoes"}) { id }
+		}`,
+		VariableValues: map[string]interface{}{"tenant": "acme"},
+	})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	expected := []*graphql.ShardKey{
+		{Path: []string{"acme"}, ParentType: "Query", FieldName: "tenant", Arg: "tenantId", Value: "acme"},
+		{Path: []string{"acme", "orders"}, ParentType: "Tenant", FieldName: "orders", Arg: "region", Value: "eu"},
+		{Path: []string{"search"}, ParentType: "Query", FieldName: "search", Arg: "filter.tenantId", Value: "globex"},
+	}
+	if !reflect.DeepEqual(expected, keys) {
+		t.Fatalf("Unexpected keys, Diff: %v", testutil.Diff(expected, keys))
+	}
+}
+
+func TestShardKeys_Errors(t *testing.T) {
+	schema := shardKeysTestSchema(t)
+	keys, errs := graphql.ShardKeys(schema, graphql.Params{
+		RequestString: `query ($tenant: ID!) { tenant(tenantId: $tenant) { orders { id } } }`,
+	})
+	expected := `Variable "$tenant" of required type "ID!" was not provided.`
+	if keys != nil || len(errs) != 1 || errs[0].Message != expected {
+		t.Fatalf("expected error %q, got %v, %v", expected, keys, errs)
+	}
+
+	keys, errs = graphql.ShardKeys(schema, graphql.Params{RequestString: `{ search { id } }`})
+	if len(errs) != 0 || len(keys) != 1 || keys[0].Value != nil {
+		t.Fatalf("expected the key of the missing argument to be nil, got %v, %v", keys, errs)
+	}
+}
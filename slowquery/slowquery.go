@@ -0,0 +1,336 @@
+// Package slowquery provides a graphql.Extension capturing the details of
+// requests that exceed a latency threshold, or that are sampled at a given
+// rate, for offline analysis: the operation text, its variables redacted by a
+// policy, and the timing of each resolver.
+//
+//     schema.AddExtensions(slowquery.New(slowquery.Config{
+//         Threshold: 500 * time.Millisecond,
+//         SampleRate: 0.001,
+//         Sink: slowquery.SinkFunc(func(ctx context.Context, r *slowquery.Record) {
+//             log.Printf("slow query %q took %v", r.OperationName, r.Duration)
+//         }),
+//     }))
+package slowquery
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Redacted replaces the values of redacted variables.
+const Redacted = "[REDACTED]"
+
+// Record describes a captured request.
+type Record struct {
+	OperationName string
+	Query         string
+	Variables     map[string]interface{}
+
+	// VariableTransforms are the names of the graphql.VariableTransforms
+	// applied to the variables, in order, and TransformedVariables the
+	// variables they returned, redacted like Variables.
+	VariableTransforms   []string
+	TransformedVariables map[string]interface{}
+
+	Start    time.Time
+	Duration time.Duration
+
+	// Sampled is set when the request was captured by sampling rather than
+	// because it exceeded the threshold.
+	Sampled bool
+
+	Resolvers []*ResolverTiming
+	Errors    []gqlerrors.FormattedError
+}
+
+// ResolverTiming describes the call of a resolver.
+type ResolverTiming struct {
+	Path       []interface{}
+	ParentType string
+	FieldName  string
+	ReturnType string
+
+	// Offset is the time elapsed since the start of the request when the resolver was called.
+	Offset   time.Duration
+	Duration time.Duration
+}
+
+// Sink receives the captured records. It is called synchronously at the end of
+// the execution, implementations should hand the records off quickly.
+type Sink interface {
+	Capture(ctx context.Context, record *Record)
+}
+
+// SinkFunc is an adapter to allow the use of ordinary functions as sinks.
+type SinkFunc func(ctx context.Context, record *Record)
+
+// Capture calls f(ctx, record).
+func (f SinkFunc) Capture(ctx context.Context, record *Record) {
+	f(ctx, record)
+}
+
+// RedactFunc returns the variables to record in place of the given variables.
+// It must not modify the given map.
+type RedactFunc func(variables map[string]interface{}) map[string]interface{}
+
+// RedactAll redacts the value of every variable, keeping their names.
+func RedactAll(variables map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(variables))
+	for name := range variables {
+		redacted[name] = Redacted
+	}
+	return redacted
+}
+
+// RedactNone records the variables as they are.
+func RedactNone(variables map[string]interface{}) map[string]interface{} {
+	return variables
+}
+
+// RedactNames redacts the values of the variables and input object fields
+// with one of the given names, e.g. "password".
+func RedactNames(names ...string) RedactFunc {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	var redact func(value interface{}) interface{}
+	redact = func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			redacted := make(map[string]interface{}, len(value))
+			for name, v := range value {
+				if set[name] {
+					redacted[name] = Redacted
+					continue
+				}
+				redacted[name] = redact(v)
+			}
+			return redacted
+		case []interface{}:
+			redacted := make([]interface{}, len(value))
+			for i, v := range value {
+				redacted[i] = redact(v)
+			}
+			return redacted
+		}
+		return value
+	}
+	return func(variables map[string]interface{}) map[string]interface{} {
+		redacted, _ := redact(variables).(map[string]interface{})
+		return redacted
+	}
+}
+
+// Config configures the capture of slow queries.
+type Config struct {
+	// Threshold is the duration above which requests are captured, requests
+	// are not captured based on their duration when zero.
+	Threshold time.Duration
+
+	// SampleRate is the fraction of requests captured regardless of their
+	// duration, between 0 and 1.
+	SampleRate float64
+
+	// Sink receives the captured records.
+	Sink Sink
+
+	// Redact is applied to the variables of the captured requests, defaults to RedactAll.
+	Redact RedactFunc
+}
+
+// Extension captures slow queries, see New.
+type Extension struct {
+	config Config
+	random func() float64
+}
+
+// New returns an extension capturing slow queries according to the given config.
+func New(config Config) *Extension {
+	if config.Redact == nil {
+		config.Redact = RedactAll
+	}
+	return &Extension{
+		config: config,
+		random: rand.Float64,
+	}
+}
+
+type traceKeyType int
+
+const traceKey traceKeyType = 0
+
+// trace holds the state of a request being traced.
+type trace struct {
+	params  *graphql.Params
+	start   time.Time
+	sampled bool
+
+	mu                   sync.Mutex
+	resolvers            []*ResolverTiming
+	variableTransforms   []string
+	transformedVariables map[string]interface{}
+}
+
+func traceFromContext(ctx context.Context) *trace {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(traceKey).(*trace)
+	return t
+}
+
+// Init implements graphql.Extension.
+func (e *Extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if e.config.Sink == nil {
+		return ctx
+	}
+	sampled := e.config.SampleRate > 0 && e.random() < e.config.SampleRate
+	if !sampled && e.config.Threshold <= 0 {
+		return ctx
+	}
+	t := &trace{
+		params:  p,
+		start:   time.Now(),
+		sampled: sampled,
+	}
+	p.VariableTransforms = t.traceVariableTransforms(p.VariableTransforms)
+	return context.WithValue(ctx, traceKey, t)
+}
+
+// traceVariableTransforms returns the transforms recording their application
+// in the trace.
+func (t *trace) traceVariableTransforms(transforms []graphql.VariableTransform) []graphql.VariableTransform {
+	traced := make([]graphql.VariableTransform, len(transforms))
+	for i, transform := range transforms {
+		transform := transform
+		if transform.Transform == nil {
+			traced[i] = transform
+			continue
+		}
+		traced[i] = graphql.VariableTransform{
+			Name: transform.Name,
+			Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+				variables, err := transform.Transform(p)
+				if err == nil {
+					t.mu.Lock()
+					t.variableTransforms = append(t.variableTransforms, transform.Name)
+					t.transformedVariables = variables
+					t.mu.Unlock()
+				}
+				return variables, err
+			},
+		}
+	}
+	return traced
+}
+
+// Name implements graphql.Extension.
+func (e *Extension) Name() string {
+	return "SlowQuery"
+}
+
+// ParseDidStart implements graphql.Extension.
+func (e *Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (e *Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart implements graphql.Extension.
+func (e *Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	t := traceFromContext(ctx)
+	if t == nil {
+		return ctx, func(*graphql.Result) {}
+	}
+	return ctx, func(result *graphql.Result) {
+		duration := time.Since(t.start)
+		if !t.sampled && duration < e.config.Threshold {
+			return
+		}
+		e.config.Sink.Capture(ctx, e.record(t, duration, result))
+	}
+}
+
+// record builds the record of the given trace.
+func (e *Extension) record(t *trace, duration time.Duration, result *graphql.Result) *Record {
+	variables := t.params.VariableValues
+	if t.params.RawVariables != nil {
+		if decoded, err := graphql.DecodeVariables(t.params.Codec, t.params.RawVariables); err == nil {
+			variables = decoded
+		}
+	}
+	if variables != nil {
+		variables = e.config.Redact(variables)
+	}
+
+	t.mu.Lock()
+	resolvers := t.resolvers
+	variableTransforms := t.variableTransforms
+	transformedVariables := t.transformedVariables
+	t.mu.Unlock()
+	if transformedVariables != nil {
+		transformedVariables = e.config.Redact(transformedVariables)
+	}
+
+	record := &Record{
+		OperationName: t.params.OperationName,
+		Query:         t.params.RequestString,
+		Variables:     variables,
+		Start:         t.start,
+		Duration:      duration,
+		Sampled:       t.sampled,
+		Resolvers:     resolvers,
+
+		VariableTransforms:   variableTransforms,
+		TransformedVariables: transformedVariables,
+	}
+	if result != nil {
+		record.Errors = result.Errors
+	}
+	return record
+}
+
+// ResolveFieldDidStart implements graphql.Extension.
+func (e *Extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	t := traceFromContext(ctx)
+	if t == nil {
+		return ctx, func(interface{}, error) {}
+	}
+	start := time.Now()
+	timing := &ResolverTiming{
+		Path:      info.Path.AsArray(),
+		FieldName: info.FieldName,
+		Offset:    start.Sub(t.start),
+	}
+	if info.ParentType != nil {
+		timing.ParentType = info.ParentType.Name()
+	}
+	if info.ReturnType != nil {
+		timing.ReturnType = info.ReturnType.String()
+	}
+	return ctx, func(interface{}, error) {
+		timing.Duration = time.Since(start)
+		t.mu.Lock()
+		t.resolvers = append(t.resolvers, timing)
+		t.mu.Unlock()
+	}
+}
+
+// HasResult implements graphql.Extension.
+func (e *Extension) HasResult() bool {
+	return false
+}
+
+// GetResult implements graphql.Extension.
+func (e *Extension) GetResult(context.Context) interface{} {
+	return nil
+}
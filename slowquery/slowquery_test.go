@@ -0,0 +1,176 @@
+package slowquery_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/slowquery"
+)
+
+func newSchema(t *testing.T, ext graphql.Extension) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"slow": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"delay":    &graphql.ArgumentConfig{Type: graphql.Int},
+						"password": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if delay, ok := p.Args["delay"].(int); ok {
+							time.Sleep(time.Duration(delay) * time.Millisecond)
+						}
+						return "done", nil
+					},
+				},
+			},
+		}),
+		Extensions: []graphql.Extension{ext},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+const query = `query Slow($delay: Int, $password: String) { slow(delay: $delay, password: $password) }`
+
+func TestCapturesRequestsAboveThreshold(t *testing.T) {
+	records := []*slowquery.Record{}
+	schema := newSchema(t, slowquery.New(slowquery.Config{
+		Threshold: 20 * time.Millisecond,
+		Sink: slowquery.SinkFunc(func(ctx context.Context, r *slowquery.Record) {
+			records = append(records, r)
+		}),
+	}))
+
+	for _, delay := range []int{0, 40} {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: map[string]interface{}{"delay": delay, "password": "secret"},
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("expected a single record, got: %v", len(records))
+	}
+	record := records[0]
+	if record.Query != query || record.Sampled || record.Duration < 20*time.Millisecond {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+	expectedVariables := map[string]interface{}{"delay": slowquery.Redacted, "password": slowquery.Redacted}
+	if !reflect.DeepEqual(record.Variables, expectedVariables) {
+		t.Fatalf("expected variables to be redacted by default, got: %v", record.Variables)
+	}
+	if len(record.Resolvers) != 1 {
+		t.Fatalf("expected a single resolver timing, got: %v", len(record.Resolvers))
+	}
+	timing := record.Resolvers[0]
+	if timing.FieldName != "slow" || timing.ParentType != "Query" || timing.ReturnType != "String" ||
+		!reflect.DeepEqual(timing.Path, []interface{}{"slow"}) || timing.Duration < 20*time.Millisecond {
+		t.Fatalf("unexpected resolver timing: %+v", timing)
+	}
+}
+
+func TestCapturesSampledRequests(t *testing.T) {
+	records := []*slowquery.Record{}
+	schema := newSchema(t, slowquery.New(slowquery.Config{
+		SampleRate: 1,
+		Sink: slowquery.SinkFunc(func(ctx context.Context, r *slowquery.Record) {
+			records = append(records, r)
+		}),
+		Redact: slowquery.RedactNames("password"),
+	}))
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		RawVariables:  []byte(`{"delay": 0, "password": "secret"}`),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(records) != 1 || !records[0].Sampled {
+		t.Fatalf("expected a sampled record, got: %v", records)
+	}
+	variables := records[0].Variables
+	if variables["password"] != slowquery.Redacted || variables["delay"] == slowquery.Redacted {
+		t.Fatalf("expected only the password to be redacted, got: %v", variables)
+	}
+}
+
+func TestRedactNames(t *testing.T) {
+	redact := slowquery.RedactNames("password", "token")
+	variables := map[string]interface{}{
+		"token": "abc",
+		"input": map[string]interface{}{
+			"name":     "luke",
+			"password": "secret",
+			"friends":  []interface{}{map[string]interface{}{"password": "other"}},
+		},
+	}
+	expected := map[string]interface{}{
+		"token": slowquery.Redacted,
+		"input": map[string]interface{}{
+			"name":     "luke",
+			"password": slowquery.Redacted,
+			"friends":  []interface{}{map[string]interface{}{"password": slowquery.Redacted}},
+		},
+	}
+	if redacted := redact(variables); !reflect.DeepEqual(redacted, expected) {
+		t.Fatalf("unexpected redacted variables: %v", redacted)
+	}
+	if variables["token"] != "abc" {
+		t.Fatalf("expected the variables not to be modified")
+	}
+}
+
+func TestRecordsVariableTransforms(t *testing.T) {
+	records := []*slowquery.Record{}
+	schema := newSchema(t, slowquery.New(slowquery.Config{
+		SampleRate: 1,
+		Sink: slowquery.SinkFunc(func(ctx context.Context, r *slowquery.Record) {
+			records = append(records, r)
+		}),
+		Redact: slowquery.RedactNames("password"),
+	}))
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"password": "secret"},
+		VariableTransforms: []graphql.VariableTransform{{
+			Name: "defaultDelay",
+			Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+				p.Variables["delay"] = 0
+				return p.Variables, nil
+			},
+		}},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected a single record, got: %v", len(records))
+	}
+	record := records[0]
+	if !reflect.DeepEqual(record.VariableTransforms, []string{"defaultDelay"}) {
+		t.Fatalf("expected the transform to be recorded, got: %v", record.VariableTransforms)
+	}
+	expectedVariables := map[string]interface{}{"delay": 0, "password": slowquery.Redacted}
+	if !reflect.DeepEqual(record.TransformedVariables, expectedVariables) {
+		t.Fatalf("expected the transformed variables, got: %v", record.TransformedVariables)
+	}
+	if _, ok := record.Variables["delay"]; ok {
+		t.Fatalf("expected the variables provided by the client, got: %v", record.Variables)
+	}
+}
@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"runtime"
+)
+
+// Stats describes the work done to execute a request, to guide performance tuning.
+// It is only collected when requested with ExecuteParams.CollectStats or Params.CollectStats.
+type Stats struct {
+	// ResolverCalls is the number of resolve functions called.
+	ResolverCalls int
+
+	// CompletedValues is the number of values completed, including list items and nulls.
+	CompletedValues int
+
+	// ResponseBytes is the estimated size of the JSON encoding of the data of
+	// the response, before compression, and ListItems the number of list
+	// items of each top level field, by response key, see
+	// ExecuteParams.ReportResponseSize.
+	ResponseBytes int
+	ListItems     map[string]int
+
+	// Mallocs and AllocatedBytes are the number of heap objects and bytes
+	// allocated during the execution, only collected when CollectAllocations is
+	// set. They are read from the runtime, so they include allocations made
+	// concurrently by other goroutines of the process.
+	Mallocs        uint64
+	AllocatedBytes uint64
+}
+
+// allocationCounter snapshots the allocation counters of the runtime.
+type allocationCounter struct {
+	mallocs    uint64
+	totalAlloc uint64
+}
+
+func readAllocationCounter() allocationCounter {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return allocationCounter{mallocs: m.Mallocs, totalAlloc: m.TotalAlloc}
+}
+
+// since records the allocations made since the snapshot was taken on the given stats.
+func (c allocationCounter) since(stats *Stats) {
+	now := readAllocationCounter()
+	stats.Mallocs = now.mallocs - c.mallocs
+	stats.AllocatedBytes = now.totalAlloc - c.totalAlloc
+}
@@ -0,0 +1,54 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/benchutil"
+)
+
+func TestResultStats(t *testing.T) {
+	schema := benchutil.DeepSchemaWithXLevelsAndYChildren(2, 2)
+	query := benchutil.DeepSchemaQuery(2)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if result.Stats != nil {
+		t.Fatalf("expected no stats unless requested, got: %+v", result.Stats)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		CollectStats:  true,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if result.Stats == nil {
+		t.Fatalf("expected stats to be collected")
+	}
+	// the root field, then depth, name and children of the root and its 2
+	// children, and depth and name of the 4 grandchildren.
+	if result.Stats.ResolverCalls != 1+3*3+4*2 {
+		t.Fatalf("unexpected resolver calls: %v", result.Stats.ResolverCalls)
+	}
+	// the values resolved plus the 6 items of the children lists.
+	if result.Stats.CompletedValues != 1+3*3+4*2+6 {
+		t.Fatalf("unexpected completed values: %v", result.Stats.CompletedValues)
+	}
+	if result.Stats.Mallocs != 0 || result.Stats.AllocatedBytes != 0 {
+		t.Fatalf("expected no allocations unless requested, got: %+v", result.Stats)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:             schema,
+		RequestString:      query,
+		CollectAllocations: true,
+	})
+	if result.Stats == nil || result.Stats.Mallocs == 0 || result.Stats.AllocatedBytes == 0 {
+		t.Fatalf("expected allocations to be collected, got: %+v", result.Stats)
+	}
+}
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -9,6 +10,9 @@ import (
 	"github.com/graphql-go/graphql/language/source"
 )
 
+// ErrSkipEvent can be returned by a SubscriptionEventFn to drop an event.
+var ErrSkipEvent = errors.New("skip subscription event")
+
 // SubscribeParams parameters for subscribing
 type SubscribeParams struct {
 	Schema        Schema
@@ -21,10 +25,18 @@ type SubscribeParams struct {
 	FieldSubscriber FieldResolveFn
 }
 
-// Subscribe performs a subscribe operation on the given query and schema
+// Subscribe performs a subscribe operation on the given query and schema using p.Context.
+//
+// Subscribe is kept for compatibility, new code should prefer SubscribeContext.
+func Subscribe(p Params) chan *Result {
+	return SubscribeContext(p.Context, p)
+}
+
+// SubscribeContext performs a subscribe operation on the given query and schema
 // To finish a subscription you can simply close the channel from inside the `Subscribe` function
+// or cancel the given context.
 // currently does not support extensions hooks
-func Subscribe(p Params) chan *Result {
+func SubscribeContext(ctx context.Context, p Params) chan *Result {
 
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
@@ -38,9 +50,9 @@ func Subscribe(p Params) chan *Result {
 	if err != nil {
 
 		// merge the errors from extensions and the original error from parser
-		return sendOneResultAndClose(&Result{
+		return sendOneResultAndClose(reportErrors(p.Debug, &Result{
 			Errors: gqlerrors.FormatErrors(err),
-		})
+		}))
 	}
 
 	// validate document
@@ -48,19 +60,57 @@ func Subscribe(p Params) chan *Result {
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
-		return sendOneResultAndClose(&Result{
+		return sendOneResultAndClose(reportErrors(p.Debug, &Result{
 			Errors: validationResult.Errors,
-		})
+		}))
 
 	}
-	return ExecuteSubscription(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
-	})
+	variableValues, err := p.variableValues()
+	if err != nil {
+		return sendOneResultAndClose(reportErrors(p.Debug, &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}))
+	}
+	return reportSubscriptionErrors(ctx, p.Debug, ExecuteSubscriptionContext(ctx, ExecuteParams{
+		Schema:          p.Schema,
+		Root:            p.RootObject,
+		AST:             AST,
+		OperationName:   p.OperationName,
+		Args:            variableValues,
+		VariablePresets: p.VariablePresets,
+		VariableLimits:  p.VariableLimits,
+		ResponseLimits:  p.ResponseLimits,
+		Codec:           p.Codec,
+
+		ExecutionID:        p.ExecutionID,
+		ReportExecutionID:  p.ReportExecutionID,
+		VariableTransforms: p.VariableTransforms,
+	}))
+}
+
+// reportSubscriptionErrors returns the channel of the results of the
+// subscription with their internal errors masked, or with the details of
+// their errors in debug mode, see Params.Debug.
+func reportSubscriptionErrors(ctx context.Context, debug bool, results chan *Result) chan *Result {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	reported := make(chan *Result)
+	go func() {
+		defer close(reported)
+		for result := range results {
+			select {
+			case reported <- reportErrors(debug, result):
+			case <-ctx.Done():
+				// drain the results so that the execution is not left
+				// blocked on a send
+				for range results {
+				}
+				return
+			}
+		}
+	}()
+	return reported
 }
 
 func sendOneResultAndClose(res *Result) chan *Result {
@@ -72,23 +122,51 @@ func sendOneResultAndClose(res *Result) chan *Result {
 
 // ExecuteSubscription is similar to graphql.Execute but returns a channel instead of a Result
 // currently does not support extensions
+//
+// ExecuteSubscription is kept for compatibility, new code should prefer ExecuteSubscriptionContext.
 func ExecuteSubscription(p ExecuteParams) chan *Result {
+	return ExecuteSubscriptionContext(p.Context, p)
+}
 
-	if p.Context == nil {
-		p.Context = context.Background()
-	}
+// ExecuteSubscriptionContext is similar to graphql.ExecuteContext but returns a channel instead of a Result
+// currently does not support extensions
+func ExecuteSubscriptionContext(ctx context.Context, p ExecuteParams) chan *Result {
 
-	var mapSourceToResponse = func(payload interface{}) *Result {
-		return Execute(ExecuteParams{
-			Schema:        p.Schema,
-			Root:          payload,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Context:       p.Context,
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// the events of the subscription are executed with its execution ID
+	ctx, p.ExecutionID = withExecutionID(ctx, p.ExecutionID)
+	p.Context = ctx
+
+	var mapSourceToResponse = func(ctx context.Context, payload interface{}) *Result {
+		return ExecuteContext(ctx, ExecuteParams{
+			Schema:          p.Schema,
+			Root:            payload,
+			AST:             p.AST,
+			OperationName:   p.OperationName,
+			Args:            p.Args,
+			VariablePresets: p.VariablePresets,
+			VariableLimits:  p.VariableLimits,
+			ResponseLimits:  p.ResponseLimits,
+			Codec:           p.Codec,
+
+			ExecutionID:        p.ExecutionID,
+			ReportExecutionID:  p.ReportExecutionID,
+			VariableTransforms: p.VariableTransforms,
 		})
 	}
 	var resultChannel = make(chan *Result)
+	// send delivers a result unless the subscription context is done, so the
+	// goroutine does not block forever on a consumer that went away.
+	var send = func(result *Result) bool {
+		select {
+		case resultChannel <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
 	go func() {
 		defer close(resultChannel)
 		defer func() {
@@ -97,35 +175,41 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 				if !ok {
 					return
 				}
-				resultChannel <- &Result{
+				send(&Result{
 					Errors: gqlerrors.FormatErrors(e),
-				}
+				})
 			}
 			return
 		}()
 
 		exeContext, err := buildExecutionContext(buildExecutionCtxParams{
-			Schema:        p.Schema,
-			Root:          p.Root,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Context:       p.Context,
+			Schema:          p.Schema,
+			Root:            p.Root,
+			AST:             p.AST,
+			OperationName:   p.OperationName,
+			Args:            p.Args,
+			VariablePresets: p.VariablePresets,
+			VariableLimits:  p.VariableLimits,
+			Context:         p.Context,
+			Codec:           p.Codec,
+			ExecutionID:     p.ExecutionID,
+
+			VariableTransforms: p.VariableTransforms,
 		})
 
 		if err != nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(err),
-			}
+			})
 
 			return
 		}
 
 		operationType, err := getOperationRootType(p.Schema, exeContext.Operation)
 		if err != nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(err),
-			}
+			})
 
 			return
 		}
@@ -147,9 +231,9 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 		fieldDef := getFieldDef(p.Schema, operationType, fieldName)
 
 		if fieldDef == nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(fmt.Errorf("the subscription field %q is not defined", fieldName)),
-			}
+			})
 
 			return
 		}
@@ -157,16 +241,16 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 		resolveFn := fieldDef.Subscribe
 
 		if resolveFn == nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(fmt.Errorf("the subscription function %q is not defined", fieldName)),
-			}
+			})
 			return
 		}
 		fieldPath := &ResponsePath{
 			Key: responseName,
 		}
 
-		args := getArgumentValues(fieldDef.Args, fieldNode.Arguments, exeContext.VariableValues)
+		args := getArgumentValues(p.Schema.fieldArgs(operationType, fieldDef), fieldNode.Arguments, exeContext.VariableValues)
 		info := ResolveInfo{
 			FieldName:      fieldName,
 			FieldASTs:      fieldNodes,
@@ -178,6 +262,8 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			RootValue:      exeContext.Root,
 			Operation:      exeContext.Operation,
 			VariableValues: exeContext.VariableValues,
+			ExecutionID:    exeContext.ExecutionID,
+			fragments:      fieldFragments(exeContext, fieldNodes),
 		}
 
 		fieldResult, err := resolveFn(ResolveParams{
@@ -187,21 +273,44 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			Context: p.Context,
 		})
 		if err != nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(err),
-			}
+			})
 
 			return
 		}
 
 		if fieldResult == nil {
-			resultChannel <- &Result{
+			send(&Result{
 				Errors: gqlerrors.FormatErrors(fmt.Errorf("no field result")),
-			}
+			})
 
 			return
 		}
 
+		// sendEvent sends the result of the given event, and returns false if
+		// the subscription must end.
+		var sendEvent = func(event interface{}) bool {
+			ctx := p.Context
+			if fieldDef.OnEvent != nil {
+				var err error
+				ctx, event, err = fieldDef.OnEvent(ctx, event)
+				if errors.Is(err, ErrSkipEvent) {
+					return true
+				}
+				if err != nil {
+					send(&Result{
+						Errors: gqlerrors.FormatErrors(err),
+					})
+					return false
+				}
+				if ctx == nil {
+					ctx = p.Context
+				}
+			}
+			return send(mapSourceToResponse(ctx, event))
+		}
+
 		switch fieldResult.(type) {
 		case chan interface{}:
 			sub := fieldResult.(chan interface{})
@@ -214,11 +323,13 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 					if !more {
 						return
 					}
-					resultChannel <- mapSourceToResponse(res)
+					if !sendEvent(res) {
+						return
+					}
 				}
 			}
 		default:
-			resultChannel <- mapSourceToResponse(fieldResult)
+			sendEvent(fieldResult)
 			return
 		}
 	}()
@@ -1,11 +1,14 @@
 package graphql_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/testutil"
 )
 
@@ -87,7 +90,10 @@ func TestSchemaSubscribe(t *testing.T) {
 				}
 			`,
 			ExpectedResults: []testutil.TestResponse{
-				{Errors: []string{"Cannot query field \"xxx\" on type \"Subscription\"."}},
+				{Errors: []string{
+					"Anonymous Subscription must select only one top level field.",
+					"Cannot query field \"xxx\" on type \"Subscription\".",
+				}},
 			},
 		},
 		{
@@ -207,6 +213,42 @@ func TestSchemaSubscribe(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "subscription_on_event_enriches_skips_and_ends",
+			Schema: makeSubscriptionSchema(t, graphql.ObjectConfig{
+				Name: "Subscription",
+				Fields: graphql.Fields{
+					"on_event": &graphql.Field{
+						Type: graphql.String,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return fmt.Sprintf("%v for %v", p.Source, p.Context.Value(subscriberKey)), nil
+						},
+						Subscribe: makeSubscribeToStringFunction([]string{"a", "skip", "b", "skip wrapped", "expired", "c"}),
+						OnEvent: func(ctx context.Context, event interface{}) (context.Context, interface{}, error) {
+							switch event {
+							case "skip":
+								return ctx, nil, graphql.ErrSkipEvent
+							case "skip wrapped":
+								return ctx, nil, fmt.Errorf("not subscribed: %w", graphql.ErrSkipEvent)
+							case "expired":
+								return ctx, nil, errors.New("token expired")
+							}
+							return context.WithValue(ctx, subscriberKey, "luke"), event, nil
+						},
+					},
+				},
+			}),
+			Query: `
+				subscription {
+					on_event
+				}
+			`,
+			ExpectedResults: []testutil.TestResponse{
+				{Data: `{ "on_event": "a for luke" }`},
+				{Data: `{ "on_event": "b for luke" }`},
+				{Errors: []string{"token expired"}},
+			},
+		},
 		{
 			Name: "schema_without_subscribe_errors",
 			Schema: makeSubscriptionSchema(t, graphql.ObjectConfig{
@@ -231,6 +273,51 @@ func TestSchemaSubscribe(t *testing.T) {
 	})
 }
 
+type subscriberKeyType int
+
+const subscriberKey subscriberKeyType = 0
+
+func TestExecuteSubscriptionContext_StopsSendingOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resolved := make(chan struct{})
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					// the consumer goes away before the event is sent
+					cancel()
+					close(resolved)
+					return p.Source, nil
+				},
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					return "a", nil
+				},
+			},
+		},
+	})
+	doc, err := parser.Parse(parser.ParseParams{Source: `subscription { event }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := graphql.ExecuteSubscriptionContext(ctx, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    doc,
+	})
+	<-resolved
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case result, ok := <-results:
+		if ok {
+			t.Fatalf("expected no result after the context is cancelled, got: %v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the results to be closed after the context is cancelled")
+	}
+}
+
 func makeSubscribeToStringFunction(elements []string) func(p graphql.ResolveParams) (interface{}, error) {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		c := make(chan interface{})
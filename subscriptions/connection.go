@@ -0,0 +1,357 @@
+// Package subscriptions multiplexes the subscriptions of a client connection,
+// e.g. a WebSocket, into a single bounded stream of messages, so a slow client
+// can't make the server buffer an unbounded number of results.
+//
+// The transport reads the messages of the connection and writes them to the
+// client, while forwarding the start and stop requests of the client:
+//
+//     conn := subscriptions.NewConnection(ctx, subscriptions.Config{
+//         SendQueueSize:    64,
+//         Overflow:         subscriptions.DropOldest,
+//         MaxSubscriptions: 20,
+//         KeepAlive:        30 * time.Second,
+//     })
+//     defer conn.Close()
+//     go func() {
+//         for msg := range conn.Messages() {
+//             ws.WriteJSON(msg)
+//         }
+//     }()
+//     ...
+//     err := conn.Subscribe(id, graphql.Params{Schema: schema, RequestString: query})
+//
+// It also provides combinators, such as WithFilter and WithMap, to process
+// the events of subscriptions before their selection set is executed.
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Message types sent on a connection.
+const (
+	MessageData      = "data"
+	MessageComplete  = "complete"
+	MessageKeepAlive = "ka"
+)
+
+// DefaultSendQueueSize is the size of the send queue when none is configured.
+const DefaultSendQueueSize = 64
+
+var (
+	// ErrTooManySubscriptions is returned when subscribing beyond Config.MaxSubscriptions.
+	ErrTooManySubscriptions = errors.New("subscriptions: too many subscriptions")
+
+	// ErrDuplicateSubscription is returned when subscribing with the id of an active subscription.
+	ErrDuplicateSubscription = errors.New("subscriptions: duplicate subscription id")
+
+	// ErrConnectionClosed is returned when subscribing on a closed connection.
+	ErrConnectionClosed = errors.New("subscriptions: connection closed")
+
+	// ErrSlowConsumer is the error of connections closed because their send
+	// queue overflowed with the CloseConnection policy.
+	ErrSlowConsumer = errors.New("subscriptions: slow consumer")
+)
+
+// OverflowPolicy describes what to do with a message when the send queue of a connection is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest queued message to make room for the new one,
+	// so a lagging client keeps receiving the latest results.
+	DropOldest OverflowPolicy = iota
+
+	// DropNewest drops the new message.
+	DropNewest
+
+	// CloseConnection closes the connection, dropping the slow client.
+	CloseConnection
+)
+
+// Config configures a connection.
+type Config struct {
+	// SendQueueSize is the maximum number of messages queued for the client,
+	// defaults to DefaultSendQueueSize.
+	SendQueueSize int
+
+	// Overflow is the policy applied to messages when the send queue is full.
+	// Complete messages are never dropped.
+	Overflow OverflowPolicy
+
+	// MaxSubscriptions is the maximum number of concurrent subscriptions, unlimited when zero.
+	MaxSubscriptions int
+
+	// KeepAlive is the interval at which keep-alive messages are queued when
+	// the queue is empty, disabled when zero.
+	KeepAlive time.Duration
+}
+
+// Message is sent to the client.
+type Message struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Payload *graphql.Result `json:"payload,omitempty"`
+}
+
+// Metrics describes the activity of a connection.
+type Metrics struct {
+	// Queued, Dropped and Sent count the messages queued, dropped by the
+	// overflow policy and taken off the queue to be sent to the client.
+	Queued  uint64
+	Dropped uint64
+	Sent    uint64
+
+	// QueueLength is the number of messages currently queued.
+	QueueLength int
+
+	// Subscriptions is the number of active subscriptions.
+	Subscriptions int
+}
+
+// Connection multiplexes the subscriptions of a client.
+type Connection struct {
+	config Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    chan Message
+
+	mu            sync.Mutex
+	subscriptions map[string]context.CancelFunc
+	err           error
+
+	queueMu sync.Mutex
+	queue   []Message
+	ready   chan struct{}
+
+	queued  uint64
+	dropped uint64
+	sent    uint64
+}
+
+// NewConnection returns a connection living until the given context is done or the connection is closed.
+func NewConnection(ctx context.Context, config Config) *Connection {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if config.SendQueueSize <= 0 {
+		config.SendQueueSize = DefaultSendQueueSize
+	}
+	c := &Connection{
+		config:        config,
+		out:           make(chan Message),
+		subscriptions: map[string]context.CancelFunc{},
+		ready:         make(chan struct{}, 1),
+	}
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	go c.send()
+	if config.KeepAlive > 0 {
+		go c.keepAlive()
+	}
+	return c
+}
+
+// Messages returns the messages to send to the client. The channel is closed
+// when the connection is done.
+func (c *Connection) Messages() <-chan Message {
+	return c.out
+}
+
+// Done returns a channel closed when the connection is done.
+func (c *Connection) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// Err returns ErrSlowConsumer if the connection was closed because of its
+// overflow policy, nil otherwise.
+func (c *Connection) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close closes the connection and its subscriptions.
+func (c *Connection) Close() {
+	c.closeWithError(nil)
+}
+
+func (c *Connection) closeWithError(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// Metrics returns the metrics of the connection.
+func (c *Connection) Metrics() Metrics {
+	c.mu.Lock()
+	subscriptions := len(c.subscriptions)
+	c.mu.Unlock()
+	c.queueMu.Lock()
+	queueLength := len(c.queue)
+	c.queueMu.Unlock()
+	return Metrics{
+		Queued:        atomic.LoadUint64(&c.queued),
+		Dropped:       atomic.LoadUint64(&c.dropped),
+		Sent:          atomic.LoadUint64(&c.sent),
+		QueueLength:   queueLength,
+		Subscriptions: subscriptions,
+	}
+}
+
+// Subscribe starts the subscription described by the given params under the
+// given id. Its results are sent as data messages followed by a complete
+// message once the subscription ends.
+func (c *Connection) Subscribe(id string, p graphql.Params) error {
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return ErrConnectionClosed
+	}
+	if _, ok := c.subscriptions[id]; ok {
+		c.mu.Unlock()
+		return ErrDuplicateSubscription
+	}
+	if c.config.MaxSubscriptions > 0 && len(c.subscriptions) >= c.config.MaxSubscriptions {
+		c.mu.Unlock()
+		return ErrTooManySubscriptions
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.subscriptions[id] = cancel
+	c.mu.Unlock()
+
+	go func() {
+		defer c.remove(id, cancel)
+		// the results are drained until the subscription is done, so its
+		// goroutine doesn't block once the connection is closed.
+		for result := range graphql.SubscribeContext(ctx, p) {
+			if ctx.Err() == nil && !c.enqueue(Message{Type: MessageData, ID: id, Payload: result}) {
+				cancel()
+			}
+		}
+		if ctx.Err() == nil {
+			c.enqueue(Message{Type: MessageComplete, ID: id})
+		}
+	}()
+	return nil
+}
+
+// Unsubscribe stops the subscription with the given id.
+func (c *Connection) Unsubscribe(id string) {
+	c.mu.Lock()
+	cancel, ok := c.subscriptions[id]
+	c.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+func (c *Connection) remove(id string, cancel context.CancelFunc) {
+	cancel()
+	c.mu.Lock()
+	delete(c.subscriptions, id)
+	c.mu.Unlock()
+}
+
+// enqueue queues a message according to the overflow policy, and returns
+// false if the connection is done. Complete messages are always queued, which
+// is bounded by the number of subscriptions.
+func (c *Connection) enqueue(msg Message) bool {
+	if c.ctx.Err() != nil {
+		return false
+	}
+	c.queueMu.Lock()
+	if len(c.queue) >= c.config.SendQueueSize && msg.Type != MessageComplete {
+		switch c.config.Overflow {
+		case DropNewest:
+			c.queueMu.Unlock()
+			atomic.AddUint64(&c.dropped, 1)
+			return true
+		case CloseConnection:
+			c.queueMu.Unlock()
+			atomic.AddUint64(&c.dropped, 1)
+			c.closeWithError(ErrSlowConsumer)
+			return false
+		default:
+			c.dropOldest()
+		}
+	}
+	c.queue = append(c.queue, msg)
+	c.queueMu.Unlock()
+	atomic.AddUint64(&c.queued, 1)
+
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// dropOldest drops the oldest queued message that isn't a complete message.
+// The caller must hold queueMu.
+func (c *Connection) dropOldest() {
+	for i, queued := range c.queue {
+		if queued.Type == MessageComplete {
+			continue
+		}
+		copy(c.queue[i:], c.queue[i+1:])
+		c.queue[len(c.queue)-1] = Message{}
+		c.queue = c.queue[:len(c.queue)-1]
+		atomic.AddUint64(&c.dropped, 1)
+		return
+	}
+}
+
+// send moves the queued messages to the output channel until the connection is done.
+func (c *Connection) send() {
+	defer close(c.out)
+	for {
+		c.queueMu.Lock()
+		if len(c.queue) == 0 {
+			c.queueMu.Unlock()
+			select {
+			case <-c.ready:
+				continue
+			case <-c.ctx.Done():
+				return
+			}
+		}
+		msg := c.queue[0]
+		c.queue[0] = Message{}
+		c.queue = c.queue[1:]
+		c.queueMu.Unlock()
+		atomic.AddUint64(&c.sent, 1)
+
+		select {
+		case c.out <- msg:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// keepAlive queues keep-alive messages while the queue is empty.
+func (c *Connection) keepAlive() {
+	ticker := time.NewTicker(c.config.KeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.queueMu.Lock()
+			empty := len(c.queue) == 0
+			c.queueMu.Unlock()
+			if empty {
+				c.enqueue(Message{Type: MessageKeepAlive})
+			}
+		}
+	}
+}
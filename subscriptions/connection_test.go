@@ -0,0 +1,211 @@
+package subscriptions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/subscriptions"
+)
+
+func newSchema(t *testing.T, events chan interface{}) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ok": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"event": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						return events, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func subscribe(t *testing.T, conn *subscriptions.Connection, id string, schema graphql.Schema) {
+	err := conn.Subscribe(id, graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { event }`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func receive(t *testing.T, conn *subscriptions.Connection) subscriptions.Message {
+	select {
+	case msg := <-conn.Messages():
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a message")
+	}
+	return subscriptions.Message{}
+}
+
+// waitFor waits until the metrics of the connection satisfy the given condition.
+func waitFor(t *testing.T, conn *subscriptions.Connection, cond func(subscriptions.Metrics) bool) {
+	deadline := time.Now().Add(time.Second)
+	for !cond(conn.Metrics()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for metrics, got: %+v", conn.Metrics())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConnection_SendsResultsAndComplete(t *testing.T) {
+	events := make(chan interface{})
+	schema := newSchema(t, events)
+	conn := subscriptions.NewConnection(context.Background(), subscriptions.Config{})
+	defer conn.Close()
+	subscribe(t, conn, "1", schema)
+
+	go func() {
+		events <- "a"
+		events <- "b"
+		close(events)
+	}()
+	for _, expected := range []string{"a", "b"} {
+		msg := receive(t, conn)
+		if msg.Type != subscriptions.MessageData || msg.ID != "1" {
+			t.Fatalf("unexpected message: %+v", msg)
+		}
+		data := msg.Payload.Data.(map[string]interface{})
+		if data["event"] != expected {
+			t.Fatalf("expected %v, got: %v", expected, data["event"])
+		}
+	}
+	if msg := receive(t, conn); msg.Type != subscriptions.MessageComplete || msg.ID != "1" {
+		t.Fatalf("expected a complete message, got: %+v", msg)
+	}
+	waitFor(t, conn, func(m subscriptions.Metrics) bool { return m.Subscriptions == 0 })
+	if metrics := conn.Metrics(); metrics.Queued != 3 || metrics.Sent != 3 || metrics.Dropped != 0 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func testOverflow(t *testing.T, policy subscriptions.OverflowPolicy) []string {
+	events := make(chan interface{})
+	schema := newSchema(t, events)
+	conn := subscriptions.NewConnection(context.Background(), subscriptions.Config{
+		SendQueueSize: 2,
+		Overflow:      policy,
+	})
+	defer conn.Close()
+	subscribe(t, conn, "1", schema)
+
+	for _, event := range []string{"a", "b", "c", "d", "e"} {
+		events <- event
+	}
+	// every result is either queued, taken off the queue by the sender, or dropped.
+	waitFor(t, conn, func(m subscriptions.Metrics) bool { return uint64(m.QueueLength)+m.Sent+m.Dropped == 5 })
+	metrics := conn.Metrics()
+	if metrics.Dropped < 2 || metrics.QueueLength > 2 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+
+	received := []string{}
+	for len(received) < 5-int(metrics.Dropped) {
+		msg := receive(t, conn)
+		received = append(received, msg.Payload.Data.(map[string]interface{})["event"].(string))
+	}
+	return received
+}
+
+func TestConnection_DropOldest(t *testing.T) {
+	received := testOverflow(t, subscriptions.DropOldest)
+	if received[len(received)-1] != "e" {
+		t.Fatalf("expected the latest result to be kept, got: %v", received)
+	}
+}
+
+func TestConnection_DropNewest(t *testing.T) {
+	received := testOverflow(t, subscriptions.DropNewest)
+	if received[0] != "a" || received[len(received)-1] == "e" {
+		t.Fatalf("expected the latest results to be dropped, got: %v", received)
+	}
+}
+
+func TestConnection_CloseSlowConsumer(t *testing.T) {
+	events := make(chan interface{})
+	schema := newSchema(t, events)
+	conn := subscriptions.NewConnection(context.Background(), subscriptions.Config{
+		SendQueueSize: 1,
+		Overflow:      subscriptions.CloseConnection,
+	})
+	subscribe(t, conn, "1", schema)
+
+	go func() {
+		for {
+			select {
+			case events <- "a":
+			case <-conn.Done():
+				return
+			}
+		}
+	}()
+	select {
+	case <-conn.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected the connection to be closed")
+	}
+	if conn.Err() != subscriptions.ErrSlowConsumer {
+		t.Fatalf("expected ErrSlowConsumer, got: %v", conn.Err())
+	}
+	for range conn.Messages() {
+	}
+	err := conn.Subscribe("2", graphql.Params{Schema: schema, RequestString: `subscription { event }`})
+	if err != subscriptions.ErrConnectionClosed {
+		t.Fatalf("expected ErrConnectionClosed, got: %v", err)
+	}
+}
+
+func TestConnection_MaxSubscriptions(t *testing.T) {
+	events := make(chan interface{})
+	schema := newSchema(t, events)
+	conn := subscriptions.NewConnection(context.Background(), subscriptions.Config{
+		MaxSubscriptions: 1,
+	})
+	defer conn.Close()
+	subscribe(t, conn, "1", schema)
+
+	params := graphql.Params{Schema: schema, RequestString: `subscription { event }`}
+	if err := conn.Subscribe("1", params); err != subscriptions.ErrDuplicateSubscription {
+		t.Fatalf("expected ErrDuplicateSubscription, got: %v", err)
+	}
+	if err := conn.Subscribe("2", params); err != subscriptions.ErrTooManySubscriptions {
+		t.Fatalf("expected ErrTooManySubscriptions, got: %v", err)
+	}
+
+	conn.Unsubscribe("1")
+	waitFor(t, conn, func(m subscriptions.Metrics) bool { return m.Subscriptions == 0 })
+	if err := conn.Subscribe("2", params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestConnection_KeepAlive(t *testing.T) {
+	conn := subscriptions.NewConnection(context.Background(), subscriptions.Config{
+		KeepAlive: 5 * time.Millisecond,
+	})
+	defer conn.Close()
+	if msg := receive(t, conn); msg.Type != subscriptions.MessageKeepAlive {
+		t.Fatalf("expected a keep-alive message, got: %+v", msg)
+	}
+}
@@ -0,0 +1,83 @@
+package subscriptions
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+)
+
+// FilterFn reports whether an event of a subscription should be sent to the subscriber.
+type FilterFn func(p graphql.ResolveParams, event interface{}) bool
+
+// MapFn transforms an event of a subscription before the selection set is
+// executed against it.
+type MapFn func(p graphql.ResolveParams, event interface{}) interface{}
+
+// WithFilter wraps the Subscribe function of a field so only the events
+// matching the predicate are executed and sent to the subscriber, e.g.
+//
+//     Subscribe: subscriptions.WithFilter(subscribeToMessages, func(p graphql.ResolveParams, event interface{}) bool {
+//         return event.(*Message).To == p.Args["user"]
+//     }),
+func WithFilter(subscribe graphql.FieldResolveFn, predicate FilterFn) graphql.FieldResolveFn {
+	return pipeline(subscribe, func(p graphql.ResolveParams, event interface{}) (interface{}, bool) {
+		return event, predicate(p, event)
+	})
+}
+
+// WithMap wraps the Subscribe function of a field so the events are
+// transformed before the selection set is executed against them.
+func WithMap(subscribe graphql.FieldResolveFn, transform MapFn) graphql.FieldResolveFn {
+	return pipeline(subscribe, func(p graphql.ResolveParams, event interface{}) (interface{}, bool) {
+		return transform(p, event), true
+	})
+}
+
+// pipeline wraps the Subscribe function of a field, passing its events through the given stage.
+func pipeline(subscribe graphql.FieldResolveFn, stage func(graphql.ResolveParams, interface{}) (interface{}, bool)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source, err := subscribe(p)
+		if err != nil || source == nil {
+			return source, err
+		}
+		events, ok := source.(chan interface{})
+		if !ok {
+			// a single event, which ends the subscription once sent.
+			if event, keep := stage(p, source); keep {
+				return event, nil
+			}
+			out := make(chan interface{})
+			close(out)
+			return out, nil
+		}
+
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		out := make(chan interface{})
+		go func() {
+			defer close(out)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, more := <-events:
+					if !more {
+						return
+					}
+					event, keep := stage(p, event)
+					if !keep {
+						continue
+					}
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+		return out, nil
+	}
+}
@@ -0,0 +1,92 @@
+package subscriptions_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/subscriptions"
+)
+
+func TestWithFilterAndMap(t *testing.T) {
+	subscribe := func(p graphql.ResolveParams) (interface{}, error) {
+		events := make(chan interface{})
+		go func() {
+			defer close(events)
+			for _, event := range []string{"luke:hi", "leia:hello", "luke:bye"} {
+				events <- event
+			}
+		}()
+		return events, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ok": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"messages": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"user": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+					Subscribe: subscriptions.WithMap(
+						subscriptions.WithFilter(subscribe, func(p graphql.ResolveParams, event interface{}) bool {
+							return strings.HasPrefix(event.(string), p.Args["user"].(string)+":")
+						}),
+						func(p graphql.ResolveParams, event interface{}) interface{} {
+							return strings.ToUpper(strings.SplitN(event.(string), ":", 2)[1])
+						},
+					),
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := graphql.SubscribeContext(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { messages(user: "luke") }`,
+	})
+	received := []interface{}{}
+	for result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+		received = append(received, result.Data.(map[string]interface{})["messages"])
+	}
+	expected := []interface{}{"HI", "BYE"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected %v, got: %v", expected, received)
+	}
+}
+
+func TestWithFilterSingleEvent(t *testing.T) {
+	subscribe := func(p graphql.ResolveParams) (interface{}, error) {
+		return "event", nil
+	}
+	reject := subscriptions.WithFilter(subscribe, func(graphql.ResolveParams, interface{}) bool { return false })
+	source, err := reject(graphql.ResolveParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, more := <-source.(chan interface{}); more {
+		t.Fatalf("expected a closed channel for a rejected event")
+	}
+
+	accept := subscriptions.WithFilter(subscribe, func(graphql.ResolveParams, interface{}) bool { return true })
+	if source, _ := accept(graphql.ResolveParams{}); source != "event" {
+		t.Fatalf("expected the event, got: %v", source)
+	}
+}
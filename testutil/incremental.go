@@ -0,0 +1,202 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// CheckIncrementalPayloads checks that the payloads of a response delivered
+// incrementally follow the semantics of the incremental delivery RFC, and
+// returns the data of the response once every payload is merged:
+//
+//   - hasNext is set on every payload but the last one;
+//   - every incremental result has a path, and holds either data or items;
+//   - the path of a deferred fragment points to an object already delivered,
+//     and a fragment is delivered once per label and path;
+//   - the path of streamed items points to the next index of a list already
+//     delivered, so items are appended in order.
+func CheckIncrementalPayloads(initial *graphql.InitialIncrementalResult, subsequent []*graphql.SubsequentIncrementalResult) (interface{}, error) {
+	if initial == nil {
+		return nil, fmt.Errorf("missing initial payload")
+	}
+	if initial.HasNext != (len(subsequent) != 0) {
+		return nil, fmt.Errorf("initial payload: hasNext is %v with %v subsequent payloads", initial.HasNext, len(subsequent))
+	}
+	data := copyJSONValue(initial.Data)
+	delivered := map[string]bool{}
+	for i, payload := range subsequent {
+		last := i == len(subsequent)-1
+		if payload.HasNext == last {
+			return nil, fmt.Errorf("payload %v: hasNext is %v on the %v payload", i+1, payload.HasNext, position(last))
+		}
+		for _, result := range payload.Incremental {
+			if err := mergeIncrementalResult(data, result, delivered); err != nil {
+				return nil, fmt.Errorf("payload %v: %v", i+1, err)
+			}
+		}
+	}
+	return data, nil
+}
+
+func position(last bool) string {
+	if last {
+		return "last"
+	}
+	return "intermediate"
+}
+
+// mergeIncrementalResult merges an incremental result into the data delivered so far.
+func mergeIncrementalResult(data interface{}, result *graphql.IncrementalResult, delivered map[string]bool) error {
+	if result.Path == nil {
+		return fmt.Errorf("incremental result %q without path", result.Label)
+	}
+	if result.Data != nil && result.Items != nil {
+		return fmt.Errorf("incremental result %q at %v has both data and items", result.Label, result.Path)
+	}
+
+	if !result.IsStream() {
+		key := fmt.Sprintf("%s:%v", result.Label, result.Path)
+		if delivered[key] {
+			return fmt.Errorf("deferred fragment %q at %v delivered twice", result.Label, result.Path)
+		}
+		delivered[key] = true
+		if result.Data == nil {
+			// the fragment was nulled because of errors
+			if len(result.Errors) == 0 {
+				return fmt.Errorf("deferred fragment %q at %v has neither data nor errors", result.Label, result.Path)
+			}
+			return nil
+		}
+		target, err := valueAtPath(data, result.Path)
+		if err != nil {
+			return fmt.Errorf("deferred fragment %q: %v", result.Label, err)
+		}
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("deferred fragment %q: %v is not an object", result.Label, result.Path)
+		}
+		fields, ok := copyJSONValue(result.Data).(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("deferred fragment %q at %v: data is not an object", result.Label, result.Path)
+		}
+		return mergeObjects(object, fields)
+	}
+
+	if len(result.Path) == 0 {
+		return fmt.Errorf("streamed items %q without path", result.Label)
+	}
+	index, ok := toIndex(result.Path[len(result.Path)-1])
+	if !ok {
+		return fmt.Errorf("streamed items %q: path %v does not end with an index", result.Label, result.Path)
+	}
+	parentPath := result.Path[:len(result.Path)-1]
+	if len(parentPath) == 0 {
+		return fmt.Errorf("streamed items %q: path %v does not point to a list", result.Label, result.Path)
+	}
+	parent, err := valueAtPath(data, parentPath[:len(parentPath)-1])
+	if err != nil {
+		return fmt.Errorf("streamed items %q: list at %v not delivered", result.Label, parentPath)
+	}
+	object, ok := parent.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("streamed items %q: %v is not an object", result.Label, parentPath[:len(parentPath)-1])
+	}
+	key, _ := parentPath[len(parentPath)-1].(string)
+	list, ok := object[key].([]interface{})
+	if !ok {
+		return fmt.Errorf("streamed items %q: %v is not a list", result.Label, parentPath)
+	}
+	if index != len(list) {
+		return fmt.Errorf("streamed items %q: expected index %v, got %v", result.Label, len(list), index)
+	}
+	for _, item := range result.Items {
+		list = append(list, copyJSONValue(item))
+	}
+	object[key] = list
+	return nil
+}
+
+// mergeObjects merges the fields of src into dst, recursively merging objects.
+func mergeObjects(dst, src map[string]interface{}) error {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		existingObject, ok1 := existing.(map[string]interface{})
+		valueObject, ok2 := value.(map[string]interface{})
+		if ok1 && ok2 {
+			if err := mergeObjects(existingObject, valueObject); err != nil {
+				return err
+			}
+			continue
+		}
+		if !reflect.DeepEqual(existing, value) {
+			return fmt.Errorf("field %q delivered twice with different values", key)
+		}
+	}
+	return nil
+}
+
+// valueAtPath returns the value found at the given path of the data.
+func valueAtPath(data interface{}, path []interface{}) (interface{}, error) {
+	value := data
+	for i, segment := range path {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			key, ok := segment.(string)
+			if !ok {
+				return nil, fmt.Errorf("path %v: expected a field name at %v", path, i)
+			}
+			if value, ok = current[key]; !ok {
+				return nil, fmt.Errorf("path %v not delivered", path[:i+1])
+			}
+		case []interface{}:
+			index, ok := toIndex(segment)
+			if !ok || index < 0 || index >= len(current) {
+				return nil, fmt.Errorf("path %v not delivered", path[:i+1])
+			}
+			value = current[index]
+		default:
+			return nil, fmt.Errorf("path %v not delivered", path[:i+1])
+		}
+	}
+	return value, nil
+}
+
+// toIndex converts a path segment decoded from JSON or built in Go to a list index.
+func toIndex(segment interface{}) (int, bool) {
+	switch segment := segment.(type) {
+	case int:
+		return segment, true
+	case float64:
+		return int(segment), float64(int(segment)) == segment
+	case json.Number:
+		i, err := segment.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
+
+// copyJSONValue deep copies maps and lists so merging doesn't modify the payloads.
+func copyJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			copied[k] = copyJSONValue(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, v := range value {
+			copied[i] = copyJSONValue(v)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,119 @@
+package testutil_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func decodeIncrementalPayloads(t *testing.T, payloads ...string) (*graphql.InitialIncrementalResult, []*graphql.SubsequentIncrementalResult) {
+	initial := &graphql.InitialIncrementalResult{}
+	if err := json.Unmarshal([]byte(payloads[0]), initial); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subsequent := []*graphql.SubsequentIncrementalResult{}
+	for _, payload := range payloads[1:] {
+		result := &graphql.SubsequentIncrementalResult{}
+		if err := json.Unmarshal([]byte(payload), result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		subsequent = append(subsequent, result)
+	}
+	return initial, subsequent
+}
+
+// rfcPayloads are the payloads of the example of the incremental delivery RFC for:
+//
+//     {
+//       person(id: "cGVvcGxlOjE=") {
+//         ...HomeWorldFragment @defer(label: "homeWorldDefer")
+//         name
+//         films @stream(initialCount: 2, label: "filmsStream") { title }
+//       }
+//     }
+//     fragment HomeWorldFragment on Person { homeWorld { name } }
+var rfcPayloads = []string{
+	`{"data": {"person": {"name": "Luke Skywalker", "films": [{"title": "A New Hope"}, {"title": "The Empire Strikes Back"}]}}, "hasNext": true}`,
+	`{"incremental": [{"label": "homeWorldDefer", "path": ["person"], "data": {"homeWorld": {"name": "Tatooine"}}}], "hasNext": true}`,
+	`{"incremental": [{"label": "filmsStream", "path": ["person", "films", 2], "items": [{"title": "Return of the Jedi"}]}], "hasNext": false}`,
+}
+
+func TestCheckIncrementalPayloads_RFCExample(t *testing.T) {
+	initial, subsequent := decodeIncrementalPayloads(t, rfcPayloads...)
+	data, err := testutil.CheckIncrementalPayloads(initial, subsequent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var expected interface{}
+	err = json.Unmarshal([]byte(`{"person": {
+		"name": "Luke Skywalker",
+		"homeWorld": {"name": "Tatooine"},
+		"films": [{"title": "A New Hope"}, {"title": "The Empire Strikes Back"}, {"title": "Return of the Jedi"}]
+	}}`), &expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("unexpected merged data: %v", testutil.Diff(expected, data))
+	}
+	if _, ok := initial.Data.(map[string]interface{})["person"].(map[string]interface{})["homeWorld"]; ok {
+		t.Fatalf("expected the payloads not to be modified")
+	}
+}
+
+func TestCheckIncrementalPayloads_Violations(t *testing.T) {
+	tests := []struct {
+		name     string
+		payloads []string
+		err      string
+	}{
+		{
+			name:     "hasNext on last payload",
+			payloads: []string{rfcPayloads[0], rfcPayloads[1], strings.Replace(rfcPayloads[2], `"hasNext": false`, `"hasNext": true`, 1)},
+			err:      "hasNext is true on the last payload",
+		},
+		{
+			name:     "no hasNext on intermediate payload",
+			payloads: []string{rfcPayloads[0], strings.Replace(rfcPayloads[1], `"hasNext": true`, `"hasNext": false`, 1), rfcPayloads[2]},
+			err:      "hasNext is false on the intermediate payload",
+		},
+		{
+			name:     "initial hasNext without subsequent payloads",
+			payloads: []string{rfcPayloads[0]},
+			err:      "initial payload: hasNext is true",
+		},
+		{
+			name:     "items out of order",
+			payloads: []string{rfcPayloads[0], rfcPayloads[1], strings.Replace(rfcPayloads[2], `"films", 2]`, `"films", 3]`, 1)},
+			err:      "expected index 2, got 3",
+		},
+		{
+			name:     "deferred fragment delivered twice",
+			payloads: []string{rfcPayloads[0], rfcPayloads[1], rfcPayloads[1], rfcPayloads[2]},
+			err:      `deferred fragment "homeWorldDefer" at [person] delivered twice`,
+		},
+		{
+			name:     "deferred fragment before its parent",
+			payloads: []string{rfcPayloads[0], strings.Replace(rfcPayloads[1], `["person"]`, `["person", "starship"]`, 1), rfcPayloads[2]},
+			err:      "path [person starship] not delivered",
+		},
+		{
+			name:     "incremental result without path",
+			payloads: []string{rfcPayloads[0], strings.Replace(rfcPayloads[1], `"path": ["person"], `, ``, 1), rfcPayloads[2]},
+			err:      "without path",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			initial, subsequent := decodeIncrementalPayloads(t, test.payloads...)
+			_, err := testutil.CheckIncrementalPayloads(initial, subsequent)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
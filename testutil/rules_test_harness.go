@@ -487,6 +487,10 @@ func init() {
 				Name:      "onInlineFragment",
 				Locations: []string{graphql.DirectiveLocationInlineFragment},
 			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onVariableDefinition",
+				Locations: []string{graphql.DirectiveLocationVariableDefinition},
+			}),
 			graphql.NewDirective(graphql.DirectiveConfig{
 				Name:      "onSchema",
 				Locations: []string{graphql.DirectiveLocationSchema},
@@ -617,7 +621,8 @@ func RuleError(message string, locs ...int) gqlerrors.FormattedError {
 		})
 	}
 	return gqlerrors.FormattedError{
-		Message:   message,
-		Locations: locations,
+		Message:    message,
+		Locations:  locations,
+		Extensions: map[string]interface{}{"code": gqlerrors.CodeValidationFailed},
 	}
 }
@@ -365,6 +365,28 @@ func TestExecute(t *testing.T, ep graphql.ExecuteParams) *graphql.Result {
 	return graphql.Execute(ep)
 }
 
+// NewSchema builds the schema of the config, failing the test if it is invalid.
+func NewSchema(t *testing.T, config graphql.SchemaConfig) graphql.Schema {
+	t.Helper()
+	schema, err := graphql.NewSchema(config)
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	return schema
+}
+
+// NewQuerySchema builds a schema with a Query type of the given fields,
+// failing the test if it is invalid.
+func NewQuerySchema(t *testing.T, fields graphql.Fields) graphql.Schema {
+	t.Helper()
+	return NewSchema(t, graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: fields,
+		}),
+	})
+}
+
 func Diff(want, got interface{}) []string {
 	return []string{fmt.Sprintf("\ngot: %v", got), fmt.Sprintf("\nwant: %v\n", want)}
 }
@@ -0,0 +1,153 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// tracingExtension records the timing of the phases of a request and of the
+// calls of its resolvers, and reports them in the Apollo Tracing format, see
+// Params.EnableTracing.
+//
+// The duration of a resolver ends when the extensions are notified that it
+// returned: the resolvers called concurrently, see Params.Concurrency, are
+// only reported as done once their siblings are.
+type tracingExtension struct{}
+
+type tracingKeyType int
+
+const tracingKey tracingKeyType = 0
+
+// requestTrace is the trace of a request.
+type requestTrace struct {
+	start time.Time
+
+	mu         sync.Mutex
+	parsing    tracingPhase
+	validation tracingPhase
+	resolvers  []map[string]interface{}
+}
+
+// tracingPhase is the timing of a phase of a request, relative to its start.
+type tracingPhase struct {
+	startOffset time.Duration
+	duration    time.Duration
+}
+
+func (p tracingPhase) result() map[string]interface{} {
+	return map[string]interface{}{
+		"startOffset": p.startOffset.Nanoseconds(),
+		"duration":    p.duration.Nanoseconds(),
+	}
+}
+
+func traceFromContext(ctx context.Context) *requestTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(tracingKey).(*requestTrace)
+	return trace
+}
+
+// phase returns the function recording the phase started now when called.
+func (trace *requestTrace) phase(phase *tracingPhase) func() {
+	start := time.Now()
+	return func() {
+		trace.mu.Lock()
+		defer trace.mu.Unlock()
+		phase.startOffset = start.Sub(trace.start)
+		phase.duration = time.Since(start)
+	}
+}
+
+// Init implements Extension, starting the trace of the request.
+func (tracingExtension) Init(ctx context.Context, p *Params) context.Context {
+	return context.WithValue(ctx, tracingKey, &requestTrace{start: time.Now()})
+}
+
+// Name implements Extension.
+func (tracingExtension) Name() string {
+	return "tracing"
+}
+
+// ParseDidStart implements Extension.
+func (tracingExtension) ParseDidStart(ctx context.Context) (context.Context, ParseFinishFunc) {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return ctx, func(error) {}
+	}
+	finish := trace.phase(&trace.parsing)
+	return ctx, func(error) { finish() }
+}
+
+// ValidationDidStart implements Extension.
+func (tracingExtension) ValidationDidStart(ctx context.Context) (context.Context, ValidationFinishFunc) {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return ctx, func([]gqlerrors.FormattedError) {}
+	}
+	finish := trace.phase(&trace.validation)
+	return ctx, func([]gqlerrors.FormattedError) { finish() }
+}
+
+// ExecutionDidStart implements Extension.
+func (tracingExtension) ExecutionDidStart(ctx context.Context) (context.Context, ExecutionFinishFunc) {
+	return ctx, func(*Result) {}
+}
+
+// ResolveFieldDidStart implements Extension, recording the call of the
+// resolver of the field.
+func (tracingExtension) ResolveFieldDidStart(ctx context.Context, info *ResolveInfo) (context.Context, ResolveFieldFinishFunc) {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return ctx, func(interface{}, error) {}
+	}
+	start := time.Now()
+	return ctx, func(interface{}, error) {
+		resolver := map[string]interface{}{
+			"path":        info.Path.AsArray(),
+			"parentType":  info.ParentType.Name(),
+			"fieldName":   info.FieldName,
+			"returnType":  info.ReturnType.String(),
+			"startOffset": start.Sub(trace.start).Nanoseconds(),
+			"duration":    time.Since(start).Nanoseconds(),
+		}
+		trace.mu.Lock()
+		defer trace.mu.Unlock()
+		trace.resolvers = append(trace.resolvers, resolver)
+	}
+}
+
+// HasResult implements Extension.
+func (tracingExtension) HasResult() bool {
+	return true
+}
+
+// GetResult implements Extension, returning the trace of the request.
+func (tracingExtension) GetResult(ctx context.Context) interface{} {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return nil
+	}
+	end := time.Now()
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	resolvers := trace.resolvers
+	if resolvers == nil {
+		resolvers = []map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"version":    1,
+		"startTime":  trace.start.UTC().Format(time.RFC3339Nano),
+		"endTime":    end.UTC().Format(time.RFC3339Nano),
+		"duration":   end.Sub(trace.start).Nanoseconds(),
+		"parsing":    trace.parsing.result(),
+		"validation": trace.validation.result(),
+		"execution": map[string]interface{}{
+			"resolvers": resolvers,
+		},
+	}
+}
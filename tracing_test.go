@@ -0,0 +1,68 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestEnableTracing(t *testing.T) {
+	query := `{ hero { name friends { name } } }`
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+		EnableTracing: true,
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	tracing, ok := result.Extensions["tracing"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected the tracing extension, got %v", result.Extensions)
+	}
+	if tracing["version"] != 1 || tracing["duration"].(int64) <= 0 {
+		t.Fatalf("unexpected tracing: %v", tracing)
+	}
+	start, err := time.Parse(time.RFC3339Nano, tracing["startTime"].(string))
+	if err != nil {
+		t.Fatalf("unexpected start time: %v", err)
+	}
+	end, err := time.Parse(time.RFC3339Nano, tracing["endTime"].(string))
+	if err != nil || end.Before(start) {
+		t.Fatalf("unexpected end time: %v, %v", tracing["endTime"], err)
+	}
+	parsing := tracing["parsing"].(map[string]interface{})
+	validation := tracing["validation"].(map[string]interface{})
+	if validation["startOffset"].(int64) < parsing["startOffset"].(int64)+parsing["duration"].(int64) {
+		t.Fatalf("expected the validation to start after the parsing, got %v and %v", parsing, validation)
+	}
+
+	resolvers := tracing["execution"].(map[string]interface{})["resolvers"].([]map[string]interface{})
+	// hero, its name and friends, and the names of its 3 friends
+	if len(resolvers) != 6 {
+		t.Fatalf("expected 6 resolvers, got %v", resolvers)
+	}
+	var friend map[string]interface{}
+	for _, resolver := range resolvers {
+		if reflect.DeepEqual(resolver["path"], []interface{}{"hero", "friends", 1, "name"}) {
+			friend = resolver
+		}
+		if resolver["startOffset"].(int64) < validation["startOffset"].(int64) || resolver["duration"].(int64) < 0 {
+			t.Fatalf("unexpected timing of the resolver: %v", resolver)
+		}
+	}
+	if friend == nil || friend["parentType"] != "Human" || friend["fieldName"] != "name" || friend["returnType"] != "String" {
+		t.Fatalf("unexpected resolver of the name of the second friend: %v", friend)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+	})
+	if _, ok := result.Extensions["tracing"]; ok {
+		t.Fatalf("expected the tracing to be reported only when enabled")
+	}
+}
@@ -5,36 +5,36 @@ import (
 )
 
 func TestIsEqualType_SameReferenceAreEqual(t *testing.T) {
-	if !isEqualType(String, String) {
+	if !IsEqualType(String, String) {
 		t.Fatalf("Expected same reference to be equal")
 	}
 }
 
 func TestIsEqualType_IntAndFloatAreNotEqual(t *testing.T) {
-	if isEqualType(Int, Float) {
+	if IsEqualType(Int, Float) {
 		t.Fatalf("Expected GraphQLInt and GraphQLFloat to not equal")
 	}
 }
 
 func TestIsEqualType_ListsOfSameTypeAreEqual(t *testing.T) {
-	if !isEqualType(NewList(Int), NewList(Int)) {
+	if !IsEqualType(NewList(Int), NewList(Int)) {
 		t.Fatalf("Expected lists of same type are equal")
 	}
 }
 
 func TestIsEqualType_ListsAreNotEqualToItem(t *testing.T) {
-	if isEqualType(NewList(Int), Int) {
+	if IsEqualType(NewList(Int), Int) {
 		t.Fatalf("Expected lists are not equal to item")
 	}
 }
 
 func TestIsEqualType_NonNullOfSameTypeAreEqual(t *testing.T) {
-	if !isEqualType(NewNonNull(Int), NewNonNull(Int)) {
+	if !IsEqualType(NewNonNull(Int), NewNonNull(Int)) {
 		t.Fatalf("Expected non-null of same type are equal")
 	}
 }
 func TestIsEqualType_NonNullIsNotEqualToNullable(t *testing.T) {
-	if isEqualType(NewNonNull(Int), Int) {
+	if IsEqualType(NewNonNull(Int), Int) {
 		t.Fatalf("Expected non-null is not equal to nullable")
 	}
 }
@@ -56,7 +56,7 @@ func TestIsTypeSubTypeOf_SameReferenceIsSubtype(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if !isTypeSubTypeOf(schema, String, String) {
+	if !IsTypeSubTypeOf(schema, String, String) {
 		t.Fatalf("Expected same reference is subtype")
 	}
 }
@@ -64,7 +64,7 @@ func TestIsTypeSubTypeOf_IntIsNotSubtypeOfFloat(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if isTypeSubTypeOf(schema, Int, Float) {
+	if IsTypeSubTypeOf(schema, Int, Float) {
 		t.Fatalf("Expected int is not subtype of float")
 	}
 }
@@ -72,7 +72,7 @@ func TestIsTypeSubTypeOf_NonNullIsSubtypeOfNullable(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if !isTypeSubTypeOf(schema, NewNonNull(Int), Int) {
+	if !IsTypeSubTypeOf(schema, NewNonNull(Int), Int) {
 		t.Fatalf("Expected non-null is subtype of nullable")
 	}
 }
@@ -80,7 +80,7 @@ func TestIsTypeSubTypeOf_NullableIsNotSubtypeOfNonNull(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if isTypeSubTypeOf(schema, Int, NewNonNull(Int)) {
+	if IsTypeSubTypeOf(schema, Int, NewNonNull(Int)) {
 		t.Fatalf("Expected nullable is not subtype of non-null")
 	}
 }
@@ -88,7 +88,7 @@ func TestIsTypeSubTypeOf_ItemIsNotSubTypeOfList(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if isTypeSubTypeOf(schema, Int, NewList(Int)) {
+	if IsTypeSubTypeOf(schema, Int, NewList(Int)) {
 		t.Fatalf("Expected item is not subtype of list")
 	}
 }
@@ -96,7 +96,7 @@ func TestIsTypeSubTypeOf_ListIsNotSubtypeOfItem(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: String},
 	})
-	if isTypeSubTypeOf(schema, NewList(Int), Int) {
+	if IsTypeSubTypeOf(schema, NewList(Int), Int) {
 		t.Fatalf("Expected list is not subtype of item")
 	}
 }
@@ -118,7 +118,7 @@ func TestIsTypeSubTypeOf_MemberIsSubtypeOfUnion(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: unionType},
 	})
-	if !isTypeSubTypeOf(schema, memberType, unionType) {
+	if !IsTypeSubTypeOf(schema, memberType, unionType) {
 		t.Fatalf("Expected member is subtype of union")
 	}
 }
@@ -143,7 +143,49 @@ func TestIsTypeSubTypeOf_ImplementationIsSubtypeOfInterface(t *testing.T) {
 	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
 		"field": &Field{Type: implType},
 	})
-	if !isTypeSubTypeOf(schema, implType, ifaceType) {
+	if !IsTypeSubTypeOf(schema, implType, ifaceType) {
 		t.Fatalf("Expected implementation is subtype of interface")
 	}
 }
+
+func TestDoTypesOverlap_AbstractTypesOverlapThroughTheirPossibleTypes(t *testing.T) {
+	ifaceType := NewInterface(InterfaceConfig{
+		Name: "Interface",
+		Fields: Fields{
+			"field": &Field{Type: String},
+		},
+	})
+	implType := NewObject(ObjectConfig{
+		Name: "Object",
+		IsTypeOf: func(p IsTypeOfParams) bool {
+			return true
+		},
+		Interfaces: []*Interface{ifaceType},
+		Fields: Fields{
+			"field": &Field{Type: String},
+		},
+	})
+	otherType := NewObject(ObjectConfig{
+		Name: "Other",
+		IsTypeOf: func(p IsTypeOfParams) bool {
+			return false
+		},
+		Fields: Fields{
+			"field": &Field{Type: String},
+		},
+	})
+	unionType := NewUnion(UnionConfig{
+		Name:  "Union",
+		Types: []*Object{implType, otherType},
+	})
+	schema := testSchemaForIsTypeSubTypeOfTest(t, Fields{
+		"iface": &Field{Type: ifaceType},
+		"union": &Field{Type: unionType},
+	})
+	if !DoTypesOverlap(schema, ifaceType, unionType) || !DoTypesOverlap(schema, unionType, implType) {
+		t.Fatalf("Expected the abstract types to overlap through their possible types")
+	}
+	if DoTypesOverlap(schema, ifaceType, otherType) || DoTypesOverlap(schema, implType, otherType) {
+		t.Fatalf("Expected types without common possible type not to overlap")
+	}
+}
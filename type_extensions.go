@@ -0,0 +1,34 @@
+package graphql
+
+// TypeExtensions returns the custom metadata of the named type of the given
+// type, see ScalarConfig.Extensions, nil if it has none, e.g. the metadata of
+// the return type of the resolved field:
+//
+//	graphql.TypeExtensions(p.Info.ReturnType)["cacheControl"]
+func TypeExtensions(ttype Type) map[string]interface{} {
+	switch ttype := GetNamed(ttype).(type) {
+	case *Scalar:
+		return ttype.Extensions
+	case *Object:
+		return ttype.Extensions
+	case *Interface:
+		return ttype.Extensions
+	case *Union:
+		return ttype.Extensions
+	case *Enum:
+		return ttype.Extensions
+	case *InputObject:
+		return ttype.Extensions
+	}
+	return nil
+}
+
+// FieldExtensions returns the custom metadata of the resolved field, see
+// Field.Extensions, nil if it has none.
+func (info ResolveInfo) FieldExtensions() map[string]interface{} {
+	object, _ := info.ParentType.(*Object)
+	if fieldDef := getFieldDef(info.Schema, object, info.FieldName); fieldDef != nil {
+		return fieldDef.Extensions
+	}
+	return nil
+}
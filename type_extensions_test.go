@@ -0,0 +1,77 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestExtensions_AreKeptOnTheSchemaAndAvailableToResolvers(t *testing.T) {
+	var fieldExtensions, typeExtensions map[string]interface{}
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"term": &graphql.InputObjectFieldConfig{
+				Type:       graphql.String,
+				Extensions: map[string]interface{}{"searchable": true},
+			},
+		},
+		Extensions: map[string]interface{}{"kind": "filter"},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		Extensions: map[string]interface{}{"key": "id"},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.NewNonNull(user),
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{
+							Type:       filter,
+							Extensions: map[string]interface{}{"audit": true},
+						},
+					},
+					Extensions: map[string]interface{}{"authz": "admin"},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						fieldExtensions = p.Info.FieldExtensions()
+						typeExtensions = graphql.TypeExtensions(p.Info.ReturnType)
+						return map[string]interface{}{"name": "Jane"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { name } }`})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if expected := map[string]interface{}{"authz": "admin"}; !reflect.DeepEqual(fieldExtensions, expected) {
+		t.Fatalf("expected field extensions %v, got: %v", expected, fieldExtensions)
+	}
+	if expected := map[string]interface{}{"key": "id"}; !reflect.DeepEqual(typeExtensions, expected) {
+		t.Fatalf("expected type extensions %v, got: %v", expected, typeExtensions)
+	}
+
+	field := schema.QueryType().Fields()["user"]
+	if field.Args[0].Extensions["audit"] != true {
+		t.Fatalf("expected the argument extensions to be kept, got: %v", field.Args[0].Extensions)
+	}
+	input := schema.Type("Filter").(*graphql.InputObject)
+	if input.Extensions["kind"] != "filter" || input.Fields()["term"].Extensions["searchable"] != true {
+		t.Fatalf("expected the input object extensions to be kept")
+	}
+	if graphql.TypeExtensions(graphql.String) != nil {
+		t.Fatalf("expected no extensions on a specified scalar")
+	}
+}
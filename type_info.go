@@ -22,11 +22,18 @@ type TypeInfo struct {
 	directive       *Directive
 	argument        *Argument
 	getFieldDef     fieldDefFn
+	fragment        func(name string) *ast.FragmentDefinition
+	fragmentSpread  *ast.FragmentSpread
 }
 
 type TypeInfoConfig struct {
 	Schema *Schema
 
+	// Fragment returns the definition of the fragment with the given name, it
+	// is used to type the arguments of fragment spreads, see the experimental
+	// parser.ParseOptions.ExperimentalFragmentVariables.
+	Fragment func(name string) *ast.FragmentDefinition
+
 	// NOTE: this experimental optional second parameter is only needed in order
 	// to support non-spec-compliant codebases. You should never need to use it.
 	// It may disappear in the future.
@@ -41,6 +48,7 @@ func NewTypeInfo(opts *TypeInfoConfig) *TypeInfo {
 	return &TypeInfo{
 		schema:      opts.Schema,
 		getFieldDef: getFieldDef,
+		fragment:    opts.Fragment,
 	}
 }
 
@@ -109,6 +117,8 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 			nameVal = node.Name.Value
 		}
 		ti.directive = schema.Directive(nameVal)
+	case *ast.FragmentSpread:
+		ti.fragmentSpread = node
 	case *ast.OperationDefinition:
 		if node.Operation == ast.OperationTypeQuery {
 			ttype = schema.QueryType()
@@ -152,6 +162,8 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 					argDef = arg
 				}
 			}
+		} else if ti.fragmentSpread != nil {
+			argDef = ti.fragmentArgument(schema, nameVal)
 		} else if fieldDef != nil {
 			for _, arg := range fieldDef.Args {
 				if arg.Name() == nameVal {
@@ -206,6 +218,8 @@ func (ti *TypeInfo) Leave(node ast.Node) {
 		}
 	case kinds.Directive:
 		ti.directive = nil
+	case kinds.FragmentSpread:
+		ti.fragmentSpread = nil
 	case kinds.OperationDefinition, kinds.InlineFragment, kinds.FragmentDefinition:
 		// pop ti.typeStack
 		if len(ti.typeStack) > 0 {
@@ -230,6 +244,34 @@ func (ti *TypeInfo) Leave(node ast.Node) {
 	}
 }
 
+// fragmentArgument returns the argument of the current fragment spread with
+// the given name, defined by a variable of the fragment.
+func (ti *TypeInfo) fragmentArgument(schema *Schema, name string) *Argument {
+	if ti.fragment == nil || ti.fragmentSpread.Name == nil {
+		return nil
+	}
+	fragment := ti.fragment(ti.fragmentSpread.Name.Value)
+	if fragment == nil {
+		return nil
+	}
+	for _, varDef := range fragment.VariableDefinitions {
+		if varDef == nil || varDef.Variable == nil || varDef.Variable.Name == nil ||
+			varDef.Variable.Name.Value != name {
+			continue
+		}
+		ttype, err := typeFromAST(*schema, varDef.Type)
+		if err != nil || !IsInputType(ttype) {
+			return nil
+		}
+		inputType, _ := ttype.(Input)
+		return &Argument{
+			PrivateName: name,
+			Type:        inputType,
+		}
+	}
+	return nil
+}
+
 // DefaultTypeInfoFieldDef Not exactly the same as the executor's definition of FieldDef, in this
 // statically evaluated environment we do not always have an Object type,
 // and need to handle Interface and Union types.
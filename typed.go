@@ -0,0 +1,36 @@
+package graphql
+
+// Typed is the value of a field of an abstract type, an interface or a union,
+// along with the name of its object type, resolving the type of the value
+// without the ResolveType function of the abstract type nor the IsTypeOf
+// functions of its possible types, e.g. for values decoded from JSON or
+// returned by a remote service:
+//
+//	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+//		item := p.Source.(map[string]interface{})["item"].(map[string]interface{})
+//		return graphql.Typed{TypeName: item["__typename"].(string), Value: item}, nil
+//	},
+//
+// The type must be a possible type of the abstract type of the field, and
+// the value is then completed like any value of the type, the field being null
+// when the value is nil. Lists of abstract types may hold Typed items.
+// A union relying only on Typed values, without a ResolveType function nor
+// IsTypeOf functions, must declare it with UnionConfig.TypedValues.
+type Typed struct {
+	TypeName string
+	Value    interface{}
+}
+
+// typedValue returns the Typed value resolved by a field, given by value or by
+// pointer.
+func typedValue(result interface{}) (Typed, bool) {
+	switch typed := result.(type) {
+	case Typed:
+		return typed, true
+	case *Typed:
+		if typed != nil {
+			return *typed, true
+		}
+	}
+	return Typed{}, false
+}
@@ -11,6 +11,9 @@ type Result struct {
 	Data       interface{}                `json:"data"`
 	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
 	Extensions map[string]interface{}     `json:"extensions,omitempty"`
+
+	// Stats is set when collecting execution stats was requested.
+	Stats *Stats `json:"-"`
 }
 
 // HasErrors just a simple function to help you decide if the result has errors or not
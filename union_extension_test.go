@@ -0,0 +1,160 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type unionExtensionArticle struct{ Title string }
+type unionExtensionPhoto struct{ URL string }
+
+func unionExtensionSchema(t *testing.T) (graphql.Schema, *graphql.Union, *graphql.Object) {
+	article := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Article",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(unionExtensionArticle)
+			return ok
+		},
+	})
+	photo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Photo",
+		Fields: graphql.Fields{
+			"url": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(unionExtensionPhoto)
+			return ok
+		},
+	})
+	searchResult := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "SearchResult",
+		Types: []*graphql.Object{article},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"search": &graphql.Field{
+					Type: graphql.NewList(searchResult),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							unionExtensionArticle{Title: "GraphQL"},
+							unionExtensionPhoto{URL: "graphql.png"},
+						}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{photo},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema, searchResult, photo
+}
+
+func searchSchema(schema graphql.Schema) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			search {
+				... on Article { title }
+				... on Photo { url }
+			}
+		}`,
+	})
+}
+
+func TestUnionAppendType_RefreshesPossibleTypes(t *testing.T) {
+	schema, searchResult, photo := unionExtensionSchema(t)
+
+	// fill the possible types cache before extending the union
+	if schema.IsPossibleType(searchResult, photo) {
+		t.Fatalf("Photo is not a member of SearchResult yet")
+	}
+	if result := searchSchema(schema); len(result.Errors) == 0 {
+		t.Fatalf("expected an error resolving a Photo, got %v", result)
+	}
+
+	if err := searchResult.AppendType(photo); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.AppendType(photo); err != nil {
+		t.Fatal(err)
+	}
+	if !schema.IsPossibleType(searchResult, photo) {
+		t.Fatalf("expected Photo to be a possible type of SearchResult")
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"search": []interface{}{
+				map[string]interface{}{"title": "GraphQL"},
+				map[string]interface{}{"url": "graphql.png"},
+			},
+		},
+	}
+	if result := searchSchema(schema); !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestUnionAppendType_RejectsExistingMember(t *testing.T) {
+	_, searchResult, photo := unionExtensionSchema(t)
+	if err := searchResult.AppendType(photo); err != nil {
+		t.Fatal(err)
+	}
+	err := searchResult.AppendType(photo)
+	expected := "SearchResult already contains the type Photo."
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestSchemaExtendUnion(t *testing.T) {
+	schema, searchResult, photo := unionExtensionSchema(t)
+	schema.IsPossibleType(searchResult, photo)
+
+	doc, err := parser.Parse(parser.ParseParams{Source: `extend union SearchResult = Photo`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.ExtendUnion(doc.Definitions[0].(*ast.UnionExtensionDefinition)); err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, member := range schema.PossibleTypes(searchResult) {
+		names = append(names, member.Name())
+	}
+	if expected := []string{"Article", "Photo"}; !reflect.DeepEqual(expected, names) {
+		t.Fatalf("expected possible types %v, got %v", expected, names)
+	}
+	if !schema.IsPossibleType(searchResult, photo) {
+		t.Fatalf("expected Photo to be a possible type of SearchResult")
+	}
+}
+
+func TestSchemaExtendUnion_RejectsUnknownTypes(t *testing.T) {
+	tests := map[string]string{
+		`extend union Photo = Article`:        `Cannot extend "Photo", it is not a union type of the schema.`,
+		`extend union SearchResult = Missing`: `Cannot add "Missing" to SearchResult, it is not an object type of the schema.`,
+	}
+	for source, expected := range tests {
+		schema, _, _ := unionExtensionSchema(t)
+		doc, err := parser.Parse(parser.ParseParams{Source: source})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = schema.ExtendUnion(doc.Definitions[0].(*ast.UnionExtensionDefinition))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("%v: expected error %q, got %v", source, expected, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// CompatibilityMatrix is the result of the validation of the operations of a
+// document against several versions of a schema, see ValidateAgainst.
+type CompatibilityMatrix struct {
+	// Operations are the names of the operations of the document, in the
+	// order of the document, empty for an anonymous operation.
+	Operations []string
+
+	// Results are the results of the validation of each operation against
+	// each schema, indexed by schema then by operation.
+	Results [][]ValidationResult
+}
+
+// Compatible reports whether all the operations are valid against the schema
+// of the given index.
+func (m *CompatibilityMatrix) Compatible(schema int) bool {
+	for _, result := range m.Results[schema] {
+		if !result.IsValid {
+			return false
+		}
+	}
+	return true
+}
+
+// CompatibleSchemas returns the indexes of the schemas the operation of the
+// given name is valid against.
+func (m *CompatibilityMatrix) CompatibleSchemas(operation string) []int {
+	schemas := []int{}
+	for i, results := range m.Results {
+		for j, name := range m.Operations {
+			if name == operation && results[j].IsValid {
+				schemas = append(schemas, i)
+				break
+			}
+		}
+	}
+	return schemas
+}
+
+// IsValid reports whether all the operations are valid against all the
+// schemas.
+func (m *CompatibilityMatrix) IsValid() bool {
+	for i := range m.Results {
+		if !m.Compatible(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateAgainst validates each operation of the document against each of
+// the schemas, such as the versions of a schema currently deployed, with the
+// specified rules. Each operation is validated along with the fragments it
+// uses only, so the rules relating the operations of the document to each
+// other, which do not depend on the schema, are left to ValidateDocument.
+func ValidateAgainst(schemas []Schema, astDoc *ast.Document) *CompatibilityMatrix {
+	matrix := &CompatibilityMatrix{
+		Operations: []string{},
+		Results:    make([][]ValidationResult, len(schemas)),
+	}
+	documents := []*ast.Document{}
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range astDoc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, definition := range astDoc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		name := ""
+		if operation.Name != nil {
+			name = operation.Name.Value
+		}
+		matrix.Operations = append(matrix.Operations, name)
+		documents = append(documents, operationDocument(astDoc, operation, fragments))
+	}
+	for i := range schemas {
+		matrix.Results[i] = make([]ValidationResult, len(documents))
+		for j, document := range documents {
+			matrix.Results[i][j] = ValidateDocument(&schemas[i], document, nil)
+		}
+	}
+	return matrix
+}
+
+// operationDocument returns a document of the operation and of the fragments
+// it uses, in the order of the document.
+func operationDocument(astDoc *ast.Document, operation *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) *ast.Document {
+	used := map[string]bool{}
+	var visit func(selectionSet *ast.SelectionSet)
+	visit = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				visit(selection.SelectionSet)
+			case *ast.InlineFragment:
+				visit(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				if selection.Name == nil || used[selection.Name.Value] {
+					continue
+				}
+				used[selection.Name.Value] = true
+				if fragment, ok := fragments[selection.Name.Value]; ok {
+					visit(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	visit(operation.SelectionSet)
+
+	document := ast.NewDocument(&ast.Document{Loc: astDoc.Loc})
+	for _, definition := range astDoc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if definition == operation {
+				document.Definitions = append(document.Definitions, definition)
+			}
+		case *ast.FragmentDefinition:
+			if definition.Name != nil && used[definition.Name.Value] {
+				document.Definitions = append(document.Definitions, definition)
+			}
+		}
+	}
+	return document
+}
@@ -0,0 +1,71 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func userSchemaVersion(t *testing.T, fields ...string) graphql.Schema {
+	userFields := graphql.Fields{}
+	for _, name := range fields {
+		userFields[name] = &graphql.Field{Type: graphql.String}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.NewObject(graphql.ObjectConfig{Name: "User", Fields: userFields}),
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestValidateAgainst(t *testing.T) {
+	schemas := []graphql.Schema{
+		userSchemaVersion(t, "name", "legacyId"),
+		userSchemaVersion(t, "name", "legacyId", "email"),
+		userSchemaVersion(t, "name", "email"),
+	}
+	doc := testutil.TestParse(t, `
+		query Name { user { name } }
+		query Email { user { ...Contact } }
+		query Legacy { user { ...Ids } }
+		fragment Contact on User { name email }
+		fragment Ids on User { legacyId }
+	`)
+	matrix := graphql.ValidateAgainst(schemas, doc)
+
+	if !reflect.DeepEqual(matrix.Operations, []string{"Name", "Email", "Legacy"}) {
+		t.Fatalf("unexpected operations %v", matrix.Operations)
+	}
+	expected := [][]bool{
+		{true, false, true},
+		{true, true, true},
+		{true, true, false},
+	}
+	for i, results := range matrix.Results {
+		for j, result := range results {
+			if result.IsValid != expected[i][j] {
+				t.Fatalf("schema %d, operation %v: expected valid %v, got %v", i, matrix.Operations[j], expected[i][j], result.Errors)
+			}
+		}
+	}
+	if err := matrix.Results[0][1].Errors; len(err) != 1 || err[0].Message != `Cannot query field "email" on type "User".` {
+		t.Fatalf("unexpected errors %v", err)
+	}
+	if matrix.Compatible(0) || !matrix.Compatible(1) || matrix.Compatible(2) || matrix.IsValid() {
+		t.Fatalf("unexpected compatibility of the schemas")
+	}
+	if schemas := matrix.CompatibleSchemas("Email"); !reflect.DeepEqual(schemas, []int{1, 2}) {
+		t.Fatalf("unexpected compatible schemas %v", schemas)
+	}
+}
@@ -838,6 +838,17 @@ func TestTypeSystem_UnionTypesMustBeResolvable_RejectsAUnionTypeNotDefiningResol
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
 }
+func TestTypeSystem_UnionTypesMustBeResolvable_AcceptsAUnionTypeResolvingTypedValues(t *testing.T) {
+
+	_, err := schemaWithFieldType(graphql.NewUnion(graphql.UnionConfig{
+		Name:        "SomeUnion",
+		Types:       []*graphql.Object{someObjectType},
+		TypedValues: true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
 
 func TestTypeSystem_ScalarTypesMustBeSerializable_AcceptsAScalarTypeDefiningSerialize(t *testing.T) {
 
@@ -1644,3 +1655,66 @@ func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectWit
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
 }
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_AcceptsAnObjectWithAnAdditionalRequiredArgumentWithADefaultValue(t *testing.T) {
+	anotherInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+		Fields: graphql.Fields{
+			"field": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+	anotherObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*graphql.Interface{anotherInterface},
+		Fields: graphql.Fields{
+			"field": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"anotherInput": &graphql.ArgumentConfig{
+						Type:         graphql.NewNonNull(graphql.String),
+						DefaultValue: "default",
+					},
+				},
+			},
+		},
+	})
+	_, err := schemaWithObjectFieldOfType(anotherObject)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v for type "%v"`, err, anotherObject)
+	}
+}
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_ReportsTheFirstMismatchingArgumentInOrder(t *testing.T) {
+	args := graphql.FieldConfigArgument{}
+	objectArgs := graphql.FieldConfigArgument{}
+	for _, name := range []string{"e", "c", "a", "d", "b"} {
+		args[name] = &graphql.ArgumentConfig{Type: graphql.String}
+		objectArgs[name] = &graphql.ArgumentConfig{Type: graphql.Int}
+	}
+	anotherInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+		Fields: graphql.Fields{
+			"field": &graphql.Field{Type: graphql.String, Args: args},
+		},
+	})
+	anotherObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*graphql.Interface{anotherInterface},
+		Fields: graphql.Fields{
+			"field": &graphql.Field{Type: graphql.String, Args: objectArgs},
+		},
+	})
+	expectedError := `AnotherInterface.field(a:) expects type "String" but AnotherObject.field(a:) provides type "Int".`
+	for i := 0; i < 10; i++ {
+		_, err := schemaWithObjectFieldOfType(anotherObject)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error: %v, got %v", expectedError, err)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 type ValidationResult struct {
 	IsValid bool
-	Errors  []gqlerrors.FormattedError
+	Errors  gqlerrors.List
 }
 
 /**
@@ -41,22 +41,49 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	}
 
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
-		Schema: schema,
+		Schema:   schema,
+		Fragment: documentFragments(astDoc),
 	})
-	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules)
+	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules).Dedupe().Sort()
 	if len(vr.Errors) == 0 {
 		vr.IsValid = true
 	}
 	return vr
 }
 
+// documentFragments returns a lookup of the fragment definitions of the
+// document by name.
+func documentFragments(astDoc *ast.Document) func(name string) *ast.FragmentDefinition {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range astDoc.Definitions {
+		if def, ok := def.(*ast.FragmentDefinition); ok && def.Name != nil {
+			fragments[def.Name.Value] = def
+		}
+	}
+	return func(name string) *ast.FragmentDefinition {
+		return fragments[name]
+	}
+}
+
+// fragmentVariableDefinitions returns the variables defined by the fragment by
+// name, see the experimental parser.ParseOptions.ExperimentalFragmentVariables.
+func fragmentVariableDefinitions(fragment *ast.FragmentDefinition) map[string]*ast.VariableDefinition {
+	varDefs := map[string]*ast.VariableDefinition{}
+	for _, varDef := range fragment.VariableDefinitions {
+		if varDef != nil && varDef.Variable != nil && varDef.Variable.Name != nil {
+			varDefs[varDef.Variable.Name.Value] = varDef
+		}
+	}
+	return varDefs
+}
+
 // VisitUsingRules This uses a specialized visitor which runs multiple visitors in parallel,
 // while maintaining the visitor skip and break API.
 //
 // @internal
 // Had to expose it to unit test experimental customizable validation feature,
 // but not meant for public consumption
-func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
+func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) gqlerrors.List {
 
 	context := NewValidationContext(schema, astDoc, typeInfo)
 	visitors := []*visitor.VisitorOptions{}
@@ -89,7 +116,7 @@ type ValidationContext struct {
 	schema                         *Schema
 	astDoc                         *ast.Document
 	typeInfo                       *TypeInfo
-	errors                         []gqlerrors.FormattedError
+	errors                         gqlerrors.List
 	fragments                      map[string]*ast.FragmentDefinition
 	variableUsages                 map[HasSelectionSet][]*VariableUsage
 	recursiveVariableUsages        map[*ast.OperationDefinition][]*VariableUsage
@@ -111,10 +138,9 @@ func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeIn
 }
 
 func (ctx *ValidationContext) ReportError(err error) {
-	formattedErr := gqlerrors.FormatError(err)
-	ctx.errors = append(ctx.errors, formattedErr)
+	ctx.errors = ctx.errors.Append(err)
 }
-func (ctx *ValidationContext) Errors() []gqlerrors.FormattedError {
+func (ctx *ValidationContext) Errors() gqlerrors.List {
 	return ctx.errors
 }
 
@@ -225,7 +251,8 @@ func (ctx *ValidationContext) VariableUsages(node HasSelectionSet) []*VariableUs
 	}
 	usages := []*VariableUsage{}
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
-		Schema: ctx.schema,
+		Schema:   ctx.schema,
+		Fragment: ctx.Fragment,
 	})
 
 	visitor.Visit(node, visitor.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
@@ -261,7 +288,17 @@ func (ctx *ValidationContext) RecursiveVariableUsages(operation *ast.OperationDe
 	fragments := ctx.RecursivelyReferencedFragments(operation)
 	for _, fragment := range fragments {
 		fragmentUsages := ctx.VariableUsages(fragment)
-		usages = append(usages, fragmentUsages...)
+		if len(fragment.VariableDefinitions) == 0 {
+			usages = append(usages, fragmentUsages...)
+			continue
+		}
+		// the variables defined by a fragment are scoped to it
+		scoped := fragmentVariableDefinitions(fragment)
+		for _, usage := range fragmentUsages {
+			if usage.Node.Name == nil || scoped[usage.Node.Name.Value] == nil {
+				usages = append(usages, usage)
+			}
+		}
 	}
 
 	ctx.recursiveVariableUsages[operation] = usages
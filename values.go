@@ -1,7 +1,6 @@
 package graphql
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -20,20 +19,74 @@ import (
 func getVariableValues(
 	schema Schema,
 	definitionASTs []*ast.VariableDefinition,
-	inputs map[string]interface{}) (map[string]interface{}, error) {
+	inputs map[string]interface{},
+	presets map[string]VariablePreset,
+	limits VariableLimits,
+	codec Codec) (map[string]interface{}, error) {
 	values := map[string]interface{}{}
+	var errs gqlerrors.List
 	for _, defAST := range definitionASTs {
 		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		if varValue, err := getVariableValue(schema, defAST, inputs[varName]); err != nil {
-			return values, err
+		input, provided := inputs[varName]
+		if preset, ok := presets[varName]; ok && preset != nil {
+			var err error
+			if input, err = preset(input, provided); err != nil {
+				errs = errs.Append(newVariableError(
+					fmt.Sprintf(`Variable "$%v" got invalid value: %v`, varName, err),
+					defAST,
+				))
+				continue
+			}
+			provided = provided || input != nil
+		}
+		if provided && input == nil && defAST.DefaultValue != nil {
+			// an explicit null overrides the default value, see "Coercing Variable Values".
+			if _, ok := defAST.Type.(*ast.NonNull); !ok {
+				values[varName] = nil
+				continue
+			}
+		}
+		if err := limits.check(defAST, input); err != nil {
+			errs = errs.Append(err)
+			continue
+		}
+		varValue, err := getVariableValue(schema, defAST, input, codec)
+		if err == nil {
+			varValue, err = applyVariableDirectives(schema, defAST, varValue)
+		}
+		if err != nil {
+			errs = errs.Append(err)
 		} else {
 			values[varName] = varValue
 		}
 	}
-	return values, nil
+	return values, errs.Err()
+}
+
+// applyVariableDirectives transforms the coerced value of a variable with the
+// directives of its definition implementing CoerceVariable, in order.
+func applyVariableDirectives(schema Schema, defAST *ast.VariableDefinition, value interface{}) (interface{}, error) {
+	for _, dirAST := range defAST.Directives {
+		if dirAST == nil || dirAST.Name == nil {
+			continue
+		}
+		directive := schema.Directive(dirAST.Name.Value)
+		if directive == nil || directive.CoerceVariable == nil {
+			continue
+		}
+		args := getArgumentValues(directive.Args, dirAST.Arguments, nil)
+		var err error
+		if value, err = directive.CoerceVariable(value, args); err != nil {
+			return nil, newVariableError(
+				fmt.Sprintf(`Variable "$%v" got invalid value: %v`, defAST.Variable.Name.Value, err),
+				defAST,
+			)
+		}
+	}
+	return value, nil
 }
 
 // Prepares an object map of argument values given a list of argument
@@ -58,7 +111,7 @@ func getArgumentValues(
 			value = tmpValue.Value
 		}
 		if tmp = valueFromAST(value, argDef.Type, variableValues); isNullish(tmp) {
-			tmp = argDef.DefaultValue
+			tmp = defaultValue(argDef.DefaultValue, argDef.Type)
 		}
 		if !isNullish(tmp) {
 			results[argDef.PrivateName] = tmp
@@ -67,9 +120,28 @@ func getArgumentValues(
 	return results
 }
 
+// defaultValue returns the value of the default value of an argument or input
+// field of the given type: the default value itself, unless it is a literal,
+// e.g. parsed with parser.ParseValue, which is coerced like the literals of
+// the requests, by the ParseLiteral of the scalars.
+func defaultValue(value interface{}, ttype Input) interface{} {
+	if literal, ok := value.(ast.Value); ok {
+		return valueFromAST(literal, ttype, nil)
+	}
+	return value
+}
+
+// newVariableError returns an error of the value provided for a variable.
+func newVariableError(message string, definitionAST *ast.VariableDefinition) *gqlerrors.Error {
+	err := gqlerrors.NewError(message, []ast.Node{definitionAST}, "", nil, []int{}, nil)
+	err.Extensions = map[string]interface{}{"code": gqlerrors.CodeBadUserInput}
+	return err
+}
+
 // Given a variable definition, and any value of input, return a value which
 // adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
+
+func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}, codec Codec) (interface{}, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
@@ -77,14 +149,10 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 	variable := definitionAST.Variable
 
 	if ttype == nil || !IsInputType(ttype) {
-		return "", gqlerrors.NewError(
+		return "", newVariableError(
 			fmt.Sprintf(`Variable "$%v" expected value of type `+
 				`"%v" which cannot be used as an input type.`, variable.Name.Value, printer.Print(definitionAST.Type)),
-			[]ast.Node{definitionAST},
-			"",
-			nil,
-			[]int{},
-			nil,
+			definitionAST,
 		)
 	}
 
@@ -95,21 +163,24 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 				return valueFromAST(definitionAST.DefaultValue, ttype, nil), nil
 			}
 		}
-		return coerceValue(ttype, input), nil
+		value, err := coerceTransformedValue(ttype, input)
+		if err != nil {
+			return "", newVariableError(
+				fmt.Sprintf(`Variable "$%v" got invalid value: %v`, variable.Name.Value, err),
+				definitionAST,
+			)
+		}
+		return value, nil
 	}
 	if isNullish(input) {
-		return "", gqlerrors.NewError(
+		return "", newVariableError(
 			fmt.Sprintf(`Variable "$%v" of required type `+
 				`"%v" was not provided.`, variable.Name.Value, printer.Print(definitionAST.Type)),
-			[]ast.Node{definitionAST},
-			"",
-			nil,
-			[]int{},
-			nil,
+			definitionAST,
 		)
 	}
 	// convert input interface into string for error message
-	bts, _ := json.Marshal(input)
+	bts, _ := codecOrDefault(codec).Marshal(input)
 	var (
 		inputStr = string(bts)
 		msg      string
@@ -118,14 +189,10 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		msg = "\n" + strings.Join(messages, "\n")
 	}
 
-	return "", gqlerrors.NewError(
+	return "", newVariableError(
 		fmt.Sprintf(`Variable "$%v" got invalid value `+
 			`%v.%v`, variable.Name.Value, inputStr, msg),
-		[]ast.Node{definitionAST},
-		"",
-		nil,
-		[]int{},
-		nil,
+		definitionAST,
 	)
 }
 
@@ -158,15 +225,15 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 		for name, field := range ttype.Fields() {
 			fieldValue := coerceValue(field.Type, valueMap[name])
 			if isNullish(fieldValue) {
-				fieldValue = field.DefaultValue
+				fieldValue = defaultValue(field.DefaultValue, field.Type)
 			}
 			if !isNullish(fieldValue) {
-				obj[name] = fieldValue
+				obj[name] = transformInputField(ttype, field, fieldValue)
 			}
 		}
 		return obj
 	case *Scalar:
-		if parsed := ttype.ParseValue(value); !isNullish(parsed) {
+		if parsed, err := ttype.ParseValueE(value); err == nil && !isNullish(parsed) {
 			return parsed
 		}
 	case *Enum:
@@ -283,7 +350,11 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 		}
 		return (len(messagesReduce) == 0), messagesReduce
 	case *Scalar:
-		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
+		parsedVal, err := ttype.ParseValueE(value)
+		if err != nil {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v"; %v`, ttype.Name(), value, err)}
+		}
+		if isNullish(parsedVal) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
 	case *Enum:
@@ -396,10 +467,10 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 			if of, ok = fieldASTs[name]; ok {
 				value = valueFromAST(of.Value, field.Type, variables)
 			} else {
-				value = field.DefaultValue
+				value = defaultValue(field.DefaultValue, field.Type)
 			}
 			if !isNullish(value) {
-				obj[name] = value
+				obj[name] = transformInputField(ttype, field, value)
 			}
 		}
 		return obj
@@ -0,0 +1,484 @@
+// Package values converts the input values of a schema between their Go
+// representation and the literals of the GraphQL language, following the
+// coercion rules of the specification.
+//
+// Unlike the lenient conversions used by the executor, which assume validated
+// documents, the functions of this package report every invalid value along
+// with its path, so tools such as gateways, mocks or linters can use them on
+// untrusted input:
+//
+//	coerced := values.CoerceInputValue(input, filterType, func(err *values.CoercionError) {
+//		log.Printf("invalid filter: %v", err)
+//	})
+package values
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// CoercionError is an error of an input value, at the path of the invalid
+// value within the converted value.
+type CoercionError struct {
+	// Path holds the names of the input object fields and the indexes of the
+	// list items leading to the invalid value, empty for the value itself.
+	Path []interface{}
+
+	// Value is the invalid value, a Go value or a literal.
+	Value interface{}
+
+	Message string
+
+	// Err is the error returned by the Transform of an input object field.
+	Err error
+}
+
+func (e *CoercionError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf(`In value%v: %v`, PathString(e.Path), e.Message)
+}
+
+func (e *CoercionError) Unwrap() error {
+	return e.Err
+}
+
+// PathString formats the path of a value, e.g. `.filter.ids[2]`.
+func PathString(path []interface{}) string {
+	var b strings.Builder
+	for _, key := range path {
+		if index, ok := key.(int); ok {
+			fmt.Fprintf(&b, "[%d]", index)
+		} else {
+			fmt.Fprintf(&b, ".%v", key)
+		}
+	}
+	return b.String()
+}
+
+// OnErrorFn is called with each error of the coercion of an input value.
+type OnErrorFn func(err *CoercionError)
+
+// CoerceInputValue coerces a Go value, e.g. decoded from the JSON variables of
+// a request, to the internal value of the input type: scalars and enums are
+// parsed, a value which is not a list is a list of one, and the missing
+// fields of input objects take their default value and are transformed.
+//
+// Each invalid value is reported to onError, which may be nil to ignore them,
+// and is coerced to nil.
+func CoerceInputValue(value interface{}, ttype graphql.Input, onError OnErrorFn) interface{} {
+	c := &coercion{onError: onError}
+	return c.coerce(value, ttype)
+}
+
+// ValueFromAST returns the internal value of the literal for the input type,
+// the values of its variables being taken from the given, already coerced,
+// variables. A nil literal, the absence of a value, has a nil value.
+//
+// The error is the first invalid value of the literal, a *CoercionError.
+func ValueFromAST(valueAST ast.Value, ttype graphql.Input, variables map[string]interface{}) (interface{}, error) {
+	var first *CoercionError
+	c := &coercion{onError: func(err *CoercionError) {
+		if first == nil {
+			first = err
+		}
+	}}
+	value := c.fromAST(valueAST, ttype, variables)
+	if first != nil {
+		return nil, first
+	}
+	return value, nil
+}
+
+// ASTFromValue returns the literal of the internal value for the input type,
+// nil for a nil value. The value is serialized by the scalars and enums, and
+// the fields of input objects are sorted by name.
+//
+// The error is the first value which cannot be serialized, a *CoercionError.
+func ASTFromValue(value interface{}, ttype graphql.Input) (ast.Value, error) {
+	var first *CoercionError
+	c := &coercion{onError: func(err *CoercionError) {
+		if first == nil {
+			first = err
+		}
+	}}
+	valueAST := c.toAST(value, ttype)
+	if first != nil {
+		return nil, first
+	}
+	return valueAST, nil
+}
+
+// coercion tracks the path of the value being converted.
+type coercion struct {
+	path    []interface{}
+	onError OnErrorFn
+}
+
+func (c *coercion) report(value interface{}, err error, format string, a ...interface{}) {
+	if c.onError == nil {
+		return
+	}
+	c.onError(&CoercionError{
+		Path:    append([]interface{}{}, c.path...),
+		Value:   value,
+		Message: fmt.Sprintf(format, a...),
+		Err:     err,
+	})
+}
+
+func (c *coercion) push(key interface{}) {
+	c.path = append(c.path, key)
+}
+
+func (c *coercion) pop() {
+	c.path = c.path[:len(c.path)-1]
+}
+
+func (c *coercion) coerce(value interface{}, ttype graphql.Input) interface{} {
+	if isNull(value) {
+		if ttype, ok := ttype.(*graphql.NonNull); ok {
+			c.report(value, nil, `Expected "%v", found null.`, ttype)
+		}
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.NonNull:
+		return c.coerce(value, ttype.OfType)
+	case *graphql.List:
+		valueVal := reflect.ValueOf(value)
+		if valueVal.Kind() != reflect.Slice && valueVal.Kind() != reflect.Array {
+			return []interface{}{c.coerce(value, ttype.OfType)}
+		}
+		items := make([]interface{}, 0, valueVal.Len())
+		for i := 0; i < valueVal.Len(); i++ {
+			c.push(i)
+			items = append(items, c.coerce(valueVal.Index(i).Interface(), ttype.OfType))
+			c.pop()
+		}
+		return items
+	case *graphql.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			c.report(value, nil, `Expected "%v", found not an object.`, ttype)
+			return nil
+		}
+		object := map[string]interface{}{}
+		for _, name := range sortedKeys(fields) {
+			if _, ok := ttype.Fields()[name]; !ok {
+				c.push(name)
+				c.report(fields[name], nil, `Field "%v" is not defined by type "%v".`, name, ttype)
+				c.pop()
+			}
+		}
+		for _, field := range sortedFields(ttype) {
+			c.push(field.Name())
+			fieldValue, ok := fields[field.Name()]
+			if ok {
+				fieldValue = c.coerce(fieldValue, field.Type)
+			} else {
+				fieldValue = c.defaultValue(ttype, field)
+			}
+			c.setField(object, field, fieldValue)
+			c.pop()
+		}
+		return object
+	case *graphql.Scalar:
+		parsed, err := ttype.ParseValueE(value)
+		if err != nil {
+			c.report(value, err, `Expected type "%v", found %v; %v`, ttype, printValue(value), err)
+			return nil
+		}
+		if isNull(parsed) {
+			c.report(value, nil, `Expected type "%v", found %v.`, ttype, printValue(value))
+			return nil
+		}
+		return parsed
+	case *graphql.Enum:
+		parsed := ttype.ParseValue(value)
+		if isNull(parsed) {
+			c.report(value, nil, `Expected type "%v", found %v.`, ttype, printValue(value))
+			return nil
+		}
+		return parsed
+	}
+	c.report(value, nil, `Expected an input type, found "%v".`, ttype)
+	return nil
+}
+
+func (c *coercion) fromAST(valueAST ast.Value, ttype graphql.Input, variables map[string]interface{}) interface{} {
+	if valueAST == nil {
+		return nil
+	}
+	if variable, ok := valueAST.(*ast.Variable); ok {
+		name := ""
+		if variable.Name != nil {
+			name = variable.Name.Value
+		}
+		value, provided := variables[name]
+		if ttype, ok := ttype.(*graphql.NonNull); ok && (!provided || isNull(value)) {
+			c.report(valueAST, nil, `Variable "$%v" of required type "%v" was not provided.`, name, ttype)
+		}
+		// variables are assumed to be coerced to the type of their definition
+		return value
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.NonNull:
+		return c.fromAST(valueAST, ttype.OfType, variables)
+	case *graphql.List:
+		list, ok := valueAST.(*ast.ListValue)
+		if !ok {
+			return []interface{}{c.fromAST(valueAST, ttype.OfType, variables)}
+		}
+		items := make([]interface{}, 0, len(list.Values))
+		for i, itemAST := range list.Values {
+			c.push(i)
+			items = append(items, c.fromAST(itemAST, ttype.OfType, variables))
+			c.pop()
+		}
+		return items
+	case *graphql.InputObject:
+		objectAST, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			c.report(valueAST, nil, `Expected "%v", found %v.`, ttype, printer.Print(valueAST))
+			return nil
+		}
+		fieldASTs := map[string]*ast.ObjectField{}
+		for _, fieldAST := range objectAST.Fields {
+			if fieldAST == nil || fieldAST.Name == nil {
+				continue
+			}
+			fieldASTs[fieldAST.Name.Value] = fieldAST
+			if _, ok := ttype.Fields()[fieldAST.Name.Value]; !ok {
+				c.push(fieldAST.Name.Value)
+				c.report(fieldAST.Value, nil, `Field "%v" is not defined by type "%v".`, fieldAST.Name.Value, ttype)
+				c.pop()
+			}
+		}
+		object := map[string]interface{}{}
+		for _, field := range sortedFields(ttype) {
+			c.push(field.Name())
+			var fieldValue interface{}
+			if fieldAST, ok := fieldASTs[field.Name()]; ok && !isMissingVariable(fieldAST.Value, variables) {
+				fieldValue = c.fromAST(fieldAST.Value, field.Type, variables)
+			} else {
+				fieldValue = c.defaultValue(ttype, field)
+			}
+			c.setField(object, field, fieldValue)
+			c.pop()
+		}
+		return object
+	case *graphql.Scalar:
+		parsed := ttype.ParseLiteral(valueAST)
+		if err, ok := parsed.(error); ok {
+			c.report(valueAST, err, `Expected type "%v", found %v; %v`, ttype, printer.Print(valueAST), err)
+			return nil
+		}
+		if isNull(parsed) {
+			c.report(valueAST, nil, `Expected type "%v", found %v.`, ttype, printer.Print(valueAST))
+			return nil
+		}
+		return parsed
+	case *graphql.Enum:
+		parsed := ttype.ParseLiteral(valueAST)
+		if isNull(parsed) {
+			c.report(valueAST, nil, `Expected type "%v", found %v.`, ttype, printer.Print(valueAST))
+			return nil
+		}
+		return parsed
+	}
+	c.report(valueAST, nil, `Expected an input type, found "%v".`, ttype)
+	return nil
+}
+
+// defaultValue returns the default value of a field which was not provided,
+// reporting the missing required fields.
+func (c *coercion) defaultValue(ttype *graphql.InputObject, field *graphql.InputObjectField) interface{} {
+	if literal, ok := field.DefaultValue.(ast.Value); ok {
+		return c.fromAST(literal, field.Type, nil)
+	}
+	if field.DefaultValue != nil {
+		return field.DefaultValue
+	}
+	if _, ok := field.Type.(*graphql.NonNull); ok {
+		c.report(nil, nil, `Field "%v.%v" of required type "%v" was not provided.`, ttype, field.Name(), field.Type)
+	}
+	return nil
+}
+
+// setField sets the coerced value of the field in the object, transformed by
+// the Transform of the field, omitting the null values.
+func (c *coercion) setField(object map[string]interface{}, field *graphql.InputObjectField, value interface{}) {
+	if isNull(value) {
+		return
+	}
+	if field.Transform != nil {
+		transformed, err := field.Transform(value)
+		if err != nil {
+			c.report(value, err, `%v`, err)
+			return
+		}
+		value = transformed
+	}
+	object[field.Name()] = value
+}
+
+func (c *coercion) toAST(value interface{}, ttype graphql.Input) ast.Value {
+	if ttype, ok := ttype.(*graphql.NonNull); ok {
+		if isNull(value) {
+			c.report(value, nil, `Expected "%v", found null.`, ttype)
+			return nil
+		}
+		return c.toAST(value, ttype.OfType)
+	}
+	if isNull(value) {
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		valueVal := reflect.ValueOf(value)
+		if valueVal.Kind() != reflect.Slice && valueVal.Kind() != reflect.Array {
+			return c.toAST(value, ttype.OfType)
+		}
+		list := ast.NewListValue(&ast.ListValue{Values: []ast.Value{}})
+		for i := 0; i < valueVal.Len(); i++ {
+			c.push(i)
+			if itemAST := c.toAST(valueVal.Index(i).Interface(), ttype.OfType); itemAST != nil {
+				list.Values = append(list.Values, itemAST)
+			}
+			c.pop()
+		}
+		return list
+	case *graphql.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			c.report(value, nil, `Expected "%v", found not an object.`, ttype)
+			return nil
+		}
+		object := ast.NewObjectValue(&ast.ObjectValue{Fields: []*ast.ObjectField{}})
+		for _, field := range sortedFields(ttype) {
+			fieldValue, ok := fields[field.Name()]
+			if !ok {
+				continue
+			}
+			c.push(field.Name())
+			if fieldAST := c.toAST(fieldValue, field.Type); fieldAST != nil {
+				object.Fields = append(object.Fields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: field.Name()}),
+					Value: fieldAST,
+				}))
+			}
+			c.pop()
+		}
+		return object
+	case *graphql.Enum:
+		name, ok := ttype.Serialize(value).(string)
+		if !ok {
+			c.report(value, nil, `Expected type "%v", found %v.`, ttype, printValue(value))
+			return nil
+		}
+		return ast.NewEnumValue(&ast.EnumValue{Value: name})
+	case *graphql.Scalar:
+		serialized := ttype.Serialize(value)
+		if valueAST := scalarAST(serialized, ttype); valueAST != nil {
+			return valueAST
+		}
+		c.report(value, nil, `Expected type "%v", found %v.`, ttype, printValue(value))
+		return nil
+	}
+	c.report(value, nil, `Expected an input type, found "%v".`, ttype)
+	return nil
+}
+
+// scalarAST returns the literal of a serialized scalar value, IDs which are
+// integers being printed as Int literals.
+func scalarAST(serialized interface{}, ttype *graphql.Scalar) ast.Value {
+	switch serialized := serialized.(type) {
+	case bool:
+		return ast.NewBooleanValue(&ast.BooleanValue{Value: serialized})
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return ast.NewIntValue(&ast.IntValue{Value: fmt.Sprintf("%d", serialized)})
+	case float32:
+		return floatAST(float64(serialized))
+	case float64:
+		return floatAST(serialized)
+	case string:
+		if ttype == graphql.ID {
+			if _, err := strconv.ParseInt(serialized, 10, 64); err == nil {
+				return ast.NewIntValue(&ast.IntValue{Value: serialized})
+			}
+		}
+		return ast.NewStringValue(&ast.StringValue{Value: serialized})
+	}
+	return nil
+}
+
+func floatAST(value float64) ast.Value {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	if value == math.Trunc(value) && math.Abs(value) < 1e21 {
+		return ast.NewIntValue(&ast.IntValue{Value: strconv.FormatFloat(value, 'f', -1, 64)})
+	}
+	return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatFloat(value, 'g', -1, 64)})
+}
+
+// isMissingVariable returns true if the literal is a variable without value,
+// in which case the default value of an input object field applies.
+func isMissingVariable(valueAST ast.Value, variables map[string]interface{}) bool {
+	variable, ok := valueAST.(*ast.Variable)
+	if !ok || variable.Name == nil {
+		return false
+	}
+	_, provided := variables[variable.Name.Value]
+	return !provided
+}
+
+// isNull returns true for nil and nil pointers, maps and slices.
+func isNull(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(v.Float())
+	}
+	return false
+}
+
+// printValue prints a Go value in error messages, strings being quoted.
+func printValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFields(ttype *graphql.InputObject) []*graphql.InputObjectField {
+	fields := make([]*graphql.InputObjectField, 0, len(ttype.Fields()))
+	for _, field := range ttype.Fields() {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name() < fields[j].Name() })
+	return fields
+}
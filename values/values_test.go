@@ -0,0 +1,154 @@
+package values_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/graphql-go/graphql/values"
+)
+
+var colorType = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Color",
+	Values: graphql.EnumValueConfigMap{
+		"RED":  &graphql.EnumValueConfig{Value: 0},
+		"BLUE": &graphql.EnumValueConfig{Value: 1},
+	},
+})
+
+var filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "Filter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"ids":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+		"color": &graphql.InputObjectFieldConfig{Type: colorType, DefaultValue: 1},
+		"name": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(graphql.String),
+			Transform: func(value interface{}) (interface{}, error) {
+				name := strings.TrimSpace(value.(string))
+				if name == "" {
+					return nil, errors.New("must not be blank")
+				}
+				return name, nil
+			},
+		},
+	},
+})
+
+func parseValue(t *testing.T, value string) ast.Value {
+	valueAST, err := parser.ParseValue(parser.ParseParams{Source: value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return valueAST
+}
+
+func TestCoerceInputValue(t *testing.T) {
+	coerced := values.CoerceInputValue(map[string]interface{}{
+		"ids":  []interface{}{1, 2},
+		"name": " Ada ",
+	}, filterType, func(err *values.CoercionError) {
+		t.Fatalf("unexpected error: %v", err)
+	})
+	expected := map[string]interface{}{"ids": []interface{}{1, 2}, "color": 1, "name": "Ada"}
+	if !reflect.DeepEqual(expected, coerced) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, coerced))
+	}
+}
+
+func TestCoerceInputValue_ReportsTheErrorsWithTheirPath(t *testing.T) {
+	errs := []string{}
+	coerced := values.CoerceInputValue(map[string]interface{}{
+		"ids":     []interface{}{1, nil, true, "x"},
+		"color":   "GREEN",
+		"name":    " ",
+		"unknown": 1,
+	}, filterType, func(err *values.CoercionError) {
+		errs = append(errs, err.Error())
+	})
+	expected := []string{
+		`In value.unknown: Field "unknown" is not defined by type "Filter".`,
+		`In value.color: Expected type "Color", found "GREEN".`,
+		`In value.ids[1]: Expected "Int!", found null.`,
+		`In value.ids[3]: Expected type "Int", found "x".`,
+		`In value.name: must not be blank`,
+	}
+	if !reflect.DeepEqual(expected, errs) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, errs))
+	}
+	if expected := map[string]interface{}{"ids": []interface{}{1, nil, 1, nil}}; !reflect.DeepEqual(expected, coerced) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, coerced))
+	}
+
+	errs = errs[:0]
+	values.CoerceInputValue(map[string]interface{}{}, filterType, func(err *values.CoercionError) {
+		errs = append(errs, err.Error())
+	})
+	if expected := []string{`In value.name: Field "Filter.name" of required type "String!" was not provided.`}; !reflect.DeepEqual(expected, errs) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, errs))
+	}
+}
+
+func TestValueFromAST(t *testing.T) {
+	value, err := values.ValueFromAST(parseValue(t, `{ids: [1, $id], name: $name, color: $color}`), filterType, map[string]interface{}{
+		"id":   2,
+		"name": "Ada",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"ids": []interface{}{1, 2}, "color": 1, "name": "Ada"}
+	if !reflect.DeepEqual(expected, value) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, value))
+	}
+
+	for literal, expected := range map[string]string{
+		`{name: "Ada", ids: [1, "2"]}`: `In value.ids[1]: Expected type "Int", found "2".`,
+		`{name: "Ada", color: GREEN}`:  `In value.color: Expected type "Color", found GREEN.`,
+		`{ids: [$id]}`:                 `In value.ids[0]: Variable "$id" of required type "Int!" was not provided.`,
+		`"Ada"`:                        `Expected "Filter", found "Ada".`,
+	} {
+		value, err := values.ValueFromAST(parseValue(t, literal), filterType, nil)
+		if err == nil || err.Error() != expected || value != nil {
+			t.Fatalf("expected error %q for %v, got %v, %v", expected, literal, value, err)
+		}
+		var coercionErr *values.CoercionError
+		if !errors.As(err, &coercionErr) {
+			t.Fatalf("expected a *CoercionError, got %T", err)
+		}
+	}
+}
+
+func TestASTFromValue(t *testing.T) {
+	for _, test := range []struct {
+		value    interface{}
+		ttype    graphql.Input
+		expected string
+	}{
+		{map[string]interface{}{"ids": []interface{}{1, 2}, "color": 0, "name": "Ada"}, filterType, `{color: RED, ids: [1, 2], name: "Ada"}`},
+		{3, graphql.NewList(graphql.Int), `3`},
+		{2.0, graphql.Float, `2`},
+		{2.5, graphql.Float, `2.5`},
+		{"12", graphql.ID, `12`},
+		{"a1", graphql.ID, `"a1"`},
+		{true, graphql.Boolean, `true`},
+	} {
+		valueAST, err := values.ASTFromValue(test.value, test.ttype)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", test.value, err)
+		}
+		if printed := printer.Print(valueAST); printed != test.expected {
+			t.Fatalf("expected %v for %v, got %v", test.expected, test.value, printed)
+		}
+	}
+
+	valueAST, err := values.ASTFromValue(map[string]interface{}{"ids": []interface{}{1, nil}, "name": "Ada"}, filterType)
+	if expected := `In value.ids[1]: Expected "Int!", found null.`; err == nil || err.Error() != expected || valueAST != nil {
+		t.Fatalf("expected error %q, got %v, %v", expected, valueAST, err)
+	}
+}
@@ -0,0 +1,117 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func variableDirectivesTestSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"], nil
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "lowerCase",
+				Locations: []string{graphql.DirectiveLocationVariableDefinition},
+				CoerceVariable: func(value interface{}, args map[string]interface{}) (interface{}, error) {
+					if s, ok := value.(string); ok {
+						return strings.ToLower(s), nil
+					}
+					return value, nil
+				},
+			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "maxLength",
+				Locations: []string{graphql.DirectiveLocationVariableDefinition},
+				Args: graphql.FieldConfigArgument{
+					"length": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				CoerceVariable: func(value interface{}, args map[string]interface{}) (interface{}, error) {
+					if s, ok := value.(string); ok && len(s) > args["length"].(int) {
+						return nil, errors.New("too long")
+					}
+					return value, nil
+				},
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestVariableDirectives_TransformVariableValues(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:         variableDirectivesTestSchema(t),
+		RequestString:  `query ($value: String = "DEFAULT" @lowerCase @maxLength(length: 8)) { echo(value: $value) }`,
+		VariableValues: map[string]interface{}{"value": "HeLLo"},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"echo": "hello"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        variableDirectivesTestSchema(t),
+		RequestString: `query ($value: String = "DEFAULT" @lowerCase) { echo(value: $value) }`,
+	})
+	expected = &graphql.Result{
+		Data: map[string]interface{}{"echo": "default"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariableDirectives_ReportTransformErrors(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:         variableDirectivesTestSchema(t),
+		RequestString:  `query ($value: String @maxLength(length: 3)) { echo(value: $value) }`,
+		VariableValues: map[string]interface{}{"value": "toolong"},
+	})
+	expected := []gqlerrors.FormattedError{
+		{
+			Message:    `Variable "$value" got invalid value: too long`,
+			Locations:  []location.SourceLocation{{Line: 1, Column: 8}},
+			Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
+		},
+	}
+	if !testutil.EqualFormattedErrors(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
+
+func TestVariableDirectives_RejectsMisplacedDirectives(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        variableDirectivesTestSchema(t),
+		RequestString: `query ($value: String) { echo(value: $value) @lowerCase }`,
+	})
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "lowerCase" may not be used on FIELD.`, 1, 46),
+	}
+	if !testutil.EqualFormattedErrors(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
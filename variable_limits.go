@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// VariableLimits bound the values provided for the variables of a request,
+// protecting the server from hostile payloads. The values exceeding a limit
+// are rejected with a BAD_USER_INPUT error before being coerced. Zero values
+// mean no limit.
+type VariableLimits struct {
+	// MaxBytes is the maximum size of Params.RawVariables, in bytes.
+	MaxBytes int
+
+	// MaxListLength is the maximum number of items of each list value.
+	MaxListLength int
+
+	// MaxDepth is the maximum nesting of input object values, a variable
+	// holding an input object with no input object field having a depth of 1.
+	MaxDepth int
+}
+
+// checkSize returns an error if the given raw variables exceed MaxBytes.
+func (l VariableLimits) checkSize(raw []byte) error {
+	if l.MaxBytes > 0 && len(raw) > l.MaxBytes {
+		return fmt.Errorf("Variables exceed the maximum size of %d bytes.", l.MaxBytes)
+	}
+	return nil
+}
+
+// check returns an error of the variable of the given definition if its value
+// exceeds the limits.
+func (l VariableLimits) check(defAST *ast.VariableDefinition, input interface{}) error {
+	if l.MaxListLength <= 0 && l.MaxDepth <= 0 {
+		return nil
+	}
+	path := "$" + defAST.Variable.Name.Value
+	if message := l.checkValue(reflect.ValueOf(input), path, 0); message != "" {
+		return newVariableError(
+			fmt.Sprintf(`Variable "%v" got invalid value: %v`, path, message),
+			defAST,
+		)
+	}
+	return nil
+}
+
+// checkValue returns the message of the limit exceeded by the value at the
+// given path and input object depth, or an empty string.
+func (l VariableLimits) checkValue(value reflect.Value, path string, depth int) string {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if l.MaxListLength > 0 && value.Len() > l.MaxListLength {
+			return fmt.Sprintf("%v has %d items, more than the maximum of %d.", path, value.Len(), l.MaxListLength)
+		}
+		for i := 0; i < value.Len(); i++ {
+			if message := l.checkValue(value.Index(i), path+"["+strconv.Itoa(i)+"]", depth); message != "" {
+				return message
+			}
+		}
+	case reflect.Map:
+		depth++
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return fmt.Sprintf("%v is nested deeper than the maximum depth of %d.", path, l.MaxDepth)
+		}
+		keys := make([]string, 0, value.Len())
+		values := make(map[string]reflect.Value, value.Len())
+		for _, key := range value.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			keys = append(keys, name)
+			values[name] = value.MapIndex(key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if message := l.checkValue(values[key], path+"."+key, depth); message != "" {
+				return message
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func variableLimitsSchema(t *testing.T) graphql.Schema {
+	var filter *graphql.InputObject
+	filter = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: (graphql.InputObjectConfigFieldMapThunk)(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"ids": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.Int)},
+				"and": &graphql.InputObjectFieldConfig{Type: filter},
+			}
+		}),
+	})
+	return testutil.NewQuerySchema(t, graphql.Fields{
+		"count": &graphql.Field{
+			Type: graphql.Int,
+			Args: graphql.FieldConfigArgument{
+				"filter": &graphql.ArgumentConfig{Type: filter},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return 0, nil
+			},
+		},
+	})
+}
+
+func TestVariableLimits(t *testing.T) {
+	schema := variableLimitsSchema(t)
+	limits := graphql.VariableLimits{MaxBytes: 64, MaxListLength: 3, MaxDepth: 2}
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{
+			name: "within limits",
+			raw:  `{"filter": {"ids": [1, 2, 3], "and": {"ids": [4]}}}`,
+		},
+		{
+			name:     "payload too large",
+			raw:      `{"filter": {"ids": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16]}}`,
+			expected: "Variables exceed the maximum size of 64 bytes.",
+		},
+		{
+			name:     "list too long",
+			raw:      `{"filter": {"and": {"ids": [1, 2, 3, 4]}}}`,
+			expected: `Variable "$filter" got invalid value: $filter.and.ids has 4 items, more than the maximum of 3.`,
+		},
+		{
+			name:     "nested too deep",
+			raw:      `{"filter": {"and": {"and": {"ids": []}}}}`,
+			expected: `Variable "$filter" got invalid value: $filter.and.and is nested deeper than the maximum depth of 2.`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := graphql.Do(graphql.Params{
+				Schema:         schema,
+				RequestString:  `query ($filter: Filter) { count(filter: $filter) }`,
+				RawVariables:   []byte(test.raw),
+				VariableLimits: limits,
+			})
+			if test.expected == "" {
+				if result.HasErrors() {
+					t.Fatalf("unexpected errors: %v", result.Errors)
+				}
+				return
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected an error, got: %v", result.Errors)
+			}
+			if result.Errors[0].Message != test.expected {
+				t.Fatalf("expected %q, got: %q", test.expected, result.Errors[0].Message)
+			}
+			if code := gqlerrors.Code(result.Errors[0]); code != gqlerrors.CodeBadUserInput {
+				t.Fatalf("expected code %q, got: %q", gqlerrors.CodeBadUserInput, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// VariableTransform rewrites the variables provided by the client once the
+// operation to execute is known, before they are coerced, e.g. to inject the
+// id of the tenant of the request or to rewrite the legacy values of an enum.
+// Unlike a VariablePreset, it sees all the variables at once along with their
+// definitions.
+type VariableTransform struct {
+	// Name identifies the transform in traces, see the slowquery extension.
+	Name string
+
+	Transform VariableTransformFn
+}
+
+// VariableTransformParams Params for VariableTransformFn()
+type VariableTransformParams struct {
+	Schema    Schema
+	Operation *ast.OperationDefinition
+
+	// VariableDefinitions are the variable definitions of the operation.
+	VariableDefinitions []*ast.VariableDefinition
+
+	// Variables are the variables before the transform, a copy of the
+	// variables provided by the client it may modify and return.
+	Variables map[string]interface{}
+
+	Context context.Context
+}
+
+// VariableTransformFn returns the transformed variables, or an error failing
+// the request, reported as it is.
+type VariableTransformFn func(p VariableTransformParams) (map[string]interface{}, error)
+
+// transformVariables applies the transforms in order to the variables of the
+// operation.
+func transformVariables(ctx context.Context, schema Schema, operation *ast.OperationDefinition, transforms []VariableTransform, variables map[string]interface{}) (map[string]interface{}, error) {
+	if len(transforms) == 0 {
+		return variables, nil
+	}
+	transformed := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		transformed[name] = value
+	}
+	for _, transform := range transforms {
+		if transform.Transform == nil {
+			continue
+		}
+		var err error
+		transformed, err = transform.Transform(VariableTransformParams{
+			Schema:              schema,
+			Operation:           operation,
+			VariableDefinitions: operation.VariableDefinitions,
+			Variables:           transformed,
+			Context:             ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if transformed == nil {
+			transformed = map[string]interface{}{}
+		}
+	}
+	return transformed, nil
+}
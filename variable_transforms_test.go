@@ -0,0 +1,100 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func variableTransformsTestSchema(t *testing.T) graphql.Schema {
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: "red"},
+			"BLUE": &graphql.EnumValueConfig{Value: "blue"},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"paint": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"tenant": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+						"color":  &graphql.ArgumentConfig{Type: color},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["tenant"].(string) + ":" + p.Args["color"].(string), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestVariableTransforms_RewriteVariablesBeforeCoercion(t *testing.T) {
+	definitions := []string{}
+	provided := map[string]interface{}{"color": "CRIMSON"}
+	result := graphql.Do(graphql.Params{
+		Schema:         variableTransformsTestSchema(t),
+		RequestString:  `query Paint($tenant: String!, $color: Color) { paint(tenant: $tenant, color: $color) }`,
+		VariableValues: provided,
+		VariableTransforms: []graphql.VariableTransform{
+			{
+				Name: "tenant",
+				Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+					for _, definition := range p.VariableDefinitions {
+						definitions = append(definitions, definition.Variable.Name.Value)
+					}
+					p.Variables["tenant"] = "acme"
+					return p.Variables, nil
+				},
+			},
+			{
+				Name: "legacyColors",
+				Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+					if p.Variables["color"] == "CRIMSON" {
+						p.Variables["color"] = "RED"
+					}
+					return p.Variables, nil
+				},
+			},
+		},
+	})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{"paint": "acme:red"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got: %v", expected, result.Data)
+	}
+	if !reflect.DeepEqual(definitions, []string{"tenant", "color"}) {
+		t.Fatalf("expected the variable definitions of the operation, got: %v", definitions)
+	}
+	if len(provided) != 1 || provided["color"] != "CRIMSON" {
+		t.Fatalf("expected the provided variables not to be modified, got: %v", provided)
+	}
+}
+
+func TestVariableTransforms_ErrorsFailTheRequest(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        variableTransformsTestSchema(t),
+		RequestString: `query Paint($tenant: String!) { paint(tenant: $tenant) }`,
+		VariableTransforms: []graphql.VariableTransform{{
+			Name: "tenant",
+			Transform: func(p graphql.VariableTransformParams) (map[string]interface{}, error) {
+				return nil, errors.New("unknown tenant")
+			},
+		}},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "unknown tenant" || result.Data != nil {
+		t.Fatalf("expected the transform error, got: %v", result.Errors)
+	}
+}
@@ -425,6 +425,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnNullForNestedNon
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -456,6 +457,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnIncorrectType(t
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -491,6 +493,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnOmissionOfNested
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -528,6 +531,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnDeepNestedErrors
 						Line: 2, Column: 19,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -570,6 +574,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnAdditionOfUnknow
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -777,6 +782,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeOmittedIn
 						Line: 2, Column: 31,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -813,6 +819,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeSetToNull
 						Line: 2, Column: 31,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1038,6 +1045,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListsToBeNull(t *testi
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1194,6 +1202,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowListOfNonNullsToContainNull(t
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1229,6 +1238,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToBeNull
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1296,6 +1306,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToContai
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1333,6 +1344,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowInvalidTypesToBeUsedAsValues(
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1371,6 +1383,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowUnknownTypesToBeUsedAsValues(
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]interface{}{"code": gqlerrors.CodeBadUserInput},
 			},
 		},
 	}
@@ -1466,3 +1479,89 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_RawVariables_ExplicitNullOverridesDefault(t *testing.T) {
+	doc := `query ($value: String = "default") { fieldWithNullableStringInput(input: $value) }`
+
+	result := graphql.Do(graphql.Params{
+		Schema:        variablesTestSchema,
+		RequestString: doc,
+		RawVariables:  []byte(`{"value": null}`),
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"fieldWithNullableStringInput": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        variablesTestSchema,
+		RequestString: doc,
+		RawVariables:  []byte(`{}`),
+	})
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"fieldWithNullableStringInput": `"default"`,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_RawVariables_CoercesNumbers(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["input"], nil
+					},
+				},
+				"id": &graphql.Field{
+					Type: graphql.ID,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.ID},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["input"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($int: Int, $id: ID) { int(input: $int) id(input: $id) }`,
+		RawVariables:  []byte(`{"int": 42, "id": 9007199254740993}`),
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"int": 42,
+			"id":  "9007199254740993",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($int: Int) { int(input: $int) }`,
+		RawVariables:  []byte(`{"int": `),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected an error for invalid JSON, got: %v", result.Errors)
+	}
+}
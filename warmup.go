@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// WarmupConfig options for warming up a schema, see Schema.Warmup.
+type WarmupConfig struct {
+	// Operations are parsed, validated and compiled against the schema, and
+	// keep their parsed documents for the params returned by
+	// OperationRegistry.Params.
+	Operations *OperationRegistry
+
+	// Requests are other documents parsed and validated against the schema,
+	// such as the most frequent requests of the clients.
+	Requests []string
+
+	// ParseOptions are the options used to parse the operations and requests.
+	ParseOptions parser.ParseOptions
+
+	// Readiness is marked ready once the warmup succeeded, otherwise it keeps
+	// the error of the warmup.
+	Readiness *Readiness
+}
+
+// Warmup prepares the schema to serve its first requests as fast as the next
+// ones: it defines the fields of all the types, caches the result of the
+// introspection query, and parses and validates the persisted operations and
+// requests of the config, failing on the first invalid one. The persisted
+// operations are also compiled, collecting their fields and coercing their
+// arguments which do not depend on variables ahead of time, for
+// DefaultExecutor.
+func (gq *Schema) Warmup(config WarmupConfig) error {
+	err := gq.warmup(config)
+	if config.Readiness != nil {
+		config.Readiness.setWarmup(err)
+	}
+	return err
+}
+
+func (gq *Schema) warmup(config WarmupConfig) error {
+	// the fields, interfaces and members of the types are defined lazily
+	for _, ttype := range gq.TypeMap() {
+		switch ttype := ttype.(type) {
+		case *Object:
+			ttype.Fields()
+			ttype.Interfaces()
+		case *Interface:
+			ttype.Fields()
+		case *Union:
+			ttype.Types()
+		case *InputObject:
+			ttype.Fields()
+		case *Enum:
+			ttype.Values()
+		}
+		if err := ttype.Error(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := gq.IntrospectionJSON(); err != nil {
+		return err
+	}
+
+	if config.Operations != nil {
+		for _, operation := range config.Operations.operationList() {
+			document, err := gq.warmupRequest(operation.Query, config.ParseOptions)
+			if err != nil {
+				return fmt.Errorf("persisted operation %q is invalid: %v", operation.ID, err)
+			}
+			config.Operations.setParsed(operation.ID, &parsedRequest{
+				request:  operation.Query,
+				options:  config.ParseOptions,
+				document: document,
+			})
+			gq.plans.set(document, operation.OperationName, compileOperation(*gq, document, operation.OperationName))
+		}
+	}
+	for i, request := range config.Requests {
+		if _, err := gq.warmupRequest(request, config.ParseOptions); err != nil {
+			return fmt.Errorf("warmup request %d is invalid: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// warmupRequest parses and validates the request against the schema.
+func (gq *Schema) warmupRequest(request string, options parser.ParseOptions) (*ast.Document, error) {
+	document, err := parser.Parse(parser.ParseParams{Source: request, Options: options})
+	if err != nil {
+		return nil, err
+	}
+	if result := ValidateDocument(gq, document, nil); !result.IsValid {
+		return nil, result.Errors[0]
+	}
+	return document, nil
+}
+
+// Readiness reports whether an instance is ready to serve requests, to the
+// readiness probes of orchestrators not to route traffic to an instance
+// before its schema is warmed up, see WarmupConfig.Readiness. It is safe for
+// concurrent use, and its zero value is not ready.
+type Readiness struct {
+	mu    sync.RWMutex
+	ready bool
+	err   error
+}
+
+// Ready reports whether the instance is ready to serve requests.
+func (r *Readiness) Ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ready
+}
+
+// SetReady marks the instance ready or not, e.g. not to receive new traffic
+// while shutting down.
+func (r *Readiness) SetReady(ready bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = ready
+}
+
+// Err returns the error of the last failed warmup, nil if none.
+func (r *Readiness) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.err
+}
+
+func (r *Readiness) setWarmup(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = err == nil
+	r.err = err
+}
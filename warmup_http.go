@@ -0,0 +1,40 @@
+//go:build !tinygo
+
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReadinessHandler returns a handler responding 200 when the instance is
+// ready, and 503 with the error of the warmup, if any, otherwise.
+func (r *Readiness) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		r.mu.RLock()
+		ready, err := r.ready, r.err
+		r.mu.RUnlock()
+		if ready {
+			fmt.Fprintln(w, "ready")
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		if err != nil {
+			fmt.Fprintf(w, "not ready: %v\n", err)
+			return
+		}
+		fmt.Fprintln(w, "not ready")
+	})
+}
+
+// HealthHandler returns a handler always responding 200, for the liveness
+// probes of orchestrators: the instance serves requests, ready or not.
+func HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintln(w, "ok")
+	})
+}
@@ -0,0 +1,32 @@
+//go:build !tinygo
+
+package graphql_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	readiness := &graphql.Readiness{}
+	get := func(handler http.Handler) (int, string) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+		return recorder.Code, strings.TrimSpace(recorder.Body.String())
+	}
+
+	if code, body := get(readiness.ReadinessHandler()); code != http.StatusServiceUnavailable || body != "not ready" {
+		t.Fatalf("unexpected response %v %q", code, body)
+	}
+	readiness.SetReady(true)
+	if code, body := get(readiness.ReadinessHandler()); code != http.StatusOK || body != "ready" {
+		t.Fatalf("unexpected response %v %q", code, body)
+	}
+	if code, body := get(graphql.HealthHandler()); code != http.StatusOK || body != "ok" {
+		t.Fatalf("unexpected response %v %q", code, body)
+	}
+}
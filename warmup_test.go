@@ -0,0 +1,58 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestWarmup_ParsesPersistedOperations(t *testing.T) {
+	schema, registry := newPersistedRegistry(t)
+	readiness := &graphql.Readiness{}
+	if readiness.Ready() {
+		t.Fatalf("expected the readiness not to be ready before the warmup")
+	}
+	err := schema.Warmup(graphql.WarmupConfig{
+		Operations: registry,
+		Requests:   []string{`{ items(limit: 1) }`},
+		Readiness:  readiness,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !readiness.Ready() {
+		t.Fatalf("expected the readiness to be ready after the warmup")
+	}
+
+	params, err := registry.Params("items", graphql.Params{
+		Schema:         schema,
+		VariableValues: map[string]interface{}{"limit": 500},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(params)
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if items := result.Data.(map[string]interface{})["items"]; items != "100 open" {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
+
+func TestWarmup_RejectsInvalidOperations(t *testing.T) {
+	schema, registry := newPersistedRegistry(t)
+	err := registry.Register(&graphql.PersistedOperation{ID: "broken", Query: `{ unknown }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readiness := &graphql.Readiness{}
+	err = schema.Warmup(graphql.WarmupConfig{Operations: registry, Readiness: readiness})
+	expected := `persisted operation "broken" is invalid: Cannot query field "unknown" on type "Query".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+	if readiness.Ready() || readiness.Err() != err {
+		t.Fatalf("expected the readiness to keep the error of the warmup")
+	}
+}